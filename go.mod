@@ -11,6 +11,7 @@ require (
 	github.com/redis/go-redis/v9 v9.1.0
 	golang.org/x/crypto v0.21.0
 	golang.org/x/oauth2 v0.12.0
+	golang.org/x/text v0.14.0
 	google.golang.org/api v0.126.0
 	gorm.io/driver/postgres v1.5.2
 	gorm.io/gorm v1.25.4
@@ -45,7 +46,6 @@ require (
 	golang.org/x/net v0.22.0 // indirect
 	golang.org/x/sync v0.6.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/time v0.5.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230530153820-e85fd2cbaebc // indirect
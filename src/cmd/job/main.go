@@ -0,0 +1,61 @@
+package main
+
+import (
+	"api/src/infra"
+	"api/src/repository"
+	"api/src/service"
+	"api/src/validator"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// バックグラウンドジョブ ※-runでジョブ名を指定した場合は単発実行、指定なしは常駐
+func main() {
+	run := flag.String("run", "", "Job name to run once")
+	flag.Parse()
+
+	// DB
+	db := infra.NewDB()
+
+	// Redis
+	redis := infra.NewRedis()
+
+	// Repository
+	redisRepository := repository.NewRedisRepository(redis)
+	userRepository := repository.NewUserRepository(db)
+	teamRepository := repository.NewTeamRepository(db)
+	scheduleRepository := repository.NewScheduleRepository(db)
+	mailRepository := repository.NewMailRepository(db)
+	loginLockoutRepository := repository.NewLoginLockoutRepository(db)
+	jobRepository := repository.NewJobRepository(db)
+
+	// Service
+	jobService := service.NewJobService(
+		jobRepository,
+		scheduleRepository,
+		userRepository,
+		loginLockoutRepository,
+		teamRepository,
+		mailRepository,
+		redisRepository,
+		validator.NewJobValidator(),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *run != "" {
+		if err := jobService.Run(ctx, *run); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	log.Println("Job runner started")
+	jobService.Start(ctx)
+	log.Println("Job runner stopped")
+}
@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IAnalysisController interface {
+	// 分析項目一覧
+	ListTerm(e echo.Context) error
+	// 選考ステータス別
+	Status(e echo.Context) error
+	// 面接回数別
+	Interview(e echo.Context) error
+	// 媒体別
+	Site(e echo.Context) error
+	// 原稿別
+	Manuscript(e echo.Context) error
+	// 応募者種別別
+	ApplicantType(e echo.Context) error
+	// 採用までの日数
+	TimeToHire(e echo.Context) error
+	// 面接官負荷
+	Interviewer(e echo.Context) error
+}
+
+type AnalysisController struct {
+	s service.IAnalysisService
+}
+
+func NewAnalysisController(
+	s service.IAnalysisService,
+) IAnalysisController {
+	return &AnalysisController{s}
+}
+
+// 分析項目一覧
+func (c *AnalysisController) ListTerm(e echo.Context) error {
+	req := request.ListAnalysisTerm{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.ListTerm(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 選考ステータス別
+func (c *AnalysisController) Status(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Status(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 面接回数別
+func (c *AnalysisController) Interview(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Interview(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 媒体別
+func (c *AnalysisController) Site(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Site(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 原稿別
+func (c *AnalysisController) Manuscript(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Manuscript(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 応募者種別別
+func (c *AnalysisController) ApplicantType(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.ApplicantType(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 採用までの日数
+func (c *AnalysisController) TimeToHire(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.TimeToHire(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 面接官負荷
+func (c *AnalysisController) Interviewer(e echo.Context) error {
+	req := request.Analysis{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Interviewer(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
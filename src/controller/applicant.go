@@ -7,6 +7,7 @@ import (
 	"api/src/model/static"
 	"api/src/service"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 
@@ -20,8 +21,16 @@ type IApplicantController interface {
 	GetSites(e echo.Context) error
 	// 応募者ステータス一覧取得
 	GetStatusList(e echo.Context) error
+	// 応募者エクスポート
+	Export(e echo.Context) error
 	// 応募者ダウンロード
 	Download(e echo.Context) error
+	// 応募者取込(CSV/TSV)
+	Import(e echo.Context) error
+	// 応募者アップロード履歴
+	SearchUploadHistory(e echo.Context) error
+	// 応募者取込取消
+	RollbackUpload(e echo.Context) error
 	// 予約表表示
 	ReserveTable(e echo.Context) error
 	// 書類アップロード
@@ -55,23 +64,15 @@ type IApplicantController interface {
 }
 
 type ApplicantController struct {
-	s     service.IApplicantService
-	user  service.IUserService
-	login service.ILoginService
-	role  service.IRoleService
+	s    service.IApplicantService
+	user service.IUserService
 }
 
 func NewApplicantController(
 	s service.IApplicantService,
 	user service.IUserService,
-	login service.ILoginService,
-	role service.IRoleService,
 ) IApplicantController {
-	return &ApplicantController{s, user, login, role}
-}
-
-func (c *ApplicantController) GetLoginService() service.ILoginService {
-	return c.login
+	return &ApplicantController{s, user}
 }
 
 // 検索
@@ -82,34 +83,7 @@ func (c *ApplicantController) Search(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	// 検索
 	res, sErr := c.s.Search(&req)
@@ -137,34 +111,7 @@ func (c *ApplicantController) GetStatusList(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.GetStatusList(&req)
 	if err != nil {
@@ -174,6 +121,32 @@ func (c *ApplicantController) GetStatusList(e echo.Context) error {
 	return e.JSON(http.StatusOK, res)
 }
 
+// 応募者エクスポート(CSV/XLSX)
+func (c *ApplicantController) Export(e echo.Context) error {
+	req := request.ExportApplicant{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Export(&req, func(fileName string, contentType string) (io.Writer, error) {
+		e.Response().Header().Set("Content-Disposition", "attachment; filename="+fileName)
+		e.Response().Header().Set("Content-Type", contentType)
+		e.Response().WriteHeader(http.StatusOK)
+		return e.Response(), nil
+	}); err != nil {
+		// 出力開始後はステータスを変更できないため終了のみ
+		if e.Response().Committed {
+			return nil
+		}
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return nil
+}
+
 // 応募者ダウンロード
 func (c *ApplicantController) Download(e echo.Context) error {
 	req := request.ApplicantDownload{}
@@ -182,36 +155,67 @@ func (c *ApplicantController) Download(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Download(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
+	return e.JSON(http.StatusOK, res)
+}
+
+// 応募者取込(CSV/TSV)
+func (c *ApplicantController) Import(e echo.Context) error {
+	req := request.ApplicantImport{
 		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
+			UserHashKey: authHashKey(e),
 		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_DOWNLOAD,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
+	}
+
+	file, fileErr := e.FormFile("file")
+	if fileErr != nil {
+		log.Printf("%v", fileErr)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	res, err := c.s.Import(&req, file)
+	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
-	res, err := c.s.Download(&req)
+	return e.JSON(http.StatusOK, res)
+}
+
+// 応募者アップロード履歴
+func (c *ApplicantController) SearchUploadHistory(e echo.Context) error {
+	req := request.SearchUploadHistory{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.SearchUploadHistory(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 応募者取込取消
+func (c *ApplicantController) RollbackUpload(e echo.Context) error {
+	req := request.RollbackUpload{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.RollbackUpload(&req)
 	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
@@ -227,10 +231,7 @@ func (c *ApplicantController) ReserveTable(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(c, e, req.HashKey, JWT_TOKEN2, JWT_SECRET2, false); err != nil {
-		return err
-	}
+	req.HashKey = authHashKey(e)
 
 	res, err := c.s.ReserveTable(&req)
 	if err != nil {
@@ -248,34 +249,7 @@ func (c *ApplicantController) GetOauthURL(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.GetOauthURL(&req)
 	if err != nil {
@@ -293,34 +267,7 @@ func (c *ApplicantController) Get(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.Get(&req)
 	if err != nil {
@@ -332,12 +279,7 @@ func (c *ApplicantController) Get(e echo.Context) error {
 
 // 書類アップロード
 func (c *ApplicantController) DocumentsUpload(e echo.Context) error {
-	hashKey := e.FormValue("hash_key")
-
-	// JWT検証
-	if err := JWTDecodeCommon(c, e, hashKey, JWT_TOKEN2, JWT_SECRET2, false); err != nil {
-		return err
-	}
+	hashKey := authHashKey(e)
 
 	resumeExtension := e.FormValue("resume_extension")
 	if resumeExtension != "" {
@@ -392,34 +334,7 @@ func (c *ApplicantController) DocumentDownload(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	file, fileName, err := c.s.S3Download(&req)
 	if err != nil {
@@ -439,10 +354,7 @@ func (c *ApplicantController) InsertDesiredAt(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(c, e, req.HashKey, JWT_TOKEN2, JWT_SECRET2, false); err != nil {
-		return err
-	}
+	req.ApplicantHashKey = authHashKey(e)
 
 	if err := c.s.InsertDesiredAt(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -459,34 +371,7 @@ func (c *ApplicantController) GetGoogleMeetUrl(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.GetGoogleMeetUrl(&req)
 	if err != nil {
@@ -503,34 +388,7 @@ func (c *ApplicantController) AssignUser(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_ASSIGN_USER,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.AssignUser(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -546,34 +404,7 @@ func (c *ApplicantController) CheckAssignableUser(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_ASSIGN_USER,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.CheckAssignableUser(&req, false)
 	if err != nil {
@@ -590,34 +421,7 @@ func (c *ApplicantController) CreateApplicantType(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.CreateApplicantType(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -633,34 +437,7 @@ func (c *ApplicantController) ListApplicantType(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.ListApplicantType(&req)
 	if err != nil {
@@ -677,34 +454,7 @@ func (c *ApplicantController) ListApplicantTypeByTeam(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.ListApplicantType(&req)
 	if err != nil {
@@ -721,34 +471,7 @@ func (c *ApplicantController) CreateApplicantTypeAssociation(e echo.Context) err
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_SETTING_TYPE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.CreateApplicantTypeAssociation(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -764,34 +487,7 @@ func (c *ApplicantController) UpdateSelectStatus(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_SETTING_STATUS,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.UpdateSelectStatus(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -807,34 +503,7 @@ func (c *ApplicantController) InputResult(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_SETTING_RESULT,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.InputResult(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
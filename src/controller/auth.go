@@ -0,0 +1,269 @@
+package controller
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 認証種別
+const (
+	// 認証不要
+	AUTH_PUBLIC uint = 1
+	// ユーザー
+	AUTH_USER uint = 2
+	// 応募者
+	AUTH_APPLICANT uint = 3
+)
+
+// 認証済み操作者ハッシュキー(echo.Context格納キー)
+const CONTEXT_HASH_KEY string = "auth_hash_key"
+
+// 認証済みセッションID(echo.Context格納キー)
+const CONTEXT_SESSION_ID string = "auth_session_id"
+
+// ローカル環境用操作者ハッシュキーヘッダー ※GO_ENV=localのみ有効
+const LOCAL_HASH_KEY_HEADER string = "X-Hash-Key"
+
+// 認可ポリシー
+type Policy struct {
+	// 認証種別
+	Auth uint
+	// ログイン種別毎の必要ロールID ※0はログインのみで可、未定義のログイン種別は不可
+	Roles map[uint]uint
+	// 権限なし時のステータス
+	DenyStatus int
+}
+
+// ログイン種別毎の必要ロール
+type Grant struct {
+	// ログイン種別
+	LoginType uint
+	// ロールID
+	RoleID uint
+}
+
+// 認証不要
+func Public() *Policy {
+	return &Policy{
+		Auth: AUTH_PUBLIC,
+	}
+}
+
+// 応募者
+func Applicant() *Policy {
+	return &Policy{
+		Auth: AUTH_APPLICANT,
+	}
+}
+
+// ユーザー ※ロール不要、ログイン種別未指定の場合は全種別
+func User(loginTypes ...uint) *Policy {
+	if len(loginTypes) == 0 {
+		loginTypes = []uint{static.LOGIN_TYPE_ADMIN, static.LOGIN_TYPE_MANAGEMENT}
+	}
+	var grants []Grant
+	for _, loginType := range loginTypes {
+		grants = append(grants, Grant{LoginType: loginType})
+	}
+	return newUserPolicy(http.StatusForbidden, grants)
+}
+
+// 参照系 ※権限なしの場合は204
+func Read(grants ...Grant) *Policy {
+	return newUserPolicy(http.StatusNoContent, grants)
+}
+
+// 更新系 ※権限なしの場合は403
+func Write(grants ...Grant) *Policy {
+	return newUserPolicy(http.StatusForbidden, grants)
+}
+
+// 管理者ロール
+func Admin(roleID uint) Grant {
+	return Grant{
+		LoginType: static.LOGIN_TYPE_ADMIN,
+		RoleID:    roleID,
+	}
+}
+
+// 企業ユーザーロール
+func Management(roleID uint) Grant {
+	return Grant{
+		LoginType: static.LOGIN_TYPE_MANAGEMENT,
+		RoleID:    roleID,
+	}
+}
+
+func newUserPolicy(denyStatus int, grants []Grant) *Policy {
+	roles := make(map[uint]uint)
+	for _, grant := range grants {
+		roles[grant.LoginType] = grant.RoleID
+	}
+	return &Policy{
+		Auth:       AUTH_USER,
+		Roles:      roles,
+		DenyStatus: denyStatus,
+	}
+}
+
+type IAuthMiddleware interface {
+	// ポリシー適用
+	Apply(p *Policy) echo.MiddlewareFunc
+}
+
+type AuthMiddleware struct {
+	login service.ILoginService
+	role  service.IRoleService
+}
+
+func NewAuthMiddleware(
+	login service.ILoginService,
+	role service.IRoleService,
+) IAuthMiddleware {
+	return &AuthMiddleware{login, role}
+}
+
+// ポリシー適用
+// JWT検証、ユーザー(応募者)存在確認、Cookie更新、ログイン種別・ロールチェックを行う
+func (m *AuthMiddleware) Apply(p *Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(e echo.Context) error {
+			if p.Auth == AUTH_PUBLIC {
+				return next(e)
+			}
+
+			// 操作者はリクエストボディではなく検証済みトークンから取得
+			// ※認証失敗時はレスポンス書き込み済みのためclaimsはnil
+			claims, err := m.authenticate(e, p)
+			if claims == nil {
+				return err
+			}
+			hashKey := claims.HashKey
+			if hashKey == "" {
+				return e.JSON(http.StatusUnauthorized, fmt.Errorf(static.MESSAGE_UNEXPECTED_COOKIE))
+			}
+
+			if p.Auth == AUTH_USER {
+				if err := m.authorize(p, hashKey); err != nil {
+					return e.JSON(err.Status, response.ErrorConvert(*err))
+				}
+			}
+
+			e.Set(CONTEXT_HASH_KEY, hashKey)
+			e.Set(CONTEXT_SESSION_ID, claims.SessionID)
+			return next(e)
+		}
+	}
+}
+
+// 認証 ※トークンのクレームを返却
+func (m *AuthMiddleware) authenticate(e echo.Context, p *Policy) (*dto.JWTClaims, error) {
+	// Go単体で動作確認したい場合はGO_ENVをlocalに ※操作者はヘッダーで指定
+	if os.Getenv("GO_ENV") == "local" {
+		return &dto.JWTClaims{
+			HashKey: e.Request().Header.Get(LOCAL_HASH_KEY_HEADER),
+		}, nil
+	}
+
+	token, secret := JWT_TOKEN, JWT_SECRET
+	if p.Auth == AUTH_APPLICANT {
+		token, secret = JWT_TOKEN2, JWT_SECRET2
+	}
+
+	cookie, cookieErr := e.Cookie(token)
+	if cookieErr != nil {
+		log.Printf("%v", cookieErr)
+		return nil, e.JSON(http.StatusUnauthorized, fmt.Errorf(static.MESSAGE_UNEXPECTED_COOKIE))
+	}
+	claims, decodeErr := m.login.JWTDecode(cookie, secret)
+	if decodeErr != nil {
+		log.Printf("%v", decodeErr)
+		return nil, e.JSON(decodeErr.Status, response.ErrorConvert(*decodeErr))
+	}
+
+	if p.Auth == AUTH_USER {
+		// ユーザーが削除されていないか、セッションが失効していないかの確認
+		// ※アクセストークンは短命のため更新はリフレッシュトークンで行う
+		if err := m.login.UserCheck(&request.JWTDecode{
+			User: ddl.User{
+				AbstractTransactionModel: ddl.AbstractTransactionModel{
+					HashKey: claims.HashKey,
+				},
+			},
+			SessionID: claims.SessionID,
+		}); err != nil {
+			return nil, e.JSON(err.Status, response.ErrorConvert(*err))
+		}
+		return claims, nil
+	}
+
+	// 応募者チェック ※ログアウト済みの場合はJWTを更新しない
+	if err := m.login.CheckApplicant(&request.CheckApplicant{
+		Applicant: ddl.Applicant{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey: claims.HashKey,
+			},
+		},
+	}); err != nil {
+		return nil, e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	// JWT＆Cookie 更新
+	refreshed, refreshErr := m.login.JWT(&claims.HashKey, token, secret)
+	if refreshErr != nil {
+		return nil, e.JSON(refreshErr.Status, response.ErrorConvert(*refreshErr))
+	}
+	e.SetCookie(refreshed)
+
+	return claims, nil
+}
+
+// 認可 ※ログイン種別毎の必要ロールを保持しているか
+func (m *AuthMiddleware) authorize(p *Policy, hashKey string) *response.Error {
+	loginType, loginTypeErr := m.login.GetLoginType(&request.GetLoginType{
+		User: ddl.User{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey: hashKey,
+			},
+		},
+	})
+	if loginTypeErr != nil {
+		return loginTypeErr
+	}
+
+	roleID, ok := p.Roles[loginType.LoginType]
+	if !ok {
+		return &response.Error{
+			Status: p.DenyStatus,
+		}
+	}
+	if roleID == 0 {
+		return nil
+	}
+
+	exist, roleErr := m.role.Check(&request.CheckRole{
+		Abstract: request.Abstract{
+			UserHashKey: hashKey,
+		},
+		ID: roleID,
+	})
+	if roleErr != nil {
+		return roleErr
+	}
+	if !exist {
+		return &response.Error{
+			Status: p.DenyStatus,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package controller
+
+import (
+	"api/src/model/dto"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	testUserA      = "user_a"
+	testUserB      = "user_b"
+	testApplicant  = "applicant_a"
+	testSecret     = "test_secret"
+	testSecret2    = "test_secret2"
+	testOtherKey   = "other_secret"
+	testOtherToken = "OTHER_SECRET"
+	testSession    = "session_a"
+)
+
+// ログインサービス(テスト用) ※JWTは本実装を使用
+type fakeLoginService struct {
+	service.ILoginService
+	jwt *service.LoginService
+	// ログアウトされたユーザー
+	logout string
+	// ログアウトされたセッション
+	logoutSession string
+}
+
+func (f *fakeLoginService) JWT(hashKey *string, name string, secret string) (*http.Cookie, *response.Error) {
+	return f.jwt.JWT(hashKey, name, secret)
+}
+
+func (f *fakeLoginService) JWTDecode(cookie *http.Cookie, secret string) (*dto.JWTClaims, *response.Error) {
+	return f.jwt.JWTDecode(cookie, secret)
+}
+
+func (f *fakeLoginService) UserCheck(req *request.JWTDecode) *response.Error {
+	if req.HashKey != testUserA && req.HashKey != testUserB {
+		return &response.Error{Status: http.StatusUnauthorized}
+	}
+	if req.SessionID != testSession {
+		return &response.Error{Status: http.StatusUnauthorized}
+	}
+	return nil
+}
+
+func (f *fakeLoginService) CheckApplicant(req *request.CheckApplicant) *response.Error {
+	if req.HashKey != testApplicant {
+		return &response.Error{Status: http.StatusUnauthorized}
+	}
+	return nil
+}
+
+func (f *fakeLoginService) GetLoginType(req *request.GetLoginType) (*response.GetLoginType, *response.Error) {
+	return &response.GetLoginType{LoginType: static.LOGIN_TYPE_MANAGEMENT}, nil
+}
+
+func (f *fakeLoginService) Logout(req *request.Logout, token string) ([]*http.Cookie, *response.Error) {
+	f.logout = req.HashKey
+	f.logoutSession = req.SessionID
+	return []*http.Cookie{{Name: token}}, nil
+}
+
+// ロールサービス(テスト用) ※ユーザーBのみ応募者閲覧ロールを保持
+type fakeRoleService struct {
+	service.IRoleService
+}
+
+func (f *fakeRoleService) Check(req *request.CheckRole) (bool, *response.Error) {
+	return req.UserHashKey == testUserB && req.ID == static.ROLE_MANAGEMENT_APPLICANT_READ, nil
+}
+
+// 通知サービス(テスト用) ※受け取った操作者を記録
+type fakeNoticeService struct {
+	service.INoticeService
+	userHashKey string
+}
+
+func (f *fakeNoticeService) CountUnread(req *request.CountUnreadNotice) (*response.CountUnreadNotice, *response.Error) {
+	f.userHashKey = req.UserHashKey
+	return &response.CountUnreadNotice{}, nil
+}
+
+func testCookie(t *testing.T, hashKey string, name string, secret string) *http.Cookie {
+	cookie, err := (&service.LoginService{}).JWT(&hashKey, name, secret)
+	if err != nil {
+		t.Fatalf("JWT() error = %v", err)
+	}
+	return cookie
+}
+
+func testAccessCookie(t *testing.T, hashKey string, sessionID string, secret string) *http.Cookie {
+	cookie, err := (&service.LoginService{}).AccessToken(&hashKey, &sessionID, JWT_TOKEN, secret)
+	if err != nil {
+		t.Fatalf("AccessToken() error = %v", err)
+	}
+	return cookie
+}
+
+func TestAuthMiddleware_Apply(t *testing.T) {
+	t.Setenv("GO_ENV", "test")
+	t.Setenv(JWT_SECRET, testSecret)
+	t.Setenv(JWT_SECRET2, testSecret2)
+	t.Setenv(testOtherToken, testOtherKey)
+
+	type args struct {
+		policy *Policy
+		cookie *http.Cookie
+		body   string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantActor  string
+	}{
+		// ok ログインのみ
+		{
+			"ok_user",
+			args{
+				User(),
+				testAccessCookie(t, testUserA, testSession, JWT_SECRET),
+				`{}`,
+			},
+			http.StatusOK,
+			testUserA,
+		},
+		// ok ボディで他ユーザーを指定してもトークンの主体で動作
+		{
+			"ok_body_ignored",
+			args{
+				User(),
+				testAccessCookie(t, testUserA, testSession, JWT_SECRET),
+				`{"user_hash_key":"` + testUserB + `","hash_key":"` + testUserB + `"}`,
+			},
+			http.StatusOK,
+			testUserA,
+		},
+		// ok ロール保持
+		{
+			"ok_role",
+			args{
+				Read(Management(static.ROLE_MANAGEMENT_APPLICANT_READ)),
+				testAccessCookie(t, testUserB, testSession, JWT_SECRET),
+				`{}`,
+			},
+			http.StatusOK,
+			testUserB,
+		},
+		// ng ロールを保持する他ユーザーをボディで指定しても権限なし
+		{
+			"ng_role_of_other_user",
+			args{
+				Read(Management(static.ROLE_MANAGEMENT_APPLICANT_READ)),
+				testAccessCookie(t, testUserA, testSession, JWT_SECRET),
+				`{"user_hash_key":"` + testUserB + `"}`,
+			},
+			http.StatusNoContent,
+			"",
+		},
+		// ng 更新系は403
+		{
+			"ng_role_write",
+			args{
+				Write(Management(static.ROLE_MANAGEMENT_APPLICANT_READ)),
+				testAccessCookie(t, testUserA, testSession, JWT_SECRET),
+				`{"user_hash_key":"` + testUserB + `"}`,
+			},
+			http.StatusForbidden,
+			"",
+		},
+		// ng ログイン種別不可
+		{
+			"ng_login_type",
+			args{
+				Write(Admin(static.ROLE_ADMIN_LOG_READ)),
+				testAccessCookie(t, testUserA, testSession, JWT_SECRET),
+				`{}`,
+			},
+			http.StatusForbidden,
+			"",
+		},
+		// ng Cookieなし
+		{
+			"ng_no_cookie",
+			args{
+				User(),
+				nil,
+				`{"user_hash_key":"` + testUserA + `"}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+		// ng 他の秘密鍵で署名されたトークン
+		{
+			"ng_forged_token",
+			args{
+				User(),
+				testAccessCookie(t, testUserB, testSession, testOtherToken),
+				`{}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+		// ng 失効したセッション
+		{
+			"ng_revoked_session",
+			args{
+				User(),
+				testAccessCookie(t, testUserA, "session_revoked", JWT_SECRET),
+				`{}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+		// ng セッションIDを含まないトークン
+		{
+			"ng_no_session",
+			args{
+				User(),
+				testCookie(t, testUserA, JWT_TOKEN, JWT_SECRET),
+				`{}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+		// ng 存在しないユーザー
+		{
+			"ng_unknown_user",
+			args{
+				User(),
+				testAccessCookie(t, "user_x", testSession, JWT_SECRET),
+				`{}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+		// ng 応募者トークンでユーザーとして操作
+		{
+			"ng_applicant_as_user",
+			args{
+				User(),
+				testAccessCookie(t, testApplicant, testSession, JWT_SECRET2),
+				`{}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+		// ok 応募者
+		{
+			"ok_applicant",
+			args{
+				Applicant(),
+				testCookie(t, testApplicant, JWT_TOKEN2, JWT_SECRET2),
+				`{"hash_key":"` + testUserA + `"}`,
+			},
+			http.StatusOK,
+			testApplicant,
+		},
+		// ng ユーザートークンで応募者として操作
+		{
+			"ng_user_as_applicant",
+			args{
+				Applicant(),
+				testCookie(t, testUserA, JWT_TOKEN2, JWT_SECRET),
+				`{}`,
+			},
+			http.StatusUnauthorized,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notice := &fakeNoticeService{}
+			m := NewAuthMiddleware(&fakeLoginService{jwt: &service.LoginService{}}, &fakeRoleService{})
+			h := m.Apply(tt.args.policy)(NewNoticeController(notice).CountUnread)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.args.cookie != nil {
+				req.AddCookie(tt.args.cookie)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// 204はボディを書き込めないためエラーとなる ※サーバー同様にエラーハンドラーへ
+			if err := h(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if notice.userHashKey != tt.wantActor {
+				t.Errorf("actor = %v, want %v", notice.userHashKey, tt.wantActor)
+			}
+		})
+	}
+}
+
+func TestLoginController_Logout(t *testing.T) {
+	t.Setenv("GO_ENV", "test")
+	t.Setenv(JWT_SECRET, testSecret)
+
+	login := &fakeLoginService{jwt: &service.LoginService{}}
+	h := NewAuthMiddleware(login, &fakeRoleService{}).Apply(User())(NewLoginController(login).Logout)
+
+	// ユーザーAのトークンでユーザーBのログアウトを指定
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"hash_key":"`+testUserB+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(testAccessCookie(t, testUserA, testSession, JWT_SECRET))
+	rec := httptest.NewRecorder()
+
+	if err := h(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if login.logout != testUserA {
+		t.Errorf("logout = %v, want %v", login.logout, testUserA)
+	}
+	if login.logoutSession != testSession {
+		t.Errorf("logoutSession = %v, want %v", login.logoutSession, testSession)
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ICalendarController interface {
+	// 購読トークン発行
+	IssueToken(e echo.Context) error
+	// 購読トークン削除
+	DeleteToken(e echo.Context) error
+	// フィード(iCalendar)
+	Feed(e echo.Context) error
+}
+
+type CalendarController struct {
+	s service.ICalendarService
+}
+
+func NewCalendarController(
+	s service.ICalendarService,
+) ICalendarController {
+	return &CalendarController{s}
+}
+
+// 購読トークン発行
+func (c *CalendarController) IssueToken(e echo.Context) error {
+	req := request.IssueCalendarToken{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.IssueToken(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 購読トークン削除
+func (c *CalendarController) DeleteToken(e echo.Context) error {
+	req := request.DeleteCalendarToken{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.DeleteToken(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// フィード(iCalendar) ※カレンダークライアントから取得するためJWTではなく購読トークンで認証
+func (c *CalendarController) Feed(e echo.Context) error {
+	req := request.CalendarFeed{
+		Token: e.Param("token"),
+	}
+
+	res, err := c.s.Feed(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	e.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	return e.Blob(http.StatusOK, static.ICS_CONTENT_TYPE, res)
+}
@@ -25,18 +25,12 @@ type ICommonController interface {
 
 type CommonController struct {
 	common service.ICommonService
-	login  service.ILoginService
 }
 
 func NewCommonController(
 	common service.ICommonService,
-	login service.ILoginService,
 ) ICommonController {
-	return &CommonController{common, login}
-}
-
-func (c *CommonController) GetLoginService() service.ILoginService {
-	return c.login
+	return &CommonController{common}
 }
 
 // ヘルスチェック
@@ -52,17 +46,7 @@ func (c *CommonController) Sidebar(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.HashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
+	req.HashKey = authHashKey(e)
 
 	res, sErr := c.common.Sidebar(&req)
 	if sErr != nil {
@@ -80,17 +64,7 @@ func (c *CommonController) Roles(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.HashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
+	req.HashKey = authHashKey(e)
 
 	res, sErr := c.common.Roles(&req)
 	if sErr != nil {
@@ -108,17 +82,7 @@ func (c *CommonController) ChangeTeam(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.common.ChangeTeam(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -17,24 +17,32 @@ type ICompanyController interface {
 	Create(e echo.Context) error
 	// 検索
 	Search(e echo.Context) error
+	// セキュリティ設定取得
+	GetSecurity(e echo.Context) error
+	// セキュリティ設定更新
+	UpdateSecurity(e echo.Context) error
+	// シングルサインオン設定取得
+	GetOIDC(e echo.Context) error
+	// シングルサインオン設定更新
+	UpdateOIDC(e echo.Context) error
+	// 休日一覧
+	ListHoliday(e echo.Context) error
+	// 休日登録
+	SaveHoliday(e echo.Context) error
+	// 休日削除
+	DeleteHoliday(e echo.Context) error
+	// 休日取込
+	ImportHoliday(e echo.Context) error
 }
 
 type CompanyController struct {
 	company service.ICompanyService
-	login   service.ILoginService
-	role    service.IRoleService
 }
 
 func NewCompanyController(
 	company service.ICompanyService,
-	login service.ILoginService,
-	role service.IRoleService,
 ) ICompanyController {
-	return &CompanyController{company, login, role}
-}
-
-func (c *CompanyController) GetLoginService() service.ILoginService {
-	return c.login
+	return &CompanyController{company}
 }
 
 // 登録
@@ -45,35 +53,7 @@ func (c *CompanyController) Create(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_ADMIN_COMPANY_CREATE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	// 登録
 	user, sErr := c.company.Create(&req)
@@ -92,35 +72,7 @@ func (c *CompanyController) Search(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_ADMIN_COMPANY_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	// 検索
 	res, sErr := c.company.Search(&req)
@@ -130,3 +82,149 @@ func (c *CompanyController) Search(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, res)
 }
+
+// セキュリティ設定取得
+func (c *CompanyController) GetSecurity(e echo.Context) error {
+	req := request.GetCompanySecurity{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.company.GetSecurity(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// セキュリティ設定更新
+func (c *CompanyController) UpdateSecurity(e echo.Context) error {
+	req := request.UpdateCompanySecurity{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.company.UpdateSecurity(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// シングルサインオン設定取得
+func (c *CompanyController) GetOIDC(e echo.Context) error {
+	req := request.GetCompanyOIDC{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.company.GetOIDC(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// シングルサインオン設定更新
+func (c *CompanyController) UpdateOIDC(e echo.Context) error {
+	req := request.UpdateCompanyOIDC{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.company.UpdateOIDC(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 休日一覧
+func (c *CompanyController) ListHoliday(e echo.Context) error {
+	req := request.ListCompanyHoliday{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.company.ListHoliday(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 休日登録
+func (c *CompanyController) SaveHoliday(e echo.Context) error {
+	req := request.SaveCompanyHoliday{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.company.SaveHoliday(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 休日削除
+func (c *CompanyController) DeleteHoliday(e echo.Context) error {
+	req := request.DeleteCompanyHoliday{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.company.DeleteHoliday(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 休日取込 ※multipart/form-data(file, kind)
+func (c *CompanyController) ImportHoliday(e echo.Context) error {
+	req := request.ImportCompanyHoliday{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	file, fileErr := e.FormFile("file")
+	if fileErr != nil {
+		log.Printf("%v", fileErr)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	res, sErr := c.company.ImportHoliday(&req, file)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
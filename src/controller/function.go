@@ -1,86 +1,37 @@
 package controller
 
 import (
-	"api/src/model/ddl"
-	"api/src/model/request"
-	"api/src/model/response"
 	"api/src/model/static"
-	"api/src/service"
-	"fmt"
-	"log"
-	"net/http"
-	"os"
 
 	"github.com/labstack/echo/v4"
 )
 
-// 任意の構造体からILoginServiceフィールドを取得
-func getServiceFromController[T any](c *T) (service.ILoginService, error) {
-	type HasLoginService interface {
-		GetLoginService() service.ILoginService
-	}
-
-	ctrl, ok := any(c).(HasLoginService)
-	if !ok {
-		return nil, fmt.Errorf(static.MESSAGE_NOT_FOUND_LOGIN_SERVICE)
-	}
-
-	return ctrl.GetLoginService(), nil
+// 認証済み操作者ハッシュキー取得 ※認証ミドルウェアで設定
+func authHashKey(e echo.Context) string {
+	hashKey, _ := e.Get(CONTEXT_HASH_KEY).(string)
+	return hashKey
 }
 
-// JWT検証_共通化
-func JWTDecodeCommon[T any](c *T, e echo.Context, hash_key string, token string, secret string, isUser bool) error {
-	// Go単体で動作確認したい場合はGO_ENVをlocalに
-	if os.Getenv("GO_ENV") == "local" {
-		return nil
-	}
+// 認証済みセッションID取得 ※ユーザーのみ
+func authSessionID(e echo.Context) string {
+	sessionID, _ := e.Get(CONTEXT_SESSION_ID).(string)
+	return sessionID
+}
 
-	s, err := getServiceFromController(c)
+// 認証アプリ登録トークン取得(Cookie) ※未設定の場合は空
+func totpSetupToken(e echo.Context) string {
+	cookie, err := e.Cookie(static.TOTP_SETUP_TOKEN_COOKIE)
 	if err != nil {
-		log.Printf("%v", err)
-		return e.JSON(http.StatusUnauthorized, err)
-	}
-
-	cookie, err2 := e.Cookie(token)
-	if err2 != nil {
-		log.Printf("%v", err2)
-		return e.JSON(http.StatusUnauthorized, fmt.Errorf(static.MESSAGE_UNEXPECTED_COOKIE))
-	}
-	if err := s.JWTDecode(cookie, secret); err != nil {
-		log.Printf("%v", err)
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
-
-	if isUser {
-		// ユーザーが削除されていないかの確認
-		if err := s.UserCheck(&request.JWTDecode{
-			User: ddl.User{
-				AbstractTransactionModel: ddl.AbstractTransactionModel{
-					HashKey: hash_key,
-				},
-			},
-		}); err != nil {
-			return e.JSON(err.Status, response.ErrorConvert(*err))
-		}
-	} else {
-		// 応募者チェック
-		if err := s.CheckApplicant(&request.CheckApplicant{
-			Applicant: ddl.Applicant{
-				AbstractTransactionModel: ddl.AbstractTransactionModel{
-					HashKey: hash_key,
-				},
-			},
-		}); err != nil {
-			return e.JSON(err.Status, response.ErrorConvert(*err))
-		}
+		return ""
 	}
+	return cookie.Value
+}
 
-	// JWT＆Cookie 更新
-	cookie, err3 := s.JWT(&hash_key, token, secret)
-	if err3 != nil {
-		return e.JSON(err3.Status, response.ErrorConvert(*err3))
+// パスワード変更トークン取得(Cookie) ※未設定の場合は空
+func passwordChangeToken(e echo.Context) string {
+	cookie, err := e.Cookie(static.PASSWORD_CHANGE_TOKEN_COOKIE)
+	if err != nil {
+		return ""
 	}
-	e.SetCookie(cookie)
-
-	return nil
+	return cookie.Value
 }
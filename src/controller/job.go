@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IJobController interface {
+	// 実行履歴検索
+	SearchRun(e echo.Context) error
+}
+
+type JobController struct {
+	s service.IJobService
+}
+
+func NewJobController(
+	s service.IJobService,
+) IJobController {
+	return &JobController{s}
+}
+
+// 実行履歴検索
+func (c *JobController) SearchRun(e echo.Context) error {
+	req := request.SearchJobRun{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.SearchRun(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
@@ -24,20 +24,44 @@ type ILoginController interface {
 	CodeGenerate(e echo.Context) error
 	// MFA
 	MFA(e echo.Context) error
+	// 認証アプリ取得
+	GetTOTP(e echo.Context) error
+	// 認証アプリ登録
+	TOTPSetup(e echo.Context) error
+	// 認証アプリ確認
+	TOTPConfirm(e echo.Context) error
+	// 認証アプリ登録(ログイン時)
+	MFATOTPSetup(e echo.Context) error
+	// 認証アプリ確認(ログイン時)
+	MFATOTPConfirm(e echo.Context) error
+	// 認証アプリ解除
+	TOTPDisable(e echo.Context) error
+	// リカバリーコード再発行
+	RegenerateRecoveryCode(e echo.Context) error
 	// JWT 検証
 	JWTDecode(e echo.Context) error
 	// パスワード変更
 	PasswordChange(e echo.Context) error
+	// シングルサインオン開始
+	SSOStart(e echo.Context) error
+	// シングルサインオン認証
+	SSOCallback(e echo.Context) error
+	// パスワード再設定メール送信
+	PasswordResetRequest(e echo.Context) error
+	// パスワード再設定
+	PasswordReset(e echo.Context) error
 	// ログアウト
 	Logout(e echo.Context) error
+	// 全端末ログアウト
+	LogoutAll(e echo.Context) error
+	// セッション更新
+	Refresh(e echo.Context) error
 	// チーム存在確認(応募者)
 	ConfirmTeamApplicant(e echo.Context) error
 	// ログイン(応募者)
 	LoginApplicant(e echo.Context) error
-	// MFA 認証コード生成(応募者)
-	CodeGenerateApplicant(e echo.Context) error
-	// MFA(応募者)
-	MFAApplicant(e echo.Context) error
+	// ログインリンク検証(応募者)
+	MagicLinkApplicant(e echo.Context) error
 	// JWT 検証(応募者)
 	JWTDecodeApplicant(e echo.Context) error
 	// ログアウト(応募者)
@@ -52,10 +76,6 @@ func NewLoginController(s service.ILoginService) ILoginController {
 	return &LoginController{s}
 }
 
-func (c *LoginController) GetLoginService() service.ILoginService {
-	return c.s
-}
-
 // ログイン
 func (c *LoginController) Login(e echo.Context) error {
 	req := request.Login{}
@@ -64,6 +84,8 @@ func (c *LoginController) Login(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
+	req.ClientIP = e.RealIP()
+
 	// ログイン
 	user, sErr := c.s.Login(&req)
 	if sErr != nil {
@@ -95,24 +117,175 @@ func (c *LoginController) MFA(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
+	req.ClientIP = e.RealIP()
+
 	// MFA
 	mfa, sErr := c.s.MFA(&req)
 	if sErr != nil {
 		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
 	}
 
-	if !mfa.IsPasswordChange {
-		// JWT＆Cookie
-		cookie, err := c.s.JWT(&req.HashKey, JWT_TOKEN, JWT_SECRET)
+	// 認証アプリ登録が必要な場合は登録専用のトークンのみ発行 ※パスワード変更は登録完了後
+	if mfa.IsTOTPSetup {
+		cookie, err := c.s.IssueTOTPSetupToken(&mfa.HashKey)
+		if err != nil {
+			return e.JSON(err.Status, response.ErrorConvert(*err))
+		}
+		e.SetCookie(cookie)
+	} else if mfa.IsPasswordChange {
+		// パスワード変更が必要な場合は変更専用のトークンのみ発行
+		cookie, err := c.s.IssuePasswordChangeToken(&mfa.HashKey)
 		if err != nil {
 			return e.JSON(err.Status, response.ErrorConvert(*err))
 		}
 		e.SetCookie(cookie)
 	}
 
+	// パスワード変更、認証アプリ登録が必要な場合は完了後にセッション開始
+	if !mfa.IsPasswordChange && !mfa.IsTOTPSetup {
+		// セッション開始(アクセストークン＆リフレッシュトークン) ※MFAで認証したユーザー
+		cookies, err := c.s.CreateSession(&mfa.HashKey, JWT_TOKEN, JWT_SECRET)
+		if err != nil {
+			return e.JSON(err.Status, response.ErrorConvert(*err))
+		}
+		for _, cookie := range cookies {
+			e.SetCookie(cookie)
+		}
+	}
+
 	return e.JSON(http.StatusOK, mfa)
 }
 
+// 認証アプリ取得
+func (c *LoginController) GetTOTP(e echo.Context) error {
+	req := request.GetTOTP{}
+	req.HashKey = authHashKey(e)
+
+	res, err := c.s.GetTOTP(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 認証アプリ登録
+func (c *LoginController) TOTPSetup(e echo.Context) error {
+	req := request.TOTPSetup{}
+	req.HashKey = authHashKey(e)
+
+	res, err := c.s.TOTPSetup(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 認証アプリ確認
+func (c *LoginController) TOTPConfirm(e echo.Context) error {
+	req := request.TOTPConfirm{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.HashKey = authHashKey(e)
+
+	res, err := c.s.TOTPConfirm(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 認証アプリ登録(ログイン時) ※ユーザーは登録トークン(Cookie)から特定
+func (c *LoginController) MFATOTPSetup(e echo.Context) error {
+	req := request.MFATOTPSetup{
+		Token: totpSetupToken(e),
+	}
+
+	res, err := c.s.MFATOTPSetup(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 認証アプリ確認(ログイン時) ※ユーザーは登録トークン(Cookie)から特定
+func (c *LoginController) MFATOTPConfirm(e echo.Context) error {
+	req := request.MFATOTPConfirm{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+	req.HashKey = ""
+	req.Token = totpSetupToken(e)
+
+	res, expired, sErr := c.s.MFATOTPConfirm(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+	e.SetCookie(expired)
+
+	if res.IsPasswordChange {
+		// パスワード変更が必要な場合は変更専用のトークンのみ発行
+		cookie, err := c.s.IssuePasswordChangeToken(&req.HashKey)
+		if err != nil {
+			return e.JSON(err.Status, response.ErrorConvert(*err))
+		}
+		e.SetCookie(cookie)
+	} else {
+		// セッション開始(アクセストークン＆リフレッシュトークン)
+		cookies, err := c.s.CreateSession(&req.HashKey, JWT_TOKEN, JWT_SECRET)
+		if err != nil {
+			return e.JSON(err.Status, response.ErrorConvert(*err))
+		}
+		for _, cookie := range cookies {
+			e.SetCookie(cookie)
+		}
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 認証アプリ解除
+func (c *LoginController) TOTPDisable(e echo.Context) error {
+	req := request.TOTPDisable{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.HashKey = authHashKey(e)
+
+	if err := c.s.TOTPDisable(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// リカバリーコード再発行
+func (c *LoginController) RegenerateRecoveryCode(e echo.Context) error {
+	req := request.RegenerateRecoveryCode{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.HashKey = authHashKey(e)
+
+	res, err := c.s.RegenerateRecoveryCode(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
 // JWT 検証
 func (c *LoginController) JWTDecode(e echo.Context) error {
 	req := request.JWTDecode{}
@@ -121,120 +294,222 @@ func (c *LoginController) JWTDecode(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(c, e, req.HashKey, JWT_TOKEN, JWT_SECRET, true); err != nil {
-		return err
-	}
-
 	return e.JSON(http.StatusOK, "OK")
 }
 
-// パスワード変更
+// パスワード変更 ※ユーザーは変更トークン(Cookie)から特定
 func (c *LoginController) PasswordChange(e echo.Context) error {
 	req := request.PasswordChange{}
 	if err := e.Bind(&req); err != nil {
 		log.Printf("%v", err)
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
+	req.HashKey = ""
+	req.Token = passwordChangeToken(e)
+	req.ClientIP = e.RealIP()
 
 	// パスワード変更
-	if err := c.s.PasswordChange(&req); err != nil {
-		return e.JSON(err.Status, response.ErrorConvert(*err))
+	expired, sErr := c.s.PasswordChange(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
 	}
+	e.SetCookie(expired)
 
-	// JWT＆Cookie
-	cookie, err := c.s.JWT(&req.HashKey, JWT_TOKEN, JWT_SECRET)
+	// セッション開始(アクセストークン＆リフレッシュトークン)
+	cookies, err := c.s.CreateSession(&req.HashKey, JWT_TOKEN, JWT_SECRET)
 	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
-	e.SetCookie(cookie)
+	for _, cookie := range cookies {
+		e.SetCookie(cookie)
+	}
 
 	return e.JSON(http.StatusOK, "OK")
 }
 
-// ログアウト
-func (c *LoginController) Logout(e echo.Context) error {
-	req := request.Logout{}
+// シングルサインオン開始
+func (c *LoginController) SSOStart(e echo.Context) error {
+	req := request.SSOStart{}
 	if err := e.Bind(&req); err != nil {
 		log.Printf("%v", err)
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	cookie, err := c.s.Logout(&req, JWT_TOKEN)
+	res, err := c.s.SSOStart(&req)
 	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
-	e.SetCookie(cookie)
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// シングルサインオン認証 ※MFAはプロバイダ側で実施するため、そのままセッション開始
+func (c *LoginController) SSOCallback(e echo.Context) error {
+	req := request.SSOCallback{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	res, sErr := c.s.SSOCallback(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	// セッション開始(アクセストークン＆リフレッシュトークン)
+	cookies, err := c.s.CreateSession(&res.HashKey, JWT_TOKEN, JWT_SECRET)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	for _, cookie := range cookies {
+		e.SetCookie(cookie)
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// パスワード再設定メール送信
+func (c *LoginController) PasswordResetRequest(e echo.Context) error {
+	req := request.PasswordResetRequest{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	if err := c.s.PasswordResetRequest(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
 
 	return e.JSON(http.StatusOK, "OK")
 }
 
-// チーム存在確認(応募者)
-func (c *LoginController) ConfirmTeamApplicant(e echo.Context) error {
-	req := request.ConfirmTeamApplicant{}
+// パスワード再設定 ※再ログインを必須とするためセッションは発行しない
+func (c *LoginController) PasswordReset(e echo.Context) error {
+	req := request.PasswordReset{}
 	if err := e.Bind(&req); err != nil {
 		log.Printf("%v", err)
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	if err := c.s.ConfirmTeamApplicant(&req); err != nil {
+	if err := c.s.PasswordReset(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
+
 	return e.JSON(http.StatusOK, "OK")
 }
 
-// ログイン(応募者)
-func (c *LoginController) LoginApplicant(e echo.Context) error {
-	req := request.LoginApplicant{}
+// ログアウト
+func (c *LoginController) Logout(e echo.Context) error {
+	req := request.Logout{}
 	if err := e.Bind(&req); err != nil {
 		log.Printf("%v", err)
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// ログイン認証
-	applicant, err := c.s.LoginApplicant(&req)
+	req.HashKey = authHashKey(e)
+	req.SessionID = authSessionID(e)
+
+	cookies, err := c.s.Logout(&req, JWT_TOKEN)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	for _, cookie := range cookies {
+		e.SetCookie(cookie)
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 全端末ログアウト
+func (c *LoginController) LogoutAll(e echo.Context) error {
+	req := request.LogoutAll{}
+	req.HashKey = authHashKey(e)
+
+	cookies, err := c.s.LogoutAll(&req, JWT_TOKEN)
 	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
+	for _, cookie := range cookies {
+		e.SetCookie(cookie)
+	}
 
-	return e.JSON(http.StatusOK, applicant)
+	return e.JSON(http.StatusOK, "OK")
 }
 
-// MFA 認証コード生成(応募者)
-func (c *LoginController) CodeGenerateApplicant(e echo.Context) error {
-	req := request.CodeGenerateApplicant{}
+// セッション更新 ※リフレッシュトークン(Cookie)で認証
+func (c *LoginController) Refresh(e echo.Context) error {
+	cookie, cookieErr := e.Cookie(static.REFRESH_TOKEN_COOKIE)
+	if cookieErr != nil {
+		log.Printf("%v", cookieErr)
+		return e.JSON(http.StatusUnauthorized, fmt.Errorf(static.MESSAGE_UNEXPECTED_COOKIE))
+	}
+
+	cookies, err := c.s.RefreshSession(&request.RefreshSession{
+		Token: cookie.Value,
+	}, JWT_TOKEN, JWT_SECRET)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	for _, cookie := range cookies {
+		e.SetCookie(cookie)
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// チーム存在確認(応募者)
+func (c *LoginController) ConfirmTeamApplicant(e echo.Context) error {
+	req := request.ConfirmTeamApplicant{}
 	if err := e.Bind(&req); err != nil {
 		log.Printf("%v", err)
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	if err := c.s.CodeGenerateApplicant(&req); err != nil {
+	if err := c.s.ConfirmTeamApplicant(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 	return e.JSON(http.StatusOK, "OK")
 }
 
-// MFA(応募者)
-func (c *LoginController) MFAApplicant(e echo.Context) error {
-	req := request.MFAApplicant{}
+// ログイン(応募者) ※ログインリンクをメール送信
+func (c *LoginController) LoginApplicant(e echo.Context) error {
+	req := request.LoginApplicant{}
 	if err := e.Bind(&req); err != nil {
 		log.Printf("%v", err)
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// MFA
-	if err := c.s.MFAApplicant(&req); err != nil {
+	if err := c.s.LoginApplicant(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// ログインリンク検証(応募者)
+func (c *LoginController) MagicLinkApplicant(e echo.Context) error {
+	req := request.MagicLinkApplicant{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.ClientIP = e.RealIP()
+
+	// ログイン認証
+	applicant, err := c.s.MagicLinkApplicant(&req)
+	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
 	// JWT＆Cookie
-	cookie, err := c.s.JWT(&req.HashKey, JWT_TOKEN2, JWT_SECRET2)
+	cookie, err := c.s.JWT(&applicant.HashKey, JWT_TOKEN2, JWT_SECRET2)
 	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 	e.SetCookie(cookie)
 
-	return e.JSON(http.StatusOK, "OK")
+	return e.JSON(http.StatusOK, applicant)
 }
 
 // JWT 検証(応募者)
@@ -245,11 +520,6 @@ func (c *LoginController) JWTDecodeApplicant(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(c, e, req.HashKey, JWT_TOKEN2, JWT_SECRET2, false); err != nil {
-		return err
-	}
-
 	return e.JSON(http.StatusOK, "OK")
 }
 
@@ -261,6 +531,8 @@ func (c *LoginController) LogoutApplicant(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
+	req.HashKey = authHashKey(e)
+
 	cookie, err := c.s.LogoutApplicant(&req, JWT_TOKEN2)
 	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
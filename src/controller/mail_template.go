@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IMailTemplateController interface {
+	// テンプレート登録
+	Create(e echo.Context) error
+	// テンプレート更新
+	Update(e echo.Context) error
+	// テンプレート削除
+	Delete(e echo.Context) error
+	// テンプレート取得
+	Get(e echo.Context) error
+	// テンプレート検索
+	Search(e echo.Context) error
+	// 変数登録
+	CreateVariable(e echo.Context) error
+	// 変数更新
+	UpdateVariable(e echo.Context) error
+	// 変数削除
+	DeleteVariable(e echo.Context) error
+	// 変数検索
+	SearchVariable(e echo.Context) error
+	// プレビュー
+	Preview(e echo.Context) error
+	// 送信_応募者
+	Send(e echo.Context) error
+}
+
+type MailTemplateController struct {
+	s service.IMailTemplateService
+}
+
+func NewMailTemplateController(
+	s service.IMailTemplateService,
+) IMailTemplateController {
+	return &MailTemplateController{s}
+}
+
+// テンプレート登録
+func (c *MailTemplateController) Create(e echo.Context) error {
+	req := request.CreateMailTemplate{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Create(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// テンプレート更新
+func (c *MailTemplateController) Update(e echo.Context) error {
+	req := request.UpdateMailTemplate{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Update(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// テンプレート削除
+func (c *MailTemplateController) Delete(e echo.Context) error {
+	req := request.DeleteMailTemplate{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Delete(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// テンプレート取得
+func (c *MailTemplateController) Get(e echo.Context) error {
+	req := request.GetMailTemplate{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.s.Get(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// テンプレート検索
+func (c *MailTemplateController) Search(e echo.Context) error {
+	req := request.SearchMailTemplate{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.s.Search(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 変数登録
+func (c *MailTemplateController) CreateVariable(e echo.Context) error {
+	req := request.CreateVariable{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.CreateVariable(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 変数更新
+func (c *MailTemplateController) UpdateVariable(e echo.Context) error {
+	req := request.UpdateVariable{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.UpdateVariable(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 変数削除
+func (c *MailTemplateController) DeleteVariable(e echo.Context) error {
+	req := request.DeleteVariable{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.DeleteVariable(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 変数検索
+func (c *MailTemplateController) SearchVariable(e echo.Context) error {
+	req := request.SearchVariable{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.s.SearchVariable(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// プレビュー
+func (c *MailTemplateController) Preview(e echo.Context) error {
+	req := request.PreviewMail{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.s.Preview(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// 送信_応募者
+func (c *MailTemplateController) Send(e echo.Context) error {
+	req := request.SendMail{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.s.Send(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
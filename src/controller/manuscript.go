@@ -26,21 +26,13 @@ type IManuscriptController interface {
 }
 
 type ManuscriptController struct {
-	s     service.IManuscriptService
-	login service.ILoginService
-	role  service.IRoleService
+	s service.IManuscriptService
 }
 
 func NewManuscriptController(
 	s service.IManuscriptService,
-	login service.ILoginService,
-	role service.IRoleService,
 ) IManuscriptController {
-	return &ManuscriptController{s, login, role}
-}
-
-func (c *ManuscriptController) GetLoginService() service.ILoginService {
-	return c.login
+	return &ManuscriptController{s}
 }
 
 // 検索
@@ -51,34 +43,7 @@ func (c *ManuscriptController) Search(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_MANUSCRIPT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, sErr := c.s.Search(&req)
 	if sErr != nil {
@@ -96,34 +61,7 @@ func (c *ManuscriptController) Create(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_MANUSCRIPT_CREATE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.Create(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -140,34 +78,7 @@ func (c *ManuscriptController) CreateApplicantAssociation(e echo.Context) error
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_SETTING_MANUSCRIPT,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.CreateApplicantAssociation(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -184,34 +95,7 @@ func (c *ManuscriptController) SearchManuscriptByTeam(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_APPLICANT_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, sErr := c.s.SearchManuscriptByTeam(&req)
 	if sErr != nil {
@@ -230,34 +114,7 @@ func (c *ManuscriptController) Delete(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_MANUSCRIPT_DELETE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	// サービスで削除処理を実行
 	if err := c.s.Delete(&req); err != nil {
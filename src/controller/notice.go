@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type INoticeController interface {
+	// 検索
+	Search(e echo.Context) error
+	// 既読
+	Read(e echo.Context) error
+	// 未読数
+	CountUnread(e echo.Context) error
+}
+
+type NoticeController struct {
+	s service.INoticeService
+}
+
+func NewNoticeController(
+	s service.INoticeService,
+) INoticeController {
+	return &NoticeController{s}
+}
+
+// 検索
+func (c *NoticeController) Search(e echo.Context) error {
+	req := request.SearchNotice{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Search(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 既読
+func (c *NoticeController) Read(e echo.Context) error {
+	req := request.ReadNotice{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Read(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 未読数
+func (c *NoticeController) CountUnread(e echo.Context) error {
+	req := request.CountUnreadNotice{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.CountUnread(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IOperationLogController interface {
+	// 検索
+	Search(e echo.Context) error
+	// 取得
+	Get(e echo.Context) error
+	// イベント一覧
+	ListEvent(e echo.Context) error
+}
+
+type OperationLogController struct {
+	s service.IOperationLogService
+}
+
+func NewOperationLogController(
+	s service.IOperationLogService,
+) IOperationLogController {
+	return &OperationLogController{s}
+}
+
+// 検索
+func (c *OperationLogController) Search(e echo.Context) error {
+	req := request.SearchOperationLog{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Search(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 取得
+func (c *OperationLogController) Get(e echo.Context) error {
+	req := request.GetOperationLog{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.Get(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// イベント一覧
+func (c *OperationLogController) ListEvent(e echo.Context) error {
+	req := request.ListOperationLogEvent{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.ListEvent(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
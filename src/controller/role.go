@@ -15,22 +15,28 @@ import (
 type IRoleController interface {
 	// 検索_企業ID
 	SearchByCompanyID(e echo.Context) error
+	// ロールマスタ一覧
+	ListMasterRole(e echo.Context) error
+	// 取得
+	Get(e echo.Context) error
+	// 登録
+	Create(e echo.Context) error
+	// 更新
+	Update(e echo.Context) error
+	// 削除
+	Delete(e echo.Context) error
+	// ユーザー割り当て
+	Assign(e echo.Context) error
 }
 
 type RoleController struct {
-	role  service.IRoleService
-	login service.ILoginService
+	role service.IRoleService
 }
 
 func NewRoleController(
 	role service.IRoleService,
-	login service.ILoginService,
 ) IRoleController {
-	return &RoleController{role, login}
-}
-
-func (c *RoleController) GetLoginService() service.ILoginService {
-	return c.login
+	return &RoleController{role}
 }
 
 // 検索_企業ID
@@ -41,40 +47,111 @@ func (c *RoleController) SearchByCompanyID(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
+	req.UserHashKey = authHashKey(e)
+
+	// 検索
+	res, sErr := c.role.SearchRoleByComapny(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
 	}
 
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_ROLE_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
+	return e.JSON(http.StatusOK, res)
+}
+
+// ロールマスタ一覧
+func (c *RoleController) ListMasterRole(e echo.Context) error {
+	req := request.ListMasterRole{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.role.ListMasterRole(&req)
+	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
+	return e.JSON(http.StatusOK, res)
+}
 
-	// 検索
-	res, sErr := c.role.SearchRoleByComapny(&req)
-	if sErr != nil {
-		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+// 取得
+func (c *RoleController) Get(e echo.Context) error {
+	req := request.GetRole{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.role.Get(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
 	return e.JSON(http.StatusOK, res)
 }
+
+// 登録
+func (c *RoleController) Create(e echo.Context) error {
+	req := request.CreateRole{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.role.Create(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 更新
+func (c *RoleController) Update(e echo.Context) error {
+	req := request.UpdateRole{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.role.Update(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 削除
+func (c *RoleController) Delete(e echo.Context) error {
+	req := request.DeleteRole{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.role.Delete(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// ユーザー割り当て
+func (c *RoleController) Assign(e echo.Context) error {
+	req := request.AssignRole{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.role.Assign(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
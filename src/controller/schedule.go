@@ -23,26 +23,22 @@ type IScheduleController interface {
 	Search(e echo.Context) error
 	// 削除
 	Delete(e echo.Context) error
+	// 例外登録
+	SaveException(e echo.Context) error
+	// 例外削除
+	DeleteException(e echo.Context) error
 }
 
 type ScheduleController struct {
-	s     service.IScheduleService
-	a     service.IApplicantService
-	login service.ILoginService
-	role  service.IRoleService
+	s service.IScheduleService
+	a service.IApplicantService
 }
 
 func NewScheduleController(
 	s service.IScheduleService,
 	a service.IApplicantService,
-	login service.ILoginService,
-	role service.IRoleService,
 ) IScheduleController {
-	return &ScheduleController{s, a, login, role}
-}
-
-func (c *ScheduleController) GetLoginService() service.ILoginService {
-	return c.login
+	return &ScheduleController{s, a}
 }
 
 // 登録種別一覧
@@ -62,34 +58,7 @@ func (c *ScheduleController) Insert(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SCHEDULE_CREATE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.Create(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -105,34 +74,7 @@ func (c *ScheduleController) Update(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SCHEDULE_EDIT,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.Update(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -148,34 +90,7 @@ func (c *ScheduleController) Search(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SCHEDULE_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.Search(&req)
 	if err != nil {
@@ -192,36 +107,41 @@ func (c *ScheduleController) Delete(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Delete(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
+	return e.JSON(http.StatusOK, "OK")
+}
 
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SCHEDULE_DELETE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
+// 例外登録
+func (c *ScheduleController) SaveException(e echo.Context) error {
+	req := request.SaveScheduleException{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.SaveException(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
+	return e.JSON(http.StatusOK, "OK")
+}
 
-	if err := c.s.Delete(&req); err != nil {
+// 例外削除
+func (c *ScheduleController) DeleteException(e echo.Context) error {
+	req := request.DeleteScheduleException{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.DeleteException(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 	return e.JSON(http.StatusOK, "OK")
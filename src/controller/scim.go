@@ -0,0 +1,348 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ISCIMController interface {
+	// トークン設定取得
+	GetToken(e echo.Context) error
+	// トークン発行
+	IssueToken(e echo.Context) error
+	// トークン削除
+	DeleteToken(e echo.Context) error
+	// ユーザー一覧
+	ListUser(e echo.Context) error
+	// ユーザー取得
+	GetUser(e echo.Context) error
+	// ユーザー登録
+	CreateUser(e echo.Context) error
+	// ユーザー置換
+	ReplaceUser(e echo.Context) error
+	// ユーザー部分更新
+	PatchUser(e echo.Context) error
+	// ユーザー削除
+	DeleteUser(e echo.Context) error
+	// グループ一覧
+	ListGroup(e echo.Context) error
+	// グループ取得
+	GetGroup(e echo.Context) error
+	// グループ登録
+	CreateGroup(e echo.Context) error
+	// グループ置換
+	ReplaceGroup(e echo.Context) error
+	// グループ部分更新
+	PatchGroup(e echo.Context) error
+	// グループ削除
+	DeleteGroup(e echo.Context) error
+}
+
+type SCIMController struct {
+	scim service.ISCIMService
+}
+
+func NewSCIMController(
+	scim service.ISCIMService,
+) ISCIMController {
+	return &SCIMController{scim}
+}
+
+// トークン設定取得
+func (c *SCIMController) GetToken(e echo.Context) error {
+	req := request.GetSCIMToken{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.scim.GetToken(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// トークン発行
+func (c *SCIMController) IssueToken(e echo.Context) error {
+	req := request.IssueSCIMToken{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, sErr := c.scim.IssueToken(&req)
+	if sErr != nil {
+		return e.JSON(sErr.Status, response.ErrorConvert(*sErr))
+	}
+
+	return e.JSON(http.StatusOK, res)
+}
+
+// トークン削除
+func (c *SCIMController) DeleteToken(e echo.Context) error {
+	req := request.DeleteSCIMToken{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.scim.DeleteToken(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// ユーザー一覧
+func (c *SCIMController) ListUser(e echo.Context) error {
+	req := request.SCIMList{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+
+	res, sErr := c.scim.ListUser(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// ユーザー取得
+func (c *SCIMController) GetUser(e echo.Context) error {
+	req := request.SCIMResource{
+		SCIMAbstract: request.SCIMAbstract{Token: scimToken(e)},
+		ID:           e.Param("id"),
+	}
+
+	res, sErr := c.scim.GetUser(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// ユーザー登録
+func (c *SCIMController) CreateUser(e echo.Context) error {
+	req := request.SCIMUser{}
+	if err := scimBind(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+
+	res, sErr := c.scim.CreateUser(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusCreated, res)
+}
+
+// ユーザー置換
+func (c *SCIMController) ReplaceUser(e echo.Context) error {
+	req := request.SCIMUser{}
+	if err := scimBind(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+	req.ID = e.Param("id")
+
+	res, sErr := c.scim.ReplaceUser(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// ユーザー部分更新
+func (c *SCIMController) PatchUser(e echo.Context) error {
+	req := request.SCIMPatch{}
+	if err := scimBind(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+	req.ID = e.Param("id")
+
+	res, sErr := c.scim.PatchUser(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// ユーザー削除
+func (c *SCIMController) DeleteUser(e echo.Context) error {
+	req := request.SCIMResource{
+		SCIMAbstract: request.SCIMAbstract{Token: scimToken(e)},
+		ID:           e.Param("id"),
+	}
+
+	if err := c.scim.DeleteUser(&req); err != nil {
+		return scimError(e, err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// グループ一覧
+func (c *SCIMController) ListGroup(e echo.Context) error {
+	req := request.SCIMList{}
+	if err := (&echo.DefaultBinder{}).BindQueryParams(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+
+	res, sErr := c.scim.ListGroup(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// グループ取得
+func (c *SCIMController) GetGroup(e echo.Context) error {
+	req := request.SCIMResource{
+		SCIMAbstract: request.SCIMAbstract{Token: scimToken(e)},
+		ID:           e.Param("id"),
+	}
+
+	res, sErr := c.scim.GetGroup(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// グループ登録
+func (c *SCIMController) CreateGroup(e echo.Context) error {
+	req := request.SCIMGroup{}
+	if err := scimBind(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+
+	res, sErr := c.scim.CreateGroup(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusCreated, res)
+}
+
+// グループ置換
+func (c *SCIMController) ReplaceGroup(e echo.Context) error {
+	req := request.SCIMGroup{}
+	if err := scimBind(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+	req.ID = e.Param("id")
+
+	res, sErr := c.scim.ReplaceGroup(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// グループ部分更新
+func (c *SCIMController) PatchGroup(e echo.Context) error {
+	req := request.SCIMPatch{}
+	if err := scimBind(e, &req); err != nil {
+		log.Printf("%v", err)
+		return scimError(e, scimBadRequest())
+	}
+	req.Token = scimToken(e)
+	req.ID = e.Param("id")
+
+	res, sErr := c.scim.PatchGroup(&req)
+	if sErr != nil {
+		return scimError(e, sErr)
+	}
+
+	return scimJSON(e, http.StatusOK, res)
+}
+
+// グループ削除
+func (c *SCIMController) DeleteGroup(e echo.Context) error {
+	req := request.SCIMResource{
+		SCIMAbstract: request.SCIMAbstract{Token: scimToken(e)},
+		ID:           e.Param("id"),
+	}
+
+	if err := c.scim.DeleteGroup(&req); err != nil {
+		return scimError(e, err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// Bearerトークン取得
+func scimToken(e echo.Context) string {
+	header := e.Request().Header.Get(echo.HeaderAuthorization)
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// リクエストボディ読込 ※Content-Typeがapplication/scim+jsonのためe.Bindは使用しない
+func scimBind(e echo.Context, v interface{}) error {
+	return json.NewDecoder(e.Request().Body).Decode(v)
+}
+
+// ボディ不正エラー
+func scimBadRequest() *response.SCIMError {
+	return &response.SCIMError{
+		Schemas:    []string{static.SCIM_SCHEMA_ERROR},
+		Status:     fmt.Sprint(http.StatusBadRequest),
+		ScimType:   static.SCIM_TYPE_INVALID_SYNTAX,
+		Detail:     static.SCIM_DETAIL_INVALID_SYNTAX,
+		HTTPStatus: http.StatusBadRequest,
+	}
+}
+
+// エラーレスポンス
+func scimError(e echo.Context, err *response.SCIMError) error {
+	return scimJSON(e, err.HTTPStatus, err)
+}
+
+// レスポンス ※Content-Typeはapplication/scim+json
+func scimJSON(e echo.Context, status int, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("%v", err)
+		return e.NoContent(http.StatusInternalServerError)
+	}
+	return e.Blob(status, static.SCIM_CONTENT_TYPE, b)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IStreamController interface {
+	// イベント購読(Server-Sent Events)
+	Subscribe(e echo.Context) error
+}
+
+type StreamController struct {
+	s service.IStreamService
+}
+
+func NewStreamController(
+	s service.IStreamService,
+) IStreamController {
+	return &StreamController{s}
+}
+
+// イベント購読(Server-Sent Events) ※操作者はCookie(JWT)から取得
+func (c *StreamController) Subscribe(e echo.Context) error {
+	req := request.Stream{
+		Abstract: request.Abstract{
+			UserHashKey: authHashKey(e),
+		},
+		SessionID: authSessionID(e),
+	}
+
+	ctx := e.Request().Context()
+	messages, err := c.s.Subscribe(ctx, &req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+
+	w := e.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	ticker := time.NewTicker(static.STREAM_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// セッション失効・チーム切替時は通知して終了 ※ローカル環境はセッションなし
+			if err := c.s.Check(ctx, &req); err != nil && os.Getenv("GO_ENV") != "local" {
+				event := static.STREAM_EVENT_RECONNECT
+				if err.Status == http.StatusUnauthorized {
+					event = static.STREAM_EVENT_UNAUTHORIZED
+				}
+				message, _ := json.Marshal(&response.StreamEvent{
+					Type: event,
+				})
+				fmt.Fprintf(w, "data: %s\n\n", message)
+				w.Flush()
+				return nil
+			}
+			// 接続維持
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return nil
+			}
+			w.Flush()
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", message); err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
@@ -33,26 +33,28 @@ type ITeamController interface {
 	StatusEvents(e echo.Context) error
 	// 面接過程マスタ一覧
 	ListInterviewProcessing(e echo.Context) error
+	// 面接予約枠設定取得
+	GetBookingWindow(e echo.Context) error
+	// 面接予約枠設定更新
+	UpdateBookingWindow(e echo.Context) error
+	// 面接予約枠設定削除
+	DeleteBookingWindow(e echo.Context) error
+	// 定休日設定取得
+	GetWeekday(e echo.Context) error
+	// 定休日設定更新
+	UpdateWeekday(e echo.Context) error
 }
 
 type TeamController struct {
-	s     service.ITeamService
-	a     service.IApplicantService
-	login service.ILoginService
-	role  service.IRoleService
+	s service.ITeamService
+	a service.IApplicantService
 }
 
 func NewTeamController(
 	s service.ITeamService,
 	a service.IApplicantService,
-	login service.ILoginService,
-	role service.IRoleService,
 ) ITeamController {
-	return &TeamController{s, a, login, role}
-}
-
-func (c *TeamController) GetLoginService() service.ILoginService {
-	return c.login
+	return &TeamController{s, a}
 }
 
 // 検索
@@ -63,34 +65,7 @@ func (c *TeamController) Search(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_TEAM_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.Search(&req)
 	if err != nil {
@@ -107,34 +82,7 @@ func (c *TeamController) Create(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_TEAM_CREATE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.Create(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -150,34 +98,7 @@ func (c *TeamController) Update(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_TEAM_EDIT,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.Update(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -193,34 +114,7 @@ func (c *TeamController) UpdateBasic(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.UpdateBasic(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -236,34 +130,7 @@ func (c *TeamController) Delete(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_TEAM_DELETE,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.Delete(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -279,34 +146,7 @@ func (c *TeamController) Get(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_TEAM_DETAIL_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.Get(&req)
 	if err != nil {
@@ -323,34 +163,7 @@ func (c *TeamController) GetOwn(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.GetOwn(&req)
 	if err != nil {
@@ -367,34 +180,7 @@ func (c *TeamController) SearchByCompany(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.HashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.HashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_TEAM_READ,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.HashKey = authHashKey(e)
 
 	res, err := c.s.SearchByCompany(&req)
 	if err != nil {
@@ -411,34 +197,7 @@ func (c *TeamController) StatusEvents(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	res, err := c.s.StatusEvents(&req)
 	if err != nil {
@@ -455,3 +214,85 @@ func (c *TeamController) ListInterviewProcessing(e echo.Context) error {
 	}
 	return e.JSON(http.StatusOK, res)
 }
+
+// 面接予約枠設定取得
+func (c *TeamController) GetBookingWindow(e echo.Context) error {
+	req := request.GetBookingWindow{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.GetBookingWindow(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 面接予約枠設定更新
+func (c *TeamController) UpdateBookingWindow(e echo.Context) error {
+	req := request.UpdateBookingWindow{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.UpdateBookingWindow(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 面接予約枠設定削除
+func (c *TeamController) DeleteBookingWindow(e echo.Context) error {
+	req := request.DeleteBookingWindow{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.DeleteBookingWindow(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 定休日設定取得
+func (c *TeamController) GetWeekday(e echo.Context) error {
+	req := request.GetTeamWeekday{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.GetWeekday(&req)
+	if err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// 定休日設定更新
+func (c *TeamController) UpdateWeekday(e echo.Context) error {
+	req := request.UpdateTeamWeekday{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.UpdateWeekday(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
+	}
+	return e.JSON(http.StatusOK, "OK")
+}
@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"api/src/model/ddl"
 	"api/src/model/request"
 	"api/src/model/response"
 	"api/src/model/static"
@@ -34,26 +33,24 @@ type IUserController interface {
 	OccupationMaster(e echo.Context) error
 	// 削除
 	Delete(e echo.Context) error
+	// 認証アプリリセット
+	ResetTOTP(e echo.Context) error
+	// パスワード再発行
+	ReissuePassword(e echo.Context) error
+	// ログインロック解除
+	Unlock(e echo.Context) error
 }
 
 type UserController struct {
-	s     service.IUserService
-	a     service.IApplicantService
-	login service.ILoginService
-	role  service.IRoleService
+	s service.IUserService
+	a service.IApplicantService
 }
 
 func NewUserController(
 	s service.IUserService,
 	a service.IApplicantService,
-	login service.ILoginService,
-	role service.IRoleService,
 ) IUserController {
-	return &UserController{s, a, login, role}
-}
-
-func (c *UserController) GetLoginService() service.ILoginService {
-	return c.login
+	return &UserController{s, a}
 }
 
 // 登録
@@ -64,51 +61,7 @@ func (c *UserController) Create(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.HashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ログイン種別取得
-	loginType, loginTypeErr := c.login.GetLoginType(&request.GetLoginType{
-		User: ddl.User{
-			AbstractTransactionModel: ddl.AbstractTransactionModel{
-				HashKey: req.HashKey,
-			},
-		},
-	})
-	if loginTypeErr != nil {
-		return e.JSON(loginTypeErr.Status, response.ErrorConvert(*loginTypeErr))
-	}
-
-	id := static.ROLE_ADMIN_USER_CREATE
-	if loginType.LoginType == static.LOGIN_TYPE_MANAGEMENT {
-		id = static.ROLE_MANAGEMENT_USER_CREATE
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.HashKey,
-		},
-		ID: id,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.HashKey = authHashKey(e)
 
 	res, sErr := c.s.Create(&req)
 	if sErr != nil {
@@ -126,51 +79,7 @@ func (c *UserController) Search(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.HashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ログイン種別取得
-	loginType, loginTypeErr := c.login.GetLoginType(&request.GetLoginType{
-		User: ddl.User{
-			AbstractTransactionModel: ddl.AbstractTransactionModel{
-				HashKey: req.HashKey,
-			},
-		},
-	})
-	if loginTypeErr != nil {
-		return e.JSON(loginTypeErr.Status, response.ErrorConvert(*loginTypeErr))
-	}
-
-	id := static.ROLE_ADMIN_USER_READ
-	if loginType.LoginType == static.LOGIN_TYPE_MANAGEMENT {
-		id = static.ROLE_MANAGEMENT_USER_READ
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.HashKey,
-		},
-		ID: id,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.HashKey = authHashKey(e)
 
 	res, err := c.s.Search(&req)
 	if err != nil {
@@ -187,51 +96,7 @@ func (c *UserController) SearchByCompany(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.HashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ログイン種別取得
-	loginType, loginTypeErr := c.login.GetLoginType(&request.GetLoginType{
-		User: ddl.User{
-			AbstractTransactionModel: ddl.AbstractTransactionModel{
-				HashKey: req.HashKey,
-			},
-		},
-	})
-	if loginTypeErr != nil {
-		return e.JSON(loginTypeErr.Status, response.ErrorConvert(*loginTypeErr))
-	}
-
-	id := static.ROLE_ADMIN_USER_READ
-	if loginType.LoginType == static.LOGIN_TYPE_MANAGEMENT {
-		id = static.ROLE_MANAGEMENT_USER_READ
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.HashKey,
-		},
-		ID: id,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusNoContent,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.HashKey = authHashKey(e)
 
 	res, err := c.s.SearchByCompany(&req)
 	if err != nil {
@@ -248,34 +113,7 @@ func (c *UserController) UpdateStatus(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.a.UpdateStatus(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -309,34 +147,7 @@ func (c *UserController) UpdateAssignMethod(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
-	}
-
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: static.ROLE_MANAGEMENT_SETTING_TEAM,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
-	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
-		return e.JSON(err.Status, response.ErrorConvert(*err))
-	}
+	req.UserHashKey = authHashKey(e)
 
 	if err := c.s.UpdateAssignMethod(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
@@ -370,54 +181,62 @@ func (c *UserController) Delete(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	// JWT検証
-	if err := JWTDecodeCommon(
-		c,
-		e,
-		req.UserHashKey,
-		JWT_TOKEN,
-		JWT_SECRET,
-		true,
-	); err != nil {
-		return err
+	req.UserHashKey = authHashKey(e)
+
+	// 削除
+	if err := c.s.Delete(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
-	// ログイン種別取得
-	loginType, loginTypeErr := c.login.GetLoginType(&request.GetLoginType{
-		User: ddl.User{
-			AbstractTransactionModel: ddl.AbstractTransactionModel{
-				HashKey: req.UserHashKey,
-			},
-		},
-	})
-	if loginTypeErr != nil {
-		return e.JSON(loginTypeErr.Status, response.ErrorConvert(*loginTypeErr))
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// 認証アプリリセット
+func (c *UserController) ResetTOTP(e echo.Context) error {
+	req := request.ResetUserTOTP{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
 
-	id := static.ROLE_ADMIN_USER_DELETE
-	if loginType.LoginType == static.LOGIN_TYPE_MANAGEMENT {
-		id = static.ROLE_MANAGEMENT_USER_DELETE
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.ResetTOTP(&req); err != nil {
+		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
-	// ロールチェック
-	exist, roleErr := c.role.Check(&request.CheckRole{
-		Abstract: request.Abstract{
-			UserHashKey: req.UserHashKey,
-		},
-		ID: id,
-	})
-	if roleErr != nil {
-		return e.JSON(roleErr.Status, response.ErrorConvert(*roleErr))
+	return e.JSON(http.StatusOK, "OK")
+}
+
+// パスワード再発行
+func (c *UserController) ReissuePassword(e echo.Context) error {
+	req := request.ReissueUserPassword{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
 	}
-	if !exist {
-		err := &response.Error{
-			Status: http.StatusForbidden,
-		}
+
+	req.UserHashKey = authHashKey(e)
+
+	res, err := c.s.ReissuePassword(&req)
+	if err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
-	// 削除
-	if err := c.s.Delete(&req); err != nil {
+	return e.JSON(http.StatusOK, res)
+}
+
+// ログインロック解除
+func (c *UserController) Unlock(e echo.Context) error {
+	req := request.UnlockUser{}
+	if err := e.Bind(&req); err != nil {
+		log.Printf("%v", err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf(static.MESSAGE_BAD_REQUEST))
+	}
+
+	req.UserHashKey = authHashKey(e)
+
+	if err := c.s.Unlock(&req); err != nil {
 		return e.JSON(err.Status, response.ErrorConvert(*err))
 	}
 
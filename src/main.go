@@ -7,6 +7,8 @@ import (
 	"api/src/router"
 	"api/src/service"
 	"api/src/validator"
+	"context"
+	"os"
 )
 
 func main() {
@@ -19,7 +21,6 @@ func main() {
 	// Repository
 	dbRepository := repository.NewDBRepository(db)
 	redisRepository := repository.NewRedisRepository(redis)
-	outerRepository := repository.NewOuterRepository()
 	awsRepository := repository.NewAWSRepository()
 	googleRepository := repository.NewGoogleRepository(redis)
 	masterRepository := repository.NewMasterRepository(db)
@@ -30,6 +31,18 @@ func main() {
 	roleRepository := repository.NewRoleRepository(db)
 	companyRepository := repository.NewCompanyRepository(db)
 	applicantRepository := repository.NewApplicantRepository(db, redis)
+	mailRepository := repository.NewMailRepository(db)
+	mailTemplateRepository := repository.NewMailTemplateRepository(db)
+	operationLogRepository := repository.NewOperationLogRepository(db)
+	uploadHistoryRepository := repository.NewUploadHistoryRepository(db)
+	noticeRepository := repository.NewNoticeRepository(db)
+	analysisRepository := repository.NewAnalysisRepository(db)
+	calendarRepository := repository.NewCalendarRepository(db)
+	loginLockoutRepository := repository.NewLoginLockoutRepository(db)
+	oidcRepository := repository.NewOIDCRepository()
+	scimRepository := repository.NewSCIMRepository(db)
+	holidayRepository := repository.NewHolidayRepository(db)
+	jobRepository := repository.NewJobRepository(db)
 
 	// Validator
 	commonValidator := validator.NewCommonValidator()
@@ -41,6 +54,12 @@ func main() {
 	companyValidator := validator.NewCompanyValidator()
 	roleValidator := validator.NewRoleValidator()
 	manuscriptValidator := validator.NewManuscriptValidator()
+	mailTemplateValidator := validator.NewMailTemplateValidator()
+	operationLogValidator := validator.NewOperationLogValidator()
+	noticeValidator := validator.NewNoticeValidator()
+	analysisValidator := validator.NewAnalysisValidator()
+	scimValidator := validator.NewSCIMValidator()
+	jobValidator := validator.NewJobValidator()
 
 	// Service
 	commonService := service.NewCommonService(
@@ -59,6 +78,8 @@ func main() {
 		teamRepository,
 		companyValidator,
 		dbRepository,
+		mailRepository,
+		holidayRepository,
 	)
 	applicantService := service.NewApplicantService(
 		applicantRepository,
@@ -72,7 +93,11 @@ func main() {
 		redisRepository,
 		applicantValidator,
 		dbRepository,
-		outerRepository,
+		holidayRepository,
+		operationLogRepository,
+		uploadHistoryRepository,
+		noticeRepository,
+		mailRepository,
 	)
 	loginService := service.NewLoginService(
 		userRepository,
@@ -82,6 +107,10 @@ func main() {
 		loginValidator,
 		userValidator,
 		dbRepository,
+		mailRepository,
+		companyRepository,
+		loginLockoutRepository,
+		oidcRepository,
 	)
 	userService := service.NewUserService(
 		userRepository,
@@ -94,8 +123,11 @@ func main() {
 		userValidator,
 		teamValidator,
 		dbRepository,
-		outerRepository,
 		redisRepository,
+		mailRepository,
+		operationLogRepository,
+		loginLockoutRepository,
+		companyRepository,
 	)
 	teamService := service.NewTeamService(
 		dbRepository,
@@ -108,7 +140,7 @@ func main() {
 		manuscriptRepository,
 		masterRepository,
 		teamValidator,
-		outerRepository,
+		operationLogRepository,
 	)
 	scheduleService := service.NewScheduleService(
 		dbRepository,
@@ -121,7 +153,7 @@ func main() {
 		manuscriptRepository,
 		masterRepository,
 		scheduleValidator,
-		outerRepository,
+		operationLogRepository,
 	)
 	manuscriptService := service.NewManuscriptService(
 		manuscriptRepository,
@@ -132,19 +164,101 @@ func main() {
 		dbRepository,
 		redisRepository,
 		manuscriptValidator,
+		operationLogRepository,
+	)
+	roleService := service.NewRoleService(
+		roleRepository,
+		masterRepository,
+		userRepository,
+		redisRepository,
+		roleValidator,
+		dbRepository,
+		operationLogRepository,
+	)
+	mailTemplateService := service.NewMailTemplateService(
+		mailTemplateRepository,
+		applicantRepository,
+		teamRepository,
+		mailRepository,
+		dbRepository,
+		redisRepository,
+		mailTemplateValidator,
+	)
+	operationLogService := service.NewOperationLogService(
+		operationLogRepository,
+		masterRepository,
+		redisRepository,
+		operationLogValidator,
+	)
+	noticeService := service.NewNoticeService(
+		noticeRepository,
+		redisRepository,
+		dbRepository,
+		noticeValidator,
+	)
+	streamService := service.NewStreamService(redisRepository)
+	analysisService := service.NewAnalysisService(
+		analysisRepository,
+		masterRepository,
+		teamRepository,
+		redisRepository,
+		analysisValidator,
+	)
+	calendarService := service.NewCalendarService(
+		calendarRepository,
+		teamRepository,
+		redisRepository,
+		dbRepository,
+	)
+	scimService := service.NewSCIMService(
+		scimRepository,
+		userRepository,
+		teamRepository,
+		roleRepository,
+		applicantRepository,
+		scheduleRepository,
+		manuscriptRepository,
+		companyRepository,
+		scimValidator,
+		userValidator,
+		teamValidator,
+		dbRepository,
+		redisRepository,
+		mailRepository,
+		operationLogRepository,
+	)
+	jobService := service.NewJobService(
+		jobRepository,
+		scheduleRepository,
+		userRepository,
+		loginLockoutRepository,
+		teamRepository,
+		mailRepository,
+		redisRepository,
+		jobValidator,
 	)
-	roleService := service.NewRoleService(roleRepository, redisRepository, roleValidator)
 
 	// Controller
-	commonController := controller.NewCommonController(commonService, loginService)
-	companyController := controller.NewCompanyController(companyService, loginService, roleService)
+	commonController := controller.NewCommonController(commonService)
+	companyController := controller.NewCompanyController(companyService)
 	loginController := controller.NewLoginController(loginService)
-	applicantController := controller.NewApplicantController(applicantService, userService, loginService, roleService)
-	userController := controller.NewUserController(userService, applicantService, loginService, roleService)
-	teamController := controller.NewTeamController(teamService, applicantService, loginService, roleService)
-	scheduleController := controller.NewScheduleController(scheduleService, applicantService, loginService, roleService)
-	roleController := controller.NewRoleController(roleService, loginService)
-	manuscriptController := controller.NewManuscriptController(manuscriptService, loginService, roleService)
+	applicantController := controller.NewApplicantController(applicantService, userService)
+	userController := controller.NewUserController(userService, applicantService)
+	teamController := controller.NewTeamController(teamService, applicantService)
+	scheduleController := controller.NewScheduleController(scheduleService, applicantService)
+	roleController := controller.NewRoleController(roleService)
+	manuscriptController := controller.NewManuscriptController(manuscriptService)
+	mailTemplateController := controller.NewMailTemplateController(mailTemplateService)
+	operationLogController := controller.NewOperationLogController(operationLogService)
+	noticeController := controller.NewNoticeController(noticeService)
+	streamController := controller.NewStreamController(streamService)
+	analysisController := controller.NewAnalysisController(analysisService)
+	calendarController := controller.NewCalendarController(calendarService)
+	scimController := controller.NewSCIMController(scimService)
+	jobController := controller.NewJobController(jobService)
+
+	// Middleware
+	authMiddleware := controller.NewAuthMiddleware(loginService, roleService)
 
 	e := router.NewRouter(
 		commonController,
@@ -156,6 +270,24 @@ func main() {
 		applicantController,
 		manuscriptController,
 		roleController,
+		mailTemplateController,
+		operationLogController,
+		noticeController,
+		streamController,
+		analysisController,
+		calendarController,
+		scimController,
+		jobController,
+		authMiddleware,
 	)
+
+	// メール送信 ※送信待ちを送信、複数プロセスで実行しても重複送信しない
+	go mailRepository.Start(context.Background())
+
+	// バックグラウンドジョブ ※専用プロセス(cmd/job)で実行する場合はJOB_RUNNER=offで無効化
+	if os.Getenv("JOB_RUNNER") != "off" {
+		go jobService.Start(context.Background())
+	}
+
 	e.Logger.Fatal(e.Start(":8080"))
 }
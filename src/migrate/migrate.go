@@ -44,10 +44,21 @@ func main() {
 			&ddl.Processing{},
 			// t
 			&ddl.Company{},
+			&ddl.CompanySecurity{},
+			&ddl.CompanyOIDC{},
+			&ddl.CompanyOIDCDomain{},
 			&ddl.CustomRole{},
 			&ddl.RoleAssociation{},
+			&ddl.CompanySCIMToken{},
+			&ddl.CompanyHoliday{},
 			&ddl.User{},
 			&ddl.UserRefreshTokenAssociation{},
+			&ddl.UserCalendarToken{},
+			&ddl.UserSessionToken{},
+			&ddl.UserTOTP{},
+			&ddl.UserRecoveryCode{},
+			&ddl.UserPasswordHistory{},
+			&ddl.UserOIDC{},
 			&ddl.Team{},
 			&ddl.TeamAssociation{},
 			&ddl.SelectStatus{},
@@ -56,9 +67,14 @@ func main() {
 			&ddl.TeamAutoAssignRule{},
 			&ddl.TeamAssignPriority{},
 			&ddl.TeamPerInterview{},
+			&ddl.TeamBookingWindow{},
+			&ddl.TeamBookingHours{},
+			&ddl.TeamWeekday{},
 			&ddl.TeamAssignPossible{},
 			&ddl.Schedule{},
 			&ddl.ScheduleAssociation{},
+			&ddl.ScheduleException{},
+			&ddl.ScheduleReminder{},
 			&ddl.Applicant{},
 			&ddl.ApplicantUserAssociation{},
 			&ddl.ApplicantType{},
@@ -77,8 +93,29 @@ func main() {
 			&ddl.Notice{},
 			&ddl.OperationLog{},
 			&ddl.HistoryOfUploadApplicant{},
+			&ddl.MailHistory{},
+			&ddl.MailOutbox{},
+			&ddl.LoginLockoutHistory{},
+			&ddl.JobRun{},
 		)
 
+		// 一意制約削除 ※タグを外してもAutoMigrateでは削除されないため明示的に削除
+		for _, u := range []struct {
+			model interface{}
+			name  string
+		}{
+			{&ddl.MailTemplate{}, "t_mail_template_title_key"},
+			{&ddl.MailTemplate{}, "t_mail_template_subject_key"},
+			{&ddl.MailTemplate{}, "t_mail_template_template_key"},
+			{&ddl.Variable{}, "t_variable_title_key"},
+			{&ddl.Variable{}, "t_variable_json_name_key"},
+			{&ddl.MailPreview{}, "t_mail_preview_title_key"},
+		} {
+			if err := DropUnique(dbConn, u.model, u.name); err != nil {
+				log.Println(err)
+			}
+		}
+
 		/*
 			論理名追加
 		*/
@@ -359,6 +396,83 @@ func main() {
 			log.Println(err)
 		}
 
+		// t_company_security
+		if err := AddTableComment(dbConn, "t_company_security", "企業セキュリティ設定"); err != nil {
+			log.Println(err)
+		}
+		companySecurity := map[string]string{
+			"company_id":           "企業ID",
+			"require_totp":         "二要素認証(認証アプリ)必須",
+			"lockout_threshold":    "ロックまでの失敗回数",
+			"lockout_seconds":      "ロック期間(秒)",
+			"lockout_max_seconds":  "最大ロック期間(秒)",
+			"password_min_length":  "パスワード最小文字数",
+			"password_min_classes": "パスワード必要文字種数",
+			"password_history":     "パスワード再利用禁止世代数",
+			"updated_at":           "更新日時",
+		}
+		if err := AddColumnComments(dbConn, "t_company_security", companySecurity); err != nil {
+			log.Println(err)
+		}
+
+		// t_company_oidc
+		if err := AddTableComment(dbConn, "t_company_oidc", "企業シングルサインオン設定(OpenID Connect)"); err != nil {
+			log.Println(err)
+		}
+		companyOIDC := map[string]string{
+			"company_id":       "企業ID",
+			"enabled":          "有効",
+			"issuer":           "発行者(Issuer)",
+			"client_id":        "クライアントID",
+			"client_secret":    "クライアントシークレット(AES-GCM暗号化)",
+			"disable_password": "パスワードログイン無効",
+			"updated_at":       "更新日時",
+		}
+		if err := AddColumnComments(dbConn, "t_company_oidc", companyOIDC); err != nil {
+			log.Println(err)
+		}
+
+		// t_company_oidc_domain
+		if err := AddTableComment(dbConn, "t_company_oidc_domain", "企業シングルサインオン許可ドメイン"); err != nil {
+			log.Println(err)
+		}
+		companyOIDCDomain := map[string]string{
+			"domain":     "ドメイン",
+			"company_id": "企業ID",
+		}
+		if err := AddColumnComments(dbConn, "t_company_oidc_domain", companyOIDCDomain); err != nil {
+			log.Println(err)
+		}
+
+		// t_company_scim_token
+		if err := AddTableComment(dbConn, "t_company_scim_token", "企業SCIMトークン"); err != nil {
+			log.Println(err)
+		}
+		companySCIMToken := map[string]string{
+			"company_id": "企業ID",
+			"token":      "トークン(SHA-256)",
+			"role_id":    "連携ユーザーのロールID",
+			"created_at": "発行日時",
+		}
+		if err := AddColumnComments(dbConn, "t_company_scim_token", companySCIMToken); err != nil {
+			log.Println(err)
+		}
+
+		// t_company_holiday
+		if err := AddTableComment(dbConn, "t_company_holiday", "企業休日"); err != nil {
+			log.Println(err)
+		}
+		companyHoliday := map[string]string{
+			"company_id": "企業ID",
+			"date":       "日付",
+			"kind":       "区分(1:休業日、2:特別営業日)",
+			"name":       "名称",
+			"created_at": "登録日時",
+		}
+		if err := AddColumnComments(dbConn, "t_company_holiday", companyHoliday); err != nil {
+			log.Println(err)
+		}
+
 		// t_role
 		if err := AddTableComment(dbConn, "t_role", "ロール"); err != nil {
 			log.Println(err)
@@ -422,6 +536,95 @@ func main() {
 			log.Println(err)
 		}
 
+		// t_user_calendar_token
+		if err := AddTableComment(dbConn, "t_user_calendar_token", "カレンダー購読トークン"); err != nil {
+			log.Println(err)
+		}
+		userCalendarToken := map[string]string{
+			"user_id":    "ユーザーID",
+			"token":      "トークン(SHA-256)",
+			"created_at": "発行日時",
+		}
+		if err := AddColumnComments(dbConn, "t_user_calendar_token", userCalendarToken); err != nil {
+			log.Println(err)
+		}
+
+		// t_user_session_token
+		if err := AddTableComment(dbConn, "t_user_session_token", "ログインセッション(リフレッシュトークン)"); err != nil {
+			log.Println(err)
+		}
+		userSessionToken := map[string]string{
+			"id":         "ID",
+			"user_id":    "ユーザーID",
+			"session_id": "セッションID",
+			"token":      "リフレッシュトークン(SHA-256)",
+			"expired_at": "有効期限",
+			"used_at":    "使用日時",
+			"revoked_at": "失効日時",
+			"created_at": "発行日時",
+		}
+		if err := AddColumnComments(dbConn, "t_user_session_token", userSessionToken); err != nil {
+			log.Println(err)
+		}
+
+		// t_user_totp
+		if err := AddTableComment(dbConn, "t_user_totp", "二要素認証(認証アプリ)"); err != nil {
+			log.Println(err)
+		}
+		userTOTP := map[string]string{
+			"user_id":      "ユーザーID",
+			"secret":       "シークレット(AES-GCM暗号化)",
+			"last_step":    "最終使用ステップ",
+			"confirmed_at": "有効化日時",
+			"created_at":   "登録日時",
+		}
+		if err := AddColumnComments(dbConn, "t_user_totp", userTOTP); err != nil {
+			log.Println(err)
+		}
+
+		// t_user_recovery_code
+		if err := AddTableComment(dbConn, "t_user_recovery_code", "二要素認証リカバリーコード"); err != nil {
+			log.Println(err)
+		}
+		userRecoveryCode := map[string]string{
+			"id":         "ID",
+			"user_id":    "ユーザーID",
+			"code":       "リカバリーコード(SHA-256)",
+			"used_at":    "使用日時",
+			"created_at": "発行日時",
+		}
+		if err := AddColumnComments(dbConn, "t_user_recovery_code", userRecoveryCode); err != nil {
+			log.Println(err)
+		}
+
+		// t_user_password_history
+		if err := AddTableComment(dbConn, "t_user_password_history", "パスワード履歴"); err != nil {
+			log.Println(err)
+		}
+		userPasswordHistory := map[string]string{
+			"id":         "ID",
+			"user_id":    "ユーザーID",
+			"password":   "パスワード(ハッシュ化)",
+			"created_at": "設定日時",
+		}
+		if err := AddColumnComments(dbConn, "t_user_password_history", userPasswordHistory); err != nil {
+			log.Println(err)
+		}
+
+		// t_user_oidc
+		if err := AddTableComment(dbConn, "t_user_oidc", "シングルサインオン紐づけ(OpenID Connect)"); err != nil {
+			log.Println(err)
+		}
+		userOIDC := map[string]string{
+			"user_id":    "ユーザーID",
+			"issuer":     "発行者(Issuer)",
+			"subject":    "サブジェクト(sub)",
+			"created_at": "紐づけ日時",
+		}
+		if err := AddColumnComments(dbConn, "t_user_oidc", userOIDC); err != nil {
+			log.Println(err)
+		}
+
 		// t_team
 		if err := AddTableComment(dbConn, "t_team", "チーム"); err != nil {
 			log.Println(err)
@@ -534,6 +737,52 @@ func main() {
 			log.Println(err)
 		}
 
+		// t_team_booking_window
+		if err := AddTableComment(dbConn, "t_team_booking_window", "面接予約枠設定"); err != nil {
+			log.Println(err)
+		}
+		teamBookingWindow := map[string]string{
+			"team_id":          "チームID",
+			"num_of_interview": "面接回数(0:チーム既定)",
+			"time_zone":        "タイムゾーン",
+			"duration":         "面接時間(分)",
+			"slot_interval":    "予約枠の刻み(分)",
+			"lead_time":        "受付締切(時間)",
+			"horizon":          "受付期間(日)",
+			"buffer":           "前後の予定との間隔(分)",
+		}
+		if err := AddColumnComments(dbConn, "t_team_booking_window", teamBookingWindow); err != nil {
+			log.Println(err)
+		}
+
+		// t_team_booking_hours
+		if err := AddTableComment(dbConn, "t_team_booking_hours", "面接予約受付時間"); err != nil {
+			log.Println(err)
+		}
+		teamBookingHours := map[string]string{
+			"team_id":          "チームID",
+			"num_of_interview": "面接回数(0:チーム既定)",
+			"weekday":          "曜日(0:日曜)",
+			"start_minute":     "開始(0時からの分)",
+			"end_minute":       "終了(0時からの分)",
+		}
+		if err := AddColumnComments(dbConn, "t_team_booking_hours", teamBookingHours); err != nil {
+			log.Println(err)
+		}
+
+		// t_team_weekday
+		if err := AddTableComment(dbConn, "t_team_weekday", "チーム曜日設定"); err != nil {
+			log.Println(err)
+		}
+		teamWeekday := map[string]string{
+			"team_id": "チームID",
+			"weekday": "曜日(0:日曜)",
+			"closed":  "定休日",
+		}
+		if err := AddColumnComments(dbConn, "t_team_weekday", teamWeekday); err != nil {
+			log.Println(err)
+		}
+
 		// t_team_assign_possible
 		if err := AddTableComment(dbConn, "t_team_assign_possible", "面接毎参加可能者"); err != nil {
 			log.Println(err)
@@ -556,6 +805,10 @@ func main() {
 			"hash_key":      "ハッシュキー",
 			"title":         "タイトル",
 			"freq_id":       "頻度ID",
+			"interval":      "間隔",
+			"by_day":        "曜日",
+			"count":         "回数",
+			"until":         "繰り返し終了日時",
 			"interview_flg": "面接フラグ",
 			"company_id":    "企業ID",
 			"start":         "開始時刻",
@@ -580,6 +833,36 @@ func main() {
 			log.Println(err)
 		}
 
+		// t_schedule_exception
+		if err := AddTableComment(dbConn, "t_schedule_exception", "予定例外"); err != nil {
+			log.Println(err)
+		}
+		scheduleException := map[string]string{
+			"schedule_id":    "予定ID",
+			"original_start": "本来の開始時刻",
+			"kind":           "区分",
+			"start":          "開始時刻",
+			"end":            "終了時刻",
+			"created_at":     "登録日時",
+		}
+		if err := AddColumnComments(dbConn, "t_schedule_exception", scheduleException); err != nil {
+			log.Println(err)
+		}
+
+		// t_schedule_reminder
+		if err := AddTableComment(dbConn, "t_schedule_reminder", "面接リマインド送信履歴"); err != nil {
+			log.Println(err)
+		}
+		scheduleReminder := map[string]string{
+			"schedule_id":  "予定ID",
+			"applicant_id": "応募者ID",
+			"start":        "開始時刻",
+			"created_at":   "送信日時",
+		}
+		if err := AddColumnComments(dbConn, "t_schedule_reminder", scheduleReminder); err != nil {
+			log.Println(err)
+		}
+
 		// t_applicant
 		if err := AddTableComment(dbConn, "t_applicant", "応募者"); err != nil {
 			log.Println(err)
@@ -821,6 +1104,8 @@ func main() {
 			"type":         "種別",
 			"from_user_id": "通知元ユーザーID",
 			"to_user_id":   "通知先ユーザーID",
+			"applicant_id": "対象応募者ID",
+			"read_at":      "既読日時",
 			"company_id":   "企業ID",
 			"created_at":   "登録日時",
 			"updated_at":   "更新日時",
@@ -834,14 +1119,17 @@ func main() {
 			log.Println(err)
 		}
 		operationLog := map[string]string{
-			"id":         "ID",
-			"hash_key":   "ハッシュキー",
-			"event_id":   "イベントID",
-			"user_id":    "対象ユーザーID",
-			"log":        "ログ",
-			"company_id": "企業ID",
-			"created_at": "登録日時",
-			"updated_at": "更新日時",
+			"id":           "ID",
+			"hash_key":     "ハッシュキー",
+			"event_id":     "イベントID",
+			"user_id":      "操作ユーザーID",
+			"applicant_id": "操作応募者ID",
+			"team_id":      "チームID",
+			"target":       "対象ハッシュキー",
+			"log":          "ログ",
+			"company_id":   "企業ID",
+			"created_at":   "登録日時",
+			"updated_at":   "更新日時",
 		}
 		if err := AddColumnComments(dbConn, "t_operation_log", operationLog); err != nil {
 			log.Println(err)
@@ -852,14 +1140,105 @@ func main() {
 			log.Println(err)
 		}
 		historyOfUploadApplicant := map[string]string{
-			"history_id": "履歴ID",
-			"commit_id":  "コミットID",
-			"csv":        "アップロードcsv",
+			"id":           "ID",
+			"hash_key":     "ハッシュキー",
+			"company_id":   "企業ID",
+			"commit_id":    "コミットID",
+			"team_id":      "チームID",
+			"user_id":      "アップロードユーザーID",
+			"site_id":      "サイトID",
+			"file_name":    "ファイル名",
+			"csv":          "アップロードcsv",
+			"total_num":    "総数",
+			"insert_num":   "登録数",
+			"dupl_num":     "重複数",
+			"init_status":  "登録時ステータス",
+			"rollback_flg": "取消フラグ",
+			"created_at":   "登録日時",
+			"updated_at":   "更新日時",
 		}
 		if err := AddColumnComments(dbConn, "t_history_of_upload_applicant", historyOfUploadApplicant); err != nil {
 			log.Println(err)
 		}
 
+		// t_mail_history
+		if err := AddTableComment(dbConn, "t_mail_history", "メール送信履歴"); err != nil {
+			log.Println(err)
+		}
+		mailHistory := map[string]string{
+			"id":            "ID",
+			"company_id":    "企業ID",
+			"kind":          "種別",
+			"to_address":    "宛先",
+			"subject":       "件名",
+			"driver":        "送信方式",
+			"status":        "ステータス",
+			"attempts":      "試行回数",
+			"error_message": "エラー内容",
+			"created_at":    "送信日時",
+		}
+		if err := AddColumnComments(dbConn, "t_mail_history", mailHistory); err != nil {
+			log.Println(err)
+		}
+
+		// t_mail_outbox
+		if err := AddTableComment(dbConn, "t_mail_outbox", "メール送信待ち"); err != nil {
+			log.Println(err)
+		}
+		mailOutbox := map[string]string{
+			"id":          "ID",
+			"company_id":  "企業ID",
+			"kind":        "種別",
+			"to_address":  "宛先",
+			"subject":     "件名",
+			"body":        "本文",
+			"attachments": "添付ファイル",
+			"attempts":    "試行回数",
+			"next_at":     "次回送信日時",
+			"created_at":  "登録日時",
+		}
+		if err := AddColumnComments(dbConn, "t_mail_outbox", mailOutbox); err != nil {
+			log.Println(err)
+		}
+
+		// t_login_lockout_history
+		if err := AddTableComment(dbConn, "t_login_lockout_history", "ログインロック履歴"); err != nil {
+			log.Println(err)
+		}
+		loginLockoutHistory := map[string]string{
+			"id":           "ID",
+			"company_id":   "企業ID",
+			"event":        "イベント",
+			"target":       "対象",
+			"ip_address":   "接続元IPアドレス",
+			"failures":     "失敗回数",
+			"locked_until": "ロック期限",
+			"operator_id":  "解除ユーザーID",
+			"created_at":   "登録日時",
+		}
+		if err := AddColumnComments(dbConn, "t_login_lockout_history", loginLockoutHistory); err != nil {
+			log.Println(err)
+		}
+
+		// t_job_run
+		if err := AddTableComment(dbConn, "t_job_run", "ジョブ実行履歴"); err != nil {
+			log.Println(err)
+		}
+		jobRun := map[string]string{
+			"id":          "ID",
+			"name":        "ジョブ名",
+			"trigger":     "実行契機",
+			"status":      "ステータス",
+			"host":        "実行ホスト",
+			"count":       "処理件数",
+			"message":     "エラー内容",
+			"started_at":  "開始日時",
+			"finished_at": "終了日時",
+		}
+		if err := AddColumnComments(dbConn, "t_job_run", jobRun); err != nil {
+			log.Println(err)
+		}
+
 		// 初期マスタデータ
 		CreateData(dbConn)
 
@@ -888,10 +1267,21 @@ func main() {
 			&ddl.Processing{},
 			// t
 			&ddl.Company{},
+			&ddl.CompanySecurity{},
+			&ddl.CompanyOIDC{},
+			&ddl.CompanyOIDCDomain{},
 			&ddl.CustomRole{},
 			&ddl.RoleAssociation{},
+			&ddl.CompanySCIMToken{},
+			&ddl.CompanyHoliday{},
 			&ddl.User{},
 			&ddl.UserRefreshTokenAssociation{},
+			&ddl.UserCalendarToken{},
+			&ddl.UserSessionToken{},
+			&ddl.UserTOTP{},
+			&ddl.UserRecoveryCode{},
+			&ddl.UserPasswordHistory{},
+			&ddl.UserOIDC{},
 			&ddl.Team{},
 			&ddl.TeamAssociation{},
 			&ddl.SelectStatus{},
@@ -900,9 +1290,14 @@ func main() {
 			&ddl.TeamAutoAssignRule{},
 			&ddl.TeamAssignPriority{},
 			&ddl.TeamPerInterview{},
+			&ddl.TeamBookingWindow{},
+			&ddl.TeamBookingHours{},
+			&ddl.TeamWeekday{},
 			&ddl.TeamAssignPossible{},
 			&ddl.Schedule{},
 			&ddl.ScheduleAssociation{},
+			&ddl.ScheduleException{},
+			&ddl.ScheduleReminder{},
 			&ddl.Applicant{},
 			&ddl.ApplicantUserAssociation{},
 			&ddl.ApplicantType{},
@@ -921,6 +1316,10 @@ func main() {
 			&ddl.Notice{},
 			&ddl.OperationLog{},
 			&ddl.HistoryOfUploadApplicant{},
+			&ddl.MailHistory{},
+			&ddl.MailOutbox{},
+			&ddl.LoginLockoutHistory{},
+			&ddl.JobRun{},
 		)
 
 		defer fmt.Println("Successfully Deleted")
@@ -942,6 +1341,18 @@ func AddColumnComments(db *gorm.DB, tableName string, comments map[string]string
 	return nil
 }
 
+// 一意制約・一意インデックス削除 ※存在しない場合は何もしない
+func DropUnique(db *gorm.DB, model interface{}, name string) error {
+	m := db.Migrator()
+	if m.HasConstraint(model, name) {
+		return m.DropConstraint(model, name)
+	}
+	if m.HasIndex(model, name) {
+		return m.DropIndex(model, name)
+	}
+	return nil
+}
+
 // 初期データ作成
 func CreateData(db *gorm.DB) {
 	master := repository.NewMasterRepository(db)
@@ -998,7 +1409,7 @@ func CreateData(db *gorm.DB) {
 			TELIndex:        static.INDEX_RECRUIT_TEL,
 			AgeIndex:        static.INDEX_RECRUIT_AGE,
 			ManuscriptIndex: static.INDEX_RECRUIT_MANUSCRIPT,
-			NameCheckType:   1,
+			NameCheckType:   static.NAME_CHECK_TYPE_FULL,
 			NumOfColumn:     static.COLUMNS_RECRUIT,
 		},
 		{
@@ -1169,6 +1580,322 @@ func CreateData(db *gorm.DB) {
 		}
 	}
 
+	// m_operation_log_event
+	operationLogEvents := []*ddl.OperationLogEvent{
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_DOWNLOAD,
+			},
+			Event: "応募者取込",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_DESIRED_AT,
+			},
+			Event: "面接希望日登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_DOCUMENT_UPLOAD,
+			},
+			Event: "書類アップロード",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_CREATE_MEET_URL,
+			},
+			Event: "Google Meet URL発行",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_ASSIGN_USER,
+			},
+			Event: "面接官割り振り",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_UPDATE_TYPE,
+			},
+			Event: "応募者種別変更",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_UPDATE_STATUS,
+			},
+			Event: "応募者ステータス変更",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_INPUT_RESULT,
+			},
+			Event: "面接結果入力",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_CREATE_TYPE,
+			},
+			Event: "応募者種別登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_SETTING_STATUS,
+			},
+			Event: "選考ステータス設定",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_APPLICANT_ROLLBACK_UPLOAD,
+			},
+			Event: "応募者取込取消",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_CREATE,
+			},
+			Event: "チーム登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_UPDATE,
+			},
+			Event: "チーム更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_UPDATE_BASIC,
+			},
+			Event: "チーム基本情報更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_DELETE,
+			},
+			Event: "チーム削除",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_UPDATE_ASSIGN,
+			},
+			Event: "面接官割り振り方法更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_UPDATE_BOOKING,
+			},
+			Event: "面接予約枠設定更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_TEAM_UPDATE_WEEKDAY,
+			},
+			Event: "定休日設定更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_USER_CREATE,
+			},
+			Event: "ユーザー登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_USER_DELETE,
+			},
+			Event: "ユーザー削除",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_USER_UPDATE,
+			},
+			Event: "ユーザー更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_SCHEDULE_CREATE,
+			},
+			Event: "予定登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_SCHEDULE_UPDATE,
+			},
+			Event: "予定更新",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_SCHEDULE_DELETE,
+			},
+			Event: "予定削除",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_SCHEDULE_SAVE_EXCEPTION,
+			},
+			Event: "予定例外登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_SCHEDULE_DELETE_EXCEPTION,
+			},
+			Event: "予定例外削除",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_MANUSCRIPT_CREATE,
+			},
+			Event: "原稿登録",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_MANUSCRIPT_ASSIGN_APPLICANT,
+			},
+			Event: "原稿応募者紐づけ",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_MANUSCRIPT_DELETE,
+			},
+			Event: "原稿削除",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_ROLE_CREATE,
+			},
+			Event: "ロール作成",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_ROLE_EDIT,
+			},
+			Event: "ロール編集",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_ROLE_DELETE,
+			},
+			Event: "ロール削除",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.OPERATION_LOG_EVENT_ROLE_ASSIGN,
+			},
+			Event: "ロール付与",
+		},
+	}
+	for _, row := range operationLogEvents {
+		_, hash, _ := service.GenerateHash(1, 25)
+		row.HashKey = "m_operation_log_event" + "_" + *hash
+		if err := master.InsertOperationLogEvent(tx, row); err != nil {
+			if err := tx.Rollback().Error; err != nil {
+				log.Printf("%v", err)
+				return
+			}
+			return
+		}
+	}
+
+	// m_analysis_term
+	analysisTerms := []*ddl.AnalysisTerm{
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_STATUS,
+			},
+			TermJa: "選考ステータス別",
+			TermEn: "status",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_INTERVIEW,
+			},
+			TermJa: "面接回数別",
+			TermEn: "interview",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_SITE,
+			},
+			TermJa: "媒体別",
+			TermEn: "site",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_MANUSCRIPT,
+			},
+			TermJa: "原稿別",
+			TermEn: "manuscript",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_APPLICANT_TYPE,
+			},
+			TermJa: "応募者種別別",
+			TermEn: "applicant_type",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_TIME_TO_HIRE,
+			},
+			TermJa: "採用までの日数",
+			TermEn: "time_to_hire",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.ANALYSIS_TERM_INTERVIEWER_LOAD,
+			},
+			TermJa: "面接官負荷",
+			TermEn: "interviewer_load",
+		},
+	}
+	for _, row := range analysisTerms {
+		_, hash, _ := service.GenerateHash(1, 25)
+		row.HashKey = "m_analysis_term" + "_" + *hash
+		if err := master.InsertAnalysisTerm(tx, row); err != nil {
+			if err := tx.Rollback().Error; err != nil {
+				log.Printf("%v", err)
+				return
+			}
+			return
+		}
+	}
+
+	// m_notice
+	noticeTypes := []*ddl.NoticeType{
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.NOTICE_APPLICANT_ASSIGN_USER,
+			},
+			Notice: "面接官に割り振られました",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.NOTICE_APPLICANT_DESIRED_AT,
+			},
+			Notice: "応募者が面接日時を登録しました",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.NOTICE_APPLICANT_DOCUMENT_UPLOAD,
+			},
+			Notice: "応募者が書類をアップロードしました",
+		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: static.NOTICE_APPLICANT_INPUT_RESULT,
+			},
+			Notice: "面接結果が入力されました",
+		},
+	}
+	for _, row := range noticeTypes {
+		_, hash, _ := service.GenerateHash(1, 25)
+		row.HashKey = "m_notice" + "_" + *hash
+		if err := master.InsertNoticeType(tx, row); err != nil {
+			if err := tx.Rollback().Error; err != nil {
+				log.Printf("%v", err)
+				return
+			}
+			return
+		}
+	}
+
 	// m_interview_processing
 	interviewResult := []*ddl.Processing{
 		{
@@ -1485,6 +2212,15 @@ func CreateData(db *gorm.DB) {
 			NameEn:   "AdminLogDetailRead",
 			RoleType: uint(static.LOGIN_TYPE_ADMIN),
 		},
+		// admin_ジョブ関連
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: uint(static.ROLE_ADMIN_JOB_READ),
+			},
+			NameJa:   "システム管理者ジョブ実行履歴閲覧",
+			NameEn:   "AdminJobRead",
+			RoleType: uint(static.LOGIN_TYPE_ADMIN),
+		},
 		// management_ロール関連
 		{
 			AbstractMasterModel: ddl.AbstractMasterModel{
@@ -1820,6 +2556,14 @@ func CreateData(db *gorm.DB) {
 			NameEn:   "ManagementMailDelete",
 			RoleType: uint(static.LOGIN_TYPE_MANAGEMENT),
 		},
+		{
+			AbstractMasterModel: ddl.AbstractMasterModel{
+				ID: uint(static.ROLE_MANAGEMENT_MAIL_SEND),
+			},
+			NameJa:   "管理者メール送信",
+			NameEn:   "ManagementMailSend",
+			RoleType: uint(static.LOGIN_TYPE_MANAGEMENT),
+		},
 		// management_変数関連
 		{
 			AbstractMasterModel: ddl.AbstractMasterModel{
@@ -2136,6 +2880,11 @@ func CreateData(db *gorm.DB) {
 			SidebarID: uint(static.SIDEBAR_ADMIN_LOG),
 			RoleID:    uint(static.ROLE_ADMIN_LOG_DETAIL_READ),
 		},
+		// admin_ジョブ関連
+		{
+			SidebarID: uint(static.SIDEBAR_ADMIN_LOG),
+			RoleID:    uint(static.ROLE_ADMIN_JOB_READ),
+		},
 		// management_ロール関連
 		{
 			SidebarID: uint(static.SIDEBAR_MANAGEMENT_ROLE),
@@ -2307,6 +3056,10 @@ func CreateData(db *gorm.DB) {
 			SidebarID: uint(static.SIDEBAR_MANAGEMENT_MAIL),
 			RoleID:    uint(static.ROLE_MANAGEMENT_MAIL_DELETE),
 		},
+		{
+			SidebarID: uint(static.SIDEBAR_MANAGEMENT_MAIL),
+			RoleID:    uint(static.ROLE_MANAGEMENT_MAIL_SEND),
+		},
 		// management_変数関連
 		{
 			SidebarID: uint(static.SIDEBAR_MANAGEMENT_VARIABLE),
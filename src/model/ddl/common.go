@@ -1,5 +1,7 @@
 package ddl
 
+import "time"
+
 /*
 t_notice
 通知
@@ -8,10 +10,14 @@ type Notice struct {
 	AbstractTransactionModel
 	// 種別
 	Type uint `json:"type"`
-	// 通知元ユーザーID
-	FromUserID uint64 `json:"from_user_id"`
+	// 通知元ユーザーID ※応募者による操作の場合はnull
+	FromUserID *uint64 `json:"from_user_id"`
 	// 通知先ユーザーID
-	ToUserID uint64 `json:"to_user_id"`
+	ToUserID uint64 `json:"to_user_id" gorm:"index"`
+	// 対象応募者ID
+	ApplicantID uint64 `json:"applicant_id" gorm:"index"`
+	// 既読日時 ※未読の場合はnull
+	ReadAt *time.Time `json:"read_at"`
 	// 通知種別(外部キー)
 	NoticeType NoticeType `gorm:"foreignKey:type;references:id"`
 	// 通知元ユーザー(外部キー)
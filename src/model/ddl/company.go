@@ -23,6 +23,122 @@ type Company struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+/*
+t_company_security
+企業セキュリティ設定
+*/
+type CompanySecurity struct {
+	// 企業ID
+	CompanyID uint64 `json:"company_id" gorm:"primaryKey"`
+	// 二要素認証(認証アプリ)必須
+	RequireTOTP bool `json:"require_totp" gorm:"not null;default:false"`
+	// ロックまでの失敗回数 ※0は既定値
+	LockoutThreshold uint `json:"lockout_threshold" gorm:"not null;default:0"`
+	// ロック期間(秒) ※0は既定値、ロックの度に倍増
+	LockoutSeconds uint `json:"lockout_seconds" gorm:"not null;default:0"`
+	// 最大ロック期間(秒) ※0は既定値
+	LockoutMaxSeconds uint `json:"lockout_max_seconds" gorm:"not null;default:0"`
+	// パスワード最小文字数 ※0は既定値
+	PasswordMinLength uint `json:"password_min_length" gorm:"not null;default:0"`
+	// パスワード必要文字種数 ※0は既定値
+	PasswordMinClasses uint `json:"password_min_classes" gorm:"not null;default:0"`
+	// パスワード再利用禁止世代数 ※0は既定値
+	PasswordHistory uint `json:"password_history" gorm:"not null;default:0"`
+	// 更新日時
+	UpdatedAt time.Time `json:"updated_at"`
+	// 企業(外部キー)
+	Company Company `gorm:"foreignKey:company_id;references:id"`
+}
+
+/*
+t_company_oidc
+企業シングルサインオン設定(OpenID Connect)
+*/
+type CompanyOIDC struct {
+	// 企業ID
+	CompanyID uint64 `json:"company_id" gorm:"primaryKey"`
+	// 有効
+	Enabled bool `json:"enabled" gorm:"not null;default:false"`
+	// 発行者(Issuer)
+	Issuer string `json:"issuer" gorm:"not null;type:varchar(255)"`
+	// クライアントID
+	ClientID string `json:"client_id" gorm:"not null;type:varchar(255)"`
+	// クライアントシークレット(AES-GCM暗号化)
+	ClientSecret string `json:"client_secret" gorm:"not null;type:text"`
+	// パスワードログイン無効
+	DisablePassword bool `json:"disable_password" gorm:"not null;default:false"`
+	// 更新日時
+	UpdatedAt time.Time `json:"updated_at"`
+	// 企業(外部キー)
+	Company Company `gorm:"foreignKey:company_id;references:id"`
+}
+
+/*
+t_company_oidc_domain
+企業シングルサインオン許可ドメイン
+*/
+type CompanyOIDCDomain struct {
+	// ドメイン ※企業間で重複不可
+	Domain string `json:"domain" gorm:"primaryKey;check:domain <> '';type:varchar(100)"`
+	// 企業ID
+	CompanyID uint64 `json:"company_id" gorm:"not null;index"`
+	// 企業(外部キー)
+	Company Company `gorm:"foreignKey:company_id;references:id"`
+}
+
+/*
+t_company_scim_token
+企業SCIMトークン
+*/
+type CompanySCIMToken struct {
+	// 企業ID
+	CompanyID uint64 `json:"company_id" gorm:"primaryKey"`
+	// トークン(SHA-256)
+	Token string `json:"token" gorm:"not null;unique;type:char(64)"`
+	// 連携ユーザーのロールID
+	RoleID uint64 `json:"role_id" gorm:"not null;index"`
+	// 発行日時
+	CreatedAt time.Time `json:"created_at"`
+	// 企業(外部キー)
+	Company Company `gorm:"foreignKey:company_id;references:id"`
+	// ロール(外部キー)
+	Role CustomRole `gorm:"foreignKey:role_id;references:id"`
+}
+
+/*
+t_company_holiday
+企業休日 ※日本の祝日・チームの定休日より優先
+*/
+type CompanyHoliday struct {
+	// 企業ID
+	CompanyID uint64 `json:"company_id" gorm:"primaryKey"`
+	// 日付
+	Date time.Time `json:"date" gorm:"primaryKey;type:date"`
+	// 区分 ※1:休業日、2:特別営業日
+	Kind uint `json:"kind" gorm:"not null;check:kind IN (1, 2)"`
+	// 名称
+	Name string `json:"name" gorm:"not null;type:varchar(50)"`
+	// 登録日時
+	CreatedAt time.Time `json:"created_at"`
+	// 企業(外部キー)
+	Company Company `gorm:"foreignKey:company_id;references:id"`
+}
+
 func (t Company) TableName() string {
 	return "t_company"
 }
+func (t CompanySecurity) TableName() string {
+	return "t_company_security"
+}
+func (t CompanyOIDC) TableName() string {
+	return "t_company_oidc"
+}
+func (t CompanyOIDCDomain) TableName() string {
+	return "t_company_oidc_domain"
+}
+func (t CompanySCIMToken) TableName() string {
+	return "t_company_scim_token"
+}
+func (t CompanyHoliday) TableName() string {
+	return "t_company_holiday"
+}
@@ -1,5 +1,7 @@
 package ddl
 
+import "time"
+
 /*
 t_operation_log
 操作ログ
@@ -7,15 +9,19 @@ t_operation_log
 type OperationLog struct {
 	AbstractTransactionModel
 	// イベントID
-	EventID uint `json:"event_id"`
-	// 対象ユーザーID
-	UserID uint64 `json:"user_id"`
+	EventID uint `json:"event_id" gorm:"index"`
+	// 操作ユーザーID ※応募者による操作の場合は0
+	UserID uint64 `json:"user_id" gorm:"index"`
+	// 操作応募者ID ※ユーザーによる操作の場合は0
+	ApplicantID uint64 `json:"applicant_id" gorm:"index"`
+	// チームID
+	TeamID uint64 `json:"team_id" gorm:"index"`
+	// 対象ハッシュキー
+	Target string `json:"target" gorm:"type:text;index"`
 	// ログ
 	Log string `json:"log" gorm:"type:text"`
 	// イベント
 	Event OperationLogEvent `gorm:"foreignKey:event_id;references:id"`
-	// ユーザー(外部キー)
-	User User `gorm:"foreignKey:user_id;references:id"`
 }
 
 /*
@@ -23,14 +29,135 @@ t_history_of_upload_applicant
 応募者アップロード履歴
 */
 type HistoryOfUploadApplicant struct {
-	// 履歴ID
-	HistoryID uint64 `json:"history_id" gorm:"primaryKey;AUTO_INCREMENT"`
+	AbstractTransactionModel
 	// コミットID
 	CommitID string `json:"commit_id" gorm:"not null;unique;check:commit_id <> '';type:text;index"`
+	// チームID
+	TeamID uint64 `json:"team_id" gorm:"index"`
+	// アップロードユーザーID
+	UserID uint64 `json:"user_id" gorm:"index"`
+	// サイトID
+	SiteID uint `json:"site_id" gorm:"index"`
+	// ファイル名
+	FileName string `json:"file_name" gorm:"type:text"`
 	// アップロードcsv
 	CSV string `json:"csv" gorm:"not null;check:csv <> '';type:text"`
-	// 操作ログ(外部キー)
-	Log OperationLog `gorm:"foreignKey:history_id;references:id"`
+	// 総数
+	TotalNum uint `json:"total_num"`
+	// 登録数
+	InsertNum uint `json:"insert_num"`
+	// 重複数
+	DuplNum uint `json:"dupl_num"`
+	// 登録時ステータス
+	InitStatus uint64 `json:"init_status"`
+	// 取消フラグ
+	RollbackFlg uint `json:"rollback_flg"`
+	// サイト(外部キー)
+	Site Site `gorm:"foreignKey:site_id;references:id"`
+}
+
+/*
+t_mail_history
+メール送信履歴
+*/
+type MailHistory struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// 企業ID
+	CompanyID uint64 `json:"company_id" gorm:"index"`
+	// 種別
+	Kind uint `json:"kind" gorm:"index"`
+	// 宛先
+	ToAddress string `json:"to_address" gorm:"not null;check:to_address <> '';type:text;index"`
+	// 件名
+	Subject string `json:"subject" gorm:"type:text"`
+	// 送信方式
+	Driver string `json:"driver" gorm:"type:varchar(10)"`
+	// ステータス
+	Status uint `json:"status"`
+	// 試行回数
+	Attempts uint `json:"attempts"`
+	// エラー内容
+	ErrorMessage string `json:"error_message" gorm:"type:text"`
+	// 送信日時
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+/*
+t_mail_outbox
+メール送信待ち ※送信完了・失敗確定で削除し、送信履歴に記録
+*/
+type MailOutbox struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// 企業ID
+	CompanyID uint64 `json:"company_id"`
+	// 種別
+	Kind uint `json:"kind"`
+	// 宛先
+	ToAddress string `json:"to_address" gorm:"not null;check:to_address <> '';type:text"`
+	// 件名
+	Subject string `json:"subject" gorm:"type:text"`
+	// 本文
+	Body string `json:"body" gorm:"type:text"`
+	// 添付ファイル(JSON)
+	Attachments string `json:"attachments" gorm:"type:text"`
+	// 試行回数
+	Attempts uint `json:"attempts"`
+	// 次回送信日時
+	NextAt time.Time `json:"next_at" gorm:"not null;index"`
+	// 登録日時
+	CreatedAt time.Time `json:"created_at"`
+}
+
+/*
+t_login_lockout_history
+ログインロック履歴
+*/
+type LoginLockoutHistory struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// 企業ID ※特定できない場合は0
+	CompanyID uint64 `json:"company_id" gorm:"index"`
+	// イベント
+	Event uint `json:"event" gorm:"index"`
+	// 対象(アカウント or IPアドレス)
+	Target string `json:"target" gorm:"not null;check:target <> '';type:varchar(255);index"`
+	// 接続元IPアドレス
+	IPAddress string `json:"ip_address" gorm:"type:varchar(45)"`
+	// 失敗回数
+	Failures uint `json:"failures"`
+	// ロック期限
+	LockedUntil *time.Time `json:"locked_until"`
+	// 解除ユーザーID ※ロック時は0
+	OperatorID uint64 `json:"operator_id"`
+	// 登録日時
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+/*
+t_job_run
+ジョブ実行履歴
+*/
+type JobRun struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// ジョブ名
+	Name string `json:"name" gorm:"not null;check:name <> '';type:varchar(50);index"`
+	// 実行契機
+	Trigger uint `json:"trigger"`
+	// ステータス
+	Status uint `json:"status" gorm:"index"`
+	// 実行ホスト
+	Host string `json:"host" gorm:"type:varchar(255)"`
+	// 処理件数
+	Count int64 `json:"count"`
+	// エラー内容
+	Message string `json:"message" gorm:"type:text"`
+	// 開始日時
+	StartedAt time.Time `json:"started_at" gorm:"not null;index"`
+	// 終了日時
+	FinishedAt *time.Time `json:"finished_at"`
 }
 
 func (t OperationLog) TableName() string {
@@ -39,3 +166,15 @@ func (t OperationLog) TableName() string {
 func (t HistoryOfUploadApplicant) TableName() string {
 	return "t_history_of_upload_applicant"
 }
+func (t MailHistory) TableName() string {
+	return "t_mail_history"
+}
+func (t MailOutbox) TableName() string {
+	return "t_mail_outbox"
+}
+func (t LoginLockoutHistory) TableName() string {
+	return "t_login_lockout_history"
+}
+func (t JobRun) TableName() string {
+	return "t_job_run"
+}
@@ -9,11 +9,11 @@ t_mail_template
 type MailTemplate struct {
 	AbstractTransactionModel
 	// メールテンプレート名
-	Title string `json:"title" gorm:"not null;unique;check:title <> '';type:varchar(50)"`
+	Title string `json:"title" gorm:"not null;check:title <> '';type:varchar(50)"`
 	// 件名
-	Subject string `json:"subject" gorm:"not null;unique;check:subject <> '';type:text"`
+	Subject string `json:"subject" gorm:"not null;check:subject <> '';type:text"`
 	// テンプレート
-	Template string `json:"template" gorm:"not null;unique;check:template <> '';type:text"`
+	Template string `json:"template" gorm:"not null;check:template <> '';type:text"`
 	// 説明
 	Desc string `json:"desc" gorm:"type:text"`
 	AbstractTransactionFlgModel
@@ -26,9 +26,9 @@ t_variable
 type Variable struct {
 	AbstractTransactionModel
 	// 変数タイトル
-	Title string `json:"title" gorm:"not null;unique;check:title <> '';type:varchar(25)"`
+	Title string `json:"title" gorm:"not null;check:title <> '';type:varchar(25)"`
 	// 変数格納Json名
-	JsonName string `json:"json_name" gorm:"not null;unique;check:json_name <> '';type:text"`
+	JsonName string `json:"json_name" gorm:"not null;check:json_name <> '';type:text"`
 	AbstractTransactionFlgModel
 }
 
@@ -44,7 +44,7 @@ type MailPreview struct {
 	// ハッシュキー
 	HashKey string `json:"hash_key" gorm:"not null;unique;check:hash_key <> '';type:text;index"`
 	// メールプレビュー名
-	Title string `json:"title" gorm:"not null;unique;check:title <> '';type:varchar(50)"`
+	Title string `json:"title" gorm:"not null;check:title <> '';type:varchar(50)"`
 	// 説明
 	Desc string `json:"desc" gorm:"type:text"`
 	// 企業ID
@@ -14,6 +14,14 @@ type Schedule struct {
 	Title string `json:"title" gorm:"not null;check:title <> '';type:varchar(30)"`
 	// 頻度ID
 	FreqID uint `json:"freq_id"`
+	// 間隔 ※頻度の単位で何回毎か
+	Interval uint `json:"interval" gorm:"not null;default:1"`
+	// 曜日 ※RRULEのBYDAY形式(カンマ区切り)、毎月の場合は「2TU」「-1FR」のように第何週かを指定可
+	ByDay string `json:"by_day" gorm:"type:varchar(50)"`
+	// 回数 ※0は無制限
+	Count uint `json:"count"`
+	// 繰り返し終了日時 ※この日時までに開始する回が対象
+	Until *time.Time `json:"until"`
 	// 面接フラグ
 	InterviewFlg uint `json:"interview_flg"`
 	// 開始時刻
@@ -43,9 +51,51 @@ type ScheduleAssociation struct {
 	User User `gorm:"foreignKey:user_id;references:id"`
 }
 
+/*
+t_schedule_exception
+予定例外 ※繰り返し予定の特定の回の取消・移動
+*/
+type ScheduleException struct {
+	// 予定ID
+	ScheduleID uint64 `json:"schedule_id" gorm:"primaryKey"`
+	// 本来の開始時刻
+	OriginalStart time.Time `json:"original_start" gorm:"primaryKey"`
+	// 区分 ※1:取消、2:移動
+	Kind uint `json:"kind" gorm:"not null;check:kind IN (1, 2)"`
+	// 開始時刻 ※移動の場合のみ
+	Start *time.Time `json:"start"`
+	// 終了時刻 ※移動の場合のみ
+	End *time.Time `json:"end"`
+	// 登録日時
+	CreatedAt time.Time `json:"created_at"`
+	// 予定(外部キー)
+	Schedule Schedule `gorm:"foreignKey:schedule_id;references:id"`
+}
+
+/*
+t_schedule_reminder
+面接リマインド送信履歴 ※予定の開始時刻毎に1回
+*/
+type ScheduleReminder struct {
+	// 予定ID
+	ScheduleID uint64 `json:"schedule_id" gorm:"primaryKey"`
+	// 応募者ID
+	ApplicantID uint64 `json:"applicant_id" gorm:"primaryKey"`
+	// 開始時刻 ※送信時点、日程変更後は再送
+	Start time.Time `json:"start" gorm:"primaryKey"`
+	// 送信日時
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
 func (t Schedule) TableName() string {
 	return "t_schedule"
 }
 func (t ScheduleAssociation) TableName() string {
 	return "t_schedule_association"
 }
+func (t ScheduleException) TableName() string {
+	return "t_schedule_exception"
+}
+func (t ScheduleReminder) TableName() string {
+	return "t_schedule_reminder"
+}
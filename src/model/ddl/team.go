@@ -118,6 +118,65 @@ type TeamPerInterview struct {
 	Team Team `gorm:"foreignKey:team_id;references:id"`
 }
 
+/*
+t_team_booking_window
+面接予約枠設定 ※面接回数0はチーム既定、面接回数毎の設定がある場合はそちらを優先
+*/
+type TeamBookingWindow struct {
+	// チームID
+	TeamID uint64 `json:"team_id" gorm:"primaryKey"`
+	// 面接回数
+	NumOfInterview uint `json:"num_of_interview" gorm:"primaryKey;check:num_of_interview <= 30"`
+	// タイムゾーン(IANA)
+	TimeZone string `json:"time_zone" gorm:"not null;type:varchar(64)"`
+	// 面接時間(分)
+	Duration uint `json:"duration" gorm:"not null;check:duration >= 5 AND duration <= 480"`
+	// 予約枠の刻み(分)
+	SlotInterval uint `json:"slot_interval" gorm:"not null;check:slot_interval >= 5 AND slot_interval <= 240"`
+	// 受付締切(時間) ※現在時刻から指定時間後以降のみ予約可能
+	LeadTime uint `json:"lead_time" gorm:"not null;check:lead_time <= 2160"`
+	// 受付期間(日) ※受付開始日からの日数
+	Horizon uint `json:"horizon" gorm:"not null;check:horizon >= 1 AND horizon <= 180"`
+	// 前後の予定との間隔(分)
+	Buffer uint `json:"buffer" gorm:"not null;check:buffer <= 240"`
+	// チーム(外部キー)
+	Team Team `gorm:"foreignKey:team_id;references:id"`
+}
+
+/*
+t_team_booking_hours
+面接予約受付時間 ※曜日毎、行のない曜日は受付なし
+*/
+type TeamBookingHours struct {
+	// チームID
+	TeamID uint64 `json:"team_id" gorm:"primaryKey"`
+	// 面接回数
+	NumOfInterview uint `json:"num_of_interview" gorm:"primaryKey"`
+	// 曜日 ※0:日曜 ～ 6:土曜
+	Weekday uint `json:"weekday" gorm:"primaryKey;check:weekday <= 6"`
+	// 開始(0時からの分)
+	StartMinute uint `json:"start_minute" gorm:"not null;check:start_minute < 1440"`
+	// 終了(0時からの分)
+	EndMinute uint `json:"end_minute" gorm:"not null;check:end_minute <= 1440 AND end_minute > start_minute"`
+	// チーム(外部キー)
+	Team Team `gorm:"foreignKey:team_id;references:id"`
+}
+
+/*
+t_team_weekday
+チーム曜日設定 ※未設定の場合は土日を定休日
+*/
+type TeamWeekday struct {
+	// チームID
+	TeamID uint64 `json:"team_id" gorm:"primaryKey"`
+	// 曜日 ※0:日曜 ～ 6:土曜
+	Weekday uint `json:"weekday" gorm:"primaryKey;check:weekday <= 6"`
+	// 定休日
+	Closed bool `json:"closed" gorm:"not null;default:false"`
+	// チーム(外部キー)
+	Team Team `gorm:"foreignKey:team_id;references:id"`
+}
+
 /*
 t_team_assign_possible
 面接毎参加可能者
@@ -170,6 +229,15 @@ func (t TeamAssignPriority) TableName() string {
 func (t TeamPerInterview) TableName() string {
 	return "t_team_per_interview"
 }
+func (t TeamBookingWindow) TableName() string {
+	return "t_team_booking_window"
+}
+func (t TeamBookingHours) TableName() string {
+	return "t_team_booking_hours"
+}
+func (t TeamWeekday) TableName() string {
+	return "t_team_weekday"
+}
 func (t TeamAssignPossible) TableName() string {
 	return "t_team_assign_possible"
 }
@@ -1,5 +1,7 @@
 package ddl
 
+import "time"
+
 /*
 t_user
 ユーザー
@@ -18,6 +20,8 @@ type User struct {
 	RoleID uint64 `json:"role_id" gorm:"index"`
 	// ユーザー種別
 	UserType uint `json:"user_type" gorm:"index"`
+	// 無効化日時 ※無効化中はログイン不可
+	DeactivatedAt *time.Time `json:"deactivated_at"`
 	// ロール(外部キー)
 	Role CustomRole `gorm:"foreignKey:role_id;references:id"`
 	// ログイン種別(外部キー)
@@ -37,9 +41,139 @@ type UserRefreshTokenAssociation struct {
 	User User `gorm:"foreignKey:user_id;references:id"`
 }
 
+/*
+t_user_calendar_token
+カレンダー購読トークン
+*/
+type UserCalendarToken struct {
+	// ユーザーID
+	UserID uint64 `json:"user_id" gorm:"primaryKey"`
+	// トークン(SHA-256)
+	Token string `json:"token" gorm:"not null;unique;type:char(64)"`
+	// 発行日時
+	CreatedAt time.Time `json:"created_at"`
+	// ユーザー(外部キー)
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+/*
+t_user_session_token
+ログインセッション(リフレッシュトークン)
+*/
+type UserSessionToken struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// ユーザーID
+	UserID uint64 `json:"user_id" gorm:"not null;index"`
+	// セッションID ※ローテーションしても同一
+	SessionID string `json:"session_id" gorm:"not null;type:char(32);index"`
+	// リフレッシュトークン(SHA-256)
+	Token string `json:"token" gorm:"not null;unique;type:char(64)"`
+	// 有効期限
+	ExpiredAt time.Time `json:"expired_at" gorm:"not null"`
+	// 使用日時 ※ローテーション済み
+	UsedAt *time.Time `json:"used_at"`
+	// 失効日時
+	RevokedAt *time.Time `json:"revoked_at" gorm:"index"`
+	// 発行日時
+	CreatedAt time.Time `json:"created_at"`
+	// ユーザー(外部キー)
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+/*
+t_user_totp
+二要素認証(認証アプリ)
+*/
+type UserTOTP struct {
+	// ユーザーID
+	UserID uint64 `json:"user_id" gorm:"primaryKey"`
+	// シークレット(AES-GCM暗号化)
+	Secret string `json:"secret" gorm:"not null;check:secret <> '';type:text"`
+	// 最終使用ステップ ※同一コードの再利用防止
+	LastStep int64 `json:"last_step" gorm:"not null;default:0"`
+	// 有効化日時 ※NULLは確認待ち
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	// 登録日時
+	CreatedAt time.Time `json:"created_at"`
+	// ユーザー(外部キー)
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+/*
+t_user_recovery_code
+二要素認証リカバリーコード
+*/
+type UserRecoveryCode struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// ユーザーID
+	UserID uint64 `json:"user_id" gorm:"not null;index"`
+	// リカバリーコード(SHA-256)
+	Code string `json:"code" gorm:"not null;type:char(64)"`
+	// 使用日時
+	UsedAt *time.Time `json:"used_at"`
+	// 発行日時
+	CreatedAt time.Time `json:"created_at"`
+	// ユーザー(外部キー)
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+/*
+t_user_password_history
+パスワード履歴
+*/
+type UserPasswordHistory struct {
+	// ID
+	ID uint64 `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	// ユーザーID
+	UserID uint64 `json:"user_id" gorm:"not null;index"`
+	// パスワード(ハッシュ化)
+	Password string `json:"password" gorm:"not null;check:password <> ''"`
+	// 設定日時
+	CreatedAt time.Time `json:"created_at"`
+	// ユーザー(外部キー)
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+/*
+t_user_oidc
+シングルサインオン紐づけ(OpenID Connect)
+*/
+type UserOIDC struct {
+	// ユーザーID
+	UserID uint64 `json:"user_id" gorm:"primaryKey"`
+	// 発行者(Issuer)
+	Issuer string `json:"issuer" gorm:"not null;uniqueIndex:idx_user_oidc_subject;type:varchar(255)"`
+	// サブジェクト(sub)
+	Subject string `json:"subject" gorm:"not null;uniqueIndex:idx_user_oidc_subject;check:subject <> '';type:varchar(255)"`
+	// 紐づけ日時
+	CreatedAt time.Time `json:"created_at"`
+	// ユーザー(外部キー)
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
 func (t User) TableName() string {
 	return "t_user"
 }
 func (t UserRefreshTokenAssociation) TableName() string {
 	return "t_user_refresh_token_association"
 }
+func (t UserCalendarToken) TableName() string {
+	return "t_user_calendar_token"
+}
+func (t UserSessionToken) TableName() string {
+	return "t_user_session_token"
+}
+func (t UserTOTP) TableName() string {
+	return "t_user_totp"
+}
+func (t UserRecoveryCode) TableName() string {
+	return "t_user_recovery_code"
+}
+func (t UserPasswordHistory) TableName() string {
+	return "t_user_password_history"
+}
+func (t UserOIDC) TableName() string {
+	return "t_user_oidc"
+}
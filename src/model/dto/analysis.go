@@ -0,0 +1,14 @@
+package dto
+
+import "api/src/model/request"
+
+// 分析
+type Analysis struct {
+	request.Analysis
+	// 企業ID
+	CompanyID uint64
+	// チームID
+	TeamID uint64
+	// 最大面接回数 ※最終面接の通過を採用とみなす
+	NumOfInterview uint
+}
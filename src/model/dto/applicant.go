@@ -39,3 +39,23 @@ type ApplicantManuscriptAssociation struct {
 	// 原稿ハッシュ
 	ManuscriptHash string `json:"manuscript_hash"`
 }
+
+// 応募者アップロード履歴
+type UploadHistory struct {
+	// ファイル名
+	FileName string
+	// アップロードcsv
+	CSV string
+	// 総数
+	TotalNum int
+}
+
+// 取込取消可否チェック
+type CheckRollbackUpload struct {
+	// コミットID
+	CommitID string
+	// チームID
+	TeamID uint64
+	// 登録時ステータス
+	InitStatus uint64
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// 祝日
+type Holiday struct {
+	// 日付
+	Date time.Time `json:"date"`
+	// 名称
+	Name string `json:"name"`
+}
+
+// 企業休日一覧の条件 ※期間は日付で両端を含む
+type HolidayRange struct {
+	// 企業ID
+	CompanyID uint64
+	// 開始日
+	From time.Time
+	// 終了日
+	To time.Time
+}
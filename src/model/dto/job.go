@@ -0,0 +1,8 @@
+package dto
+
+import "api/src/model/request"
+
+// ジョブ実行履歴検索
+type SearchJobRun struct {
+	request.SearchJobRun
+}
@@ -0,0 +1,83 @@
+package dto
+
+import "time"
+
+// ログイン試行制限ポリシー
+type LockoutPolicy struct {
+	// ロックまでの失敗回数
+	Threshold uint
+	// ロック期間 ※ロックの度に倍増
+	Duration time.Duration
+	// 最大ロック期間
+	MaxDuration time.Duration
+}
+
+// パスワードポリシー
+type PasswordPolicy struct {
+	// 最小文字数
+	MinLength uint
+	// 必要な文字種数
+	MinClasses uint
+	// 再利用禁止世代数
+	History uint
+}
+
+// 応募者マジックリンク
+type MagicLink struct {
+	// 応募者ハッシュキー
+	ApplicantHashKey string
+	// チームハッシュキー
+	TeamHashKey string
+	// 有効期限
+	ExpiredAt time.Time
+	// nonce ※一度のみ使用可
+	Nonce string
+}
+
+// OpenID Connect プロバイダ設定 ※ディスカバリー
+type OIDCProvider struct {
+	// 発行者
+	Issuer string `json:"issuer"`
+	// 認可エンドポイント
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	// トークンエンドポイント
+	TokenEndpoint string `json:"token_endpoint"`
+	// 公開鍵(JWKS)
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// OpenID Connect 認可コード交換
+type OIDCExchange struct {
+	// プロバイダ
+	Provider *OIDCProvider
+	// クライアントID
+	ClientID string
+	// クライアントシークレット
+	ClientSecret string
+	// リダイレクトURI
+	RedirectURI string
+	// 認可コード
+	Code string
+	// code_verifier(PKCE)
+	Verifier string
+}
+
+// OpenID Connect 認可リクエスト ※コールバックまでRedisに保持
+type OIDCState struct {
+	// 企業ID
+	CompanyID uint64 `json:"company_id"`
+	// nonce
+	Nonce string `json:"nonce"`
+	// code_verifier(PKCE)
+	Verifier string `json:"verifier"`
+}
+
+// OpenID Connect IDトークン(検証済み)
+type OIDCClaims struct {
+	// 発行者
+	Issuer string
+	// サブジェクト
+	Subject string
+	// メールアドレス
+	Email string
+}
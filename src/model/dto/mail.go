@@ -0,0 +1,25 @@
+package dto
+
+type Mail struct {
+	// 企業ID
+	CompanyID uint64
+	// 種別
+	Kind uint
+	// 宛先
+	To string
+	// 件名
+	Subject string
+	// 本文
+	Body string
+	// 添付ファイル
+	Attachments []MailAttachment
+}
+
+type MailAttachment struct {
+	// ファイル名
+	FileName string
+	// Content-Type
+	ContentType string
+	// 内容
+	Data []byte
+}
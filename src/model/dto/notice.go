@@ -0,0 +1,20 @@
+package dto
+
+import "api/src/model/request"
+
+// 通知登録
+type Notice struct {
+	// 通知元ユーザーハッシュキー ※応募者による操作の場合は空
+	UserHashKey string
+	// 通知種別
+	Type uint
+	// 通知先ユーザーID ※空の場合は面接官、面接官未割り振りの場合はチーム所属ユーザー
+	ToUserIDs []uint64
+}
+
+// 通知検索
+type SearchNotice struct {
+	request.SearchNotice
+	// 通知先ユーザーID
+	ToUserID uint64
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "api/src/model/request"
+
+// 操作ログ登録
+type OperationLog struct {
+	// 操作ユーザーハッシュキー ※応募者による操作の場合は空
+	UserHashKey string
+	// 操作応募者ID ※ユーザーによる操作の場合は0
+	ApplicantID uint64
+	// 企業ID ※ユーザーによる操作の場合はセッションから取得
+	CompanyID uint64
+	// チームID ※0の場合はセッションから取得
+	TeamID uint64
+	// イベントID
+	EventID uint
+	// 対象ハッシュキー
+	Target string
+	// 詳細
+	Detail interface{}
+}
+
+// 操作ログ検索
+type SearchOperationLog struct {
+	request.SearchOperationLog
+	// 企業ID ※0の場合は全企業
+	CompanyID uint64
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "api/src/model/ddl"
+import (
+	"api/src/model/ddl"
+	"time"
+)
 
 // ユーザー単位予定取得
 type GetScheduleByUser struct {
@@ -8,3 +11,27 @@ type GetScheduleByUser struct {
 	// 除外予定ハッシュリスト
 	RemoveScheduleHashKeys []string `json:"remove_schedule_hash_keys"`
 }
+
+// カレンダー予定取得
+type ListCalendarSchedule struct {
+	// ユーザーID
+	UserID uint64
+	// 掲載開始日時 ※繰り返し予定は繰り返し終了日時で判定
+	From time.Time
+	// 掲載終了日時
+	To time.Time
+}
+
+// 予定の回 ※繰り返しを展開し例外を適用したもの
+type ScheduleOccurrence struct {
+	// 予定ハッシュキー
+	HashKey string `json:"hash_key"`
+	// 開始時刻
+	Start time.Time `json:"start"`
+	// 終了時刻
+	End time.Time `json:"end"`
+	// 本来の開始時刻 ※例外の登録・削除に使用
+	OriginalStart time.Time `json:"original_start"`
+	// 移動済み
+	Moved bool `json:"moved"`
+}
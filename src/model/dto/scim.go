@@ -0,0 +1,13 @@
+package dto
+
+// SCIM 一覧条件
+type SCIMList struct {
+	// 企業ID
+	CompanyID uint64
+	// 絞り込み値 ※userName / displayName の完全一致
+	Filter string
+	// 取得開始位置(0始まり)
+	Offset int
+	// 取得件数
+	Limit int
+}
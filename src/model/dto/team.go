@@ -3,9 +3,38 @@ package dto
 import (
 	"api/src/model/ddl"
 	"api/src/model/request"
+	"time"
 )
 
 type SearchTeamByCompany struct {
 	ddl.Team
 	request.Abstract
 }
+
+// 面接予約枠 ※チーム既定・面接回数毎の設定を解決済み
+type BookingWindow struct {
+	// タイムゾーン
+	Location *time.Location
+	// 面接時間
+	Duration time.Duration
+	// 予約枠の刻み
+	SlotInterval time.Duration
+	// 受付締切 ※現在時刻からの猶予
+	LeadTime time.Duration
+	// 受付期間(日)
+	Horizon int
+	// 前後の予定との間隔
+	Buffer time.Duration
+	// 曜日毎の受付時間 ※nilは受付なし
+	Hours [7]*ddl.TeamBookingHours
+}
+
+// 予約枠
+type BookingSlot struct {
+	// 開始
+	Start time.Time
+	// 終了
+	End time.Time
+	// 受付可否 ※休日・受付締切前は不可
+	Open bool
+}
@@ -10,3 +10,11 @@ type SearchUser struct {
 	CompanyID uint64
 	// TODO
 }
+
+// JWTクレーム
+type JWTClaims struct {
+	// ハッシュキー
+	HashKey string
+	// セッションID ※ユーザーのみ
+	SessionID string
+}
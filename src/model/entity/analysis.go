@@ -0,0 +1,71 @@
+package entity
+
+// 選考ステータス別
+type AnalysisStatus struct {
+	// ステータスハッシュキー
+	StatusHash string `json:"status_hash"`
+	// ステータス名
+	StatusName string `json:"status_name"`
+	// 応募者数
+	Num int64 `json:"num"`
+	// 割合
+	Rate float64 `json:"rate" gorm:"-"`
+}
+
+// 面接回数別
+type AnalysisInterview struct {
+	// 面接回数
+	NumOfInterview uint `json:"num_of_interview" gorm:"-"`
+	// 到達数
+	Reached int64 `json:"reached"`
+	// 通過数
+	Passed int64 `json:"passed"`
+	// 不通過数
+	Failed int64 `json:"failed"`
+	// 選考中
+	InProgress int64 `json:"in_progress"`
+	// 通過率 ※結果入力済みの応募者に対する割合
+	PassRate float64 `json:"pass_rate" gorm:"-"`
+}
+
+// 媒体別、原稿別、応募者種別別
+type AnalysisPassRate struct {
+	// ハッシュキー
+	HashKey string `json:"hash_key"`
+	// 名称
+	Name string `json:"name"`
+	// 応募者数
+	Total int64 `json:"total"`
+	// 採用数
+	Hired int64 `json:"hired"`
+	// 不採用数
+	Failed int64 `json:"failed"`
+	// 採用率
+	HireRate float64 `json:"hire_rate" gorm:"-"`
+}
+
+// 採用までの日数
+type AnalysisTimeToHire struct {
+	// 採用数
+	Num int64 `json:"num"`
+	// 平均
+	Avg float64 `json:"avg"`
+	// 中央値
+	Median float64 `json:"median"`
+	// 最短
+	Min float64 `json:"min"`
+	// 最長
+	Max float64 `json:"max"`
+}
+
+// 面接官負荷
+type AnalysisInterviewer struct {
+	// ユーザーハッシュキー
+	UserHash string `json:"user_hash"`
+	// ユーザー名
+	UserName string `json:"user_name"`
+	// 面接予定数
+	ScheduleNum int64 `json:"schedule_num"`
+	// 担当応募者数
+	AssignNum int64 `json:"assign_num"`
+}
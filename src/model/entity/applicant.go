@@ -83,3 +83,24 @@ type ApplicantStatusList struct {
 type ApplicantURLAssociation struct {
 	ddl.ApplicantURLAssociation
 }
+
+// 応募者取込エラー
+type ApplicantImportError struct {
+	// 行番号 ※ヘッダー行を含む
+	Row int `json:"row"`
+	// 媒体側ID
+	OuterID string `json:"outer_id"`
+	// エラー内容(項目名: メッセージ)
+	Errors map[string]string `json:"errors"`
+}
+
+// 応募者アップロード履歴
+type UploadHistory struct {
+	ddl.HistoryOfUploadApplicant
+	// 媒体名
+	SiteName string `json:"site_name"`
+	// アップロードユーザーハッシュキー
+	UserHash string `json:"user_hash"`
+	// アップロードユーザー名
+	UserName string `json:"user_name"`
+}
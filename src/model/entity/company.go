@@ -6,3 +6,28 @@ import "api/src/model/ddl"
 type Company struct {
 	ddl.Company
 }
+
+// 企業セキュリティ設定
+type CompanySecurity struct {
+	ddl.CompanySecurity
+}
+
+// 企業シングルサインオン設定
+type CompanyOIDC struct {
+	ddl.CompanyOIDC
+}
+
+// 企業シングルサインオン許可ドメイン
+type CompanyOIDCDomain struct {
+	ddl.CompanyOIDCDomain
+}
+
+// 企業SCIMトークン
+type CompanySCIMToken struct {
+	ddl.CompanySCIMToken
+}
+
+// 企業休日
+type CompanyHoliday struct {
+	ddl.CompanyHoliday
+}
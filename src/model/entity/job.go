@@ -0,0 +1,8 @@
+package entity
+
+import "api/src/model/ddl"
+
+// ジョブ実行履歴
+type JobRun struct {
+	ddl.JobRun
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "api/src/model/ddl"
+
+// メールテンプレート
+type MailTemplate struct {
+	ddl.MailTemplate
+}
+
+// メールテンプレート詳細
+type MailTemplateDetail struct {
+	ddl.MailTemplate
+	// 使用変数
+	Variables []Variable `json:"variables" gorm:"-"`
+}
+
+// 変数
+type Variable struct {
+	ddl.Variable
+}
+
+// メールプレビュー(テンプレート変数紐づけ)
+type MailPreview struct {
+	ddl.MailPreview
+}
+
+// メールプレビュー結果
+type RenderedMail struct {
+	// 宛先
+	To string `json:"to"`
+	// 件名
+	Subject string `json:"subject"`
+	// 本文
+	Body string `json:"body"`
+}
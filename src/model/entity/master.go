@@ -12,6 +12,16 @@ type ScheduleFreqStatus struct {
 	ddl.ScheduleFreqStatus
 }
 
+// m_operation_log_event
+type OperationLogEvent struct {
+	ddl.OperationLogEvent
+}
+
+// m_analysis_term
+type AnalysisTerm struct {
+	ddl.AnalysisTerm
+}
+
 // m_select_status_event
 type SelectStatusEvent struct {
 	ddl.SelectStatusEvent
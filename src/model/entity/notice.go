@@ -0,0 +1,18 @@
+package entity
+
+import "api/src/model/ddl"
+
+// 通知
+type Notice struct {
+	ddl.Notice
+	// 通知内容
+	NoticeName string `json:"notice_name"`
+	// 通知元ユーザーハッシュキー
+	FromUserHash string `json:"from_user_hash"`
+	// 通知元ユーザー名
+	FromUserName string `json:"from_user_name"`
+	// 対象応募者ハッシュキー
+	ApplicantHash string `json:"applicant_hash"`
+	// 対象応募者名
+	ApplicantName string `json:"applicant_name"`
+}
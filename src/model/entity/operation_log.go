@@ -0,0 +1,24 @@
+package entity
+
+import "api/src/model/ddl"
+
+// 操作ログ
+type OperationLog struct {
+	ddl.OperationLog
+	// イベント内容
+	EventName string `json:"event_name"`
+	// イベントハッシュキー
+	EventHash string `json:"event_hash"`
+	// 操作ユーザーハッシュキー
+	UserHash string `json:"user_hash"`
+	// 操作ユーザー名
+	UserName string `json:"user_name"`
+	// 操作応募者ハッシュキー
+	ApplicantHash string `json:"applicant_hash"`
+	// 操作応募者名
+	ApplicantName string `json:"applicant_name"`
+	// チームハッシュキー
+	TeamHash string `json:"team_hash"`
+	// チーム名
+	TeamName string `json:"team_name"`
+}
@@ -9,14 +9,47 @@ type Schedule struct {
 	FreqName string `json:"freq_name"`
 	// 該当ユーザー
 	Users []*User `json:"users" gorm:"many2many:t_schedule_association;foreignKey:id;joinForeignKey:schedule_id;References:id;joinReferences:user_id"`
+	// 例外
+	Exceptions []ScheduleException `json:"exceptions" gorm:"foreignKey:schedule_id;references:id"`
 }
 
 // Schedule
 type Schedule2 struct {
 	ddl.Schedule
+	// 例外
+	Exceptions []ScheduleException `json:"exceptions" gorm:"foreignKey:schedule_id;references:id"`
+}
+
+// カレンダー予定
+type CalendarSchedule struct {
+	ddl.Schedule
+	// 応募者氏名 ※面接時のみ
+	ApplicantName string `json:"applicant_name"`
+	// Google Meet URL ※面接時のみ
+	GoogleMeetURL string `json:"google_meet_url"`
+	// 例外
+	Exceptions []ScheduleException `json:"exceptions" gorm:"foreignKey:schedule_id;references:id"`
 }
 
 // ScheduleAssociation
 type ScheduleAssociation struct {
 	ddl.ScheduleAssociation
 }
+
+// ScheduleException
+type ScheduleException struct {
+	ddl.ScheduleException
+}
+
+// 面接リマインド対象
+type InterviewReminder struct {
+	ddl.Schedule
+	// 応募者ID
+	ApplicantID uint64 `json:"applicant_id"`
+	// 応募者氏名
+	ApplicantName string `json:"applicant_name"`
+	// 応募者メールアドレス
+	ApplicantEmail string `json:"applicant_email"`
+	// Google Meet URL
+	GoogleMeetURL string `json:"google_meet_url"`
+}
@@ -64,6 +64,21 @@ type TeamPerInterview struct {
 	ddl.TeamPerInterview
 }
 
+// Team Booking Window
+type TeamBookingWindow struct {
+	ddl.TeamBookingWindow
+}
+
+// Team Booking Hours
+type TeamBookingHours struct {
+	ddl.TeamBookingHours
+}
+
+// Team Weekday
+type TeamWeekday struct {
+	ddl.TeamWeekday
+}
+
 // チーム毎イベント
 type StatusEventsByTeam struct {
 	// イベントハッシュキー
@@ -25,3 +25,30 @@ type SearchUser struct {
 type UserRefreshTokenAssociation struct {
 	ddl.UserRefreshTokenAssociation
 }
+
+// User Calendar Token
+type UserCalendarToken struct {
+	ddl.UserCalendarToken
+}
+
+// User Session Token
+type UserSessionToken struct {
+	ddl.UserSessionToken
+	// ユーザーハッシュキー
+	UserHashKey string `json:"user_hash_key"`
+}
+
+// User TOTP
+type UserTOTP struct {
+	ddl.UserTOTP
+}
+
+// User Password History
+type UserPasswordHistory struct {
+	ddl.UserPasswordHistory
+}
+
+// User OIDC
+type UserOIDC struct {
+	ddl.UserOIDC
+}
@@ -1,6 +1,6 @@
 package request
 
 type Abstract struct {
-	// ユーザーハッシュキー
-	UserHashKey string `json:"user_hash_key"`
+	// ユーザーハッシュキー ※認証済みトークンから設定するためリクエストボディからは受け付けない
+	UserHashKey string `json:"-"`
 }
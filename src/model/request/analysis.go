@@ -0,0 +1,17 @@
+package request
+
+import "time"
+
+// 分析
+type Analysis struct {
+	Abstract
+	// 応募日時_From
+	CreatedAtFrom time.Time `json:"created_at_from"`
+	// 応募日時_To
+	CreatedAtTo time.Time `json:"created_at_to"`
+}
+
+// 分析項目一覧
+type ListAnalysisTerm struct {
+	Abstract
+}
@@ -41,6 +41,13 @@ type SearchApplicant struct {
 	SortAsc bool `json:"sort_asc"`
 }
 
+// 応募者エクスポート ※検索条件はSearchApplicantと共通、ページングは無視
+type ExportApplicant struct {
+	SearchApplicant
+	// 形式(csv, xlsx)
+	Format string `json:"format"`
+}
+
 // 応募者ステータス一覧取得
 type ApplicantStatusList struct {
 	Abstract
@@ -62,6 +69,8 @@ type ApplicantDownloadSub struct {
 	ManuscriptID uint64 `json:"manuscript_id"`
 	// 原稿
 	ManuscriptHash string `json:"manuscript_hash"`
+	// 原稿内容 ※ファイル取込時
+	Manuscript string `json:"-"`
 }
 
 // 応募者ダウンロード
@@ -73,6 +82,23 @@ type ApplicantDownload struct {
 	Applicants []ApplicantDownloadSub `json:"applicants"`
 }
 
+// 応募者取込
+type ApplicantImport struct {
+	Abstract
+}
+
+// 応募者アップロード履歴
+type SearchUploadHistory struct {
+	Abstract
+}
+
+// 応募者取込取消
+type RollbackUpload struct {
+	Abstract
+	// コミットID
+	CommitID string `json:"commit_id"`
+}
+
 // 予約表表示
 type ReserveTable struct {
 	ddl.Applicant
@@ -186,6 +212,10 @@ type CheckAssignableUser struct {
 	Abstract
 	// 開始時刻
 	Start time.Time `json:"start"`
+	// 終了時刻 ※未指定の場合は面接予約枠設定の面接時間から算出
+	End time.Time `json:"end"`
+	// 応募者ハッシュキー ※指定時は応募者の面接回数の面接予約枠設定を使用
+	ApplicantHashKey string `json:"applicant_hash_key"`
 	// ハッシュキーリスト
 	HashKeys []string `json:"hash_keys"`
 	// 除外予定ハッシュリスト
@@ -0,0 +1,17 @@
+package request
+
+// カレンダー購読トークン発行
+type IssueCalendarToken struct {
+	Abstract
+}
+
+// カレンダー購読トークン削除
+type DeleteCalendarToken struct {
+	Abstract
+}
+
+// カレンダーフィード
+type CalendarFeed struct {
+	// 購読トークン
+	Token string `json:"token"`
+}
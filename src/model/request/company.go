@@ -15,3 +15,59 @@ type SearchCompany struct {
 	Abstract
 	ddl.Company
 }
+
+// セキュリティ設定取得
+type GetCompanySecurity struct {
+	Abstract
+}
+
+// セキュリティ設定更新
+type UpdateCompanySecurity struct {
+	Abstract
+	ddl.CompanySecurity
+}
+
+// シングルサインオン設定取得
+type GetCompanyOIDC struct {
+	Abstract
+}
+
+// シングルサインオン設定更新
+type UpdateCompanyOIDC struct {
+	Abstract
+	ddl.CompanyOIDC
+	// 許可ドメイン
+	Domains []string `json:"domains"`
+}
+
+// 休日一覧
+type ListCompanyHoliday struct {
+	Abstract
+	// 年
+	Year int `json:"year"`
+}
+
+// 休日登録 ※同日の登録がある場合は上書き
+type SaveCompanyHoliday struct {
+	Abstract
+	// 日付(YYYY-MM-DD)
+	Date string `json:"date"`
+	// 区分 ※1:休業日、2:特別営業日
+	Kind uint `json:"kind"`
+	// 名称
+	Name string `json:"name"`
+}
+
+// 休日削除
+type DeleteCompanyHoliday struct {
+	Abstract
+	// 日付(YYYY-MM-DD)
+	Date string `json:"date"`
+}
+
+// 休日取込 ※ファイルはCSV(日付,名称)またはiCalendar
+type ImportCompanyHoliday struct {
+	Abstract
+	// 区分 ※1:休業日、2:特別営業日
+	Kind uint `form:"kind"`
+}
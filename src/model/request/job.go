@@ -0,0 +1,20 @@
+package request
+
+import "time"
+
+// ジョブ実行履歴検索
+type SearchJobRun struct {
+	Abstract
+	// ページ
+	Page int `json:"page"`
+	// ページサイズ
+	PageSize int `json:"page_size"`
+	// ジョブ名
+	Names []string `json:"names"`
+	// ステータス
+	Statuses []uint `json:"statuses"`
+	// 開始日時_From
+	StartedAtFrom time.Time `json:"started_at_from"`
+	// 開始日時_To
+	StartedAtTo time.Time `json:"started_at_to"`
+}
@@ -5,6 +5,8 @@ import "api/src/model/ddl"
 // Login
 type Login struct {
 	ddl.User
+	// 接続元IPアドレス ※試行制限用
+	ClientIP string `json:"-"`
 }
 
 // CodeGenerate
@@ -15,23 +17,120 @@ type CodeGenerate struct {
 // MFA
 type MFA struct {
 	ddl.User
-	// 認証コード
+	// 認証コード ※認証アプリ登録済みの場合は認証アプリのコード
 	Code string `json:"code"`
+	// リカバリーコード ※認証アプリ登録済みのみ
+	RecoveryCode string `json:"recovery_code"`
+	// 接続元IPアドレス ※試行制限用
+	ClientIP string `json:"-"`
 }
 
 // JWTDecode
 type JWTDecode struct {
 	ddl.User
+	// セッションID ※トークンから設定
+	SessionID string `json:"-"`
 }
 
 // PasswordChange
 type PasswordChange struct {
 	ddl.User
+	// パスワード変更トークン ※Cookieから取得
+	Token string `json:"-"`
+	// 接続元IPアドレス ※試行制限用
+	ClientIP string `json:"-"`
+}
+
+// パスワード再設定メール送信
+type PasswordResetRequest struct {
+	ddl.User
+}
+
+// パスワード再設定
+type PasswordReset struct {
+	// 再設定トークン
+	Token string `json:"token"`
+	// 新しいパスワード
+	Password string `json:"password"`
+}
+
+// シングルサインオン開始
+type SSOStart struct {
+	// メールアドレス ※ドメインから企業を特定
+	Email string `json:"email"`
+}
+
+// シングルサインオン認証
+type SSOCallback struct {
+	// 認可コード
+	Code string `json:"code"`
+	// state
+	State string `json:"state"`
 }
 
 // Logout
 type Logout struct {
 	ddl.User
+	// セッションID ※トークンから設定
+	SessionID string `json:"-"`
+}
+
+// LogoutAll
+type LogoutAll struct {
+	ddl.User
+}
+
+// RefreshSession
+type RefreshSession struct {
+	// リフレッシュトークン ※Cookieから設定
+	Token string `json:"-"`
+}
+
+// GetTOTP
+type GetTOTP struct {
+	ddl.User
+}
+
+// TOTPSetup
+type TOTPSetup struct {
+	ddl.User
+}
+
+// TOTPConfirm
+type TOTPConfirm struct {
+	ddl.User
+	// 認証コード
+	Code string `json:"code"`
+}
+
+// MFATOTPSetup ※ユーザーは登録トークンから特定
+type MFATOTPSetup struct {
+	TOTPSetup
+	// 認証アプリ登録トークン ※Cookieから設定
+	Token string `json:"-"`
+}
+
+// MFATOTPConfirm ※ユーザーは登録トークンから特定
+type MFATOTPConfirm struct {
+	TOTPConfirm
+	// 認証アプリ登録トークン ※Cookieから設定
+	Token string `json:"-"`
+}
+
+// TOTPDisable
+type TOTPDisable struct {
+	ddl.User
+	// 認証コード
+	Code string `json:"code"`
+	// リカバリーコード
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// RegenerateRecoveryCode
+type RegenerateRecoveryCode struct {
+	ddl.User
+	// 認証コード
+	Code string `json:"code"`
 }
 
 // GetLoginType
@@ -46,16 +145,12 @@ type LoginApplicant struct {
 	TeamHashKey string `json:"team_hash_key"`
 }
 
-// CodeGenerateApplicant
-type CodeGenerateApplicant struct {
-	ddl.Applicant
-}
-
-// MFAApplicant
-type MFAApplicant struct {
-	ddl.Applicant
-	// 認証コード
-	Code string `json:"code"`
+// MagicLinkApplicant
+type MagicLinkApplicant struct {
+	// ログインリンクのトークン
+	Token string `json:"token"`
+	// 接続元IPアドレス ※試行制限用
+	ClientIP string `json:"-"`
 }
 
 // JWTDecodeApplicant
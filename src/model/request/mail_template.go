@@ -0,0 +1,73 @@
+package request
+
+import "api/src/model/ddl"
+
+// メールテンプレート登録
+type CreateMailTemplate struct {
+	Abstract
+	ddl.MailTemplate
+}
+
+// メールテンプレート更新
+type UpdateMailTemplate struct {
+	Abstract
+	ddl.MailTemplate
+}
+
+// メールテンプレート削除
+type DeleteMailTemplate struct {
+	Abstract
+	ddl.MailTemplate
+}
+
+// メールテンプレート取得
+type GetMailTemplate struct {
+	Abstract
+	ddl.MailTemplate
+}
+
+// メールテンプレート検索
+type SearchMailTemplate struct {
+	Abstract
+}
+
+// 変数登録
+type CreateVariable struct {
+	Abstract
+	ddl.Variable
+}
+
+// 変数更新
+type UpdateVariable struct {
+	Abstract
+	ddl.Variable
+}
+
+// 変数削除
+type DeleteVariable struct {
+	Abstract
+	ddl.Variable
+}
+
+// 変数検索
+type SearchVariable struct {
+	Abstract
+}
+
+// メールプレビュー
+type PreviewMail struct {
+	Abstract
+	// テンプレートハッシュキー
+	TemplateHash string `json:"template_hash"`
+	// 応募者ハッシュキー
+	ApplicantHash string `json:"applicant_hash"`
+}
+
+// メール送信_応募者
+type SendMail struct {
+	Abstract
+	// テンプレートハッシュキー
+	TemplateHash string `json:"template_hash"`
+	// 応募者
+	Applicants []string `json:"applicants"`
+}
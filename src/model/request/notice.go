@@ -0,0 +1,24 @@
+package request
+
+// 通知検索
+type SearchNotice struct {
+	Abstract
+	// ページ
+	Page int `json:"page"`
+	// ページサイズ
+	PageSize int `json:"page_size"`
+	// 未読のみ
+	UnreadOnly bool `json:"unread_only"`
+}
+
+// 通知既読
+type ReadNotice struct {
+	Abstract
+	// 通知ハッシュキー ※空の場合は全件
+	HashKeys []string `json:"hash_keys"`
+}
+
+// 未読通知数
+type CountUnreadNotice struct {
+	Abstract
+}
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"api/src/model/ddl"
+	"time"
+)
+
+// 操作ログ検索
+type SearchOperationLog struct {
+	Abstract
+	// ページ
+	Page int `json:"page"`
+	// ページサイズ
+	PageSize int `json:"page_size"`
+	// 操作ユーザー
+	Users []string `json:"users"`
+	// イベント
+	Events []string `json:"events"`
+	// チーム
+	Teams []string `json:"teams"`
+	// 操作日時_From
+	CreatedAtFrom time.Time `json:"created_at_from"`
+	// 操作日時_To
+	CreatedAtTo time.Time `json:"created_at_to"`
+}
+
+// 操作ログ取得
+type GetOperationLog struct {
+	Abstract
+	ddl.OperationLog
+}
+
+// 操作ログイベント一覧
+type ListOperationLogEvent struct {
+	Abstract
+}
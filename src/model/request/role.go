@@ -1,5 +1,7 @@
 package request
 
+import "api/src/model/ddl"
+
 // ロールチェック
 type CheckRole struct {
 	Abstract
@@ -11,3 +13,44 @@ type CheckRole struct {
 type SearchRoleByComapny struct {
 	Abstract
 }
+
+// ロールマスタ一覧
+type ListMasterRole struct {
+	Abstract
+}
+
+// ロール取得
+type GetRole struct {
+	Abstract
+	ddl.CustomRole
+}
+
+// ロール登録
+type CreateRole struct {
+	Abstract
+	ddl.CustomRole
+	// 付与ロール(マスタハッシュキー)
+	Roles []string `json:"roles"`
+}
+
+// ロール更新
+type UpdateRole struct {
+	Abstract
+	ddl.CustomRole
+	// 付与ロール(マスタハッシュキー)
+	Roles []string `json:"roles"`
+}
+
+// ロール削除
+type DeleteRole struct {
+	Abstract
+	ddl.CustomRole
+}
+
+// ロール割り当て
+type AssignRole struct {
+	Abstract
+	ddl.CustomRole
+	// ユーザー(ハッシュキー)
+	Users []string `json:"users"`
+}
@@ -1,6 +1,9 @@
 package request
 
-import "api/src/model/ddl"
+import (
+	"api/src/model/ddl"
+	"time"
+)
 
 // 予定登録
 type CreateSchedule struct {
@@ -21,6 +24,10 @@ type UpdateSchedule struct {
 // 予定検索
 type SearchSchedule struct {
 	Abstract
+	// 期間開始 ※未指定の場合は当日
+	From time.Time `json:"from"`
+	// 期間終了 ※未指定の場合は既定の日数
+	To time.Time `json:"to"`
 }
 
 // 予定削除
@@ -28,3 +35,27 @@ type DeleteSchedule struct {
 	Abstract
 	ddl.Schedule
 }
+
+// 予定例外登録
+type SaveScheduleException struct {
+	Abstract
+	// 予定ハッシュキー
+	HashKey string `json:"hash_key"`
+	// 本来の開始時刻
+	OriginalStart time.Time `json:"original_start"`
+	// 区分
+	Kind uint `json:"kind"`
+	// 開始時刻 ※移動の場合のみ
+	Start time.Time `json:"start"`
+	// 終了時刻 ※移動の場合のみ
+	End time.Time `json:"end"`
+}
+
+// 予定例外削除
+type DeleteScheduleException struct {
+	Abstract
+	// 予定ハッシュキー
+	HashKey string `json:"hash_key"`
+	// 本来の開始時刻
+	OriginalStart time.Time `json:"original_start"`
+}
@@ -0,0 +1,149 @@
+package request
+
+// SCIM 共通
+type SCIMAbstract struct {
+	// Bearerトークン ※Authorizationヘッダーから設定するためリクエストボディからは受け付けない
+	Token string `json:"-"`
+}
+
+// SCIM 氏名
+type SCIMName struct {
+	// 表示用氏名
+	Formatted string `json:"formatted,omitempty"`
+	// 姓
+	FamilyName string `json:"familyName,omitempty"`
+	// 名
+	GivenName string `json:"givenName,omitempty"`
+}
+
+// SCIM メールアドレス
+type SCIMEmail struct {
+	// メールアドレス
+	Value string `json:"value"`
+	// 種別
+	Type string `json:"type,omitempty"`
+	// 主アドレス
+	Primary bool `json:"primary,omitempty"`
+}
+
+// SCIM メンバー ※ユーザーの所属グループ・グループの所属ユーザー
+type SCIMMember struct {
+	// ID(ハッシュキー)
+	Value string `json:"value"`
+	// 表示名
+	Display string `json:"display,omitempty"`
+}
+
+// SCIM メタ情報
+type SCIMMeta struct {
+	// リソース種別
+	ResourceType string `json:"resourceType"`
+	// 登録日時
+	Created string `json:"created,omitempty"`
+	// 更新日時
+	LastModified string `json:"lastModified,omitempty"`
+	// URI
+	Location string `json:"location,omitempty"`
+}
+
+// SCIM ユーザー ※userNameはメールアドレス
+type SCIMUserResource struct {
+	// スキーマ
+	Schemas []string `json:"schemas"`
+	// ID(ハッシュキー)
+	ID string `json:"id,omitempty"`
+	// ユーザー名
+	UserName string `json:"userName"`
+	// 氏名
+	Name *SCIMName `json:"name,omitempty"`
+	// 表示名
+	DisplayName string `json:"displayName,omitempty"`
+	// メールアドレス
+	Emails []SCIMEmail `json:"emails,omitempty"`
+	// 有効 ※無効化はユーザー削除として扱う
+	Active *bool `json:"active,omitempty"`
+	// メタ情報
+	Meta *SCIMMeta `json:"meta,omitempty"`
+}
+
+// SCIM グループ ※チーム
+type SCIMGroupResource struct {
+	// スキーマ
+	Schemas []string `json:"schemas"`
+	// ID(ハッシュキー)
+	ID string `json:"id,omitempty"`
+	// 表示名(チーム名)
+	DisplayName string `json:"displayName"`
+	// メンバー
+	Members []SCIMMember `json:"members"`
+	// メタ情報
+	Meta *SCIMMeta `json:"meta,omitempty"`
+}
+
+// SCIM PATCH操作
+type SCIMPatchOperation struct {
+	// 操作 ※add, replace, remove
+	Op string `json:"op"`
+	// パス
+	Path string `json:"path,omitempty"`
+	// 値
+	Value interface{} `json:"value,omitempty"`
+}
+
+// SCIM 一覧
+type SCIMList struct {
+	SCIMAbstract
+	// 絞り込み ※userName eq "..." / displayName eq "..." のみ
+	Filter string `query:"filter"`
+	// 取得開始位置(1始まり)
+	StartIndex int `query:"startIndex"`
+	// 取得件数
+	Count int `query:"count"`
+}
+
+// SCIM 取得・削除
+type SCIMResource struct {
+	SCIMAbstract
+	// ID(ハッシュキー)
+	ID string `json:"-"`
+}
+
+// SCIM ユーザー登録・置換
+type SCIMUser struct {
+	SCIMAbstract
+	SCIMUserResource
+}
+
+// SCIM グループ登録・置換
+type SCIMGroup struct {
+	SCIMAbstract
+	SCIMGroupResource
+}
+
+// SCIM 部分更新
+type SCIMPatch struct {
+	SCIMAbstract
+	// ID(ハッシュキー)
+	ID string `json:"-"`
+	// スキーマ
+	Schemas []string `json:"schemas"`
+	// 操作
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMトークン設定取得
+type GetSCIMToken struct {
+	Abstract
+}
+
+// SCIMトークン発行 ※発行済みの場合は再発行
+type IssueSCIMToken struct {
+	Abstract
+	// 連携ユーザーのロールハッシュキー
+	RoleHashKey string `json:"role_hash_key"`
+}
+
+// SCIMトークン削除
+type DeleteSCIMToken struct {
+	Abstract
+}
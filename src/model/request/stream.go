@@ -0,0 +1,10 @@
+package request
+
+// イベント購読
+type Stream struct {
+	Abstract
+	// セッションID ※認証済みトークンから設定
+	SessionID string `json:"-"`
+	// 購読チームID ※購読開始時に設定
+	TeamID uint64 `json:"-"`
+}
@@ -81,3 +81,37 @@ type UpdateAssignMethodSub struct {
 	// ハッシュキーリスト
 	HashKeys []string `json:"hash_keys"`
 }
+
+// 面接予約枠設定取得
+type GetBookingWindow struct {
+	Abstract
+	// 面接回数 ※0はチーム既定
+	NumOfInterview uint `json:"num_of_interview"`
+}
+
+// 面接予約枠設定更新
+type UpdateBookingWindow struct {
+	Abstract
+	ddl.TeamBookingWindow
+	// 曜日毎の受付時間
+	Hours []ddl.TeamBookingHours `json:"hours"`
+}
+
+// 面接予約枠設定削除 ※チーム既定(面接回数0の場合はシステム既定)に戻す
+type DeleteBookingWindow struct {
+	Abstract
+	// 面接回数 ※0はチーム既定
+	NumOfInterview uint `json:"num_of_interview"`
+}
+
+// 定休日設定取得
+type GetTeamWeekday struct {
+	Abstract
+}
+
+// 定休日設定更新
+type UpdateTeamWeekday struct {
+	Abstract
+	// 定休日の曜日 ※0:日曜 ～ 6:土曜
+	ClosedWeekdays []uint `json:"closed_weekdays"`
+}
@@ -31,3 +31,21 @@ type DeleteUser struct {
 	Abstract
 	HashKeys []string `json:"hash_keys"`
 }
+
+// 認証アプリリセット
+type ResetUserTOTP struct {
+	Abstract
+	ddl.User
+}
+
+// パスワード再発行
+type ReissueUserPassword struct {
+	Abstract
+	ddl.User
+}
+
+// ログインロック解除
+type UnlockUser struct {
+	Abstract
+	ddl.User
+}
@@ -0,0 +1,35 @@
+package response
+
+import "api/src/model/entity"
+
+// 分析項目一覧
+type ListAnalysisTerm struct {
+	List []entity.AnalysisTerm `json:"list"`
+}
+
+// 選考ステータス別
+type AnalysisStatus struct {
+	List []entity.AnalysisStatus `json:"list"`
+	// 総数
+	Total int64 `json:"total"`
+}
+
+// 面接回数別
+type AnalysisInterview struct {
+	List []entity.AnalysisInterview `json:"list"`
+}
+
+// 媒体別、原稿別、応募者種別別
+type AnalysisPassRate struct {
+	List []entity.AnalysisPassRate `json:"list"`
+}
+
+// 採用までの日数
+type AnalysisTimeToHire struct {
+	entity.AnalysisTimeToHire
+}
+
+// 面接官負荷
+type AnalysisInterviewer struct {
+	List []entity.AnalysisInterviewer `json:"list"`
+}
@@ -26,6 +26,37 @@ type ApplicantStatusList struct {
 // 応募者ダウンロード
 type ApplicantDownload struct {
 	UpdateNum int `json:"update_num"`
+	// コミットID
+	CommitID string `json:"commit_id"`
+}
+
+// 応募者取込
+type ApplicantImport struct {
+	// サイトハッシュキー
+	SiteHashKey string `json:"site_hash_key"`
+	// 媒体名
+	SiteName string `json:"site_name"`
+	// 総行数
+	Total int `json:"total"`
+	// 登録数
+	UpdateNum int `json:"update_num"`
+	// 重複数
+	DuplNum int `json:"dupl_num"`
+	// エラー行
+	Errors []entity.ApplicantImportError `json:"errors"`
+	// コミットID
+	CommitID string `json:"commit_id"`
+}
+
+// 応募者アップロード履歴
+type SearchUploadHistory struct {
+	List []entity.UploadHistory `json:"list"`
+}
+
+// 応募者取込取消
+type RollbackUpload struct {
+	// 削除数
+	DeleteNum int `json:"delete_num"`
 }
 
 // 予約表
@@ -34,6 +65,8 @@ type ReserveTable struct {
 	Dates []time.Time `json:"date"`
 	// 予約時間
 	Options []dto.ReserveTableSub `json:"options"`
+	// 面接時間(分)
+	Duration uint `json:"duration"`
 	// 予定
 	Schedule time.Time `json:"schedule"`
 	// 予定ハッシュキー
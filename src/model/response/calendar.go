@@ -0,0 +1,9 @@
+package response
+
+// カレンダー購読トークン発行
+type IssueCalendarToken struct {
+	// 購読トークン ※発行時のみ返却、再表示不可
+	Token string `json:"token"`
+	// フィードパス
+	Path string `json:"path"`
+}
@@ -1,6 +1,9 @@
 package response
 
-import "api/src/model/entity"
+import (
+	"api/src/model/dto"
+	"api/src/model/entity"
+)
 
 // 登録
 type CreateCompany struct {
@@ -12,3 +15,40 @@ type CreateCompany struct {
 type SearchCompany struct {
 	List []entity.Company `json:"list"`
 }
+
+// セキュリティ設定取得
+type GetCompanySecurity struct {
+	entity.CompanySecurity
+}
+
+// シングルサインオン設定取得 ※クライアントシークレットは返却しない
+type GetCompanyOIDC struct {
+	// 有効
+	Enabled bool `json:"enabled"`
+	// 発行者(Issuer)
+	Issuer string `json:"issuer"`
+	// クライアントID
+	ClientID string `json:"client_id"`
+	// クライアントシークレット設定済み
+	HasClientSecret bool `json:"has_client_secret"`
+	// パスワードログイン無効
+	DisablePassword bool `json:"disable_password"`
+	// 許可ドメイン
+	Domains []string `json:"domains"`
+	// リダイレクトURI ※プロバイダに登録
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// 休日一覧
+type ListCompanyHoliday struct {
+	// 企業休日
+	List []entity.CompanyHoliday `json:"list"`
+	// 日本の祝日 ※同梱データ
+	Jp []dto.Holiday `json:"jp"`
+}
+
+// 休日取込
+type ImportCompanyHoliday struct {
+	// 取込件数
+	Count int `json:"count"`
+}
@@ -0,0 +1,10 @@
+package response
+
+import "api/src/model/entity"
+
+// ジョブ実行履歴検索
+type SearchJobRun struct {
+	List []entity.JobRun `json:"list"`
+	// 総数
+	Num int64 `json:"num"`
+}
@@ -7,6 +7,8 @@ import (
 // Login
 type Login struct {
 	entity.User
+	// 認証アプリ登録済み ※認証コードのメール送信は不要
+	IsTOTP bool `json:"is_totp"`
 }
 
 // MFA
@@ -15,6 +17,47 @@ type MFA struct {
 	Path string `json:"path"`
 	// パスワード変更_必要性
 	IsPasswordChange bool `json:"is_password_change"`
+	// 認証アプリ登録_必要性
+	IsTOTPSetup bool `json:"is_totp_setup"`
+	// 認証したユーザーのハッシュキー ※セッション開始用、レスポンスには含めない
+	HashKey string `json:"-"`
+}
+
+// シングルサインオン開始
+type SSOStart struct {
+	// 認可URL ※プロバイダへ遷移
+	URL string `json:"url"`
+}
+
+// シングルサインオン認証
+type SSOCallback struct {
+	entity.User
+	// 遷移パス
+	Path string `json:"path"`
+}
+
+// GetTOTP
+type GetTOTP struct {
+	// 登録済み
+	Enabled bool `json:"enabled"`
+	// 企業で必須
+	Required bool `json:"required"`
+}
+
+// TOTPSetup
+type TOTPSetup struct {
+	// シークレット(Base32) ※手入力用
+	Secret string `json:"secret"`
+	// プロビジョニングURI ※QRコード化して表示
+	URI string `json:"uri"`
+}
+
+// TOTPConfirm
+type TOTPConfirm struct {
+	// リカバリーコード ※表示はこの一度のみ
+	RecoveryCodes []string `json:"recovery_codes"`
+	// パスワード変更_必要性 ※ログイン時の登録で使用
+	IsPasswordChange bool `json:"is_password_change"`
 }
 
 // GetLoginType
@@ -26,3 +69,9 @@ type GetLoginType struct {
 type LoginApplicant struct {
 	entity.Applicant
 }
+
+// RegenerateRecoveryCode
+type RegenerateRecoveryCode struct {
+	// リカバリーコード ※表示はこの一度のみ
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import "api/src/model/entity"
+
+// メールテンプレート取得
+type GetMailTemplate struct {
+	entity.MailTemplateDetail
+}
+
+// メールテンプレート検索
+type SearchMailTemplate struct {
+	List []entity.MailTemplate `json:"list"`
+}
+
+// 変数検索
+type SearchVariable struct {
+	List []entity.Variable `json:"list"`
+}
+
+// メールプレビュー
+type PreviewMail struct {
+	entity.RenderedMail
+}
+
+// メール送信_応募者
+type SendMail struct {
+	// 送信受付 ※送信結果は送信履歴で確認
+	Success []string `json:"success"`
+	// 送信待ち登録件数
+	Queued int `json:"queued"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "api/src/model/entity"
+
+// 通知検索
+type SearchNotice struct {
+	List []entity.Notice `json:"list"`
+	// 総数
+	Num int64 `json:"num"`
+}
+
+// 未読通知数
+type CountUnreadNotice struct {
+	Num int64 `json:"num"`
+}
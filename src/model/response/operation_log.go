@@ -0,0 +1,20 @@
+package response
+
+import "api/src/model/entity"
+
+// 操作ログ検索
+type SearchOperationLog struct {
+	List []entity.OperationLog `json:"list"`
+	// 総数
+	Num int64 `json:"num"`
+}
+
+// 操作ログ取得
+type GetOperationLog struct {
+	entity.OperationLog
+}
+
+// 操作ログイベント一覧
+type ListOperationLogEvent struct {
+	List []entity.OperationLogEvent `json:"list"`
+}
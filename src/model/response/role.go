@@ -6,3 +6,15 @@ import "api/src/model/entity"
 type SearchRoleByComapny struct {
 	List []entity.CustomRole `json:"list"`
 }
+
+// ロールマスタ一覧
+type ListMasterRole struct {
+	List []entity.Role `json:"list"`
+}
+
+// ロール取得
+type GetRole struct {
+	entity.CustomRole
+	// 付与ロール
+	Roles []entity.Role `json:"roles"`
+}
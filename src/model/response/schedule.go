@@ -1,6 +1,9 @@
 package response
 
-import "api/src/model/entity"
+import (
+	"api/src/model/dto"
+	"api/src/model/entity"
+)
 
 // 予定登録種別一覧
 type SearchScheduleType struct {
@@ -15,4 +18,6 @@ type CreateSchedule struct {
 // 予定検索
 type SearchSchedule struct {
 	List []entity.Schedule `json:"list"`
+	// 期間内の各回
+	Occurrences []dto.ScheduleOccurrence `json:"occurrences"`
 }
@@ -0,0 +1,64 @@
+package response
+
+import (
+	"api/src/model/request"
+	"time"
+)
+
+// SCIM 一覧
+type SCIMList struct {
+	// スキーマ
+	Schemas []string `json:"schemas"`
+	// 総件数
+	TotalResults int64 `json:"totalResults"`
+	// 取得開始位置(1始まり)
+	StartIndex int `json:"startIndex"`
+	// 取得件数
+	ItemsPerPage int `json:"itemsPerPage"`
+	// リソース
+	Resources interface{} `json:"Resources"`
+}
+
+// SCIM ユーザー
+type SCIMUser struct {
+	request.SCIMUserResource
+}
+
+// SCIM グループ
+type SCIMGroup struct {
+	request.SCIMGroupResource
+}
+
+// SCIM エラー ※RFC 7644 3.12
+type SCIMError struct {
+	// スキーマ
+	Schemas []string `json:"schemas"`
+	// ステータス
+	Status string `json:"status"`
+	// エラー種別
+	ScimType string `json:"scimType,omitempty"`
+	// 詳細
+	Detail string `json:"detail,omitempty"`
+	// ステータス(ヘッダー)
+	HTTPStatus int `json:"-"`
+}
+
+// SCIMトークン設定取得
+type GetSCIMToken struct {
+	// 発行済み
+	Issued bool `json:"issued"`
+	// 連携ユーザーのロールハッシュキー
+	RoleHashKey string `json:"role_hash_key"`
+	// 発行日時
+	CreatedAt time.Time `json:"created_at"`
+	// ベースパス
+	Path string `json:"path"`
+}
+
+// SCIMトークン発行
+type IssueSCIMToken struct {
+	// トークン ※発行時のみ返却、再表示不可
+	Token string `json:"token"`
+	// ベースパス
+	Path string `json:"path"`
+}
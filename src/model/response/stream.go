@@ -0,0 +1,11 @@
+package response
+
+// 配信イベント
+type StreamEvent struct {
+	// イベント種別
+	Type string `json:"type"`
+	// 対象ハッシュキー
+	Target string `json:"target,omitempty"`
+	// 件数
+	Num int `json:"num,omitempty"`
+}
@@ -36,3 +36,21 @@ type StatusEventsByTeam struct {
 type ListInterviewProcessing struct {
 	List []entity.Processing `json:"list"`
 }
+
+// 面接予約枠設定取得
+type GetBookingWindow struct {
+	// 設定
+	Window entity.TeamBookingWindow `json:"window"`
+	// 曜日毎の受付時間
+	Hours []entity.TeamBookingHours `json:"hours"`
+	// 継承フラグ ※指定の面接回数の設定がなく、チーム既定またはシステム既定を表示
+	Inherited bool `json:"inherited"`
+}
+
+// 定休日設定取得
+type GetTeamWeekday struct {
+	// 定休日の曜日 ※0:日曜 ～ 6:土曜
+	ClosedWeekdays []uint `json:"closed_weekdays"`
+	// 継承フラグ ※チームの設定がなく、システム既定(土日)を表示
+	Inherited bool `json:"inherited"`
+}
@@ -7,6 +7,11 @@ type CreateUser struct {
 	entity.User
 }
 
+// パスワード再発行
+type ReissueUserPassword struct {
+	entity.User
+}
+
 // 検索
 type SearchUser struct {
 	List []entity.SearchUser `json:"list"`
@@ -0,0 +1,37 @@
+package static
+
+import "time"
+
+// 面接予約枠の既定値 ※チーム・面接回数毎の設定がない場合に使用
+const (
+	// タイムゾーン
+	BOOKING_DEFAULT_TIME_ZONE string = "Asia/Tokyo"
+	// 面接時間(分)
+	BOOKING_DEFAULT_DURATION uint = 60
+	// 予約枠の刻み(分)
+	BOOKING_DEFAULT_SLOT_INTERVAL uint = 30
+	// 受付開始(0時からの分)
+	BOOKING_DEFAULT_START_MINUTE uint = 9 * 60
+	// 受付終了(0時からの分)
+	BOOKING_DEFAULT_END_MINUTE uint = 20 * 60
+	// 受付締切(時間)
+	BOOKING_DEFAULT_LEAD_TIME uint = 7 * 24
+	// 受付期間(日)
+	BOOKING_DEFAULT_HORIZON uint = 14
+	// 前後の予定との間隔(分)
+	BOOKING_DEFAULT_BUFFER uint = 0
+)
+
+// 面接予約の排他 ※同一企業の予約を直列化し、面接官の重複割り当てを防止
+const (
+	// ロック有効期限 ※保持中は延長するため、延長間隔より長くする
+	BOOKING_LOCK_TTL time.Duration = 10 * time.Second
+	// ロック延長間隔
+	BOOKING_LOCK_RENEW_INTERVAL time.Duration = 3 * time.Second
+	// ロック取得の待機時間
+	BOOKING_LOCK_WAIT time.Duration = 5 * time.Second
+	// ロック取得の再試行間隔
+	BOOKING_LOCK_RETRY_INTERVAL time.Duration = 50 * time.Millisecond
+	// ロック(Redis)キー接頭辞
+	REDIS_BOOKING_LOCK_PRE string = "booking_lock_"
+)
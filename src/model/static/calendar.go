@@ -0,0 +1,35 @@
+package static
+
+// iCalendar
+const (
+	// 製品識別子
+	ICS_PRODID string = "-//adoption//calendar//JA"
+	// UIDドメイン
+	ICS_UID_DOMAIN string = "adoption"
+	// Content-Type
+	ICS_CONTENT_TYPE string = "text/calendar; charset=UTF-8"
+	// 招待ファイル名
+	ICS_INVITE_FILE_NAME string = "invite.ics"
+	// カレンダー名(購読)
+	ICS_CALENDAR_NAME string = "採用管理"
+	// フィード公開(購読)
+	ICS_METHOD_PUBLISH string = "PUBLISH"
+	// 日時書式(UTC)
+	ICS_DATE_FORMAT string = "20060102T150405Z"
+	// 1行の最大オクテット数
+	ICS_LINE_OCTETS int = 75
+	// 面接予定の詳細
+	ICS_DESCRIPTION_INTERVIEW string = "応募者: %s"
+)
+
+// カレンダーフィード
+const (
+	// 購読トークン長(バイト)
+	CALENDAR_TOKEN_BYTES int = 32
+	// 過去予定の掲載日数
+	CALENDAR_FEED_PAST_DAYS int = 90
+	// 今後の予定の掲載日数 ※繰り返し予定はこの期間の各回を掲載
+	CALENDAR_FEED_FUTURE_DAYS int = 365
+	// フィードパス
+	CALENDAR_FEED_PATH string = "/calendar/feed/"
+)
@@ -0,0 +1,34 @@
+package static
+
+import (
+	_ "embed"
+	"time"
+)
+
+// 企業休日区分
+const (
+	// 休業日
+	HOLIDAY_KIND_CLOSED uint = 1
+	// 特別営業日 ※祝日・定休日でも営業
+	HOLIDAY_KIND_WORKING uint = 2
+)
+
+// 企業休日
+const (
+	// キャッシュ有効期間 ※他プロセスでの更新はこの期間内に反映
+	HOLIDAY_CACHE_TTL time.Duration = 5 * time.Minute
+	// 取込ファイルの最大サイズ
+	HOLIDAY_IMPORT_MAX_FILE_SIZE int64 = 1 << 20
+	// 取込件数の上限
+	HOLIDAY_IMPORT_MAX_ROWS int = 1000
+	// 取込ファイル拡張子(iCalendar)
+	HOLIDAY_IMPORT_EXT_ICS string = ".ics"
+	// 名称の最大文字数
+	HOLIDAY_NAME_MAX_LENGTH int = 50
+)
+
+// 日本の祝日(国民の祝日・休日) ※1行1件「YYYY-MM-DD,名称」、毎年翌年分を追記
+// 春分の日・秋分の日は官報公示前の年は計算値
+//
+//go:embed holidays_jp.csv
+var HolidaysJp string
@@ -6,21 +6,47 @@ const (
 		共通
 	*/
 	CODE_BAD_REQUEST uint = 101
+	// ログイン試行制限
+	CODE_ACCOUNT_LOCKED uint = 102
+	CODE_IP_LOCKED      uint = 103
 
 	/*
 		login
 	*/
 	// ログイン認証
-	CODE_LOGIN_AUTH uint = 1
+	CODE_LOGIN_AUTH         uint = 1
+	CODE_LOGIN_SSO_REQUIRED uint = 2
 	// MFA作成
-	CODE_LOGIN_REQUIRED uint = 1
+	CODE_LOGIN_REQUIRED     uint = 1
+	CODE_LOGIN_TOTP_ENABLED uint = 2
 	// MFA
 	CODE_INVALID_CODE uint = 1
 	CODE_EXPIRED      uint = 2
 	// パスワード変更
 	CODE_INIT_PASSWORD_INCORRECT uint = 1
+	CODE_PASSWORD_POLICY         uint = 2
+	CODE_PASSWORD_BREACHED       uint = 3
+	CODE_PASSWORD_REUSED         uint = 4
+	// パスワード再設定
+	CODE_PASSWORD_RESET_INVALID_TOKEN uint = 1
+	// 認証アプリ登録
+	CODE_TOTP_ENABLED uint = 1
+	// 認証アプリ確認
+	CODE_TOTP_NOT_STARTED uint = 2
+	// 認証アプリ解除
+	CODE_TOTP_REQUIRED uint = 1
+	CODE_TOTP_DISABLED uint = 2
 	// チーム未検証
 	CODE_CONFIRM_TEAM_NOT_EXIST uint = 1
+	// マジックリンク
+	CODE_MAGIC_LINK_INVALID uint = 1
+	// シングルサインオン開始
+	CODE_SSO_NOT_CONFIGURED uint = 1
+	// シングルサインオン認証
+	CODE_SSO_INVALID_STATE      uint = 1
+	CODE_SSO_AUTH               uint = 2
+	CODE_SSO_DOMAIN_NOT_ALLOWED uint = 3
+	CODE_SSO_USER_NOT_FOUND     uint = 4
 	// 応募者チェック
 	CODE_CHECK_APPLICANT_TEST_FINISHED          uint = 11
 	CODE_CHECK_APPLICANT_CANNOT_UPDATE_SCHEDULE uint = 12
@@ -31,6 +57,12 @@ const (
 	// 登録
 	CODE_COMPANY_NAME_DUPL  uint = 1
 	CODE_COMPANY_EMAIL_DUPL uint = 2
+	// シングルサインオン設定
+	CODE_COMPANY_SSO_DOMAIN_DUPL  uint = 1
+	CODE_COMPANY_SSO_CLIENT_EMPTY uint = 2
+	// 休日取込
+	CODE_COMPANY_HOLIDAY_IMPORT_INVALID_FORMAT uint = 1
+	CODE_COMPANY_HOLIDAY_IMPORT_TOO_LARGE      uint = 2
 
 	/*
 		ユーザー
@@ -53,10 +85,26 @@ const (
 		応募者
 	*/
 	// 面接希望日登録
-	CODE_APPLICANT_CANNOT_ASSIGN_USER uint = 1
+	CODE_APPLICANT_CANNOT_ASSIGN_USER  uint = 1
+	CODE_APPLICANT_OUT_OF_BOOKING_SLOT uint = 2
+	CODE_APPLICANT_SLOT_TAKEN          uint = 3
 	// 面接官割り振り
 	CODE_APPLICANT_SCHEDULE_DOES_NOT_EXIST uint = 1
 	CODE_APPLICANT_SHORTAGE_USER_MIN       uint = 2
+	// 応募者取込
+	CODE_APPLICANT_IMPORT_UNKNOWN_SITE   uint = 1
+	CODE_APPLICANT_IMPORT_INVALID_FORMAT uint = 2
+	CODE_APPLICANT_IMPORT_TOO_LARGE      uint = 3
+	// 応募者取込取消
+	CODE_APPLICANT_ROLLBACK_ALREADY    uint = 1
+	CODE_APPLICANT_ROLLBACK_PROGRESSED uint = 2
+
+	/*
+		予定
+	*/
+	// 例外登録
+	CODE_SCHEDULE_NOT_RECURRING        uint = 1
+	CODE_SCHEDULE_OCCURRENCE_NOT_FOUND uint = 2
 
 	/*
 		原稿
@@ -65,6 +113,39 @@ const (
 	CODE_MANUSCRIPT_DUPLICATE_CONTENT uint = 1
 	// 原稿削除
 	CODE_MANUSCRIPT_CANNOT_DELETE_APPLICANT uint = 1
+
+	/*
+		ロール
+	*/
+	// 登録・更新
+	CODE_ROLE_DUPL_NAME   uint = 1
+	CODE_ROLE_CANNOT_EDIT uint = 2
+	// 削除
+	CODE_ROLE_CANNOT_DELETE      uint = 1
+	CODE_ROLE_CANNOT_DELETE_USER uint = 2
+	// ユーザー割り当て
+	CODE_ROLE_CANNOT_ASSIGN_SELF      uint = 1
+	CODE_ROLE_CANNOT_ASSIGN_PROTECTED uint = 2
+	CODE_ROLE_CANNOT_ASSIGN_EXCEED    uint = 3
+
+	/*
+		メールテンプレート
+	*/
+	// 登録・更新
+	CODE_MAIL_TEMPLATE_DUPL_TITLE  uint = 1
+	CODE_MAIL_TEMPLATE_CANNOT_EDIT uint = 2
+	// 削除
+	CODE_MAIL_TEMPLATE_CANNOT_DELETE uint = 1
+
+	/*
+		変数
+	*/
+	// 登録・更新
+	CODE_VARIABLE_DUPL_TITLE  uint = 1
+	CODE_VARIABLE_CANNOT_EDIT uint = 2
+	// 削除
+	CODE_VARIABLE_CANNOT_DELETE          uint = 1
+	CODE_VARIABLE_CANNOT_DELETE_TEMPLATE uint = 2
 )
 
 // Response Body メッセージ
@@ -99,10 +180,13 @@ const (
 const (
 	// ユーザー
 	REDIS_USER_HASH_KEY   string = "hash_key"
+	REDIS_USER_ID         string = "user_id"
 	REDIS_USER_ROLE       string = "role_id"
 	REDIS_USER_LOGIN_TYPE string = "login_type"
 	REDIS_USER_COMPANY_ID string = "company_id"
 	REDIS_USER_TEAM_ID    string = "team_id"
+	// ログイン状態 ※LoginStatus、MFA完了後のみセッション開始可
+	REDIS_USER_LOGIN_STATUS string = "login_status"
 	// 応募者
 	REDIS_APPLICANT_HASH_KEY  string = "applicant_hash_key"
 	REDIS_CODE                string = "code"
@@ -0,0 +1,65 @@
+package static
+
+import "time"
+
+// ジョブ名
+const (
+	// 予定メンテナンス ※保持期間を過ぎた予定例外・リマインド送信履歴の削除
+	JOB_SCHEDULE_MAINTENANCE string = "schedule_maintenance"
+	// 面接リマインド送信
+	JOB_INTERVIEW_REMINDER string = "interview_reminder"
+	// 期限切れデータ削除 ※セッション・ログインロック履歴・ジョブ実行履歴
+	JOB_STALE_CLEANUP string = "stale_cleanup"
+)
+
+// ジョブ実行スケジュール ※cron形式(分 時 日 月 曜日)、JOB_TIME_ZONEの時刻
+const (
+	JOB_SPEC_SCHEDULE_MAINTENANCE string = "15 3 * * *"
+	JOB_SPEC_INTERVIEW_REMINDER   string = "*/10 * * * *"
+	JOB_SPEC_STALE_CLEANUP        string = "45 3 * * *"
+)
+
+// ジョブ実行ステータス
+const (
+	JOB_STATUS_RUNNING uint = 1
+	JOB_STATUS_SUCCESS uint = 2
+	JOB_STATUS_FAILED  uint = 3
+)
+
+// ジョブ実行契機
+const (
+	// スケジュール
+	JOB_TRIGGER_SCHEDULE uint = 1
+	// 手動(コマンド)
+	JOB_TRIGGER_MANUAL uint = 2
+)
+
+// ジョブ
+const (
+	// 実行スケジュールのタイムゾーン
+	JOB_TIME_ZONE string = "Asia/Tokyo"
+	// 実行中ロックの有効期限 ※ジョブの最大実行時間より長くする
+	JOB_LOCK_TTL time.Duration = 30 * time.Minute
+	// 実行予定時刻ロックの有効期限 ※同一時刻の実行を1インスタンスに限定
+	JOB_SLOT_TTL time.Duration = 24 * time.Hour
+	// エラー内容の最大文字数
+	JOB_MESSAGE_MAX_LENGTH int = 1000
+	// 面接リマインドの送信対象 ※この期間内に開始する面接
+	JOB_REMINDER_LEAD time.Duration = 24 * time.Hour
+	// 予定例外・リマインド送信履歴の保持日数 ※カレンダーフィードの掲載期間より長くする
+	JOB_SCHEDULE_RETENTION_DAYS int = 400
+	// 期限切れセッションの保持日数
+	JOB_SESSION_RETENTION_DAYS int = 30
+	// ログインロック履歴の保持日数
+	JOB_LOCKOUT_RETENTION_DAYS int = 365
+	// ジョブ実行履歴の保持日数
+	JOB_RUN_RETENTION_DAYS int = 90
+)
+
+// ジョブ_Redisキー接頭辞
+const (
+	// 実行中ロック
+	REDIS_JOB_LOCK_PRE string = "job_lock_"
+	// 実行予定時刻ロック
+	REDIS_JOB_SLOT_PRE string = "job_slot_"
+)
@@ -0,0 +1,49 @@
+package static
+
+import "time"
+
+// ログイン試行制限
+const (
+	// ロックまでの失敗回数(既定値)
+	LOCKOUT_THRESHOLD uint = 5
+	// ロック期間(既定値) ※ロックの度に倍増
+	LOCKOUT_DURATION time.Duration = 1 * time.Minute
+	// 最大ロック期間(既定値)
+	LOCKOUT_MAX_DURATION time.Duration = 1 * time.Hour
+	// 失敗回数の集計期間
+	LOCKOUT_WINDOW time.Duration = 15 * time.Minute
+	// ロック段階の保持期間 ※期間内に再度ロックされると期間を倍増
+	LOCKOUT_LEVEL_TTL time.Duration = 24 * time.Hour
+	// ロックまでの失敗回数(IPアドレス)
+	IP_LOCKOUT_THRESHOLD uint = 30
+	// ロック期間(IPアドレス)
+	IP_LOCKOUT_DURATION time.Duration = 5 * time.Minute
+	// 最大ロック期間(IPアドレス)
+	IP_LOCKOUT_MAX_DURATION time.Duration = 24 * time.Hour
+)
+
+// ログイン試行制限_対象接頭辞
+const (
+	LOCKOUT_TARGET_LOGIN           string = "login:"
+	LOCKOUT_TARGET_MFA             string = "mfa:"
+	LOCKOUT_TARGET_PASSWORD_CHANGE string = "password_change:"
+	LOCKOUT_TARGET_IP              string = "ip:"
+)
+
+// ログイン試行制限_Redisキー接頭辞
+const (
+	// 失敗回数
+	REDIS_LOCKOUT_FAIL_PRE string = "lockout_fail_"
+	// ロック段階
+	REDIS_LOCKOUT_LEVEL_PRE string = "lockout_level_"
+	// ロック中 ※有効期限がロック期限
+	REDIS_LOCKOUT_PRE string = "lockout_until_"
+	// ロック期限(UNIX時間)
+	REDIS_LOCKOUT_UNTIL string = "until"
+)
+
+// ログインロック履歴_イベント
+const (
+	LOCKOUT_EVENT_LOCK   uint = 1
+	LOCKOUT_EVENT_UNLOCK uint = 2
+)
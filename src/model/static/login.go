@@ -1,5 +1,7 @@
 package static
 
+import "time"
+
 type LoginStatus int8
 type PasswordChangeFlg int8
 
@@ -12,3 +14,13 @@ const (
 	PASSWORD_CHANGE_UNREQUIRED PasswordChangeFlg = 0
 	PASSWORD_CHANGE_REQUIRED   PasswordChangeFlg = 1
 )
+
+// MFA 認証コード(メール送信)
+const (
+	// 桁数
+	MFA_CODE_DIGITS int = 6
+	// 有効期限
+	MFA_CODE_TTL time.Duration = 5 * time.Minute
+	// 認証コード(Redis)接頭辞 ※ユーザー毎、検証時に取り出して失効
+	REDIS_MFA_CODE_PRE string = "mfa_code_"
+)
@@ -0,0 +1,25 @@
+package static
+
+import "time"
+
+// 応募者マジックリンク
+const (
+	// 署名鍵(環境変数名)
+	MAGIC_LINK_SECRET string = "MAGIC_LINK_SECRET"
+	// ログインリンク有効期限
+	MAGIC_LINK_LOGIN_TTL time.Duration = 15 * time.Minute
+	// 招待リンク有効期限 ※応募者取込時に発行
+	MAGIC_LINK_INVITE_TTL time.Duration = 7 * 24 * time.Hour
+	// nonce長(バイト)
+	MAGIC_LINK_NONCE_BYTES int = 16
+	// 再送間隔 ※同一チーム・メールアドレスへの連続送信を抑止
+	MAGIC_LINK_INTERVAL time.Duration = 1 * time.Minute
+	// 応募者画面パス(フロント)
+	MAGIC_LINK_PATH string = "/magic_link?token="
+	// 未使用リンク(Redis) ※キーはnonce
+	REDIS_MAGIC_LINK_PRE string = "magic_link_"
+	// 未使用リンク_応募者ハッシュキー
+	REDIS_MAGIC_LINK_APPLICANT string = "applicant"
+	// 送信済み(Redis)
+	REDIS_MAGIC_LINK_SENT_PRE string = "magic_link_sent_"
+)
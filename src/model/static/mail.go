@@ -0,0 +1,175 @@
+package static
+
+import "time"
+
+// メール種別
+const (
+	// MFA認証コード
+	MAIL_KIND_MFA_CODE uint = 1
+	// MFA認証コード(応募者)
+	MAIL_KIND_MFA_CODE_APPLICANT uint = 2
+	// 初回パスワード(企業登録)
+	MAIL_KIND_INIT_PASSWORD_COMPANY uint = 3
+	// 初回パスワード(ユーザー登録)
+	MAIL_KIND_INIT_PASSWORD_USER uint = 4
+	// テンプレート送信(応募者)
+	MAIL_KIND_TEMPLATE uint = 5
+	// 面接招待(応募者)
+	MAIL_KIND_INTERVIEW_INVITE uint = 6
+	// パスワード再設定
+	MAIL_KIND_PASSWORD_RESET uint = 7
+	// パスワード再発行(管理者)
+	MAIL_KIND_PASSWORD_REISSUE uint = 8
+	// ログインリンク(応募者)
+	MAIL_KIND_LOGIN_LINK_APPLICANT uint = 9
+	// 招待リンク(応募者)
+	MAIL_KIND_INVITATION_APPLICANT uint = 10
+	// 面接リマインド(応募者)
+	MAIL_KIND_INTERVIEW_REMINDER uint = 11
+)
+
+// メール送信ステータス
+const (
+	MAIL_STATUS_SUCCESS uint = 1
+	MAIL_STATUS_FAILED  uint = 2
+)
+
+// メール送信方式
+const (
+	MAIL_DRIVER_SMTP string = "smtp"
+	MAIL_DRIVER_FILE string = "file"
+)
+
+// メール送信リトライ ※間隔は試行回数に比例
+const (
+	MAIL_RETRY_COUNT    int           = 3
+	MAIL_RETRY_INTERVAL time.Duration = 30 * time.Second
+)
+
+// メール送信処理
+const (
+	// SMTP接続・送信のタイムアウト
+	MAIL_SMTP_TIMEOUT time.Duration = 10 * time.Second
+	// 送信待ち確認間隔 ※同一プロセスからの登録時は即時
+	MAIL_OUTBOX_POLL_INTERVAL time.Duration = 5 * time.Second
+	// 1回の取得件数
+	MAIL_OUTBOX_BATCH_SIZE int = 20
+	// 取得した送信待ちの占有期間 ※送信中に停止した場合はこの期間後に再送
+	MAIL_OUTBOX_LEASE time.Duration = 5 * time.Minute
+)
+
+// メール件名
+const (
+	MAIL_SUBJECT_MFA_CODE         string = "【認証コード】ログイン認証コードのお知らせ"
+	MAIL_SUBJECT_INIT_PASSWORD    string = "【アカウント発行】初回パスワードのお知らせ"
+	MAIL_SUBJECT_INTERVIEW        string = "【面接日程】面接日時確定のお知らせ"
+	MAIL_SUBJECT_PASSWORD_RESET   string = "【パスワード再設定】パスワード再設定のご案内"
+	MAIL_SUBJECT_PASSWORD_REISSUE string = "【パスワード再発行】仮パスワードのお知らせ"
+	MAIL_SUBJECT_LOGIN_LINK       string = "【ログイン】ログイン用URLのお知らせ"
+	MAIL_SUBJECT_INVITATION       string = "【面接日程】面接日程ご予約のお願い"
+	MAIL_SUBJECT_REMINDER         string = "【面接日程】面接日時のご確認"
+)
+
+// メール本文
+const (
+	MAIL_BODY_MFA_CODE string = `認証コードは以下の通りです。
+
+%s
+
+有効期限は5分です。
+本メールにお心当たりのない場合は破棄してください。
+`
+	MAIL_BODY_INIT_PASSWORD string = `%s 様
+
+アカウントが発行されました。
+以下の初回パスワードでログインし、パスワードを変更してください。
+
+メールアドレス: %s
+初回パスワード: %s
+`
+	MAIL_BODY_INTERVIEW string = `%s 様
+
+以下の日時で面接が確定しました。
+
+件名: %s
+日時: %s
+%s
+添付のカレンダーファイルからご自身のカレンダーへ登録いただけます。
+`
+	MAIL_BODY_INTERVIEW_URL  string = "URL: %s\n"
+	MAIL_BODY_PASSWORD_RESET string = `%s 様
+
+パスワード再設定のリクエストを受け付けました。
+以下のURLから新しいパスワードを設定してください。
+
+%s
+
+有効期限は30分で、一度のみ使用できます。
+本メールにお心当たりのない場合は破棄してください。
+`
+	MAIL_BODY_LOGIN_LINK string = `%s 様
+
+以下のURLからログインしてください。
+
+%s
+
+有効期限は15分で、一度のみ使用できます。
+本メールにお心当たりのない場合は破棄してください。
+`
+	MAIL_BODY_INVITATION string = `%s 様
+
+%s にご応募いただきありがとうございます。
+以下のURLから面接日程をご予約ください。
+
+%s
+
+有効期限は7日間で、一度のみ使用できます。
+期限切れの場合はログイン画面からログイン用URLを再発行してください。
+`
+	MAIL_BODY_PASSWORD_REISSUE string = `%s 様
+
+管理者によりパスワードが再発行されました。
+以下の仮パスワードでログインし、パスワードを変更してください。
+
+メールアドレス: %s
+仮パスワード: %s
+`
+	MAIL_BODY_REMINDER string = `%s 様
+
+以下の日時で面接を予定しております。
+
+件名: %s
+日時: %s
+%s
+ご都合が悪くなった場合は、お早めにご連絡ください。
+`
+)
+
+// メールテンプレート変数 格納Json名
+const (
+	// 応募者氏名
+	MAIL_VARIABLE_NAME string = "name"
+	// 応募者メールアドレス
+	MAIL_VARIABLE_EMAIL string = "email"
+	// チーム名
+	MAIL_VARIABLE_TEAM string = "team"
+	// 面接日時
+	MAIL_VARIABLE_INTERVIEW_DATE string = "interview_date"
+	// Google Meet URL
+	MAIL_VARIABLE_GOOGLE_MEET_URL string = "google_meet_url"
+)
+
+var MailVariables = []interface{}{
+	MAIL_VARIABLE_NAME,
+	MAIL_VARIABLE_EMAIL,
+	MAIL_VARIABLE_TEAM,
+	MAIL_VARIABLE_INTERVIEW_DATE,
+	MAIL_VARIABLE_GOOGLE_MEET_URL,
+}
+
+// メールテンプレート変数 書式
+const (
+	MAIL_VARIABLE_PREFIX string = "{{"
+	MAIL_VARIABLE_SUFFIX string = "}}"
+	MAIL_DATE_FORMAT     string = "2006/01/02 15:04"
+)
@@ -29,6 +29,8 @@ const (
 	// admin_操作ログ関連
 	ROLE_ADMIN_LOG_READ        uint = 301
 	ROLE_ADMIN_LOG_DETAIL_READ uint = 302
+	// admin_ジョブ関連
+	ROLE_ADMIN_JOB_READ uint = 401
 	// management_ロール関連
 	ROLE_MANAGEMENT_ROLE_CREATE      uint = 1001
 	ROLE_MANAGEMENT_ROLE_READ        uint = 1002
@@ -77,6 +79,7 @@ const (
 	ROLE_MANAGEMENT_MAIL_DETAIL_READ uint = 1603
 	ROLE_MANAGEMENT_MAIL_EDIT        uint = 1604
 	ROLE_MANAGEMENT_MAIL_DELETE      uint = 1605
+	ROLE_MANAGEMENT_MAIL_SEND        uint = 1606
 	// management_変数関連
 	ROLE_MANAGEMENT_VARIABLE_CREATE      uint = 1701
 	ROLE_MANAGEMENT_VARIABLE_READ        uint = 1702
@@ -117,6 +120,68 @@ const (
 	LOGIN_TYPE_MANAGEMENT uint = 2
 )
 
+// m_operation_log_event
+const (
+	// 応募者関連
+	OPERATION_LOG_EVENT_APPLICANT_DOWNLOAD        uint = 101
+	OPERATION_LOG_EVENT_APPLICANT_DESIRED_AT      uint = 102
+	OPERATION_LOG_EVENT_APPLICANT_DOCUMENT_UPLOAD uint = 103
+	OPERATION_LOG_EVENT_APPLICANT_CREATE_MEET_URL uint = 104
+	OPERATION_LOG_EVENT_APPLICANT_ASSIGN_USER     uint = 105
+	OPERATION_LOG_EVENT_APPLICANT_UPDATE_TYPE     uint = 106
+	OPERATION_LOG_EVENT_APPLICANT_UPDATE_STATUS   uint = 107
+	OPERATION_LOG_EVENT_APPLICANT_INPUT_RESULT    uint = 108
+	OPERATION_LOG_EVENT_APPLICANT_CREATE_TYPE     uint = 109
+	OPERATION_LOG_EVENT_APPLICANT_SETTING_STATUS  uint = 110
+	OPERATION_LOG_EVENT_APPLICANT_ROLLBACK_UPLOAD uint = 111
+	// チーム関連
+	OPERATION_LOG_EVENT_TEAM_CREATE         uint = 201
+	OPERATION_LOG_EVENT_TEAM_UPDATE         uint = 202
+	OPERATION_LOG_EVENT_TEAM_UPDATE_BASIC   uint = 203
+	OPERATION_LOG_EVENT_TEAM_DELETE         uint = 204
+	OPERATION_LOG_EVENT_TEAM_UPDATE_ASSIGN  uint = 205
+	OPERATION_LOG_EVENT_TEAM_UPDATE_BOOKING uint = 206
+	OPERATION_LOG_EVENT_TEAM_UPDATE_WEEKDAY uint = 207
+	// ユーザー関連
+	OPERATION_LOG_EVENT_USER_CREATE uint = 301
+	OPERATION_LOG_EVENT_USER_DELETE uint = 302
+	OPERATION_LOG_EVENT_USER_UPDATE uint = 303
+	// 予定関連
+	OPERATION_LOG_EVENT_SCHEDULE_CREATE           uint = 401
+	OPERATION_LOG_EVENT_SCHEDULE_UPDATE           uint = 402
+	OPERATION_LOG_EVENT_SCHEDULE_DELETE           uint = 403
+	OPERATION_LOG_EVENT_SCHEDULE_SAVE_EXCEPTION   uint = 404
+	OPERATION_LOG_EVENT_SCHEDULE_DELETE_EXCEPTION uint = 405
+	// 原稿関連
+	OPERATION_LOG_EVENT_MANUSCRIPT_CREATE           uint = 501
+	OPERATION_LOG_EVENT_MANUSCRIPT_ASSIGN_APPLICANT uint = 502
+	OPERATION_LOG_EVENT_MANUSCRIPT_DELETE           uint = 503
+	// ロール関連
+	OPERATION_LOG_EVENT_ROLE_CREATE uint = 601
+	OPERATION_LOG_EVENT_ROLE_EDIT   uint = 602
+	OPERATION_LOG_EVENT_ROLE_DELETE uint = 603
+	OPERATION_LOG_EVENT_ROLE_ASSIGN uint = 604
+)
+
+// m_analysis_term
+const (
+	ANALYSIS_TERM_STATUS           uint = 1
+	ANALYSIS_TERM_INTERVIEW        uint = 2
+	ANALYSIS_TERM_SITE             uint = 3
+	ANALYSIS_TERM_MANUSCRIPT       uint = 4
+	ANALYSIS_TERM_APPLICANT_TYPE   uint = 5
+	ANALYSIS_TERM_TIME_TO_HIRE     uint = 6
+	ANALYSIS_TERM_INTERVIEWER_LOAD uint = 7
+)
+
+// m_notice
+const (
+	NOTICE_APPLICANT_ASSIGN_USER     uint = 1
+	NOTICE_APPLICANT_DESIRED_AT      uint = 2
+	NOTICE_APPLICANT_DOCUMENT_UPLOAD uint = 3
+	NOTICE_APPLICANT_INPUT_RESULT    uint = 4
+)
+
 // m_select_status_event
 const (
 	STATUS_EVENT_DECIDE_SCHEDULE           uint = 1
@@ -159,6 +224,12 @@ const (
 	PRE_APPLICANT      string = "applicant"
 	PRE_APPLICANT_TYPE string = "applicant_type"
 	PRE_MANUSCRIPT     string = "manuscript"
+	PRE_MAIL_TEMPLATE  string = "mail_template"
+	PRE_VARIABLE       string = "variable"
+	PRE_MAIL_PREVIEW   string = "mail_preview"
+	PRE_OPERATION_LOG  string = "operation_log"
+	PRE_UPLOAD_HISTORY string = "upload_history"
+	PRE_NOTICE         string = "notice"
 )
 
 // m_site
@@ -185,6 +256,7 @@ const (
 	INDEX_MYNAVI_NAME       uint   = 1 // ※性: 1, 名: 2
 	INDEX_MYNAVI_EMAIL      uint   = 9
 	INDEX_MYNAVI_TEL        uint   = 11 // 空文字の場合は12の電話番号(自宅)をチェック
+	INDEX_MYNAVI_TEL_SUB    uint   = 12
 	INDEX_MYNAVI_AGE        uint   = 6
 	INDEX_MYNAVI_MANUSCRIPT uint   = 18
 	COLUMNS_MYNAVI          uint   = 381
@@ -194,9 +266,40 @@ const (
 	INDEX_DODA_NAME       uint   = 6 // ※性: 6, 名: 7
 	INDEX_DODA_EMAIL      uint   = 13
 	INDEX_DODA_TEL        uint   = 18 // 空文字の場合は19の電話番号(自宅)をチェック
+	INDEX_DODA_TEL_SUB    uint   = 19
 	INDEX_DODA_AGE        uint   = 11
 	INDEX_DODA_MANUSCRIPT uint   = 3
 	COLUMNS_DODA          uint   = 186
+
+	// 氏名_チェックタイプ
+	NAME_CHECK_TYPE_SPLIT uint = 0 // 性・名が別カラム
+	NAME_CHECK_TYPE_FULL  uint = 1 // 氏名が1カラム
+)
+
+// 電話番号(自宅)_index ※携帯電話番号が空文字の場合に使用
+var TelSubIndex = map[uint]uint{
+	MYNAVI: INDEX_MYNAVI_TEL_SUB,
+	DODA:   INDEX_DODA_TEL_SUB,
+}
+
+// 応募者取込
+const (
+	// 最大ファイルサイズ
+	IMPORT_MAX_FILE_SIZE int64 = 10 << 20
+	// ヘッダー行数
+	IMPORT_HEADER_ROWS int = 1
+	// TSV拡張子
+	IMPORT_EXT_TSV string = ".tsv"
+)
+
+// 応募者エクスポート
+const (
+	EXPORT_FORMAT_CSV  string = "csv"
+	EXPORT_FORMAT_XLSX string = "xlsx"
+	// 一度に読み込む件数
+	EXPORT_BATCH_SIZE int = 500
+	// 日時書式 ※チームのタイムゾーン
+	EXPORT_DATE_FORMAT string = "2006/01/02 15:04"
 )
 
 // m_schedule_freq_status
@@ -0,0 +1,33 @@
+package static
+
+import "time"
+
+// シングルサインオン(OpenID Connect) ※認可コード＋PKCE
+const (
+	// クライアントシークレット暗号鍵(環境変数名)
+	OIDC_ENCRYPTION_KEY string = "OIDC_ENCRYPTION_KEY"
+	// スコープ
+	OIDC_SCOPE string = "openid email profile"
+	// 認可リクエスト有効期限 ※state・nonce・code_verifierの保持期間
+	OIDC_STATE_TTL time.Duration = 10 * time.Minute
+	// state長(バイト)
+	OIDC_STATE_BYTES int = 32
+	// nonce長(バイト)
+	OIDC_NONCE_BYTES int = 16
+	// code_verifier長(バイト) ※16進数64桁、RFC 7636 の43〜128文字に収まる
+	OIDC_VERIFIER_BYTES int = 32
+	// 許容時刻ずれ ※IDトークンの有効期限・発行日時
+	OIDC_CLOCK_SKEW time.Duration = 1 * time.Minute
+	// プロバイダ設定・公開鍵のキャッシュ期間
+	OIDC_CACHE_TTL time.Duration = 1 * time.Hour
+	// HTTPタイムアウト
+	OIDC_HTTP_TIMEOUT time.Duration = 10 * time.Second
+	// 許可ドメイン上限
+	OIDC_MAX_DOMAINS int = 20
+	// リダイレクト先パス(フロント)
+	OIDC_CALLBACK_PATH string = "/sso/callback"
+	// 認可リクエスト(Redis) ※キーはstate
+	REDIS_OIDC_STATE_PRE string = "oidc_state_"
+	// 認可リクエスト_内容(JSON)
+	REDIS_OIDC_STATE string = "state"
+)
@@ -0,0 +1,61 @@
+package static
+
+import (
+	_ "embed"
+	"time"
+)
+
+// パスワードポリシー 既定値 ※企業設定が0の項目に適用
+const (
+	// 最小文字数
+	PASSWORD_MIN_LENGTH uint = 8
+	// 最大文字数 ※bcryptの上限(72バイト)未満
+	PASSWORD_MAX_LENGTH int = 64
+	// 必要な文字種数(英小文字・英大文字・数字・記号)
+	PASSWORD_MIN_CLASSES uint = 3
+	// 再利用禁止世代数
+	PASSWORD_HISTORY uint = 5
+	// 自動生成パスワード文字数
+	PASSWORD_GENERATE_LENGTH int = 16
+	// 自動生成パスワードの記号 ※メール上で判別しやすいもののみ
+	PASSWORD_SYMBOLS string = "!#$%&*+-=?@^_"
+)
+
+// パスワード再設定
+const (
+	// トークン長(バイト)
+	PASSWORD_RESET_TOKEN_BYTES int = 32
+	// 有効期限
+	PASSWORD_RESET_TTL time.Duration = 30 * time.Minute
+	// 再送間隔 ※同一メールアドレスへの連続送信を抑止
+	PASSWORD_RESET_INTERVAL time.Duration = 1 * time.Minute
+	// 再設定画面パス(フロント)
+	PASSWORD_RESET_PATH string = "/password_reset?token="
+	// トークン(Redis) ※キーはトークンのハッシュ
+	REDIS_PASSWORD_RESET_PRE string = "password_reset_"
+	// トークン_ユーザーハッシュキー
+	REDIS_PASSWORD_RESET_USER string = "user"
+	// 送信済み(Redis)
+	REDIS_PASSWORD_RESET_SENT_PRE string = "password_reset_sent_"
+)
+
+// パスワード変更(ログイン時) ※初回パスワードのユーザーがMFA完了時に発行、変更完了で失効
+const (
+	// トークンCookie名
+	PASSWORD_CHANGE_TOKEN_COOKIE string = "password_change_token"
+	// トークンCookieパス
+	PASSWORD_CHANGE_TOKEN_PATH string = "/password_change"
+	// トークン有効期限
+	PASSWORD_CHANGE_TOKEN_TTL time.Duration = 10 * time.Minute
+	// トークン長(バイト)
+	PASSWORD_CHANGE_TOKEN_BYTES int = 32
+	// トークン(Redis)接頭辞 ※トークンのハッシュ値→ユーザー
+	REDIS_PASSWORD_CHANGE_TOKEN_PRE string = "password_change_"
+	// トークン(Redis) ※ユーザー毎に最新のトークンのハッシュ値のみ有効
+	REDIS_PASSWORD_CHANGE_TOKEN string = "password_change_token"
+)
+
+// 漏洩パスワード一覧 ※1行1件、大文字小文字は区別しない
+//
+//go:embed breached_passwords.txt
+var BreachedPasswords string
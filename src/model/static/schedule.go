@@ -0,0 +1,28 @@
+package static
+
+// 予定の例外区分
+const (
+	// 取消 ※該当回のみ休み
+	SCHEDULE_EXCEPTION_SKIP uint = 1
+	// 移動 ※該当回のみ日時を変更
+	SCHEDULE_EXCEPTION_MOVE uint = 2
+)
+
+// 予定の繰り返し(RFC 5545のRRULE相当)
+const (
+	// 間隔の上限
+	SCHEDULE_INTERVAL_MAX uint = 99
+	// 回数の上限
+	SCHEDULE_COUNT_MAX uint = 999
+	// 曜日指定の最大文字数
+	SCHEDULE_BYDAY_MAX_LENGTH int = 50
+	// 展開時の走査回数の上限 ※不正な規則による無限ループ防止
+	SCHEDULE_EXPAND_MAX_ITERATIONS int = 100000
+	// 検索の既定期間(日) ※期間の指定がない場合は当日から
+	SCHEDULE_SEARCH_DEFAULT_DAYS int = 31
+	// 検索の最大期間(日)
+	SCHEDULE_SEARCH_MAX_DAYS int = 366
+)
+
+// 曜日(BYDAY) ※time.Weekday順
+var SCHEDULE_BYDAY_WEEKDAYS = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
@@ -0,0 +1,60 @@
+package static
+
+// SCIM 2.0
+const (
+	// ベースパス
+	SCIM_BASE_PATH string = "/scim/v2"
+	// Content-Type
+	SCIM_CONTENT_TYPE string = "application/scim+json"
+	// トークン長(バイト)
+	SCIM_TOKEN_BYTES int = 32
+	// 一覧の既定件数
+	SCIM_DEFAULT_COUNT int = 100
+	// 一覧の最大件数
+	SCIM_MAX_COUNT int = 1000
+	// 操作ログ詳細の連携元
+	SCIM_OPERATION_SOURCE string = "scim"
+)
+
+// SCIM スキーマURN
+const (
+	SCIM_SCHEMA_USER  string = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIM_SCHEMA_GROUP string = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIM_SCHEMA_LIST  string = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIM_SCHEMA_PATCH string = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIM_SCHEMA_ERROR string = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIM リソース種別
+const (
+	SCIM_RESOURCE_USER  string = "User"
+	SCIM_RESOURCE_GROUP string = "Group"
+)
+
+// SCIM エラー種別(scimType) ※RFC 7644 3.12
+const (
+	SCIM_TYPE_INVALID_FILTER string = "invalidFilter"
+	SCIM_TYPE_INVALID_SYNTAX string = "invalidSyntax"
+	SCIM_TYPE_INVALID_PATH   string = "invalidPath"
+	SCIM_TYPE_INVALID_VALUE  string = "invalidValue"
+	SCIM_TYPE_UNIQUENESS     string = "uniqueness"
+	SCIM_TYPE_MUTABILITY     string = "mutability"
+)
+
+// SCIM エラー詳細
+const (
+	SCIM_DETAIL_UNAUTHORIZED         string = "invalid bearer token"
+	SCIM_DETAIL_NOT_FOUND            string = "resource not found"
+	SCIM_DETAIL_INVALID_FILTER       string = "only 'eq' filter on userName / displayName is supported"
+	SCIM_DETAIL_INVALID_SYNTAX       string = "request body is malformed"
+	SCIM_DETAIL_INVALID_PATH         string = "unsupported patch path"
+	SCIM_DETAIL_INVALID_USER         string = "userName must be an email address and name must be 1-30 characters"
+	SCIM_DETAIL_INVALID_GROUP        string = "displayName is required"
+	SCIM_DETAIL_INVALID_MEMBER       string = "member does not exist"
+	SCIM_DETAIL_USER_NAME_DUPL       string = "userName is already in use"
+	SCIM_DETAIL_USER_HAS_APPLICANT   string = "user is assigned to applicants"
+	SCIM_DETAIL_USER_HAS_SCHEDULE    string = "user has schedules"
+	SCIM_DETAIL_GROUP_HAS_APPLICANT  string = "group has applicants"
+	SCIM_DETAIL_GROUP_HAS_SCHEDULE   string = "group has schedules"
+	SCIM_DETAIL_GROUP_HAS_MANUSCRIPT string = "group has manuscripts"
+)
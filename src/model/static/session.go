@@ -0,0 +1,21 @@
+package static
+
+import "time"
+
+// ログインセッション
+const (
+	// アクセストークン有効期限
+	ACCESS_TOKEN_TTL time.Duration = 15 * time.Minute
+	// リフレッシュトークン有効期限 ※ユーザーセッション(Redis)と同一
+	REFRESH_TOKEN_TTL time.Duration = 24 * time.Hour
+	// リフレッシュトークン長(バイト)
+	REFRESH_TOKEN_BYTES int = 32
+	// セッションID長(バイト)
+	SESSION_ID_BYTES int = 16
+	// リフレッシュトークンCookie名
+	REFRESH_TOKEN_COOKIE string = "refresh_token"
+	// リフレッシュトークンCookieパス
+	REFRESH_TOKEN_PATH string = "/"
+	// セッションキー(Redis)接頭辞
+	REDIS_SESSION_PRE string = "session_"
+)
@@ -0,0 +1,25 @@
+package static
+
+import "time"
+
+// 配信チャネル
+const (
+	STREAM_CHANNEL_TEAM string = "stream_team"
+	STREAM_CHANNEL_USER string = "stream_user"
+)
+
+// 配信イベント
+const (
+	STREAM_EVENT_APPLICANT_CREATE string = "applicant_create"
+	STREAM_EVENT_APPLICANT_DELETE string = "applicant_delete"
+	STREAM_EVENT_APPLICANT_STATUS string = "applicant_status"
+	STREAM_EVENT_APPLICANT_ASSIGN string = "applicant_assign"
+	STREAM_EVENT_SCHEDULE_BOOK    string = "schedule_book"
+	STREAM_EVENT_NOTICE           string = "notice"
+	// セッション失効・チーム切替 ※配信終了、クライアントは再認証・再接続
+	STREAM_EVENT_UNAUTHORIZED string = "unauthorized"
+	STREAM_EVENT_RECONNECT    string = "reconnect"
+)
+
+// 接続維持間隔
+const STREAM_HEARTBEAT_INTERVAL time.Duration = 30 * time.Second
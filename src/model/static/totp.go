@@ -0,0 +1,41 @@
+package static
+
+import "time"
+
+// 二要素認証(認証アプリ) ※RFC 6238
+const (
+	// 時間ステップ
+	TOTP_PERIOD time.Duration = 30 * time.Second
+	// 桁数
+	TOTP_DIGITS int = 6
+	// 許容ずれ(ステップ数) ※端末の時刻ずれ対策
+	TOTP_SKEW int64 = 1
+	// シークレット長(バイト) ※HMAC-SHA1のブロック長に合わせる
+	TOTP_SECRET_BYTES int = 20
+	// 発行者 ※認証アプリの表示名
+	TOTP_ISSUER string = "adoption"
+	// シークレット暗号鍵(環境変数名)
+	TOTP_ENCRYPTION_KEY string = "TOTP_ENCRYPTION_KEY"
+	// リカバリーコード発行数
+	RECOVERY_CODE_COUNT int = 10
+	// リカバリーコード長(バイト) ※16進数10桁
+	RECOVERY_CODE_BYTES int = 5
+	// 認証アプリ登録待ち(Redis) ※企業で必須かつ未登録のユーザー
+	REDIS_TOTP_SETUP string = "totp_setup"
+	// 認証アプリ登録待ち_あり
+	TOTP_SETUP_PENDING string = "1"
+	// 認証アプリ登録待ち_なし
+	TOTP_SETUP_NONE string = "0"
+	// 認証アプリ登録トークン(ログイン時) ※MFA完了時に発行、登録完了で失効
+	TOTP_SETUP_TOKEN_COOKIE string = "totp_setup_token"
+	// 認証アプリ登録トークンCookieパス
+	TOTP_SETUP_TOKEN_PATH string = "/mfa"
+	// 認証アプリ登録トークン有効期限
+	TOTP_SETUP_TOKEN_TTL time.Duration = 10 * time.Minute
+	// 認証アプリ登録トークン長(バイト)
+	TOTP_SETUP_TOKEN_BYTES int = 32
+	// 認証アプリ登録トークン(Redis)接頭辞 ※トークンのハッシュ値→ユーザー
+	REDIS_TOTP_SETUP_TOKEN_PRE string = "totp_setup_"
+	// 認証アプリ登録トークン(Redis) ※ユーザー毎に最新のトークンのハッシュ値のみ有効
+	REDIS_TOTP_SETUP_TOKEN string = "totp_setup_token"
+)
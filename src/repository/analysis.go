@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type IAnalysisRepository interface {
+	// 選考ステータス別
+	Status(m *dto.Analysis) ([]entity.AnalysisStatus, error)
+	// 面接回数別
+	Interview(m *dto.Analysis, numOfInterview uint) (*entity.AnalysisInterview, error)
+	// 媒体別
+	Site(m *dto.Analysis) ([]entity.AnalysisPassRate, error)
+	// 原稿別
+	Manuscript(m *dto.Analysis) ([]entity.AnalysisPassRate, error)
+	// 応募者種別別
+	ApplicantType(m *dto.Analysis) ([]entity.AnalysisPassRate, error)
+	// 採用までの日数
+	TimeToHire(m *dto.Analysis) (*entity.AnalysisTimeToHire, error)
+	// 面接官負荷
+	Interviewer(m *dto.Analysis) ([]entity.AnalysisInterviewer, error)
+}
+
+type AnalysisRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalysisRepository(db *gorm.DB) IAnalysisRepository {
+	return &AnalysisRepository{db}
+}
+
+// 選考ステータス別
+func (r *AnalysisRepository) Status(m *dto.Analysis) ([]entity.AnalysisStatus, error) {
+	var res []entity.AnalysisStatus
+	if err := r.db.Table("t_select_status").
+		Select(`
+			t_select_status.hash_key as status_hash,
+			t_select_status.status_name,
+			COUNT(a.id) as num
+		`).
+		Joins("LEFT JOIN (?) a ON a.status = t_select_status.id", r.applicants(m).Select("t_applicant.id, t_applicant.status")).
+		Where("t_select_status.team_id = ?", m.TeamID).
+		Group("t_select_status.id, t_select_status.hash_key, t_select_status.status_name").
+		Order("t_select_status.id ASC").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 面接回数別
+func (r *AnalysisRepository) Interview(m *dto.Analysis, numOfInterview uint) (*entity.AnalysisInterview, error) {
+	final := numOfInterview == m.NumOfInterview
+
+	// 通過 ※最終面接は通過で確定、それ以外は次の面接へ進んでいる
+	passed := `(
+		t_applicant.num_of_interview > ?
+		OR (t_applicant.num_of_interview = ? AND ? AND t_applicant.processing_id = ?)
+	)`
+	// 不通過
+	failed := `(t_applicant.num_of_interview = ? AND t_applicant.processing_id = ?)`
+	// 選考中 ※面接予定が登録済み
+	inProgress := `(
+		t_applicant.num_of_interview = ?
+		AND t_applicant.processing_id <> ?
+		AND NOT (? AND t_applicant.processing_id = ?)
+		AND EXISTS (
+			SELECT 1 FROM t_applicant_schedule_association
+			WHERE t_applicant_schedule_association.applicant_id = t_applicant.id
+		)
+	)`
+
+	var res entity.AnalysisInterview
+	if err := r.applicants(m).
+		Select(
+			fmt.Sprintf(`
+				COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) as passed,
+				COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) as failed,
+				COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) as in_progress
+			`, passed, failed, inProgress),
+			numOfInterview, numOfInterview, final, static.INTERVIEW_PROCESSING_PASS,
+			numOfInterview, static.INTERVIEW_PROCESSING_FAIL,
+			numOfInterview, static.INTERVIEW_PROCESSING_FAIL, final, static.INTERVIEW_PROCESSING_PASS,
+		).
+		Scan(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	res.NumOfInterview = numOfInterview
+	res.Reached = res.Passed + res.Failed + res.InProgress
+	return &res, nil
+}
+
+// 媒体別
+func (r *AnalysisRepository) Site(m *dto.Analysis) ([]entity.AnalysisPassRate, error) {
+	return r.passRate(
+		m,
+		[]string{
+			"INNER JOIN m_site ON t_applicant.site_id = m_site.id",
+		},
+		"m_site",
+		"m_site.site_name",
+	)
+}
+
+// 原稿別
+func (r *AnalysisRepository) Manuscript(m *dto.Analysis) ([]entity.AnalysisPassRate, error) {
+	return r.passRate(
+		m,
+		[]string{
+			"INNER JOIN t_manuscript_applicant_association ON t_applicant.id = t_manuscript_applicant_association.applicant_id",
+			"INNER JOIN t_manuscript ON t_manuscript_applicant_association.manuscript_id = t_manuscript.id",
+		},
+		"t_manuscript",
+		"t_manuscript.content",
+	)
+}
+
+// 応募者種別別
+func (r *AnalysisRepository) ApplicantType(m *dto.Analysis) ([]entity.AnalysisPassRate, error) {
+	return r.passRate(
+		m,
+		[]string{
+			"INNER JOIN t_applicant_type_association ON t_applicant.id = t_applicant_type_association.applicant_id",
+			"INNER JOIN t_applicant_type ON t_applicant_type_association.type_id = t_applicant_type.id",
+		},
+		"t_applicant_type",
+		"t_applicant_type.name",
+	)
+}
+
+// 採用までの日数 ※採用確定時の更新日時から算出
+func (r *AnalysisRepository) TimeToHire(m *dto.Analysis) (*entity.AnalysisTimeToHire, error) {
+	hired := r.applicants(m).
+		Select("EXTRACT(EPOCH FROM (t_applicant.updated_at - t_applicant.created_at)) / 86400 as days").
+		Where(hiredCondition, m.NumOfInterview, static.INTERVIEW_PROCESSING_PASS)
+
+	var res entity.AnalysisTimeToHire
+	if err := r.db.Table("(?) as h", hired).
+		Select(`
+			COUNT(*) as num,
+			COALESCE(AVG(h.days), 0) as avg,
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY h.days), 0) as median,
+			COALESCE(MIN(h.days), 0) as min,
+			COALESCE(MAX(h.days), 0) as max
+		`).
+		Scan(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// 面接官負荷 ※面接予定数は開始時刻、担当応募者数は応募日時で集計
+func (r *AnalysisRepository) Interviewer(m *dto.Analysis) ([]entity.AnalysisInterviewer, error) {
+	schedule := r.db.Table("t_schedule_association").
+		Select("COUNT(*)").
+		Joins("INNER JOIN t_schedule ON t_schedule_association.schedule_id = t_schedule.id").
+		Where("t_schedule_association.user_id = t_user.id").
+		Where("t_schedule.team_id = ?", m.TeamID).
+		Where("t_schedule.interview_flg = ?", uint(static.USER_INTERVIEW))
+	if !m.CreatedAtFrom.IsZero() {
+		schedule = schedule.Where("t_schedule.start >= ?", m.CreatedAtFrom)
+	}
+	if !m.CreatedAtTo.IsZero() {
+		schedule = schedule.Where("t_schedule.start < ?", m.CreatedAtTo.AddDate(0, 0, 1))
+	}
+
+	assign := r.applicants(m).
+		Select("COUNT(*)").
+		Joins("INNER JOIN t_applicant_user_association ON t_applicant.id = t_applicant_user_association.applicant_id").
+		Where("t_applicant_user_association.user_id = t_user.id")
+
+	var res []entity.AnalysisInterviewer
+	if err := r.db.Table("t_team_association").
+		Select(`
+			t_user.hash_key as user_hash,
+			t_user.name as user_name,
+			(?) as schedule_num,
+			(?) as assign_num
+		`, schedule, assign).
+		Joins("INNER JOIN t_user ON t_team_association.user_id = t_user.id").
+		Where("t_team_association.team_id = ?", m.TeamID).
+		Order("t_user.id ASC").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 採用 ※最終面接の通過
+const hiredCondition = "t_applicant.num_of_interview = ? AND t_applicant.processing_id = ?"
+
+// 不採用 ※書類選考の不通過を含む
+const failedCondition = "t_applicant.processing_id = ? OR t_applicant.document_pass_flg = ?"
+
+// 集計対象応募者
+func (r *AnalysisRepository) applicants(m *dto.Analysis) *gorm.DB {
+	query := r.db.Table("t_applicant").
+		Where("t_applicant.company_id = ?", m.CompanyID).
+		Where("t_applicant.team_id = ?", m.TeamID)
+	if !m.CreatedAtFrom.IsZero() {
+		query = query.Where("t_applicant.created_at >= ?", m.CreatedAtFrom)
+	}
+	if !m.CreatedAtTo.IsZero() {
+		query = query.Where("t_applicant.created_at < ?", m.CreatedAtTo.AddDate(0, 0, 1))
+	}
+	return query
+}
+
+// 採用率集計
+func (r *AnalysisRepository) passRate(m *dto.Analysis, joins []string, table string, name string) ([]entity.AnalysisPassRate, error) {
+	query := r.applicants(m)
+	for _, join := range joins {
+		query = query.Joins(join)
+	}
+
+	var res []entity.AnalysisPassRate
+	if err := query.
+		Select(
+			fmt.Sprintf(`
+				%s.hash_key,
+				%s as name,
+				COUNT(*) as total,
+				COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) as hired,
+				COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) as failed
+			`, table, name, hiredCondition, failedCondition),
+			m.NumOfInterview, static.INTERVIEW_PROCESSING_PASS,
+			static.INTERVIEW_PROCESSING_FAIL, static.DOCUMENT_FAIL,
+		).
+		Group(fmt.Sprintf("%s.id, %s.hash_key, %s", table, table, name)).
+		Order(fmt.Sprintf("%s.id ASC", table)).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"api/src/model/dto"
+	"api/src/model/request"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 集計SQL生成確認用DB ※DB接続なし、発行SQLを記録
+func newAnalysisDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: "host=localhost",
+	}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sqls []string
+	record := func(tx *gorm.DB) {
+		sqls = append(sqls, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:record", record); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("test:record", record); err != nil {
+		t.Fatal(err)
+	}
+	return db, &sqls
+}
+
+// 最後に発行したSQL ※サブクエリ生成分は除く
+func lastSQL(t *testing.T, sqls *[]string) string {
+	if len(*sqls) == 0 {
+		t.Fatal("no sql issued")
+	}
+	return (*sqls)[len(*sqls)-1]
+}
+
+// 空白を詰めたSQL
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+func TestAnalysisRepository_Interview(t *testing.T) {
+	m := &dto.Analysis{
+		CompanyID:      1,
+		TeamID:         2,
+		NumOfInterview: 3,
+	}
+	type args struct {
+		numOfInterview uint
+	}
+	tests := []struct {
+		name     string
+		args     args
+		contains []string
+	}{
+		// ok 途中の面接 ※次の面接へ進んでいれば通過、通過のままは通過としない
+		{
+			"ok_middle",
+			args{1},
+			[]string{
+				"t_applicant.num_of_interview > 1 OR (t_applicant.num_of_interview = 1 AND false AND t_applicant.processing_id = 2)",
+				"(t_applicant.num_of_interview = 1 AND t_applicant.processing_id = 3)",
+				"AND t_applicant.processing_id <> 3 AND NOT (false AND t_applicant.processing_id = 2)",
+			},
+		},
+		// ok 最終面接 ※通過で採用確定、選考中から除外
+		{
+			"ok_final",
+			args{3},
+			[]string{
+				"t_applicant.num_of_interview > 3 OR (t_applicant.num_of_interview = 3 AND true AND t_applicant.processing_id = 2)",
+				"(t_applicant.num_of_interview = 3 AND t_applicant.processing_id = 3)",
+				"AND t_applicant.processing_id <> 3 AND NOT (true AND t_applicant.processing_id = 2)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqls := newAnalysisDryRunDB(t)
+			r := &AnalysisRepository{db}
+			_, _ = r.Interview(m, tt.args.numOfInterview)
+			got := compactSQL(lastSQL(t, sqls))
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("AnalysisRepository.Interview() sql = %s, want contains %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalysisRepository_passRate(t *testing.T) {
+	type args struct {
+		m *dto.Analysis
+	}
+	tests := []struct {
+		name        string
+		args        args
+		contains    []string
+		notContains []string
+	}{
+		// ok 期間指定なし ※最終面接の通過を採用、面接・書類の不通過を不採用
+		{
+			"ok",
+			args{&dto.Analysis{
+				CompanyID:      1,
+				TeamID:         2,
+				NumOfInterview: 3,
+			}},
+			[]string{
+				"WHEN t_applicant.num_of_interview = 3 AND t_applicant.processing_id = 2 THEN 1",
+				"WHEN t_applicant.processing_id = 3 OR t_applicant.document_pass_flg = 2 THEN 1",
+				"t_applicant.company_id = 1 AND t_applicant.team_id = 2",
+				"GROUP BY m_site.id, m_site.hash_key, m_site.site_name",
+			},
+			[]string{
+				"t_applicant.created_at",
+			},
+		},
+		// ok 期間指定 ※終了日は当日を含む
+		{
+			"ok_term",
+			args{&dto.Analysis{
+				Analysis: request.Analysis{
+					CreatedAtFrom: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+					CreatedAtTo:   time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+				},
+				CompanyID:      1,
+				TeamID:         2,
+				NumOfInterview: 1,
+			}},
+			[]string{
+				"WHEN t_applicant.num_of_interview = 1 AND t_applicant.processing_id = 2 THEN 1",
+				"t_applicant.created_at >= '2024-04-01 00:00:00'",
+				"t_applicant.created_at < '2024-05-01 00:00:00'",
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqls := newAnalysisDryRunDB(t)
+			r := &AnalysisRepository{db}
+			if _, err := r.Site(tt.args.m); err != nil {
+				t.Fatal(err)
+			}
+			got := compactSQL(lastSQL(t, sqls))
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("AnalysisRepository.Site() sql = %s, want contains %s", got, want)
+				}
+			}
+			for _, want := range tt.notContains {
+				if strings.Contains(got, want) {
+					t.Errorf("AnalysisRepository.Site() sql = %s, want not contains %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalysisRepository_TimeToHire(t *testing.T) {
+	db, sqls := newAnalysisDryRunDB(t)
+	r := &AnalysisRepository{db}
+	_, _ = r.TimeToHire(&dto.Analysis{
+		CompanyID:      1,
+		TeamID:         2,
+		NumOfInterview: 3,
+	})
+	// 採用者のみ集計
+	got := compactSQL(lastSQL(t, sqls))
+	want := "t_applicant.num_of_interview = 3 AND t_applicant.processing_id = 2"
+	if !strings.Contains(got, want) {
+		t.Errorf("AnalysisRepository.TimeToHire() sql = %s, want contains %s", got, want)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IApplicantRepository interface {
@@ -23,6 +24,8 @@ type IApplicantRepository interface {
 	UpdatesByPrimary(tx *gorm.DB, m *ddl.Applicant, ids []uint64) error
 	// 検索
 	Search(m *dto.SearchApplicant) ([]*entity.SearchApplicant, int64, error)
+	// 検索_全件 ※batchSize件ずつコールバックに渡す
+	SearchAll(m *dto.SearchApplicant, batchSize int, f func(applicants []*entity.SearchApplicant) error) error
 	// 取得
 	Get(m *ddl.Applicant) (*entity.Applicant, error)
 	// 種別登録
@@ -81,6 +84,12 @@ type IApplicantRepository interface {
 	DeleteUserAssociation(tx *gorm.DB, m *ddl.ApplicantUserAssociation) error
 	// 応募者ID取得
 	GetIDs(m []string) ([]uint64, error)
+	// 応募者ID取得_コミットID ※取消完了まで他の更新・紐づけを待たせるため行ロック
+	GetIDsByCommitID(tx *gorm.DB, m *ddl.Applicant) ([]uint64, error)
+	// 選考が進んでいる応募者数_コミットID
+	CountProgressedByCommitID(tx *gorm.DB, m *dto.CheckRollbackUpload) (*int64, error)
+	// 一括削除
+	DeletesByPrimary(tx *gorm.DB, m []uint64) error
 }
 
 type ApplicantRepository struct {
@@ -160,6 +169,109 @@ func (a *ApplicantRepository) Search(m *dto.SearchApplicant) ([]*entity.SearchAp
 	var applicants []*entity.SearchApplicant
 	var totalCount int64
 
+	query := a.searchQuery(m)
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	offset := (m.Page - 1) * m.PageSize
+
+	if err := query.Select(selectSearchApplicant).
+		Offset(offset).
+		Limit(m.PageSize).
+		Find(&applicants).
+		Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	if err := a.searchUsers(applicants); err != nil {
+		return nil, 0, err
+	}
+
+	return applicants, totalCount, nil
+}
+
+// 検索_全件 ※batchSize件ずつコールバックに渡す
+func (a *ApplicantRepository) SearchAll(m *dto.SearchApplicant, batchSize int, f func(applicants []*entity.SearchApplicant) error) error {
+	query := a.searchQuery(m)
+	if m.SortKey == "" {
+		query = query.Order("t_applicant.id ASC")
+	}
+
+	rows, err := query.Select(selectSearchApplicant).Rows()
+	if err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	defer rows.Close()
+
+	var applicants []*entity.SearchApplicant
+	flush := func() error {
+		if len(applicants) == 0 {
+			return nil
+		}
+		if err := a.searchUsers(applicants); err != nil {
+			return err
+		}
+		if err := f(applicants); err != nil {
+			return err
+		}
+		applicants = nil
+		return nil
+	}
+
+	for rows.Next() {
+		var applicant entity.SearchApplicant
+		if err := a.db.ScanRows(rows, &applicant); err != nil {
+			log.Printf("%v", err)
+			return err
+		}
+		applicants = append(applicants, &applicant)
+		if len(applicants) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+
+	return flush()
+}
+
+const selectSearchApplicant = `
+		t_applicant.id,
+		t_applicant.hash_key,
+		t_applicant.outer_id,
+		t_applicant.site_id,
+		t_applicant.status,
+		t_applicant.name,
+		t_applicant.email,
+		t_applicant.tel,
+		t_applicant.age,
+		t_applicant.commit_id,
+		t_applicant.num_of_interview,
+		t_applicant.document_pass_flg,
+		t_applicant.created_at,
+		t_select_status.status_name,
+		m_site.site_name,
+		m_interview_processing.hash_key as process_hash,
+		t_schedule.hash_key as schedule_hash_key,
+		t_schedule.start,
+		t_applicant_resume_association.extension as resume_extension,
+		t_applicant_curriculum_vitae_association.extension as curriculum_vitae_extension,
+		t_applicant_url_association.url as google_meet_url,
+		t_manuscript.content as content,
+		t_applicant_type.name as type
+`
+
+// 検索条件
+func (a *ApplicantRepository) searchQuery(m *dto.SearchApplicant) *gorm.DB {
 	query := a.db.Table("t_applicant").
 		Joins(`
 			INNER JOIN
@@ -297,45 +409,11 @@ func (a *ApplicantRepository) Search(m *dto.SearchApplicant) ([]*entity.SearchAp
 		}
 	}
 
-	if err := query.Count(&totalCount).Error; err != nil {
-		log.Printf("%v", err)
-		return nil, 0, err
-	}
-
-	offset := (m.Page - 1) * m.PageSize
-
-	if err := query.Select(`
-		t_applicant.id,
-		t_applicant.hash_key,
-		t_applicant.outer_id,
-		t_applicant.site_id,
-		t_applicant.status,
-		t_applicant.name,
-		t_applicant.email,
-		t_applicant.age,
-		t_applicant.commit_id,
-		t_applicant.num_of_interview,
-		t_applicant.document_pass_flg,
-		t_applicant.created_at,
-		t_select_status.status_name,
-		m_site.site_name,
-		m_interview_processing.hash_key as process_hash,
-		t_schedule.hash_key as schedule_hash_key,
-		t_schedule.start,
-		t_applicant_resume_association.extension as resume_extension,
-		t_applicant_curriculum_vitae_association.extension as curriculum_vitae_extension,
-		t_applicant_url_association.url as google_meet_url,
-		t_manuscript.content as content,
-		t_applicant_type.name as type
-	`).
-		Offset(offset).
-		Limit(m.PageSize).
-		Find(&applicants).
-		Error; err != nil {
-		log.Printf("%v", err)
-		return nil, 0, err
-	}
+	return query
+}
 
+// 担当面接官取得
+func (a *ApplicantRepository) searchUsers(applicants []*entity.SearchApplicant) error {
 	if len(applicants) > 0 {
 		var applicantIDs []uint64
 		for _, app := range applicants {
@@ -361,7 +439,7 @@ func (a *ApplicantRepository) Search(m *dto.SearchApplicant) ([]*entity.SearchAp
 			Where("t_applicant_user_association.display_flg = ?", static.INTERVIEWER_DISPLAY).
 			Find(&userAssociations).Error; err != nil {
 			log.Printf("%v", err)
-			return nil, 0, err
+			return err
 		}
 
 		userMap := make(map[uint64][]*ddl.User)
@@ -381,7 +459,7 @@ func (a *ApplicantRepository) Search(m *dto.SearchApplicant) ([]*entity.SearchAp
 		}
 	}
 
-	return applicants, totalCount, nil
+	return nil
 }
 
 // 応募者取得(ハッシュキー)
@@ -851,3 +929,74 @@ func (u *ApplicantRepository) GetIDs(m []string) ([]uint64, error) {
 
 	return IDs, nil
 }
+
+// 応募者ID取得_コミットID
+func (a *ApplicantRepository) GetIDsByCommitID(tx *gorm.DB, m *ddl.Applicant) ([]uint64, error) {
+	var res []entity.Applicant
+	if err := tx.Model(&ddl.Applicant{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where(&ddl.Applicant{
+			CommitID: m.CommitID,
+			TeamID:   m.TeamID,
+		}).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	var IDs []uint64
+	for _, row := range res {
+		IDs = append(IDs, row.ID)
+	}
+
+	return IDs, nil
+}
+
+// 選考が進んでいる応募者数_コミットID
+func (a *ApplicantRepository) CountProgressedByCommitID(tx *gorm.DB, m *dto.CheckRollbackUpload) (*int64, error) {
+	var count int64
+	if err := tx.Model(&ddl.Applicant{}).
+		Where("t_applicant.commit_id = ?", m.CommitID).
+		Where("t_applicant.team_id = ?", m.TeamID).
+		Where(`(
+			t_applicant.status <> ?
+			OR t_applicant.num_of_interview <> 1
+			OR t_applicant.processing_id <> ?
+			OR t_applicant.document_pass_flg <> 0
+			OR EXISTS (
+				SELECT 1 FROM t_applicant_schedule_association
+				WHERE t_applicant_schedule_association.applicant_id = t_applicant.id
+			)
+			OR EXISTS (
+				SELECT 1 FROM t_applicant_user_association
+				WHERE t_applicant_user_association.applicant_id = t_applicant.id
+			)
+			OR EXISTS (
+				SELECT 1 FROM t_applicant_resume_association
+				WHERE t_applicant_resume_association.applicant_id = t_applicant.id
+			)
+			OR EXISTS (
+				SELECT 1 FROM t_applicant_curriculum_vitae_association
+				WHERE t_applicant_curriculum_vitae_association.applicant_id = t_applicant.id
+			)
+			OR EXISTS (
+				SELECT 1 FROM t_applicant_url_association
+				WHERE t_applicant_url_association.applicant_id = t_applicant.id
+			)
+		)`, m.InitStatus, static.INTERVIEW_PROCESSING_NOW).
+		Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
+
+// 一括削除
+func (a *ApplicantRepository) DeletesByPrimary(tx *gorm.DB, m []uint64) error {
+	if err := tx.Where("id IN ?", m).Delete(&ddl.Applicant{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
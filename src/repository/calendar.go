@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type ICalendarRepository interface {
+	// 購読トークン登録
+	InsertToken(tx *gorm.DB, m *ddl.UserCalendarToken) error
+	// 購読トークン取得
+	GetToken(m *ddl.UserCalendarToken) (*entity.UserCalendarToken, error)
+	// 購読トークン削除
+	DeleteToken(tx *gorm.DB, m *ddl.UserCalendarToken) error
+	// 予定一覧 ※ユーザーに紐づく予定・面接
+	ListSchedule(m *dto.ListCalendarSchedule) ([]entity.CalendarSchedule, error)
+}
+
+type CalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) ICalendarRepository {
+	return &CalendarRepository{db}
+}
+
+// 購読トークン登録
+func (r *CalendarRepository) InsertToken(tx *gorm.DB, m *ddl.UserCalendarToken) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 購読トークン取得
+func (r *CalendarRepository) GetToken(m *ddl.UserCalendarToken) (*entity.UserCalendarToken, error) {
+	var res entity.UserCalendarToken
+	if err := r.db.Where(
+		&ddl.UserCalendarToken{
+			Token: m.Token,
+		},
+	).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// 購読トークン削除
+func (r *CalendarRepository) DeleteToken(tx *gorm.DB, m *ddl.UserCalendarToken) error {
+	if err := tx.
+		Where("user_id = ?", m.UserID).
+		Delete(&ddl.UserCalendarToken{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 予定一覧 ※ユーザーに紐づく予定・面接
+func (r *CalendarRepository) ListSchedule(m *dto.ListCalendarSchedule) ([]entity.CalendarSchedule, error) {
+	var res []entity.CalendarSchedule
+	if err := r.db.Table("t_schedule").
+		Select(`
+			t_schedule.id,
+			t_schedule.hash_key,
+			t_schedule.title,
+			t_schedule.freq_id,
+			t_schedule.interval,
+			t_schedule.by_day,
+			t_schedule.count,
+			t_schedule.until,
+			t_schedule.interview_flg,
+			t_schedule.start,
+			t_schedule.end,
+			t_schedule.team_id,
+			t_schedule.created_at,
+			t_schedule.updated_at,
+			t_applicant.name as applicant_name,
+			t_applicant_url_association.url as google_meet_url
+		`).
+		Joins(`
+			INNER JOIN
+				t_schedule_association
+			ON
+				t_schedule_association.schedule_id = t_schedule.id
+		`).
+		Joins(`
+			LEFT JOIN
+				t_applicant_schedule_association
+			ON
+				t_applicant_schedule_association.schedule_id = t_schedule.id
+		`).
+		Joins(`
+			LEFT JOIN
+				t_applicant
+			ON
+				t_applicant_schedule_association.applicant_id = t_applicant.id
+		`).
+		Joins(`
+			LEFT JOIN
+				t_applicant_url_association
+			ON
+				t_applicant_url_association.applicant_id = t_applicant.id
+		`).
+		Where("t_schedule_association.user_id = ?", m.UserID).
+		Where(
+			"((t_schedule.freq_id IN ? AND (t_schedule.until IS NULL OR t_schedule.until >= ?)) OR t_schedule.end >= ?)",
+			[]uint{
+				static.FREQ_DAILY,
+				static.FREQ_WEEKLY,
+				static.FREQ_MONTHLY,
+				static.FREQ_YEARLY,
+			},
+			m.From,
+			m.From,
+		).
+		Where("t_schedule.start <= ?", m.To).
+		Order("t_schedule.start").
+		Preload("Exceptions").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
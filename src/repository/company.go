@@ -16,6 +16,24 @@ type ICompanyRepository interface {
 	Search(m *ddl.Company) ([]entity.Company, error)
 	// 企業名重複確認
 	IsDuplName(m *ddl.Company) error
+	// セキュリティ設定取得 ※未設定の場合は初期値
+	GetSecurity(m *ddl.CompanySecurity) (*entity.CompanySecurity, error)
+	// セキュリティ設定更新
+	SaveSecurity(tx *gorm.DB, m *ddl.CompanySecurity) error
+	// シングルサインオン設定取得 ※未設定の場合は無効
+	GetOIDC(m *ddl.CompanyOIDC) (*entity.CompanyOIDC, error)
+	// シングルサインオン設定更新
+	SaveOIDC(tx *gorm.DB, m *ddl.CompanyOIDC) error
+	// シングルサインオン許可ドメイン一覧
+	ListOIDCDomain(m *ddl.CompanyOIDCDomain) ([]entity.CompanyOIDCDomain, error)
+	// シングルサインオン許可ドメイン取得
+	GetOIDCDomain(m *ddl.CompanyOIDCDomain) (*entity.CompanyOIDCDomain, error)
+	// シングルサインオン許可ドメイン重複確認 ※他企業で登録済みの場合はエラー
+	IsDuplOIDCDomain(companyID uint64, domains []string) error
+	// シングルサインオン許可ドメイン登録
+	InsertOIDCDomain(tx *gorm.DB, m []ddl.CompanyOIDCDomain) error
+	// 削除_シングルサインオン許可ドメイン
+	DeleteOIDCDomain(tx *gorm.DB, m *ddl.CompanyOIDCDomain) error
 }
 
 type CompanyRepository struct {
@@ -74,3 +92,145 @@ func (r *CompanyRepository) IsDuplName(m *ddl.Company) error {
 
 	return nil
 }
+
+// セキュリティ設定取得
+func (r *CompanyRepository) GetSecurity(m *ddl.CompanySecurity) (*entity.CompanySecurity, error) {
+	var res entity.CompanySecurity
+
+	if err := r.db.Where(
+		&ddl.CompanySecurity{
+			CompanyID: m.CompanyID,
+		},
+	).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &entity.CompanySecurity{
+				CompanySecurity: ddl.CompanySecurity{
+					CompanyID: m.CompanyID,
+				},
+			}, nil
+		}
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// セキュリティ設定更新
+func (r *CompanyRepository) SaveSecurity(tx *gorm.DB, m *ddl.CompanySecurity) error {
+	if err := tx.Save(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// シングルサインオン設定取得
+func (r *CompanyRepository) GetOIDC(m *ddl.CompanyOIDC) (*entity.CompanyOIDC, error) {
+	var res entity.CompanyOIDC
+
+	if err := r.db.Where(
+		&ddl.CompanyOIDC{
+			CompanyID: m.CompanyID,
+		},
+	).First(&res).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &entity.CompanyOIDC{
+				CompanyOIDC: ddl.CompanyOIDC{
+					CompanyID: m.CompanyID,
+				},
+			}, nil
+		}
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// シングルサインオン設定更新
+func (r *CompanyRepository) SaveOIDC(tx *gorm.DB, m *ddl.CompanyOIDC) error {
+	if err := tx.Save(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// シングルサインオン許可ドメイン一覧
+func (r *CompanyRepository) ListOIDCDomain(m *ddl.CompanyOIDCDomain) ([]entity.CompanyOIDCDomain, error) {
+	var res []entity.CompanyOIDCDomain
+
+	if err := r.db.Where(
+		&ddl.CompanyOIDCDomain{
+			CompanyID: m.CompanyID,
+		},
+	).Order("domain").Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// シングルサインオン許可ドメイン取得
+func (r *CompanyRepository) GetOIDCDomain(m *ddl.CompanyOIDCDomain) (*entity.CompanyOIDCDomain, error) {
+	var res entity.CompanyOIDCDomain
+
+	if err := r.db.Where(
+		&ddl.CompanyOIDCDomain{
+			Domain: m.Domain,
+		},
+	).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// シングルサインオン許可ドメイン重複確認
+func (r *CompanyRepository) IsDuplOIDCDomain(companyID uint64, domains []string) error {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := r.db.Model(&ddl.CompanyOIDCDomain{}).
+		Where("domain IN ? AND company_id <> ?", domains, companyID).
+		Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("duplicate sso domain")
+	}
+
+	return nil
+}
+
+// シングルサインオン許可ドメイン登録
+func (r *CompanyRepository) InsertOIDCDomain(tx *gorm.DB, m []ddl.CompanyOIDCDomain) error {
+	if len(m) == 0 {
+		return nil
+	}
+	if err := tx.Create(&m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 削除_シングルサインオン許可ドメイン
+func (r *CompanyRepository) DeleteOIDCDomain(tx *gorm.DB, m *ddl.CompanyOIDCDomain) error {
+	if err := tx.Where(
+		&ddl.CompanyOIDCDomain{
+			CompanyID: m.CompanyID,
+		},
+	).Delete(&ddl.CompanyOIDCDomain{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IHolidayRepository interface {
+	// 日本の祝日一覧 ※同梱データ、期間は日付で両端を含む
+	ListJp(from time.Time, to time.Time) []dto.Holiday
+	// 企業休日一覧 ※キャッシュ
+	ListCompany(m *dto.HolidayRange) ([]entity.CompanyHoliday, error)
+	// 企業休日一括登録 ※同日の登録がある場合は上書き
+	InsertsCompany(tx *gorm.DB, m []*ddl.CompanyHoliday) error
+	// 企業休日削除
+	DeleteCompany(tx *gorm.DB, m *ddl.CompanyHoliday) error
+	// 企業休日キャッシュ破棄 ※更新のコミット後に呼び出す
+	ClearCache(companyID uint64)
+}
+
+type HolidayRepository struct {
+	db    *gorm.DB
+	mu    sync.Mutex
+	cache map[uint64]*companyHolidayCache
+}
+
+// 企業休日キャッシュ
+type companyHolidayCache struct {
+	list    []entity.CompanyHoliday
+	expires time.Time
+}
+
+func NewHolidayRepository(db *gorm.DB) IHolidayRepository {
+	return &HolidayRepository{
+		db:    db,
+		cache: make(map[uint64]*companyHolidayCache),
+	}
+}
+
+// 日本の祝日 ※日付順
+var holidaysJp = func() []dto.Holiday {
+	var res []dto.Holiday
+	for _, line := range strings.Split(static.HolidaysJp, "\n") {
+		date, name, ok := strings.Cut(strings.TrimSpace(line), ",")
+		if !ok {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			log.Printf("%v", err)
+			continue
+		}
+		res = append(res, dto.Holiday{
+			Date: d,
+			Name: name,
+		})
+	}
+	return res
+}()
+
+// 日本の祝日一覧
+func (r *HolidayRepository) ListJp(from time.Time, to time.Time) []dto.Holiday {
+	start := holidayDate(from)
+	end := holidayDate(to)
+
+	var res []dto.Holiday
+	for _, row := range holidaysJp {
+		if row.Date.Before(start) {
+			continue
+		}
+		if row.Date.After(end) {
+			break
+		}
+		res = append(res, row)
+	}
+	return res
+}
+
+// 企業休日一覧
+func (r *HolidayRepository) ListCompany(m *dto.HolidayRange) ([]entity.CompanyHoliday, error) {
+	list, err := r.companyHolidays(m.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := holidayDate(m.From)
+	end := holidayDate(m.To)
+
+	var res []entity.CompanyHoliday
+	for _, row := range list {
+		if row.Date.Before(start) || row.Date.After(end) {
+			continue
+		}
+		res = append(res, row)
+	}
+	return res, nil
+}
+
+// 企業休日全件 ※有効期間内はキャッシュを使用
+func (r *HolidayRepository) companyHolidays(companyID uint64) ([]entity.CompanyHoliday, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[companyID]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.list, nil
+	}
+
+	var res []entity.CompanyHoliday
+	if err := r.db.Model(&ddl.CompanyHoliday{}).
+		Where("company_id = ?", companyID).
+		Order("date").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	// 日付型はUTCの0時に揃える
+	for i := range res {
+		res[i].Date = holidayDate(res[i].Date)
+	}
+
+	r.mu.Lock()
+	r.cache[companyID] = &companyHolidayCache{
+		list:    res,
+		expires: time.Now().Add(static.HOLIDAY_CACHE_TTL),
+	}
+	r.mu.Unlock()
+	return res, nil
+}
+
+// 企業休日一括登録
+func (r *HolidayRepository) InsertsCompany(tx *gorm.DB, m []*ddl.CompanyHoliday) error {
+	if len(m) == 0 {
+		return nil
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "name"}),
+	}).Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 企業休日削除
+func (r *HolidayRepository) DeleteCompany(tx *gorm.DB, m *ddl.CompanyHoliday) error {
+	if err := tx.Where("company_id = ? AND date = ?", m.CompanyID, m.Date.Format("2006-01-02")).
+		Delete(&ddl.CompanyHoliday{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 企業休日キャッシュ破棄
+func (r *HolidayRepository) ClearCache(companyID uint64) {
+	r.mu.Lock()
+	delete(r.cache, companyID)
+	r.mu.Unlock()
+}
+
+// 日付(UTCの0時)
+func holidayDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
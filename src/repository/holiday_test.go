@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHolidayRepository_ListJp(t *testing.T) {
+	r := &HolidayRepository{}
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want []string
+	}{
+		// ok 年を跨ぐ期間
+		{
+			"ok_cross_year",
+			time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC),
+			[]string{"2025-01-01", "2025-01-13"},
+		},
+		// ok 振替休日・国民の休日 ※期間の両端を含む
+		{
+			"ok_substitute",
+			time.Date(2026, 9, 21, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 9, 23, 0, 0, 0, 0, time.UTC),
+			[]string{"2026-09-21", "2026-09-22", "2026-09-23"},
+		},
+		// ok 時刻・タイムゾーンは日付のみ使用
+		{
+			"ok_location",
+			time.Date(2024, 5, 6, 23, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+			time.Date(2024, 5, 6, 23, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+			[]string{"2024-05-06"},
+		},
+		// ok 該当なし
+		{
+			"ok_empty",
+			time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.ListJp(tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("HolidayRepository.ListJp() = %v, want %v", got, tt.want)
+			}
+			for i, row := range got {
+				if d := row.Date.Format("2006-01-02"); d != tt.want[i] {
+					t.Errorf("HolidayRepository.ListJp()[%d] = %v, want %v", i, d, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IJobRepository interface {
+	// 実行履歴登録
+	InsertRun(m *ddl.JobRun) error
+	// 実行履歴更新 ※終了時
+	UpdateRun(m *ddl.JobRun) error
+	// 実行履歴検索
+	SearchRun(m *dto.SearchJobRun) ([]entity.JobRun, int64, error)
+	// 実行履歴削除_保持期間切れ ※削除件数を返却
+	DeleteRunBefore(before time.Time) (int64, error)
+}
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) IJobRepository {
+	return &JobRepository{db}
+}
+
+// 実行履歴登録
+func (r *JobRepository) InsertRun(m *ddl.JobRun) error {
+	if err := r.db.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 実行履歴更新
+func (r *JobRepository) UpdateRun(m *ddl.JobRun) error {
+	if err := r.db.Model(&ddl.JobRun{}).
+		Where("id = ?", m.ID).
+		Select("status", "count", "message", "finished_at").
+		Updates(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 実行履歴検索
+func (r *JobRepository) SearchRun(m *dto.SearchJobRun) ([]entity.JobRun, int64, error) {
+	var res []entity.JobRun
+	var count int64
+
+	query := r.db.Model(&ddl.JobRun{})
+
+	if len(m.Names) > 0 {
+		query = query.Where("name IN ?", m.Names)
+	}
+	if len(m.Statuses) > 0 {
+		query = query.Where("status IN ?", m.Statuses)
+	}
+
+	if !m.StartedAtFrom.IsZero() {
+		query = query.Where("started_at >= ?", m.StartedAtFrom)
+	}
+	if !m.StartedAtTo.IsZero() {
+		query = query.Where("started_at < ?", m.StartedAtTo.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	offset := (m.Page - 1) * m.PageSize
+
+	if err := query.
+		Order("started_at DESC").
+		Order("id DESC").
+		Offset(offset).
+		Limit(m.PageSize).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	return res, count, nil
+}
+
+// 実行履歴削除_保持期間切れ
+func (r *JobRepository) DeleteRunBefore(before time.Time) (int64, error) {
+	res := r.db.Where("started_at < ?", before).Delete(&ddl.JobRun{})
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ILoginLockoutRepository interface {
+	// 履歴登録
+	InsertHistory(m *ddl.LoginLockoutHistory) error
+	// 履歴削除_保持期間切れ ※削除件数を返却
+	DeleteHistoryBefore(before time.Time) (int64, error)
+}
+
+type LoginLockoutRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginLockoutRepository(db *gorm.DB) ILoginLockoutRepository {
+	return &LoginLockoutRepository{db}
+}
+
+// 履歴登録
+func (r *LoginLockoutRepository) InsertHistory(m *ddl.LoginLockoutHistory) error {
+	if err := r.db.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 履歴削除_保持期間切れ
+func (r *LoginLockoutRepository) DeleteHistoryBefore(before time.Time) (int64, error) {
+	res := r.db.Where("created_at < ?", before).Delete(&ddl.LoginLockoutHistory{})
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,375 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/static"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IMailRepository interface {
+	// メール送信 ※送信待ちに登録し、送信処理で送信
+	Send(m *dto.Mail) error
+	// メール一括送信 ※全件を送信待ちに登録、一部のみ登録されることはない
+	SendAll(ms []*dto.Mail) error
+	// 送信処理の常駐実行 ※ctx終了まで送信待ちを送信
+	Start(ctx context.Context)
+}
+
+// 送信方式毎の実装
+type mailSender interface {
+	// 送信方式名
+	driver() string
+	// 送信
+	send(m *dto.Mail) error
+}
+
+type MailRepository struct {
+	db       *gorm.DB
+	sender   mailSender
+	retry    int
+	interval time.Duration
+	wake     chan struct{}
+}
+
+func NewMailRepository(db *gorm.DB) IMailRepository {
+	var sender mailSender
+	switch os.Getenv("MAIL_DRIVER") {
+	case static.MAIL_DRIVER_SMTP:
+		sender = &smtpMailSender{
+			host:     os.Getenv("MAIL_SMTP_HOST"),
+			port:     os.Getenv("MAIL_SMTP_PORT"),
+			user:     os.Getenv("MAIL_SMTP_USER"),
+			password: os.Getenv("MAIL_SMTP_PASSWORD"),
+			from:     os.Getenv("MAIL_FROM"),
+			timeout:  static.MAIL_SMTP_TIMEOUT,
+		}
+	default:
+		dir := os.Getenv("MAIL_OUTBOX_DIR")
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "outbox")
+		}
+		sender = &fileMailSender{
+			dir:  dir,
+			from: os.Getenv("MAIL_FROM"),
+		}
+	}
+
+	return &MailRepository{
+		db:       db,
+		sender:   sender,
+		retry:    static.MAIL_RETRY_COUNT,
+		interval: static.MAIL_RETRY_INTERVAL,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// メール送信
+func (r *MailRepository) Send(m *dto.Mail) error {
+	return r.SendAll([]*dto.Mail{m})
+}
+
+// メール一括送信
+func (r *MailRepository) SendAll(ms []*dto.Mail) error {
+	if len(ms) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	outboxes := make([]ddl.MailOutbox, 0, len(ms))
+	for _, m := range ms {
+		attachments, attachmentsErr := json.Marshal(m.Attachments)
+		if attachmentsErr != nil {
+			log.Printf("%v", attachmentsErr)
+			return attachmentsErr
+		}
+		outboxes = append(outboxes, ddl.MailOutbox{
+			CompanyID:   m.CompanyID,
+			Kind:        m.Kind,
+			ToAddress:   m.To,
+			Subject:     m.Subject,
+			Body:        m.Body,
+			Attachments: string(attachments),
+			NextAt:      now,
+		})
+	}
+	if err := r.db.CreateInBatches(outboxes, static.MAIL_OUTBOX_BATCH_SIZE).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+
+	// 送信処理へ通知 ※通知済みの場合は不要
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// 送信処理の常駐実行
+func (r *MailRepository) Start(ctx context.Context) {
+	ticker := time.NewTicker(static.MAIL_OUTBOX_POLL_INTERVAL)
+	defer ticker.Stop()
+	for {
+		// 取得件数に達した場合は続けて送信
+		for r.deliver() >= static.MAIL_OUTBOX_BATCH_SIZE {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// 送信待ちの送信 ※取得件数を返却
+func (r *MailRepository) deliver() int {
+	outboxes, claimErr := r.claim()
+	if claimErr != nil {
+		return 0
+	}
+
+	for i := range outboxes {
+		o := &outboxes[i]
+		m := &dto.Mail{
+			CompanyID: o.CompanyID,
+			Kind:      o.Kind,
+			To:        o.ToAddress,
+			Subject:   o.Subject,
+			Body:      o.Body,
+		}
+		sendErr := json.Unmarshal([]byte(o.Attachments), &m.Attachments)
+		if sendErr == nil {
+			sendErr = r.sender.send(m)
+		}
+		if sendErr != nil {
+			log.Printf("%v", sendErr)
+			// 再送
+			if int(o.Attempts) < r.retry {
+				if err := r.db.Model(&ddl.MailOutbox{}).
+					Where("id = ?", o.ID).
+					Update("next_at", time.Now().Add(r.interval*time.Duration(o.Attempts))).Error; err != nil {
+					log.Printf("%v", err)
+				}
+				continue
+			}
+		}
+		r.finish(o, sendErr)
+	}
+	return len(outboxes)
+}
+
+// 送信待ち取得 ※他の送信処理と重複しないよう占有期間を設定し、試行回数を加算
+func (r *MailRepository) claim() ([]ddl.MailOutbox, error) {
+	var res []ddl.MailOutbox
+	now := time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("next_at <= ?", now).
+			Order("id").
+			Limit(static.MAIL_OUTBOX_BATCH_SIZE).
+			Find(&res).Error; err != nil {
+			return err
+		}
+		if len(res) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, 0, len(res))
+		for i := range res {
+			ids = append(ids, res[i].ID)
+			res[i].Attempts++
+		}
+		return tx.Model(&ddl.MailOutbox{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"attempts": gorm.Expr("attempts + 1"),
+				"next_at":  now.Add(static.MAIL_OUTBOX_LEASE),
+			}).Error
+	})
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 送信完了・失敗確定 ※送信待ちを削除し送信履歴登録、本文は認証情報を含むため保存しない
+func (r *MailRepository) finish(o *ddl.MailOutbox, sendErr error) {
+	history := ddl.MailHistory{
+		CompanyID: o.CompanyID,
+		Kind:      o.Kind,
+		ToAddress: o.ToAddress,
+		Subject:   o.Subject,
+		Driver:    r.sender.driver(),
+		Status:    static.MAIL_STATUS_SUCCESS,
+		Attempts:  o.Attempts,
+	}
+	if sendErr != nil {
+		history.Status = static.MAIL_STATUS_FAILED
+		history.ErrorMessage = sendErr.Error()
+	}
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", o.ID).Delete(&ddl.MailOutbox{}).Error
+	}); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+// SMTP
+type smtpMailSender struct {
+	host     string
+	port     string
+	user     string
+	password string
+	from     string
+	timeout  time.Duration
+}
+
+func (s *smtpMailSender) driver() string {
+	return static.MAIL_DRIVER_SMTP
+}
+
+// 送信 ※接続から送信完了までをタイムアウト内に制限
+func (s *smtpMailSender) send(m *dto.Mail) error {
+	conn, dialErr := (&net.Dialer{Timeout: s.timeout}).Dial("tcp", net.JoinHostPort(s.host, s.port))
+	if dialErr != nil {
+		return dialErr
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	c, clientErr := smtp.NewClient(conn, s.host)
+	if clientErr != nil {
+		return clientErr
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.user != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.user, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return err
+	}
+	w, dataErr := c.Data()
+	if dataErr != nil {
+		return dataErr
+	}
+	if _, err := w.Write(buildMessage(s.from, m)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// ファイル出力(ローカル・検証環境用)
+type fileMailSender struct {
+	dir  string
+	from string
+}
+
+func (f *fileMailSender) driver() string {
+	return static.MAIL_DRIVER_FILE
+}
+
+func (f *fileMailSender) send(m *dto.Mail) error {
+	if err := os.MkdirAll(f.dir, 0o700); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf(
+		"%s_%s.eml",
+		time.Now().Format("20060102150405.000000000"),
+		strings.NewReplacer("@", "_at_", "/", "_").Replace(m.To),
+	)
+	if err := os.WriteFile(filepath.Join(f.dir, name), buildMessage(f.from, m), 0o600); err != nil {
+		return err
+	}
+	return nil
+}
+
+// メッセージ作成
+func buildMessage(from string, m *dto.Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + m.To + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", m.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	body := strings.ReplaceAll(m.Body, "\n", "\r\n")
+	if len(m.Attachments) == 0 {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(body)
+		return []byte(b.String())
+	}
+
+	// 添付ファイルあり ※bytes.Bufferへの書き込みは失敗しない
+	var parts bytes.Buffer
+	w := multipart.NewWriter(&parts)
+	text, _ := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"8bit"},
+	})
+	_, _ = text.Write([]byte(body))
+	for _, a := range m.Attachments {
+		part, _ := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName})},
+		})
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			_, _ = part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		_, _ = part.Write([]byte(encoded + "\r\n"))
+	}
+	_ = w.Close()
+
+	b.WriteString("Content-Type: multipart/mixed; boundary=\"" + w.Boundary() + "\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(parts.String())
+	return []byte(b.String())
+}
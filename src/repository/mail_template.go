@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IMailTemplateRepository interface {
+	// テンプレート登録
+	Insert(tx *gorm.DB, m *ddl.MailTemplate) (*entity.MailTemplate, error)
+	// テンプレート更新
+	Update(tx *gorm.DB, m *ddl.MailTemplate) error
+	// テンプレート削除
+	Delete(tx *gorm.DB, m *ddl.MailTemplate) error
+	// テンプレート取得
+	Get(m *ddl.MailTemplate) (*entity.MailTemplate, error)
+	// テンプレート検索_同一企業
+	Search(m *ddl.MailTemplate) ([]entity.MailTemplate, error)
+	// テンプレート名重複数
+	CountDuplTitle(m *ddl.MailTemplate) (*int64, error)
+	// 変数登録
+	InsertVariable(tx *gorm.DB, m *ddl.Variable) (*entity.Variable, error)
+	// 変数更新
+	UpdateVariable(tx *gorm.DB, m *ddl.Variable) error
+	// 変数削除
+	DeleteVariable(tx *gorm.DB, m *ddl.Variable) error
+	// 変数取得
+	GetVariable(m *ddl.Variable) (*entity.Variable, error)
+	// 変数検索_同一企業
+	SearchVariable(m *ddl.Variable) ([]entity.Variable, error)
+	// 変数名重複数
+	CountDuplVariableTitle(m *ddl.Variable) (*int64, error)
+	// テンプレート変数紐づけ一括登録
+	InsertsPreview(tx *gorm.DB, m []*ddl.MailPreview) error
+	// テンプレート変数紐づけ削除_テンプレート
+	DeletePreviewByTemplate(tx *gorm.DB, m *ddl.MailPreview) error
+	// テンプレート使用変数取得
+	ListVariableByTemplate(m *ddl.MailPreview) ([]entity.Variable, error)
+	// 変数使用テンプレート数
+	CountPreviewByVariable(m *ddl.MailPreview) (*int64, error)
+}
+
+type MailTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewMailTemplateRepository(db *gorm.DB) IMailTemplateRepository {
+	return &MailTemplateRepository{db}
+}
+
+// テンプレート登録
+func (r *MailTemplateRepository) Insert(tx *gorm.DB, m *ddl.MailTemplate) (*entity.MailTemplate, error) {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &entity.MailTemplate{
+		MailTemplate: *m,
+	}, nil
+}
+
+// テンプレート更新
+func (r *MailTemplateRepository) Update(tx *gorm.DB, m *ddl.MailTemplate) error {
+	if err := tx.Model(&ddl.MailTemplate{}).Where(
+		&ddl.MailTemplate{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				ID: m.ID,
+			},
+		},
+	).Updates(map[string]interface{}{
+		"title":      m.Title,
+		"subject":    m.Subject,
+		"template":   m.Template,
+		"desc":       m.Desc,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// テンプレート削除
+func (r *MailTemplateRepository) Delete(tx *gorm.DB, m *ddl.MailTemplate) error {
+	if err := tx.Where(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: m.ID,
+		},
+	}).Delete(&ddl.MailTemplate{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// テンプレート取得
+func (r *MailTemplateRepository) Get(m *ddl.MailTemplate) (*entity.MailTemplate, error) {
+	var res entity.MailTemplate
+	if err := r.db.Where(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   m.HashKey,
+			CompanyID: m.CompanyID,
+		},
+	}).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// テンプレート検索_同一企業
+func (r *MailTemplateRepository) Search(m *ddl.MailTemplate) ([]entity.MailTemplate, error) {
+	var res []entity.MailTemplate
+	if err := r.db.Where(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: m.CompanyID,
+		},
+	}).Order("id ASC").Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// テンプレート名重複数
+func (r *MailTemplateRepository) CountDuplTitle(m *ddl.MailTemplate) (*int64, error) {
+	var count int64
+	query := r.db.Model(&ddl.MailTemplate{}).
+		Where("company_id = ?", m.CompanyID).
+		Where("title = ?", m.Title)
+	if m.ID > 0 {
+		query = query.Where("id <> ?", m.ID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
+
+// 変数登録
+func (r *MailTemplateRepository) InsertVariable(tx *gorm.DB, m *ddl.Variable) (*entity.Variable, error) {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &entity.Variable{
+		Variable: *m,
+	}, nil
+}
+
+// 変数更新
+func (r *MailTemplateRepository) UpdateVariable(tx *gorm.DB, m *ddl.Variable) error {
+	if err := tx.Model(&ddl.Variable{}).Where(
+		&ddl.Variable{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				ID: m.ID,
+			},
+		},
+	).Updates(map[string]interface{}{
+		"title":      m.Title,
+		"json_name":  m.JsonName,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 変数削除
+func (r *MailTemplateRepository) DeleteVariable(tx *gorm.DB, m *ddl.Variable) error {
+	if err := tx.Where(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: m.ID,
+		},
+	}).Delete(&ddl.Variable{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 変数取得
+func (r *MailTemplateRepository) GetVariable(m *ddl.Variable) (*entity.Variable, error) {
+	var res entity.Variable
+	if err := r.db.Where(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   m.HashKey,
+			CompanyID: m.CompanyID,
+		},
+	}).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// 変数検索_同一企業
+func (r *MailTemplateRepository) SearchVariable(m *ddl.Variable) ([]entity.Variable, error) {
+	var res []entity.Variable
+	if err := r.db.Where(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: m.CompanyID,
+		},
+	}).Order("id ASC").Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 変数名重複数
+func (r *MailTemplateRepository) CountDuplVariableTitle(m *ddl.Variable) (*int64, error) {
+	var count int64
+	query := r.db.Model(&ddl.Variable{}).
+		Where("company_id = ?", m.CompanyID).
+		Where("title = ?", m.Title)
+	if m.ID > 0 {
+		query = query.Where("id <> ?", m.ID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
+
+// テンプレート変数紐づけ一括登録
+func (r *MailTemplateRepository) InsertsPreview(tx *gorm.DB, m []*ddl.MailPreview) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// テンプレート変数紐づけ削除_テンプレート
+func (r *MailTemplateRepository) DeletePreviewByTemplate(tx *gorm.DB, m *ddl.MailPreview) error {
+	if err := tx.Where(&ddl.MailPreview{
+		TemplateID: m.TemplateID,
+	}).Delete(&ddl.MailPreview{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// テンプレート使用変数取得
+func (r *MailTemplateRepository) ListVariableByTemplate(m *ddl.MailPreview) ([]entity.Variable, error) {
+	var res []entity.Variable
+	if err := r.db.Table("t_variable").
+		Select("t_variable.*").
+		Joins(`
+			INNER JOIN
+				t_mail_preview
+			ON
+				t_mail_preview.variable_id = t_variable.id
+		`).
+		Where("t_mail_preview.template_id = ?", m.TemplateID).
+		Order("t_variable.id ASC").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 変数使用テンプレート数
+func (r *MailTemplateRepository) CountPreviewByVariable(m *ddl.MailPreview) (*int64, error) {
+	var count int64
+	if err := r.db.Model(&ddl.MailPreview{}).
+		Where(&ddl.MailPreview{
+			VariableID: m.VariableID,
+		}).
+		Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
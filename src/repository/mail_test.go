@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"api/src/model/dto"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSmtpMailSender_Timeout(t *testing.T) {
+	// 接続を受け付けるが応答しないSMTPサーバー
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	s := &smtpMailSender{
+		host:    host,
+		port:    port,
+		from:    "from@example.com",
+		timeout: 200 * time.Millisecond,
+	}
+
+	start := time.Now()
+	sendErr := s.send(&dto.Mail{
+		To:      "to@example.com",
+		Subject: "subject",
+		Body:    "body",
+	})
+	if sendErr == nil {
+		t.Fatal("smtpMailSender.send() error = nil, want timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("smtpMailSender.send() took %v, want within timeout", elapsed)
+	}
+}
@@ -49,6 +49,25 @@ type IMasterRepository interface {
 	*/
 	// insert
 	InsertHashKeyPre(tx *gorm.DB, m *ddl.HashKeyPre) error
+	/*
+		m_operation_log_event
+	*/
+	// insert
+	InsertOperationLogEvent(tx *gorm.DB, m *ddl.OperationLogEvent) error
+	// list
+	ListOperationLogEvent() ([]entity.OperationLogEvent, error)
+	/*
+		m_analysis_term
+	*/
+	// insert
+	InsertAnalysisTerm(tx *gorm.DB, m *ddl.AnalysisTerm) error
+	// list
+	ListAnalysisTerm() ([]entity.AnalysisTerm, error)
+	/*
+		m_notice
+	*/
+	// insert
+	InsertNoticeType(tx *gorm.DB, m *ddl.NoticeType) error
 	/*
 		m_schedule_freq_status
 	*/
@@ -277,6 +296,62 @@ func (r *MasterRepository) InsertHashKeyPre(tx *gorm.DB, m *ddl.HashKeyPre) erro
 	return nil
 }
 
+/*
+	m_operation_log_event
+*/
+// insert
+func (r *MasterRepository) InsertOperationLogEvent(tx *gorm.DB, m *ddl.OperationLogEvent) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// list
+func (r *MasterRepository) ListOperationLogEvent() ([]entity.OperationLogEvent, error) {
+	var res []entity.OperationLogEvent
+	if err := r.db.Table("m_operation_log_event").Order("id ASC").Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+/*
+	m_analysis_term
+*/
+// insert
+func (r *MasterRepository) InsertAnalysisTerm(tx *gorm.DB, m *ddl.AnalysisTerm) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// list
+func (r *MasterRepository) ListAnalysisTerm() ([]entity.AnalysisTerm, error) {
+	var res []entity.AnalysisTerm
+	if err := r.db.Table("m_analysis_term").Order("id ASC").Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+/*
+	m_notice
+*/
+// insert
+func (r *MasterRepository) InsertNoticeType(tx *gorm.DB, m *ddl.NoticeType) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
 /*
 	m_schedule_freq_status
 */
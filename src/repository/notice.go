@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type INoticeRepository interface {
+	// 一括登録
+	Inserts(tx *gorm.DB, m []*ddl.Notice) error
+	// 検索
+	Search(m *dto.SearchNotice) ([]entity.Notice, int64, error)
+	// 既読 ※ハッシュキー未指定の場合は全件
+	Read(tx *gorm.DB, m *ddl.Notice, hashKeys []string) error
+	// 未読数
+	CountUnread(m *ddl.Notice) (*int64, error)
+	// 通知先ユーザーID取得 ※面接官未割り振りの場合はチーム所属ユーザー
+	ListRecipient(tx *gorm.DB, m *ddl.Applicant) ([]uint64, error)
+	// 削除_応募者
+	DeleteByApplicant(tx *gorm.DB, applicantIDs []uint64) error
+}
+
+type NoticeRepository struct {
+	db *gorm.DB
+}
+
+func NewNoticeRepository(db *gorm.DB) INoticeRepository {
+	return &NoticeRepository{db}
+}
+
+// 一括登録
+func (r *NoticeRepository) Inserts(tx *gorm.DB, m []*ddl.Notice) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 検索
+func (r *NoticeRepository) Search(m *dto.SearchNotice) ([]entity.Notice, int64, error) {
+	var res []entity.Notice
+	var count int64
+
+	query := r.db.Table("t_notice").
+		Joins("INNER JOIN m_notice ON t_notice.type = m_notice.id").
+		Joins("LEFT JOIN t_user ON t_notice.from_user_id = t_user.id").
+		Joins("LEFT JOIN t_applicant ON t_notice.applicant_id = t_applicant.id").
+		Where("t_notice.to_user_id = ?", m.ToUserID)
+
+	if m.UnreadOnly {
+		query = query.Where("t_notice.read_at IS NULL")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	offset := (m.Page - 1) * m.PageSize
+
+	if err := query.Select(`
+			t_notice.hash_key,
+			t_notice.type,
+			t_notice.read_at,
+			t_notice.created_at,
+			m_notice.notice as notice_name,
+			t_user.hash_key as from_user_hash,
+			t_user.name as from_user_name,
+			t_applicant.hash_key as applicant_hash,
+			t_applicant.name as applicant_name
+		`).
+		Order("t_notice.created_at DESC").
+		Order("t_notice.id DESC").
+		Offset(offset).
+		Limit(m.PageSize).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	return res, count, nil
+}
+
+// 既読 ※ハッシュキー未指定の場合は全件
+func (r *NoticeRepository) Read(tx *gorm.DB, m *ddl.Notice, hashKeys []string) error {
+	query := tx.Model(&ddl.Notice{}).
+		Where("to_user_id = ?", m.ToUserID).
+		Where("read_at IS NULL")
+	if len(hashKeys) > 0 {
+		query = query.Where("hash_key IN ?", hashKeys)
+	}
+
+	if err := query.Updates(map[string]interface{}{
+		"read_at":    m.ReadAt,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 未読数
+func (r *NoticeRepository) CountUnread(m *ddl.Notice) (*int64, error) {
+	var count int64
+	if err := r.db.Model(&ddl.Notice{}).
+		Where("to_user_id = ?", m.ToUserID).
+		Where("read_at IS NULL").
+		Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
+
+// 通知先ユーザーID取得 ※面接官未割り振りの場合はチーム所属ユーザー
+func (r *NoticeRepository) ListRecipient(tx *gorm.DB, m *ddl.Applicant) ([]uint64, error) {
+	var IDs []uint64
+	if err := tx.Model(&ddl.ApplicantUserAssociation{}).
+		Where("applicant_id = ?", m.ID).
+		Pluck("user_id", &IDs).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	if len(IDs) > 0 {
+		return IDs, nil
+	}
+
+	if err := tx.Model(&ddl.TeamAssociation{}).
+		Where("team_id = ?", m.TeamID).
+		Pluck("user_id", &IDs).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return IDs, nil
+}
+
+// 削除_応募者
+func (r *NoticeRepository) DeleteByApplicant(tx *gorm.DB, applicantIDs []uint64) error {
+	if err := tx.Where("applicant_id IN ?", applicantIDs).Delete(&ddl.Notice{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
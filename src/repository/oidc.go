@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"api/src/model/dto"
+	"api/src/model/static"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type IOIDCRepository interface {
+	// プロバイダ設定取得(ディスカバリー)
+	Discover(issuer string) (*dto.OIDCProvider, error)
+	// 公開鍵取得 ※未知の鍵IDの場合はJWKSを再取得
+	PublicKey(jwksURI string, kid string) (*rsa.PublicKey, error)
+	// 認可URL作成 ※PKCE(S256)
+	AuthCodeURL(m *dto.OIDCExchange, state string, nonce string, challenge string) string
+	// 認可コード交換 ※IDトークンを返却
+	Exchange(m *dto.OIDCExchange) (string, error)
+}
+
+type OIDCRepository struct {
+	client *http.Client
+	mu     sync.Mutex
+	// プロバイダ設定 ※キーは発行者
+	providers map[string]*oidcProviderCache
+	// 公開鍵 ※キーはJWKS URI
+	keys map[string]*oidcKeyCache
+}
+
+type oidcProviderCache struct {
+	provider  *dto.OIDCProvider
+	expiredAt time.Time
+}
+
+type oidcKeyCache struct {
+	keys      map[string]*rsa.PublicKey
+	expiredAt time.Time
+}
+
+func NewOIDCRepository() IOIDCRepository {
+	return &OIDCRepository{
+		client:    &http.Client{Timeout: static.OIDC_HTTP_TIMEOUT},
+		providers: map[string]*oidcProviderCache{},
+		keys:      map[string]*oidcKeyCache{},
+	}
+}
+
+// プロバイダ設定取得
+func (o *OIDCRepository) Discover(issuer string) (*dto.OIDCProvider, error) {
+	o.mu.Lock()
+	cache, ok := o.providers[issuer]
+	o.mu.Unlock()
+	if ok && time.Now().Before(cache.expiredAt) {
+		return cache.provider, nil
+	}
+
+	var provider dto.OIDCProvider
+	if err := o.getJSON(strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &provider); err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	// 発行者の一致確認 ※OpenID Connect Discovery 4.3
+	if provider.Issuer != issuer {
+		err := fmt.Errorf("issuer mismatch: %s", provider.Issuer)
+		log.Printf("%v", err)
+		return nil, err
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		err := errors.New("incomplete openid configuration")
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	o.mu.Lock()
+	o.providers[issuer] = &oidcProviderCache{
+		provider:  &provider,
+		expiredAt: time.Now().Add(static.OIDC_CACHE_TTL),
+	}
+	o.mu.Unlock()
+
+	return &provider, nil
+}
+
+// 公開鍵取得
+func (o *OIDCRepository) PublicKey(jwksURI string, kid string) (*rsa.PublicKey, error) {
+	o.mu.Lock()
+	cache, ok := o.keys[jwksURI]
+	o.mu.Unlock()
+	if ok && time.Now().Before(cache.expiredAt) {
+		if key, ok := cache.keys[kid]; ok {
+			return key, nil
+		}
+	}
+
+	// 鍵のローテーションに追従するため再取得
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := o.getJSON(jwksURI, &jwks); err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, nErr := base64.RawURLEncoding.DecodeString(k.N)
+		e, eErr := base64.RawURLEncoding.DecodeString(k.E)
+		if nErr != nil || eErr != nil {
+			log.Printf("invalid jwk: %s", k.Kid)
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	o.mu.Lock()
+	o.keys[jwksURI] = &oidcKeyCache{
+		keys:      keys,
+		expiredAt: time.Now().Add(static.OIDC_CACHE_TTL),
+	}
+	o.mu.Unlock()
+
+	key, ok := keys[kid]
+	if !ok {
+		err := fmt.Errorf("unknown key id: %s", kid)
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return key, nil
+}
+
+// 認可URL作成
+func (o *OIDCRepository) AuthCodeURL(m *dto.OIDCExchange, state string, nonce string, challenge string) string {
+	return oidcConfig(m).AuthCodeURL(
+		state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.SetAuthURLParam("code_challenge", challenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+// 認可コード交換
+func (o *OIDCRepository) Exchange(m *dto.OIDCExchange) (string, error) {
+	ctx, cancel := context.WithTimeout(
+		context.WithValue(context.Background(), oauth2.HTTPClient, o.client),
+		static.OIDC_HTTP_TIMEOUT,
+	)
+	defer cancel()
+
+	token, err := oidcConfig(m).Exchange(
+		ctx,
+		m.Code,
+		oauth2.SetAuthURLParam("code_verifier", m.Verifier),
+	)
+	if err != nil {
+		log.Printf("%v", err)
+		return "", err
+	}
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		err := errors.New("id_token is not included")
+		log.Printf("%v", err)
+		return "", err
+	}
+	return idToken, nil
+}
+
+// クライアント設定
+func oidcConfig(m *dto.OIDCExchange) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     m.ClientID,
+		ClientSecret: m.ClientSecret,
+		RedirectURL:  m.RedirectURI,
+		Scopes:       strings.Fields(static.OIDC_SCOPE),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  m.Provider.AuthorizationEndpoint,
+			TokenURL: m.Provider.TokenEndpoint,
+		},
+	}
+}
+
+// JSON取得
+func (o *OIDCRepository) getJSON(url string, v interface{}) error {
+	res, err := o.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IOperationLogRepository interface {
+	// 登録
+	Insert(tx *gorm.DB, m *ddl.OperationLog) error
+	// 検索
+	Search(m *dto.SearchOperationLog) ([]entity.OperationLog, int64, error)
+	// 取得
+	Get(m *ddl.OperationLog) (*entity.OperationLog, error)
+	// 応募者切り離し ※応募者による操作
+	DetachApplicant(tx *gorm.DB, applicantIDs []uint64) error
+}
+
+type OperationLogRepository struct {
+	db *gorm.DB
+}
+
+func NewOperationLogRepository(db *gorm.DB) IOperationLogRepository {
+	return &OperationLogRepository{db}
+}
+
+// 登録
+func (r *OperationLogRepository) Insert(tx *gorm.DB, m *ddl.OperationLog) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 検索
+func (r *OperationLogRepository) Search(m *dto.SearchOperationLog) ([]entity.OperationLog, int64, error) {
+	var res []entity.OperationLog
+	var count int64
+
+	query := r.joined()
+
+	if m.CompanyID > 0 {
+		query = query.Where("t_operation_log.company_id = ?", m.CompanyID)
+	}
+	if len(m.Users) > 0 {
+		query = query.Where("t_user.hash_key IN ?", m.Users)
+	}
+	if len(m.Events) > 0 {
+		query = query.Where("m_operation_log_event.hash_key IN ?", m.Events)
+	}
+	if len(m.Teams) > 0 {
+		query = query.Where("t_team.hash_key IN ?", m.Teams)
+	}
+
+	if !m.CreatedAtFrom.IsZero() {
+		query = query.Where("t_operation_log.created_at >= ?", m.CreatedAtFrom)
+	}
+	if !m.CreatedAtTo.IsZero() {
+		query = query.Where("t_operation_log.created_at < ?", m.CreatedAtTo.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	offset := (m.Page - 1) * m.PageSize
+
+	if err := query.Select(selectOperationLog).
+		Order("t_operation_log.created_at DESC").
+		Order("t_operation_log.id DESC").
+		Offset(offset).
+		Limit(m.PageSize).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	return res, count, nil
+}
+
+// 取得
+func (r *OperationLogRepository) Get(m *ddl.OperationLog) (*entity.OperationLog, error) {
+	var res entity.OperationLog
+
+	query := r.joined().Where("t_operation_log.hash_key = ?", m.HashKey)
+	if m.CompanyID > 0 {
+		query = query.Where("t_operation_log.company_id = ?", m.CompanyID)
+	}
+
+	if err := query.Select(selectOperationLog).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const selectOperationLog = `
+	t_operation_log.hash_key,
+	t_operation_log.event_id,
+	t_operation_log.target,
+	t_operation_log.log,
+	t_operation_log.created_at,
+	m_operation_log_event.event as event_name,
+	m_operation_log_event.hash_key as event_hash,
+	t_user.hash_key as user_hash,
+	t_user.name as user_name,
+	t_applicant.hash_key as applicant_hash,
+	t_applicant.name as applicant_name,
+	t_team.hash_key as team_hash,
+	t_team.name as team_name
+`
+
+func (r *OperationLogRepository) joined() *gorm.DB {
+	return r.db.Table("t_operation_log").
+		Joins(`
+			INNER JOIN
+				m_operation_log_event
+			ON
+				t_operation_log.event_id = m_operation_log_event.id
+		`).
+		Joins("LEFT JOIN t_user ON t_operation_log.user_id = t_user.id").
+		Joins("LEFT JOIN t_applicant ON t_operation_log.applicant_id = t_applicant.id").
+		Joins("LEFT JOIN t_team ON t_operation_log.team_id = t_team.id")
+}
+
+// 応募者切り離し ※応募者の削除前に実行、ログ自体は監査のため残す
+func (r *OperationLogRepository) DetachApplicant(tx *gorm.DB, applicantIDs []uint64) error {
+	if err := tx.Model(&ddl.OperationLog{}).
+		Where("applicant_id IN ?", applicantIDs).
+		Updates(map[string]interface{}{
+			"applicant_id": 0,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
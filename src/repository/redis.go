@@ -18,12 +18,42 @@ type IRedisRepository interface {
 	) error
 	Get(ctx context.Context, hashKey string, key string) (*string, error)
 	Delete(ctx context.Context, hashKey string) error
+	// 取得と同時に削除 ※一度のみ使用可能な値用
+	Pop(ctx context.Context, hashKey string, key string) (*string, error)
+	// カウンター加算 ※初回のみ有効期限を設定
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// 配信
+	Publish(ctx context.Context, channel string, message string) error
+	// 購読 ※ctx終了時に購読を解除
+	Subscribe(ctx context.Context, channels []string) (<-chan string, error)
+	// ロック取得 ※他で取得済みの場合はfalse、ttl経過で自動解放
+	Lock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	// ロック解放 ※tokenが一致する(自身が取得した)場合のみ
+	Unlock(ctx context.Context, key string, token string) error
+	// ロック延長 ※tokenが一致する(自身が取得した)場合のみ、失われていた場合はfalse
+	Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
 }
 
 type RedisRepository struct {
 	redis *redis.Client
 }
 
+// ロック解放 ※取得後に期限切れで他に取得されたロックは解放しない
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// ロック延長 ※取得後に期限切れで他に取得されたロックは延長しない
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
 func NewRedisRepository(redis *redis.Client) IRedisRepository {
 	return &RedisRepository{redis}
 }
@@ -70,3 +100,108 @@ func (r *RedisRepository) Delete(ctx context.Context, hashKey string) error {
 	}
 	return nil
 }
+
+func (r *RedisRepository) Pop(ctx context.Context, hashKey string, key string) (*string, error) {
+	var get *redis.StringCmd
+	if _, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.HGet(ctx, hashKey, key)
+		pipe.Del(ctx, hashKey)
+		return nil
+	}); err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	value := get.Val()
+	return &value, nil
+}
+
+func (r *RedisRepository) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	count, err := r.redis.Incr(ctx, key).Result()
+	if err != nil {
+		log.Printf("%v", err)
+		return 0, err
+	}
+
+	if count == 1 && ttl > 0 {
+		_, err = r.redis.Expire(ctx, key, ttl).Result()
+		if err != nil {
+			log.Printf("%v", err)
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+func (r *RedisRepository) Publish(ctx context.Context, channel string, message string) error {
+	_, err := r.redis.Publish(ctx, channel, message).Result()
+	if err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *RedisRepository) Subscribe(ctx context.Context, channels []string) (<-chan string, error) {
+	pubsub := r.redis.Subscribe(ctx, channels...)
+
+	// 購読完了待ち
+	if _, err := pubsub.Receive(ctx); err != nil {
+		log.Printf("%v", err)
+		if err := pubsub.Close(); err != nil {
+			log.Printf("%v", err)
+		}
+		return nil, err
+	}
+
+	messages := make(chan string)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+func (r *RedisRepository) Lock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	ok, err := r.redis.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		log.Printf("%v", err)
+		return false, err
+	}
+	return ok, nil
+}
+
+func (r *RedisRepository) Unlock(ctx context.Context, key string, token string) error {
+	if err := unlockScript.Run(ctx, r.redis, []string{key}, token).Err(); err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *RedisRepository) Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	res, err := extendScript.Run(ctx, r.redis, []string{key}, token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		log.Printf("%v", err)
+		return false, err
+	}
+	return res == 1, nil
+}
@@ -4,6 +4,7 @@ import (
 	"api/src/model/ddl"
 	"api/src/model/entity"
 	"log"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -21,6 +22,20 @@ type IRoleRepository interface {
 	InsertsAssociation(tx *gorm.DB, m []*ddl.RoleAssociation) error
 	// 該当ロールのマスタID取得
 	GetRoleIDs(m *ddl.CustomRole) ([]entity.RoleAssociation, error)
+	// 付与ロール一覧
+	ListMasterRole(m *ddl.CustomRole) ([]entity.Role, error)
+	// 更新
+	Update(tx *gorm.DB, m *ddl.CustomRole) error
+	// 削除
+	Delete(tx *gorm.DB, m *ddl.CustomRole) error
+	// 付与ロール削除
+	DeleteAssociation(tx *gorm.DB, m *ddl.CustomRole) error
+	// ロール名重複数
+	CountDuplName(m *ddl.CustomRole) (*int64, error)
+	// 割り当てユーザー数
+	CountUser(m *ddl.CustomRole) (*int64, error)
+	// ユーザー割り当て
+	AssignUser(tx *gorm.DB, m *ddl.CustomRole, users []uint64) error
 }
 
 type RoleRepository struct {
@@ -48,7 +63,8 @@ func (r *RoleRepository) Get(m *ddl.CustomRole) (*entity.CustomRole, error) {
 	if err := r.db.Where(
 		&ddl.CustomRole{
 			AbstractTransactionModel: ddl.AbstractTransactionModel{
-				HashKey: m.HashKey,
+				HashKey:   m.HashKey,
+				CompanyID: m.CompanyID,
 			},
 		},
 	).First(&res).Error; err != nil {
@@ -64,8 +80,9 @@ func (r *RoleRepository) SearchByCompanyID(m *ddl.CustomRole) ([]entity.CustomRo
 	var l []entity.CustomRole
 
 	query := r.db.Model(&entity.CustomRole{}).
-		Select(`hash_key, name`).
-		Where("company_id = ?", m.CompanyID)
+		Select(`hash_key, name, edit_flg, delete_flg`).
+		Where("company_id = ?", m.CompanyID).
+		Order("id ASC")
 
 	if err := query.Find(&l).Error; err != nil {
 		log.Printf("%v", err)
@@ -107,3 +124,102 @@ func (r *RoleRepository) GetRoleIDs(m *ddl.CustomRole) ([]entity.RoleAssociation
 
 	return res, nil
 }
+
+// 付与ロール一覧
+func (r *RoleRepository) ListMasterRole(m *ddl.CustomRole) ([]entity.Role, error) {
+	var res []entity.Role
+	if err := r.db.Model(&ddl.Role{}).
+		Joins("INNER JOIN t_role_association ON t_role_association.master_role_id = m_role.id").
+		Where("t_role_association.role_id = ?", m.ID).
+		Order("m_role.id ASC").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 更新
+func (r *RoleRepository) Update(tx *gorm.DB, m *ddl.CustomRole) error {
+	if err := tx.Model(&ddl.CustomRole{}).Where(
+		&ddl.CustomRole{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				ID: m.ID,
+			},
+		},
+	).Updates(map[string]interface{}{
+		"name":       m.Name,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 削除
+func (r *RoleRepository) Delete(tx *gorm.DB, m *ddl.CustomRole) error {
+	if err := tx.Where(&ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: m.ID,
+		},
+	}).Delete(&ddl.CustomRole{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 付与ロール削除
+func (r *RoleRepository) DeleteAssociation(tx *gorm.DB, m *ddl.CustomRole) error {
+	if err := tx.
+		Where("role_id = ?", m.ID).
+		Delete(&ddl.RoleAssociation{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// ロール名重複数
+func (r *RoleRepository) CountDuplName(m *ddl.CustomRole) (*int64, error) {
+	var count int64
+	query := r.db.Model(&ddl.CustomRole{}).
+		Where("company_id = ?", m.CompanyID).
+		Where("name = ?", m.Name)
+	if m.ID > 0 {
+		query = query.Where("id <> ?", m.ID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
+
+// 割り当てユーザー数
+func (r *RoleRepository) CountUser(m *ddl.CustomRole) (*int64, error) {
+	var count int64
+	if err := r.db.Model(&ddl.User{}).
+		Where("role_id = ?", m.ID).
+		Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &count, nil
+}
+
+// ユーザー割り当て
+func (r *RoleRepository) AssignUser(tx *gorm.DB, m *ddl.CustomRole, users []uint64) error {
+	if err := tx.Model(&ddl.User{}).
+		Where("id IN ?", users).
+		Where("company_id = ?", m.CompanyID).
+		Updates(map[string]interface{}{
+			"role_id":    m.ID,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
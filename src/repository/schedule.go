@@ -4,10 +4,12 @@ import (
 	"api/src/model/ddl"
 	"api/src/model/dto"
 	"api/src/model/entity"
+	"api/src/model/static"
 	"log"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IScheduleRepository interface {
@@ -43,6 +45,22 @@ type IScheduleRepository interface {
 	GetScheduleByUser(m *dto.GetScheduleByUser) ([]entity.Schedule2, error)
 	// 予定紐づけ削除
 	DeleteScheduleAssociation(tx *gorm.DB, m *ddl.ScheduleAssociation) error
+	// 予定例外登録 ※同じ回の登録がある場合は上書き
+	InsertException(tx *gorm.DB, m *ddl.ScheduleException) error
+	// 予定例外一覧
+	ListException(m *ddl.ScheduleException) ([]entity.ScheduleException, error)
+	// 予定例外削除
+	DeleteException(tx *gorm.DB, m *ddl.ScheduleException) error
+	// 予定例外削除_予定ID
+	DeleteExceptionBySchedule(tx *gorm.DB, m *ddl.ScheduleException) error
+	// 予定例外削除_保持期間切れ ※本来・移動後の開始時刻がいずれもbefore以前、削除件数を返却
+	DeleteExceptionBefore(before time.Time) (int64, error)
+	// 面接リマインド対象一覧 ※期間内(fromを含まずtoを含む)に開始する未送信の面接
+	ListReminder(from time.Time, to time.Time) ([]entity.InterviewReminder, error)
+	// 面接リマインド送信登録 ※送信済みの場合は登録しない、登録件数を返却
+	InsertReminder(m *ddl.ScheduleReminder) (int64, error)
+	// 面接リマインド送信履歴削除_保持期間切れ ※削除件数を返却
+	DeleteReminderBefore(before time.Time) (int64, error)
 }
 
 type ScheduleRepository struct {
@@ -83,9 +101,14 @@ func (u *ScheduleRepository) Search(m *ddl.Schedule) ([]*entity.Schedule, error)
 		t_schedule.hash_key,
 		t_schedule.title,
 		t_schedule.freq_id,
+		t_schedule.interval,
+		t_schedule.by_day,
+		t_schedule.count,
+		t_schedule.until,
 		t_schedule.interview_flg,
 		t_schedule.start,
 		t_schedule.end,
+		t_schedule.team_id,
 		m_schedule_freq_status.freq_name
 	`).
 		Joins("LEFT JOIN m_schedule_freq_status ON t_schedule.freq_id = m_schedule_freq_status.id").
@@ -93,7 +116,7 @@ func (u *ScheduleRepository) Search(m *ddl.Schedule) ([]*entity.Schedule, error)
 
 	if err := query.Preload("Users", func(db *gorm.DB) *gorm.DB {
 		return db.Table("t_user").Select("id, hash_key, name, email")
-	}).Find(&res).Error; err != nil {
+	}).Preload("Exceptions").Find(&res).Error; err != nil {
 		log.Printf("%v", err)
 		return nil, err
 	}
@@ -149,7 +172,7 @@ func (u *ScheduleRepository) Get(m *ddl.Schedule) (*entity.Schedule, error) {
 	return &res, nil
 }
 
-// 予定更新
+// 予定更新 ※繰り返しの設定は未指定(ゼロ値)も反映
 func (u *ScheduleRepository) Update(tx *gorm.DB, m *ddl.Schedule) error {
 	if err := tx.Model(&ddl.Schedule{}).Where(
 		&ddl.Schedule{
@@ -157,15 +180,20 @@ func (u *ScheduleRepository) Update(tx *gorm.DB, m *ddl.Schedule) error {
 				HashKey: m.HashKey,
 			},
 		},
+	).Select(
+		"updated_at", "title", "freq_id", "interval", "by_day", "count", "until", "start", "end",
 	).Updates(&ddl.Schedule{
 		AbstractTransactionModel: ddl.AbstractTransactionModel{
 			UpdatedAt: time.Now(),
 		},
-		InterviewFlg: m.InterviewFlg,
-		Title:        m.Title,
-		FreqID:       m.FreqID,
-		Start:        m.Start,
-		End:          m.End,
+		Title:    m.Title,
+		FreqID:   m.FreqID,
+		Interval: m.Interval,
+		ByDay:    m.ByDay,
+		Count:    m.Count,
+		Until:    m.Until,
+		Start:    m.Start,
+		End:      m.End,
 	}).Error; err != nil {
 		log.Printf("%v", err)
 		return err
@@ -272,12 +300,18 @@ func (u *ScheduleRepository) SearchScheduleUserAssociation(m *ddl.ScheduleAssoci
 // ユーザー単位予定取得
 func (u *ScheduleRepository) GetScheduleByUser(m *dto.GetScheduleByUser) ([]entity.Schedule2, error) {
 	var res []entity.Schedule2
-	if err := u.db.Table("t_schedule").
+	query := u.db.Table("t_schedule").
 		Select(`
+			t_schedule.id,
 			t_schedule.start,
 			t_schedule.end,
 			t_schedule.freq_id,
-			t_schedule.interview_flg
+			t_schedule.interval,
+			t_schedule.by_day,
+			t_schedule.count,
+			t_schedule.until,
+			t_schedule.interview_flg,
+			t_schedule.team_id
 		`).
 		Joins(`
 			LEFT JOIN
@@ -285,8 +319,15 @@ func (u *ScheduleRepository) GetScheduleByUser(m *dto.GetScheduleByUser) ([]enti
 			ON
 				t_schedule_association.schedule_id = t_schedule.id
 		`).
-		Where("t_schedule_association.user_id = ?", m.UserID).
-		Where("t_schedule.hash_key NOT IN ?", m.RemoveScheduleHashKeys).
+		Where("t_schedule_association.user_id = ?", m.UserID)
+
+	// 除外予定 ※空の場合はNOT IN (NULL)となり全件除外されるため指定時のみ
+	if len(m.RemoveScheduleHashKeys) > 0 {
+		query = query.Where("t_schedule.hash_key NOT IN ?", m.RemoveScheduleHashKeys)
+	}
+
+	if err := query.
+		Preload("Exceptions").
 		Find(&res).Error; err != nil {
 		log.Printf("%v", err)
 		return nil, err
@@ -305,3 +346,134 @@ func (u *ScheduleRepository) DeleteScheduleAssociation(tx *gorm.DB, m *ddl.Sched
 	}
 	return nil
 }
+
+// 予定例外登録
+func (u *ScheduleRepository) InsertException(tx *gorm.DB, m *ddl.ScheduleException) error {
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "schedule_id"}, {Name: "original_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "start", "end"}),
+	}).Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 予定例外一覧
+func (u *ScheduleRepository) ListException(m *ddl.ScheduleException) ([]entity.ScheduleException, error) {
+	var res []entity.ScheduleException
+	if err := u.db.Where(&ddl.ScheduleException{
+		ScheduleID: m.ScheduleID,
+	}).Order("original_start").Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 予定例外削除
+func (u *ScheduleRepository) DeleteException(tx *gorm.DB, m *ddl.ScheduleException) error {
+	if err := tx.Where("schedule_id = ? AND original_start = ?", m.ScheduleID, m.OriginalStart).
+		Delete(&ddl.ScheduleException{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 予定例外削除_予定ID
+func (u *ScheduleRepository) DeleteExceptionBySchedule(tx *gorm.DB, m *ddl.ScheduleException) error {
+	if err := tx.Where(&ddl.ScheduleException{
+		ScheduleID: m.ScheduleID,
+	}).Delete(&ddl.ScheduleException{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 予定例外削除_保持期間切れ
+func (u *ScheduleRepository) DeleteExceptionBefore(before time.Time) (int64, error) {
+	res := u.db.Where("original_start < ? AND (start IS NULL OR start < ?)", before, before).
+		Delete(&ddl.ScheduleException{})
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// 面接リマインド対象一覧
+func (u *ScheduleRepository) ListReminder(from time.Time, to time.Time) ([]entity.InterviewReminder, error) {
+	var res []entity.InterviewReminder
+	if err := u.db.Table("t_schedule").
+		Select(`
+			t_schedule.id,
+			t_schedule.hash_key,
+			t_schedule.title,
+			t_schedule.start,
+			t_schedule.end,
+			t_schedule.team_id,
+			t_schedule.updated_at,
+			t_applicant.id as applicant_id,
+			t_applicant.company_id,
+			t_applicant.name as applicant_name,
+			t_applicant.email as applicant_email,
+			t_applicant_url_association.url as google_meet_url
+		`).
+		Joins(`
+			INNER JOIN
+				t_applicant_schedule_association
+			ON
+				t_applicant_schedule_association.schedule_id = t_schedule.id
+		`).
+		Joins(`
+			INNER JOIN
+				t_applicant
+			ON
+				t_applicant_schedule_association.applicant_id = t_applicant.id
+		`).
+		Joins(`
+			LEFT JOIN
+				t_applicant_url_association
+			ON
+				t_applicant_url_association.applicant_id = t_applicant.id
+		`).
+		Joins(`
+			LEFT JOIN
+				t_schedule_reminder
+			ON
+				t_schedule_reminder.schedule_id = t_schedule.id
+				AND t_schedule_reminder.applicant_id = t_applicant.id
+				AND t_schedule_reminder.start = t_schedule.start
+		`).
+		Where("t_schedule.interview_flg = ?", uint(static.USER_INTERVIEW)).
+		Where("t_schedule.start > ? AND t_schedule.start <= ?", from, to).
+		Where("t_schedule_reminder.schedule_id IS NULL").
+		Order("t_schedule.start").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 面接リマインド送信登録
+func (u *ScheduleRepository) InsertReminder(m *ddl.ScheduleReminder) (int64, error) {
+	res := u.db.Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// 面接リマインド送信履歴削除_保持期間切れ
+func (u *ScheduleRepository) DeleteReminderBefore(before time.Time) (int64, error) {
+	res := u.db.Where("start < ?", before).Delete(&ddl.ScheduleReminder{})
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
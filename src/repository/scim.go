@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+type ISCIMRepository interface {
+	// トークン登録
+	InsertToken(tx *gorm.DB, m *ddl.CompanySCIMToken) error
+	// トークン取得 ※トークン or 企業ID
+	GetToken(m *ddl.CompanySCIMToken) (*entity.CompanySCIMToken, error)
+	// トークン削除
+	DeleteToken(tx *gorm.DB, m *ddl.CompanySCIMToken) error
+	// ユーザー一覧 ※総件数を返却
+	ListUser(m *dto.SCIMList) ([]entity.User, int64, error)
+	// チーム一覧 ※総件数を返却
+	ListTeam(m *dto.SCIMList) ([]entity.Team, int64, error)
+}
+
+type SCIMRepository struct {
+	db *gorm.DB
+}
+
+func NewSCIMRepository(db *gorm.DB) ISCIMRepository {
+	return &SCIMRepository{db}
+}
+
+// トークン登録
+func (r *SCIMRepository) InsertToken(tx *gorm.DB, m *ddl.CompanySCIMToken) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// トークン取得
+func (r *SCIMRepository) GetToken(m *ddl.CompanySCIMToken) (*entity.CompanySCIMToken, error) {
+	var res entity.CompanySCIMToken
+	if err := r.db.Where(
+		&ddl.CompanySCIMToken{
+			CompanyID: m.CompanyID,
+			Token:     m.Token,
+		},
+	).Preload("Role").First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// トークン削除
+func (r *SCIMRepository) DeleteToken(tx *gorm.DB, m *ddl.CompanySCIMToken) error {
+	if err := tx.
+		Where("company_id = ?", m.CompanyID).
+		Delete(&ddl.CompanySCIMToken{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// ユーザー一覧
+func (r *SCIMRepository) ListUser(m *dto.SCIMList) ([]entity.User, int64, error) {
+	query := r.db.Model(&ddl.User{}).
+		Where("company_id = ?", m.CompanyID)
+	if m.Filter != "" {
+		query = query.Where("LOWER(email) = LOWER(?)", m.Filter)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	var res []entity.User
+	if err := query.
+		Select("id, hash_key, name, email, company_id, created_at, updated_at").
+		Order("id").
+		Offset(m.Offset).
+		Limit(m.Limit).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+	return res, count, nil
+}
+
+// チーム一覧
+func (r *SCIMRepository) ListTeam(m *dto.SCIMList) ([]entity.Team, int64, error) {
+	query := r.db.Model(&ddl.Team{}).
+		Where("company_id = ?", m.CompanyID)
+	if m.Filter != "" {
+		query = query.Where("name = ?", m.Filter)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+
+	var res []entity.Team
+	if err := query.
+		Order("id").
+		Offset(m.Offset).
+		Limit(m.Limit).
+		Preload("Users", func(db *gorm.DB) *gorm.DB {
+			return db.Table("t_user").Select("id, hash_key, name, email")
+		}).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, 0, err
+	}
+	return res, count, nil
+}
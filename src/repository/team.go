@@ -38,6 +38,8 @@ type ITeamRepository interface {
 	ListUserAssociation(m *ddl.TeamAssociation) ([]entity.TeamAssociation, error)
 	// チーム紐づけ削除
 	DeleteTeamAssociation(tx *gorm.DB, m *ddl.TeamAssociation) error
+	// メンバー削除 ※チーム紐づけ・面接毎参加可能者・面接割り振り優先順位
+	DeleteMember(tx *gorm.DB, m *ddl.TeamAssociation) error
 	// チーム毎ステータスイベント取得
 	StatusEventsByTeam(m *ddl.Team) ([]entity.StatusEventsByTeam, error)
 	// チーム面接毎イベント取得
@@ -106,6 +108,26 @@ type ITeamRepository interface {
 	DeletePerInterview(tx *gorm.DB, m *ddl.TeamPerInterview) error
 	// 面接毎設定削除_面接回数
 	DeletePerInterviewByNum(tx *gorm.DB, m *ddl.TeamPerInterview) error
+	// 面接予約枠設定登録
+	InsertBookingWindow(tx *gorm.DB, m *ddl.TeamBookingWindow) error
+	// 面接予約受付時間一括登録
+	InsertsBookingHours(tx *gorm.DB, m []*ddl.TeamBookingHours) error
+	// 面接予約枠設定取得 ※チーム既定(面接回数0)と指定の面接回数
+	ListBookingWindow(m *ddl.TeamBookingWindow) ([]*ddl.TeamBookingWindow, error)
+	// 面接予約受付時間取得 ※チーム既定(面接回数0)と指定の面接回数
+	ListBookingHours(m *ddl.TeamBookingHours) ([]*ddl.TeamBookingHours, error)
+	// 面接予約枠設定削除 ※受付時間を含む
+	DeleteBookingWindow(tx *gorm.DB, m *ddl.TeamBookingWindow) error
+	// 面接予約枠設定削除_PK ※受付時間を含む
+	DeleteBookingWindowByPrimary(tx *gorm.DB, m *ddl.TeamBookingWindow) error
+	// 面接予約枠設定削除_面接回数 ※受付時間を含む
+	DeleteBookingWindowByNum(tx *gorm.DB, m *ddl.TeamBookingWindow) error
+	// 曜日設定一括登録
+	InsertsWeekday(tx *gorm.DB, m []*ddl.TeamWeekday) error
+	// 曜日設定取得
+	ListWeekday(m *ddl.TeamWeekday) ([]*ddl.TeamWeekday, error)
+	// 曜日設定削除
+	DeleteWeekday(tx *gorm.DB, m *ddl.TeamWeekday) error
 	// チームID取得
 	GetIDs(m []string) ([]uint64, error)
 	// チーム取得_ハッシュキー配列
@@ -341,6 +363,29 @@ func (u *TeamRepository) DeleteTeamAssociation(tx *gorm.DB, m *ddl.TeamAssociati
 	return nil
 }
 
+// メンバー削除
+func (u *TeamRepository) DeleteMember(tx *gorm.DB, m *ddl.TeamAssociation) error {
+	if err := tx.
+		Where("team_id = ? AND user_id = ?", m.TeamID, m.UserID).
+		Delete(&ddl.TeamAssignPossible{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	if err := tx.
+		Where("team_id = ? AND user_id = ?", m.TeamID, m.UserID).
+		Delete(&ddl.TeamAssignPriority{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	if err := tx.
+		Where("team_id = ? AND user_id = ?", m.TeamID, m.UserID).
+		Delete(&ddl.TeamAssociation{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
 // チーム毎ステータスイベント取得
 func (u *TeamRepository) StatusEventsByTeam(m *ddl.Team) ([]entity.StatusEventsByTeam, error) {
 	var res []entity.StatusEventsByTeam
@@ -762,7 +807,7 @@ func (u *TeamRepository) GetAssignPossibleSchedule(m *ddl.TeamAssignPossible) ([
 		Where(m)
 
 	if err := query.Preload("Schedules", func(db *gorm.DB) *gorm.DB {
-		return db.Preload("Users")
+		return db.Preload("Users").Preload("Exceptions")
 	}).Find(&res).Error; err != nil {
 		log.Printf("%v", err)
 		return nil, err
@@ -858,6 +903,134 @@ func (u *TeamRepository) DeletePerInterviewByNum(tx *gorm.DB, m *ddl.TeamPerInte
 	return nil
 }
 
+// 面接予約枠設定登録
+func (u *TeamRepository) InsertBookingWindow(tx *gorm.DB, m *ddl.TeamBookingWindow) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 面接予約受付時間一括登録
+func (u *TeamRepository) InsertsBookingHours(tx *gorm.DB, m []*ddl.TeamBookingHours) error {
+	if len(m) == 0 {
+		return nil
+	}
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 面接予約枠設定取得
+func (u *TeamRepository) ListBookingWindow(m *ddl.TeamBookingWindow) ([]*ddl.TeamBookingWindow, error) {
+	var res []*ddl.TeamBookingWindow
+	if err := u.db.Model(&ddl.TeamBookingWindow{}).
+		Where("team_id = ?", m.TeamID).
+		Where("num_of_interview IN ?", []uint{0, m.NumOfInterview}).
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 面接予約受付時間取得
+func (u *TeamRepository) ListBookingHours(m *ddl.TeamBookingHours) ([]*ddl.TeamBookingHours, error) {
+	var res []*ddl.TeamBookingHours
+	if err := u.db.Model(&ddl.TeamBookingHours{}).
+		Where("team_id = ?", m.TeamID).
+		Where("num_of_interview IN ?", []uint{0, m.NumOfInterview}).
+		Order("weekday").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 面接予約枠設定削除
+func (u *TeamRepository) DeleteBookingWindow(tx *gorm.DB, m *ddl.TeamBookingWindow) error {
+	if err := tx.Where("team_id = ?", m.TeamID).
+		Delete(&ddl.TeamBookingHours{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	if err := tx.Where("team_id = ?", m.TeamID).
+		Delete(&ddl.TeamBookingWindow{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 面接予約枠設定削除_PK
+func (u *TeamRepository) DeleteBookingWindowByPrimary(tx *gorm.DB, m *ddl.TeamBookingWindow) error {
+	if err := tx.Where("team_id = ? AND num_of_interview = ?", m.TeamID, m.NumOfInterview).
+		Delete(&ddl.TeamBookingHours{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	if err := tx.Where("team_id = ? AND num_of_interview = ?", m.TeamID, m.NumOfInterview).
+		Delete(&ddl.TeamBookingWindow{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 面接予約枠設定削除_面接回数
+func (u *TeamRepository) DeleteBookingWindowByNum(tx *gorm.DB, m *ddl.TeamBookingWindow) error {
+	if err := tx.Where("team_id = ? AND num_of_interview > ?", m.TeamID, m.NumOfInterview).
+		Delete(&ddl.TeamBookingHours{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	if err := tx.Where("team_id = ? AND num_of_interview > ?", m.TeamID, m.NumOfInterview).
+		Delete(&ddl.TeamBookingWindow{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 曜日設定一括登録
+func (u *TeamRepository) InsertsWeekday(tx *gorm.DB, m []*ddl.TeamWeekday) error {
+	if len(m) == 0 {
+		return nil
+	}
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 曜日設定取得
+func (u *TeamRepository) ListWeekday(m *ddl.TeamWeekday) ([]*ddl.TeamWeekday, error) {
+	var res []*ddl.TeamWeekday
+	if err := u.db.Model(&ddl.TeamWeekday{}).
+		Where("team_id = ?", m.TeamID).
+		Order("weekday").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 曜日設定削除
+func (u *TeamRepository) DeleteWeekday(tx *gorm.DB, m *ddl.TeamWeekday) error {
+	if err := tx.Where("team_id = ?", m.TeamID).
+		Delete(&ddl.TeamWeekday{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
 // チームID取得
 func (u *TeamRepository) GetIDs(m []string) ([]uint64, error) {
 	var res []entity.Team
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUploadHistoryRepository interface {
+	// 登録
+	Insert(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) error
+	// 検索_同一チーム
+	Search(m *ddl.HistoryOfUploadApplicant) ([]entity.UploadHistory, error)
+	// 取得_コミットID ※行ロック
+	Get(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) (*entity.UploadHistory, error)
+	// 取消
+	Rollback(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) error
+}
+
+type UploadHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadHistoryRepository(db *gorm.DB) IUploadHistoryRepository {
+	return &UploadHistoryRepository{db}
+}
+
+// 登録
+func (r *UploadHistoryRepository) Insert(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 検索_同一チーム ※アップロードcsvは含まない
+func (r *UploadHistoryRepository) Search(m *ddl.HistoryOfUploadApplicant) ([]entity.UploadHistory, error) {
+	var res []entity.UploadHistory
+	if err := r.db.Table("t_history_of_upload_applicant").
+		Select(`
+			t_history_of_upload_applicant.id,
+			t_history_of_upload_applicant.hash_key,
+			t_history_of_upload_applicant.commit_id,
+			t_history_of_upload_applicant.file_name,
+			t_history_of_upload_applicant.total_num,
+			t_history_of_upload_applicant.insert_num,
+			t_history_of_upload_applicant.dupl_num,
+			t_history_of_upload_applicant.rollback_flg,
+			t_history_of_upload_applicant.created_at,
+			t_history_of_upload_applicant.updated_at,
+			m_site.site_name,
+			t_user.hash_key as user_hash,
+			t_user.name as user_name
+		`).
+		Joins("INNER JOIN m_site ON t_history_of_upload_applicant.site_id = m_site.id").
+		Joins("LEFT JOIN t_user ON t_history_of_upload_applicant.user_id = t_user.id").
+		Where("t_history_of_upload_applicant.team_id = ?", m.TeamID).
+		Order("t_history_of_upload_applicant.created_at DESC").
+		Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// 取得_コミットID ※行ロックにより同一履歴の取消を直列化
+func (r *UploadHistoryRepository) Get(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) (*entity.UploadHistory, error) {
+	var res entity.UploadHistory
+	if err := tx.Model(&ddl.HistoryOfUploadApplicant{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(&ddl.HistoryOfUploadApplicant{
+			CommitID: m.CommitID,
+			TeamID:   m.TeamID,
+		}).
+		First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// 取消
+func (r *UploadHistoryRepository) Rollback(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) error {
+	if err := tx.Model(&ddl.HistoryOfUploadApplicant{}).
+		Where(&ddl.HistoryOfUploadApplicant{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				ID: m.ID,
+			},
+		}).
+		Updates(map[string]interface{}{
+			"rollback_flg": m.RollbackFlg,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
@@ -28,6 +28,8 @@ type IUserRepository interface {
 	Update(tx *gorm.DB, m *ddl.User) error
 	// 削除
 	Delete(tx *gorm.DB, m []string) error
+	// 無効化日時更新 ※nilの場合は有効化
+	UpdateDeactivated(tx *gorm.DB, m *ddl.User) error
 	// リフレッシュトークン紐づけ登録
 	InsertUserRefreshTokenAssociation(tx *gorm.DB, m *ddl.UserRefreshTokenAssociation) error
 	// リフレッシュトークン紐づけ取得
@@ -56,6 +58,52 @@ type IUserRepository interface {
 	DeleteTeamAssociation(tx *gorm.DB, m []uint64) error
 	// 削除_リフレッシュトークン紐づけ
 	DeleteUserRefreshTokenAssociation(tx *gorm.DB, m []uint64) error
+	// 削除_カレンダー購読トークン
+	DeleteCalendarToken(tx *gorm.DB, m []uint64) error
+	// セッショントークン登録
+	InsertSessionToken(tx *gorm.DB, m *ddl.UserSessionToken) error
+	// セッショントークン取得
+	GetSessionToken(m *ddl.UserSessionToken) (*entity.UserSessionToken, error)
+	// セッショントークン使用 ※未使用・未失効の場合のみ、更新件数を返却
+	UseSessionToken(tx *gorm.DB, m *ddl.UserSessionToken) (int64, error)
+	// セッション失効_セッションID
+	RevokeSession(tx *gorm.DB, m *ddl.UserSessionToken) error
+	// セッション失効_ユーザー
+	RevokeSessionByUser(tx *gorm.DB, m []uint64) error
+	// 有効セッションID一覧_ユーザー
+	ListSessionID(m []uint64) ([]string, error)
+	// 削除_セッショントークン
+	DeleteSessionToken(tx *gorm.DB, m []uint64) error
+	// 削除_期限切れセッショントークン ※before以前に期限切れ、削除件数を返却
+	DeleteExpiredSessionToken(before time.Time) (int64, error)
+	// 認証アプリ登録
+	InsertTOTP(tx *gorm.DB, m *ddl.UserTOTP) error
+	// 認証アプリ取得
+	GetTOTP(m *ddl.UserTOTP) (*entity.UserTOTP, error)
+	// 認証アプリ有効化
+	ConfirmTOTP(tx *gorm.DB, m *ddl.UserTOTP) error
+	// 認証アプリ使用 ※最終使用ステップより新しい場合のみ、更新件数を返却
+	UseTOTP(m *ddl.UserTOTP) (int64, error)
+	// 削除_認証アプリ
+	DeleteTOTP(tx *gorm.DB, m []uint64) error
+	// リカバリーコード登録
+	InsertRecoveryCode(tx *gorm.DB, m []ddl.UserRecoveryCode) error
+	// リカバリーコード使用 ※未使用の場合のみ、更新件数を返却
+	UseRecoveryCode(m *ddl.UserRecoveryCode) (int64, error)
+	// 削除_リカバリーコード
+	DeleteRecoveryCode(tx *gorm.DB, m []uint64) error
+	// パスワード履歴登録
+	InsertPasswordHistory(tx *gorm.DB, m *ddl.UserPasswordHistory) error
+	// パスワード履歴取得 ※新しい順に指定件数
+	ListPasswordHistory(m *ddl.UserPasswordHistory, limit int) ([]entity.UserPasswordHistory, error)
+	// 削除_パスワード履歴
+	DeletePasswordHistory(tx *gorm.DB, m []uint64) error
+	// シングルサインオン紐づけ登録
+	InsertOIDC(tx *gorm.DB, m *ddl.UserOIDC) error
+	// シングルサインオン紐づけ取得 ※ユーザーID or 発行者＋サブジェクト
+	GetOIDC(m *ddl.UserOIDC) (*entity.UserOIDC, error)
+	// 削除_シングルサインオン紐づけ
+	DeleteOIDC(tx *gorm.DB, m []uint64) error
 }
 
 type UserRepository struct {
@@ -84,7 +132,9 @@ func (u *UserRepository) Login(m *ddl.User) ([]entity.Login, error) {
 			&ddl.User{
 				Email: m.Email,
 			},
-		).Find(&res).Error; err != nil {
+		).
+		Where("t_user.deactivated_at IS NULL").
+		Find(&res).Error; err != nil {
 		log.Printf("%v", err)
 		return nil, err
 	}
@@ -176,10 +226,11 @@ func (u *UserRepository) GetByPrimary(m *ddl.User) (*entity.User, error) {
 // 更新
 func (u *UserRepository) Update(tx *gorm.DB, m *ddl.User) error {
 	user := ddl.User{
-		Name:     m.Name,
-		Email:    m.Email,
-		Password: m.Password,
-		RoleID:   m.RoleID,
+		Name:         m.Name,
+		Email:        m.Email,
+		Password:     m.Password,
+		InitPassword: m.InitPassword,
+		RoleID:       m.RoleID,
 		AbstractTransactionModel: ddl.AbstractTransactionModel{
 			UpdatedAt: time.Now(),
 		},
@@ -209,6 +260,20 @@ func (u *UserRepository) Delete(tx *gorm.DB, m []string) error {
 	return nil
 }
 
+// 無効化日時更新 ※nilの場合は有効化
+func (u *UserRepository) UpdateDeactivated(tx *gorm.DB, m *ddl.User) error {
+	if err := tx.Model(&ddl.User{}).
+		Where("hash_key = ?", m.HashKey).
+		Updates(map[string]interface{}{
+			"deactivated_at": m.DeactivatedAt,
+			"updated_at":     time.Now(),
+		}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
 // リフレッシュトークン紐づけ登録
 func (u *UserRepository) InsertUserRefreshTokenAssociation(tx *gorm.DB, m *ddl.UserRefreshTokenAssociation) error {
 	if err := tx.Create(m).Error; err != nil {
@@ -291,7 +356,7 @@ func (u *UserRepository) GetIDs(m []string) ([]uint64, error) {
 func (u *UserRepository) GetByHashKeys(m []string) ([]entity.User, error) {
 	var res []entity.User
 	if err := u.db.Model(&ddl.User{}).
-		Select("id, hash_key, name, email").
+		Select("id, hash_key, name, email, company_id").
 		Where("hash_key IN ?", m).
 		Find(&res).Error; err != nil {
 		log.Printf("%v", err)
@@ -406,3 +471,277 @@ func (u *UserRepository) DeleteUserRefreshTokenAssociation(tx *gorm.DB, m []uint
 	}
 	return nil
 }
+
+// 削除_カレンダー購読トークン
+func (u *UserRepository) DeleteCalendarToken(tx *gorm.DB, m []uint64) error {
+	if err := tx.
+		Where("user_id IN ?", m).
+		Delete(&ddl.UserCalendarToken{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// セッショントークン登録
+func (u *UserRepository) InsertSessionToken(tx *gorm.DB, m *ddl.UserSessionToken) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// セッショントークン取得
+func (u *UserRepository) GetSessionToken(m *ddl.UserSessionToken) (*entity.UserSessionToken, error) {
+	var res entity.UserSessionToken
+
+	if err := u.db.Model(&ddl.UserSessionToken{}).
+		Select("t_user_session_token.*, t_user.hash_key as user_hash_key").
+		Joins("INNER JOIN t_user ON t_user.id = t_user_session_token.user_id").
+		Where("t_user_session_token.token = ?", m.Token).
+		First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// セッショントークン使用
+func (u *UserRepository) UseSessionToken(tx *gorm.DB, m *ddl.UserSessionToken) (int64, error) {
+	res := tx.Model(&ddl.UserSessionToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", m.ID).
+		Update("used_at", m.UsedAt)
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// セッション失効_セッションID
+func (u *UserRepository) RevokeSession(tx *gorm.DB, m *ddl.UserSessionToken) error {
+	if err := tx.Model(&ddl.UserSessionToken{}).
+		Where("session_id = ? AND revoked_at IS NULL", m.SessionID).
+		Update("revoked_at", m.RevokedAt).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// セッション失効_ユーザー
+func (u *UserRepository) RevokeSessionByUser(tx *gorm.DB, m []uint64) error {
+	if err := tx.Model(&ddl.UserSessionToken{}).
+		Where("user_id IN ? AND revoked_at IS NULL", m).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 有効セッションID一覧_ユーザー
+func (u *UserRepository) ListSessionID(m []uint64) ([]string, error) {
+	var res []string
+
+	if err := u.db.Model(&ddl.UserSessionToken{}).
+		Distinct("session_id").
+		Where("user_id IN ? AND revoked_at IS NULL AND expired_at > ?", m, time.Now()).
+		Pluck("session_id", &res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// 削除_セッショントークン
+func (u *UserRepository) DeleteSessionToken(tx *gorm.DB, m []uint64) error {
+	if err := tx.
+		Where("user_id IN ?", m).
+		Delete(&ddl.UserSessionToken{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 削除_期限切れセッショントークン
+func (u *UserRepository) DeleteExpiredSessionToken(before time.Time) (int64, error) {
+	res := u.db.Where("expired_at < ?", before).Delete(&ddl.UserSessionToken{})
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// 認証アプリ登録
+func (u *UserRepository) InsertTOTP(tx *gorm.DB, m *ddl.UserTOTP) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 認証アプリ取得
+func (u *UserRepository) GetTOTP(m *ddl.UserTOTP) (*entity.UserTOTP, error) {
+	var res entity.UserTOTP
+
+	if err := u.db.Where(
+		&ddl.UserTOTP{
+			UserID: m.UserID,
+		},
+	).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// 認証アプリ有効化
+func (u *UserRepository) ConfirmTOTP(tx *gorm.DB, m *ddl.UserTOTP) error {
+	if err := tx.Model(&ddl.UserTOTP{}).
+		Where("user_id = ? AND confirmed_at IS NULL", m.UserID).
+		Updates(map[string]interface{}{
+			"confirmed_at": m.ConfirmedAt,
+			"last_step":    m.LastStep,
+		}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// 認証アプリ使用
+func (u *UserRepository) UseTOTP(m *ddl.UserTOTP) (int64, error) {
+	res := u.db.Model(&ddl.UserTOTP{}).
+		Where("user_id = ? AND last_step < ?", m.UserID, m.LastStep).
+		Update("last_step", m.LastStep)
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// 削除_認証アプリ
+func (u *UserRepository) DeleteTOTP(tx *gorm.DB, m []uint64) error {
+	if err := tx.
+		Where("user_id IN ?", m).
+		Delete(&ddl.UserTOTP{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// リカバリーコード登録
+func (u *UserRepository) InsertRecoveryCode(tx *gorm.DB, m []ddl.UserRecoveryCode) error {
+	if err := tx.Create(&m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// リカバリーコード使用
+func (u *UserRepository) UseRecoveryCode(m *ddl.UserRecoveryCode) (int64, error) {
+	res := u.db.Model(&ddl.UserRecoveryCode{}).
+		Where("user_id = ? AND code = ? AND used_at IS NULL", m.UserID, m.Code).
+		Update("used_at", m.UsedAt)
+	if res.Error != nil {
+		log.Printf("%v", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// 削除_リカバリーコード
+func (u *UserRepository) DeleteRecoveryCode(tx *gorm.DB, m []uint64) error {
+	if err := tx.
+		Where("user_id IN ?", m).
+		Delete(&ddl.UserRecoveryCode{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// パスワード履歴登録
+func (u *UserRepository) InsertPasswordHistory(tx *gorm.DB, m *ddl.UserPasswordHistory) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// パスワード履歴取得
+func (u *UserRepository) ListPasswordHistory(m *ddl.UserPasswordHistory, limit int) ([]entity.UserPasswordHistory, error) {
+	var res []entity.UserPasswordHistory
+
+	if err := u.db.Where(
+		&ddl.UserPasswordHistory{
+			UserID: m.UserID,
+		},
+	).Order("id DESC").Limit(limit).Find(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// 削除_パスワード履歴
+func (u *UserRepository) DeletePasswordHistory(tx *gorm.DB, m []uint64) error {
+	if err := tx.
+		Where("user_id IN ?", m).
+		Delete(&ddl.UserPasswordHistory{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// シングルサインオン紐づけ登録
+func (u *UserRepository) InsertOIDC(tx *gorm.DB, m *ddl.UserOIDC) error {
+	if err := tx.Create(m).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
+
+// シングルサインオン紐づけ取得
+func (u *UserRepository) GetOIDC(m *ddl.UserOIDC) (*entity.UserOIDC, error) {
+	var res entity.UserOIDC
+
+	if err := u.db.Where(
+		&ddl.UserOIDC{
+			UserID:  m.UserID,
+			Issuer:  m.Issuer,
+			Subject: m.Subject,
+		},
+	).First(&res).Error; err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// 削除_シングルサインオン紐づけ
+func (u *UserRepository) DeleteOIDC(tx *gorm.DB, m []uint64) error {
+	if err := tx.
+		Where("user_id IN ?", m).
+		Delete(&ddl.UserOIDC{}).Error; err != nil {
+		log.Printf("%v", err)
+		return err
+	}
+	return nil
+}
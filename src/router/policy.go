@@ -0,0 +1,88 @@
+package router
+
+import (
+	"api/src/controller"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ポリシー付きルーター
+type policyRouter struct {
+	e        *echo.Echo
+	auth     controller.IAuthMiddleware
+	policies map[string]*controller.Policy
+}
+
+func newPolicyRouter(e *echo.Echo, auth controller.IAuthMiddleware) *policyRouter {
+	return &policyRouter{e, auth, make(map[string]*controller.Policy)}
+}
+
+// GET
+func (r *policyRouter) GET(path string, h echo.HandlerFunc, p *controller.Policy) {
+	r.add(http.MethodGet, path, h, p)
+}
+
+// POST
+func (r *policyRouter) POST(path string, h echo.HandlerFunc, p *controller.Policy) {
+	r.add(http.MethodPost, path, h, p)
+}
+
+// PUT
+func (r *policyRouter) PUT(path string, h echo.HandlerFunc, p *controller.Policy) {
+	r.add(http.MethodPut, path, h, p)
+}
+
+// PATCH
+func (r *policyRouter) PATCH(path string, h echo.HandlerFunc, p *controller.Policy) {
+	r.add(http.MethodPatch, path, h, p)
+}
+
+// DELETE
+func (r *policyRouter) DELETE(path string, h echo.HandlerFunc, p *controller.Policy) {
+	r.add(http.MethodDelete, path, h, p)
+}
+
+func (r *policyRouter) add(method string, path string, h echo.HandlerFunc, p *controller.Policy) {
+	if p != nil {
+		r.policies[routeKey(method, path)] = p
+		r.e.Add(method, path, h, r.auth.Apply(p))
+		return
+	}
+	r.e.Add(method, path, h)
+}
+
+// 起動時チェック ※ポリシー未宣言のルートが存在する場合は起動しない
+func (r *policyRouter) verify() {
+	if missing := r.missing(); len(missing) > 0 {
+		log.Fatalf("routes without policy: %v", missing)
+	}
+}
+
+// ポリシー未宣言のルート一覧(ソート済み)
+func (r *policyRouter) missing() []string {
+	var missing []string
+	for _, route := range r.e.Routes() {
+		if _, ok := r.policies[routeKey(route.Method, route.Path)]; !ok {
+			missing = append(missing, routeKey(route.Method, route.Path))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func routeKey(method string, path string) string {
+	return method + " " + path
+}
+
+// 企業ユーザー参照系
+func manageRead(roleID uint) *controller.Policy {
+	return controller.Read(controller.Management(roleID))
+}
+
+// 企業ユーザー更新系
+func manageWrite(roleID uint) *controller.Policy {
+	return controller.Write(controller.Management(roleID))
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"api/src/controller"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 認証ミドルウェア(テスト用) ※適用されたポリシーを記録
+type fakeAuthMiddleware struct {
+	applied []*controller.Policy
+}
+
+func (f *fakeAuthMiddleware) Apply(p *controller.Policy) echo.MiddlewareFunc {
+	f.applied = append(f.applied, p)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(e echo.Context) error {
+			e.Response().Header().Set("X-Policy-Applied", "1")
+			return next(e)
+		}
+	}
+}
+
+func noContent(e echo.Context) error {
+	return e.NoContent(http.StatusNoContent)
+}
+
+func TestPolicyRouter_missing(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(r *policyRouter)
+		want  []string
+	}{
+		// ok 全ルートにポリシー宣言あり
+		{"ok", func(r *policyRouter) {
+			r.POST("/login", noContent, controller.Public())
+			r.GET("/stream", noContent, controller.User())
+			r.PUT("/role", noContent, manageWrite(1))
+			r.PATCH("/role", noContent, manageWrite(1))
+			r.DELETE("/role", noContent, manageWrite(1))
+		}, nil},
+		// ok ルートなし
+		{"ok_empty", func(r *policyRouter) {}, nil},
+		// ng ポリシーなし(nil)
+		{"ng_nil", func(r *policyRouter) {
+			r.POST("/login", noContent, controller.Public())
+			r.POST("/user/create", noContent, nil)
+		}, []string{"POST /user/create"}},
+		// ng ルーターを経由せず直接登録
+		{"ng_direct", func(r *policyRouter) {
+			r.POST("/login", noContent, controller.Public())
+			r.e.GET("/debug", noContent)
+			r.e.POST("/admin", noContent)
+		}, []string{"GET /debug", "POST /admin"}},
+		// ng 同一パスでもメソッド毎に宣言が必要
+		{"ng_method", func(r *policyRouter) {
+			r.POST("/role", noContent, manageWrite(1))
+			r.e.DELETE("/role", noContent)
+		}, []string{"DELETE /role"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPolicyRouter(echo.New(), &fakeAuthMiddleware{})
+			tt.setup(r)
+			if got := r.missing(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("policyRouter.missing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyRouter_add(t *testing.T) {
+	auth := &fakeAuthMiddleware{}
+	r := newPolicyRouter(echo.New(), auth)
+	p := controller.User()
+	r.GET("/stream", noContent, p)
+
+	// 宣言したポリシーの認証ミドルウェアを経由
+	if len(auth.applied) != 1 || auth.applied[0] != p {
+		t.Fatalf("applied = %v, want [%v]", auth.applied, p)
+	}
+	if r.policies[routeKey(http.MethodGet, "/stream")] != p {
+		t.Errorf("policies = %v", r.policies)
+	}
+	rec := httptest.NewRecorder()
+	r.e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if rec.Code != http.StatusNoContent || rec.Header().Get("X-Policy-Applied") == "" {
+		t.Errorf("response = %d %v, want middleware applied", rec.Code, rec.Header())
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"api/src/controller"
+	"api/src/model/static"
 	"os"
 
 	"github.com/labstack/echo/v4"
@@ -18,9 +19,21 @@ func NewRouter(
 	applicant controller.IApplicantController,
 	manuscript controller.IManuscriptController,
 	role controller.IRoleController,
+	mail controller.IMailTemplateController,
+	operationLog controller.IOperationLogController,
+	notice controller.INoticeController,
+	stream controller.IStreamController,
+	analysis controller.IAnalysisController,
+	calendar controller.ICalendarController,
+	scim controller.ISCIMController,
+	job controller.IJobController,
+	auth controller.IAuthMiddleware,
 ) *echo.Echo {
 	e := echo.New()
 
+	// 接続元IPアドレス ※ロードバランサー経由のため X-Forwarded-For から取得
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+
 	// CORSミドルウェアの設定。認証情報を含むリクエストを許可
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{
@@ -33,93 +46,232 @@ func NewRouter(
 		AllowCredentials: true, // 認証情報を含むリクエストを許可
 	}))
 
+	// ルート毎に認証・認可ポリシーを宣言
+	r := newPolicyRouter(e, auth)
+
 	// ログイン
-	e.POST("/login", login.Login)
-	e.POST("/logout", login.Logout)
-	e.POST("/code_gen", login.CodeGenerate)
-	e.POST("/mfa", login.MFA)
-	e.POST("/decode", login.JWTDecode)
-	e.POST("/password_change", login.PasswordChange)
-	e.POST("/confirm_team_applicant", login.ConfirmTeamApplicant)
-	e.POST("/login_applicant", login.LoginApplicant)
-	e.POST("/mfa_applicant", login.MFAApplicant)
-	e.POST("/decode_applicant", login.JWTDecodeApplicant)
-	e.POST("/code_gen_applicant", login.CodeGenerateApplicant)
-	e.POST("/logout_applicant", login.LogoutApplicant)
+	r.POST("/login", login.Login, controller.Public())
+	r.POST("/logout", login.Logout, controller.User())
+	r.POST("/logout_all", login.LogoutAll, controller.User())
+	r.POST("/refresh", login.Refresh, controller.Public())
+	r.POST("/code_gen", login.CodeGenerate, controller.Public())
+	r.POST("/mfa", login.MFA, controller.Public())
+	r.POST("/mfa/totp_setup", login.MFATOTPSetup, controller.Public())
+	r.POST("/mfa/totp_confirm", login.MFATOTPConfirm, controller.Public())
+	r.POST("/totp", login.GetTOTP, controller.User())
+	r.POST("/totp/setup", login.TOTPSetup, controller.User())
+	r.POST("/totp/confirm", login.TOTPConfirm, controller.User())
+	r.POST("/totp/disable", login.TOTPDisable, controller.User())
+	r.POST("/totp/recovery_codes", login.RegenerateRecoveryCode, controller.User())
+	r.POST("/decode", login.JWTDecode, controller.User())
+	r.POST("/password_change", login.PasswordChange, controller.Public())
+	r.POST("/password_reset/request", login.PasswordResetRequest, controller.Public())
+	r.POST("/password_reset", login.PasswordReset, controller.Public())
+	r.POST("/sso/start", login.SSOStart, controller.Public())
+	r.POST("/sso/callback", login.SSOCallback, controller.Public())
+	r.POST("/confirm_team_applicant", login.ConfirmTeamApplicant, controller.Public())
+	r.POST("/login_applicant", login.LoginApplicant, controller.Public())
+	r.POST("/login_applicant/verify", login.MagicLinkApplicant, controller.Public())
+	r.POST("/decode_applicant", login.JWTDecodeApplicant, controller.Applicant())
+	r.POST("/logout_applicant", login.LogoutApplicant, controller.Applicant())
 
 	// 共通
-	e.GET("/health", common.HealthCheck)
-	e.POST("/sidebar", common.Sidebar)
-	e.POST("/roles", common.Roles)
-	e.POST("/change_team", common.ChangeTeam)
+	r.GET("/health", common.HealthCheck, controller.Public())
+	r.POST("/sidebar", common.Sidebar, controller.User())
+	r.POST("/roles", common.Roles, controller.User())
+	r.POST("/change_team", common.ChangeTeam, controller.User())
 
 	// ユーザー
-	e.POST("/user/search_company", user.SearchByCompany)
-	e.POST("/user/search", user.Search)
-	e.POST("/user/create", user.Create)
-	e.POST("/user/delete", user.Delete)
+	r.POST("/user/search_company", user.SearchByCompany, controller.Read(
+		controller.Admin(static.ROLE_ADMIN_USER_READ),
+		controller.Management(static.ROLE_MANAGEMENT_USER_READ),
+	))
+	r.POST("/user/search", user.Search, controller.Read(
+		controller.Admin(static.ROLE_ADMIN_USER_READ),
+		controller.Management(static.ROLE_MANAGEMENT_USER_READ),
+	))
+	r.POST("/user/create", user.Create, controller.Write(
+		controller.Admin(static.ROLE_ADMIN_USER_CREATE),
+		controller.Management(static.ROLE_MANAGEMENT_USER_CREATE),
+	))
+	r.POST("/user/delete", user.Delete, controller.Write(
+		controller.Admin(static.ROLE_ADMIN_USER_DELETE),
+		controller.Management(static.ROLE_MANAGEMENT_USER_DELETE),
+	))
+	r.POST("/user/reset_totp", user.ResetTOTP, controller.Write(
+		controller.Admin(static.ROLE_ADMIN_USER_EDIT),
+		controller.Management(static.ROLE_MANAGEMENT_USER_EDIT),
+	))
+	r.POST("/user/reissue_password", user.ReissuePassword, controller.Write(
+		controller.Admin(static.ROLE_ADMIN_USER_EDIT),
+		controller.Management(static.ROLE_MANAGEMENT_USER_EDIT),
+	))
+	r.POST("/user/unlock", user.Unlock, controller.Write(
+		controller.Admin(static.ROLE_ADMIN_USER_EDIT),
+		controller.Management(static.ROLE_MANAGEMENT_USER_EDIT),
+	))
 
 	// チーム
-	e.POST("/team/create", team.Create)
-	e.POST("/team/update", team.Update)
-	e.POST("/team/delete", team.Delete)
-	e.POST("/team/search", team.Search)
-	e.POST("/team/search_company", team.SearchByCompany)
-	e.POST("/team/get", team.Get)
+	r.POST("/team/create", team.Create, manageWrite(static.ROLE_MANAGEMENT_TEAM_CREATE))
+	r.POST("/team/update", team.Update, manageWrite(static.ROLE_MANAGEMENT_TEAM_EDIT))
+	r.POST("/team/delete", team.Delete, manageWrite(static.ROLE_MANAGEMENT_TEAM_DELETE))
+	r.POST("/team/search", team.Search, manageRead(static.ROLE_MANAGEMENT_TEAM_READ))
+	r.POST("/team/search_company", team.SearchByCompany, manageRead(static.ROLE_MANAGEMENT_TEAM_READ))
+	r.POST("/team/get", team.Get, manageRead(static.ROLE_MANAGEMENT_TEAM_DETAIL_READ))
 
 	// 予定
-	e.POST("/schedule/type", schedule.SearchScheduleType)
-	e.POST("/schedule/create", schedule.Insert)
-	e.POST("/schedule/update", schedule.Update)
-	e.POST("/schedule/search", schedule.Search)
-	e.POST("/schedule/delete", schedule.Delete)
+	r.POST("/schedule/type", schedule.SearchScheduleType, controller.Public())
+	r.POST("/schedule/create", schedule.Insert, manageWrite(static.ROLE_MANAGEMENT_SCHEDULE_CREATE))
+	r.POST("/schedule/update", schedule.Update, manageWrite(static.ROLE_MANAGEMENT_SCHEDULE_EDIT))
+	r.POST("/schedule/search", schedule.Search, manageRead(static.ROLE_MANAGEMENT_SCHEDULE_READ))
+	r.POST("/schedule/delete", schedule.Delete, manageWrite(static.ROLE_MANAGEMENT_SCHEDULE_DELETE))
+	r.POST("/schedule/save_exception", schedule.SaveException, manageWrite(static.ROLE_MANAGEMENT_SCHEDULE_EDIT))
+	r.POST("/schedule/delete_exception", schedule.DeleteException, manageWrite(static.ROLE_MANAGEMENT_SCHEDULE_EDIT))
 
 	// 企業
-	e.POST("/company/create", company.Create)
-	e.POST("/company/search", company.Search)
+	r.POST("/company/create", company.Create, controller.Write(controller.Admin(static.ROLE_ADMIN_COMPANY_CREATE)))
+	r.POST("/company/search", company.Search, controller.Read(controller.Admin(static.ROLE_ADMIN_COMPANY_READ)))
 
 	// 応募者
-	e.POST("/applicant/get_url", applicant.GetOauthURL)
-	e.POST("/applicant/download", applicant.Download)
-	e.POST("/applicant/get", applicant.Get)
-	e.POST("/applicant/search", applicant.Search)
-	e.POST("/applicant/documents", applicant.DocumentsUpload)
-	e.POST("/applicant/documents_download", applicant.DocumentDownload)
-	e.POST("/applicant/desired", applicant.InsertDesiredAt)
-	e.POST("/applicant/status", applicant.GetStatusList)
-	e.POST("/applicant/sites", applicant.GetSites)
-	e.POST("/applicant/get_google_meet_url", applicant.GetGoogleMeetUrl)
-	e.POST("/applicant/reserve_table", applicant.ReserveTable)
-	e.POST("/applicant/assign_user", applicant.AssignUser)
-	e.POST("/applicant/check_assign_user", applicant.CheckAssignableUser)
-	e.POST("/applicant/types", applicant.ListApplicantTypeByTeam)
-	e.POST("/applicant/update_type", applicant.CreateApplicantTypeAssociation)
-	e.POST("/applicant/update_status", applicant.UpdateSelectStatus)
-	e.POST("/applicant/result", applicant.InputResult)
-
-	// ロール
-	e.POST("/role/search_company", role.SearchByCompanyID)
+	r.POST("/applicant/get_url", applicant.GetOauthURL, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/download", applicant.Download, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_DOWNLOAD))
+	r.POST("/applicant/import", applicant.Import, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_DOWNLOAD))
+	r.POST("/applicant/export", applicant.Export, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_DOWNLOAD))
+	r.POST("/applicant/upload_history", applicant.SearchUploadHistory, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/rollback_upload", applicant.RollbackUpload, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_DOWNLOAD))
+	r.POST("/applicant/get", applicant.Get, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/search", applicant.Search, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/documents", applicant.DocumentsUpload, controller.Applicant())
+	r.POST("/applicant/documents_download", applicant.DocumentDownload, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/desired", applicant.InsertDesiredAt, controller.Applicant())
+	r.POST("/applicant/status", applicant.GetStatusList, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/sites", applicant.GetSites, controller.Public())
+	r.POST("/applicant/get_google_meet_url", applicant.GetGoogleMeetUrl, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/reserve_table", applicant.ReserveTable, controller.Applicant())
+	r.POST("/applicant/assign_user", applicant.AssignUser, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_ASSIGN_USER))
+	r.POST("/applicant/check_assign_user", applicant.CheckAssignableUser, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_ASSIGN_USER))
+	r.POST("/applicant/types", applicant.ListApplicantTypeByTeam, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/applicant/update_type", applicant.CreateApplicantTypeAssociation, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_SETTING_TYPE))
+	r.POST("/applicant/update_status", applicant.UpdateSelectStatus, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_SETTING_STATUS))
+	r.POST("/applicant/result", applicant.InputResult, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_SETTING_RESULT))
+
+	// ロール ※参照系も権限なしの場合は403
+	r.POST("/role/search_company", role.SearchByCompanyID, manageWrite(static.ROLE_MANAGEMENT_ROLE_READ))
+	r.POST("/role/masters", role.ListMasterRole, manageWrite(static.ROLE_MANAGEMENT_ROLE_READ))
+	r.POST("/role/get", role.Get, manageWrite(static.ROLE_MANAGEMENT_ROLE_DETAIL_READ))
+	r.POST("/role/create", role.Create, manageWrite(static.ROLE_MANAGEMENT_ROLE_CREATE))
+	r.POST("/role/update", role.Update, manageWrite(static.ROLE_MANAGEMENT_ROLE_EDIT))
+	r.POST("/role/delete", role.Delete, manageWrite(static.ROLE_MANAGEMENT_ROLE_DELETE))
+	r.POST("/role/assign", role.Assign, manageWrite(static.ROLE_MANAGEMENT_ROLE_ASSIGN))
 
 	// 原稿
-	e.POST("/manuscript/search", manuscript.Search)
-	e.POST("/manuscript/search_by_team", manuscript.SearchManuscriptByTeam)
-	e.POST("/manuscript/create", manuscript.Create)
-	e.POST("/manuscript/assign_applicant", manuscript.CreateApplicantAssociation)
-	e.POST("/manuscript/delete", manuscript.Delete)
+	r.POST("/manuscript/search", manuscript.Search, manageRead(static.ROLE_MANAGEMENT_MANUSCRIPT_READ))
+	r.POST("/manuscript/search_by_team", manuscript.SearchManuscriptByTeam, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
+	r.POST("/manuscript/create", manuscript.Create, manageWrite(static.ROLE_MANAGEMENT_MANUSCRIPT_CREATE))
+	r.POST("/manuscript/assign_applicant", manuscript.CreateApplicantAssociation, manageWrite(static.ROLE_MANAGEMENT_APPLICANT_SETTING_MANUSCRIPT))
+	r.POST("/manuscript/delete", manuscript.Delete, manageWrite(static.ROLE_MANAGEMENT_MANUSCRIPT_DELETE))
+
+	// メールテンプレート
+	r.POST("/mail/create", mail.Create, manageWrite(static.ROLE_MANAGEMENT_MAIL_CREATE))
+	r.POST("/mail/update", mail.Update, manageWrite(static.ROLE_MANAGEMENT_MAIL_EDIT))
+	r.POST("/mail/delete", mail.Delete, manageWrite(static.ROLE_MANAGEMENT_MAIL_DELETE))
+	r.POST("/mail/get", mail.Get, manageRead(static.ROLE_MANAGEMENT_MAIL_DETAIL_READ))
+	r.POST("/mail/search", mail.Search, manageRead(static.ROLE_MANAGEMENT_MAIL_READ))
+	r.POST("/mail/preview", mail.Preview, manageRead(static.ROLE_MANAGEMENT_MAIL_DETAIL_READ))
+	r.POST("/mail/send", mail.Send, manageWrite(static.ROLE_MANAGEMENT_MAIL_SEND))
+
+	// 変数
+	r.POST("/variable/create", mail.CreateVariable, manageWrite(static.ROLE_MANAGEMENT_VARIABLE_CREATE))
+	r.POST("/variable/update", mail.UpdateVariable, manageWrite(static.ROLE_MANAGEMENT_VARIABLE_EDIT))
+	r.POST("/variable/delete", mail.DeleteVariable, manageWrite(static.ROLE_MANAGEMENT_VARIABLE_DELETE))
+	r.POST("/variable/search", mail.SearchVariable, manageRead(static.ROLE_MANAGEMENT_VARIABLE_READ))
+
+	// 操作ログ
+	r.POST("/log/search", operationLog.Search, controller.Read(
+		controller.Admin(static.ROLE_ADMIN_LOG_READ),
+		controller.Management(static.ROLE_MANAGEMENT_LOG_READ),
+	))
+	r.POST("/log/get", operationLog.Get, controller.Read(
+		controller.Admin(static.ROLE_ADMIN_LOG_DETAIL_READ),
+		controller.Management(static.ROLE_MANAGEMENT_LOG_DETAIL_READ),
+	))
+	r.POST("/log/events", operationLog.ListEvent, controller.Read(
+		controller.Admin(static.ROLE_ADMIN_LOG_READ),
+		controller.Management(static.ROLE_MANAGEMENT_LOG_READ),
+	))
+
+	// ジョブ
+	r.POST("/job/runs", job.SearchRun, controller.Read(controller.Admin(static.ROLE_ADMIN_JOB_READ)))
+
+	// 分析
+	r.POST("/analysis/terms", analysis.ListTerm, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/status", analysis.Status, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/interview", analysis.Interview, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/site", analysis.Site, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/manuscript", analysis.Manuscript, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/applicant_type", analysis.ApplicantType, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/time_to_hire", analysis.TimeToHire, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+	r.POST("/analysis/interviewer", analysis.Interviewer, manageRead(static.ROLE_MANAGEMENT_ANALYSIS_READ))
+
+	// 通知
+	r.POST("/notice/search", notice.Search, controller.User())
+	r.POST("/notice/read", notice.Read, controller.User())
+	r.POST("/notice/unread_count", notice.CountUnread, controller.User())
+
+	// カレンダー(iCalendar) ※フィードはトークンで認証
+	r.POST("/calendar/token", calendar.IssueToken, controller.User())
+	r.POST("/calendar/token/delete", calendar.DeleteToken, controller.User())
+	r.GET("/calendar/feed/:token", calendar.Feed, controller.Public())
+
+	// SCIM 2.0 ※IdPからのプロビジョニング、企業毎のBearerトークンで認証
+	r.GET(static.SCIM_BASE_PATH+"/Users", scim.ListUser, controller.Public())
+	r.POST(static.SCIM_BASE_PATH+"/Users", scim.CreateUser, controller.Public())
+	r.GET(static.SCIM_BASE_PATH+"/Users/:id", scim.GetUser, controller.Public())
+	r.PUT(static.SCIM_BASE_PATH+"/Users/:id", scim.ReplaceUser, controller.Public())
+	r.PATCH(static.SCIM_BASE_PATH+"/Users/:id", scim.PatchUser, controller.Public())
+	r.DELETE(static.SCIM_BASE_PATH+"/Users/:id", scim.DeleteUser, controller.Public())
+	r.GET(static.SCIM_BASE_PATH+"/Groups", scim.ListGroup, controller.Public())
+	r.POST(static.SCIM_BASE_PATH+"/Groups", scim.CreateGroup, controller.Public())
+	r.GET(static.SCIM_BASE_PATH+"/Groups/:id", scim.GetGroup, controller.Public())
+	r.PUT(static.SCIM_BASE_PATH+"/Groups/:id", scim.ReplaceGroup, controller.Public())
+	r.PATCH(static.SCIM_BASE_PATH+"/Groups/:id", scim.PatchGroup, controller.Public())
+	r.DELETE(static.SCIM_BASE_PATH+"/Groups/:id", scim.DeleteGroup, controller.Public())
+
+	// イベント配信(Server-Sent Events)
+	r.GET("/stream", stream.Subscribe, manageRead(static.ROLE_MANAGEMENT_APPLICANT_READ))
 
 	// 設定
-	e.POST("/setting/get_team", team.GetOwn)
-	e.POST("/setting/update_team", team.UpdateBasic)
-	e.POST("/setting/team", user.UpdateStatus)
-	e.POST("/setting/status_events", user.ListStatusEvent)
-	e.POST("/setting/status_events_of_team", team.StatusEvents)
-	e.POST("/setting/assign_masters", user.AssignMaster)
-	e.POST("/setting/processing_list", team.ListInterviewProcessing)
-	e.POST("/setting/update_assign", user.UpdateAssignMethod)
-	e.POST("/setting/document_rules", user.DocumentRuleMaster)
-	e.POST("/setting/occupations", user.OccupationMaster)
-	e.POST("/setting/create_applicant_type", applicant.CreateApplicantType)
-	e.POST("/setting/applicant_types", applicant.ListApplicantType)
+	r.POST("/setting/get_security", company.GetSecurity, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/update_security", company.UpdateSecurity, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/get_sso", company.GetOIDC, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/update_sso", company.UpdateOIDC, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/get_scim", scim.GetToken, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/issue_scim_token", scim.IssueToken, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/delete_scim_token", scim.DeleteToken, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/holidays", company.ListHoliday, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/save_holiday", company.SaveHoliday, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/delete_holiday", company.DeleteHoliday, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/import_holiday", company.ImportHoliday, manageWrite(static.ROLE_MANAGEMENT_SETTING_COMPANY))
+	r.POST("/setting/get_team", team.GetOwn, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/update_team", team.UpdateBasic, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/get_booking_window", team.GetBookingWindow, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/update_booking_window", team.UpdateBookingWindow, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/delete_booking_window", team.DeleteBookingWindow, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/get_weekday", team.GetWeekday, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/update_weekday", team.UpdateWeekday, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/team", user.UpdateStatus, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/status_events", user.ListStatusEvent, controller.Public())
+	r.POST("/setting/status_events_of_team", team.StatusEvents, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/assign_masters", user.AssignMaster, controller.Public())
+	r.POST("/setting/processing_list", team.ListInterviewProcessing, controller.Public())
+	r.POST("/setting/update_assign", user.UpdateAssignMethod, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/document_rules", user.DocumentRuleMaster, controller.Public())
+	r.POST("/setting/occupations", user.OccupationMaster, controller.Public())
+	r.POST("/setting/create_applicant_type", applicant.CreateApplicantType, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+	r.POST("/setting/applicant_types", applicant.ListApplicantType, manageWrite(static.ROLE_MANAGEMENT_SETTING_TEAM))
+
+	// ポリシー未宣言のルートがないか確認
+	r.verify()
 
 	return e
 }
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type IAnalysisService interface {
+	// 分析項目一覧
+	ListTerm(req *request.ListAnalysisTerm) (*response.ListAnalysisTerm, *response.Error)
+	// 選考ステータス別
+	Status(req *request.Analysis) (*response.AnalysisStatus, *response.Error)
+	// 面接回数別
+	Interview(req *request.Analysis) (*response.AnalysisInterview, *response.Error)
+	// 媒体別
+	Site(req *request.Analysis) (*response.AnalysisPassRate, *response.Error)
+	// 原稿別
+	Manuscript(req *request.Analysis) (*response.AnalysisPassRate, *response.Error)
+	// 応募者種別別
+	ApplicantType(req *request.Analysis) (*response.AnalysisPassRate, *response.Error)
+	// 採用までの日数
+	TimeToHire(req *request.Analysis) (*response.AnalysisTimeToHire, *response.Error)
+	// 面接官負荷
+	Interviewer(req *request.Analysis) (*response.AnalysisInterviewer, *response.Error)
+}
+
+type AnalysisService struct {
+	r      repository.IAnalysisRepository
+	master repository.IMasterRepository
+	t      repository.ITeamRepository
+	redis  repository.IRedisRepository
+	v      validator.IAnalysisValidator
+}
+
+func NewAnalysisService(
+	r repository.IAnalysisRepository,
+	master repository.IMasterRepository,
+	t repository.ITeamRepository,
+	redis repository.IRedisRepository,
+	v validator.IAnalysisValidator,
+) IAnalysisService {
+	return &AnalysisService{r, master, t, redis, v}
+}
+
+// 分析項目一覧
+func (s *AnalysisService) ListTerm(req *request.ListAnalysisTerm) (*response.ListAnalysisTerm, *response.Error) {
+	terms, err := s.master.ListAnalysisTerm()
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.AnalysisTerm{}
+	for _, term := range terms {
+		term.ID = 0
+		res = append(res, term)
+	}
+
+	return &response.ListAnalysisTerm{
+		List: res,
+	}, nil
+}
+
+// 選考ステータス別
+func (s *AnalysisService) Status(req *request.Analysis) (*response.AnalysisStatus, *response.Error) {
+	m, mErr := s.scope(req)
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	list, err := s.r.Status(m)
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	var total int64
+	for _, row := range list {
+		total += row.Num
+	}
+	res := []entity.AnalysisStatus{}
+	for _, row := range list {
+		row.Rate = rate(row.Num, total)
+		res = append(res, row)
+	}
+
+	return &response.AnalysisStatus{
+		List:  res,
+		Total: total,
+	}, nil
+}
+
+// 面接回数別
+func (s *AnalysisService) Interview(req *request.Analysis) (*response.AnalysisInterview, *response.Error) {
+	m, mErr := s.scope(req)
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	res := []entity.AnalysisInterview{}
+	for i := uint(1); i <= m.NumOfInterview; i++ {
+		row, err := s.r.Interview(m, i)
+		if err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		row.PassRate = rate(row.Passed, row.Passed+row.Failed)
+		res = append(res, *row)
+	}
+
+	return &response.AnalysisInterview{
+		List: res,
+	}, nil
+}
+
+// 媒体別
+func (s *AnalysisService) Site(req *request.Analysis) (*response.AnalysisPassRate, *response.Error) {
+	return s.passRate(req, s.r.Site)
+}
+
+// 原稿別
+func (s *AnalysisService) Manuscript(req *request.Analysis) (*response.AnalysisPassRate, *response.Error) {
+	return s.passRate(req, s.r.Manuscript)
+}
+
+// 応募者種別別
+func (s *AnalysisService) ApplicantType(req *request.Analysis) (*response.AnalysisPassRate, *response.Error) {
+	return s.passRate(req, s.r.ApplicantType)
+}
+
+// 採用までの日数
+func (s *AnalysisService) TimeToHire(req *request.Analysis) (*response.AnalysisTimeToHire, *response.Error) {
+	m, mErr := s.scope(req)
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	res, err := s.r.TimeToHire(m)
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.AnalysisTimeToHire{
+		AnalysisTimeToHire: *res,
+	}, nil
+}
+
+// 面接官負荷
+func (s *AnalysisService) Interviewer(req *request.Analysis) (*response.AnalysisInterviewer, *response.Error) {
+	m, mErr := s.scope(req)
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	list, err := s.r.Interviewer(m)
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.AnalysisInterviewer{}
+	res = append(res, list...)
+
+	return &response.AnalysisInterviewer{
+		List: res,
+	}, nil
+}
+
+// 採用率集計
+func (s *AnalysisService) passRate(
+	req *request.Analysis,
+	f func(m *dto.Analysis) ([]entity.AnalysisPassRate, error),
+) (*response.AnalysisPassRate, *response.Error) {
+	m, mErr := s.scope(req)
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	list, err := f(m)
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.AnalysisPassRate{}
+	for _, row := range list {
+		row.HireRate = rate(row.Hired, row.Total)
+		res = append(res, row)
+	}
+
+	return &response.AnalysisPassRate{
+		List: res,
+	}, nil
+}
+
+// 集計対象取得 ※ログインユーザーのチーム、企業
+func (s *AnalysisService) scope(req *request.Analysis) (*dto.Analysis, *response.Error) {
+	// バリデーション
+	if err := s.v.Analysis(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// チーム、企業取得
+	ctx := context.Background()
+	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*team, 10, 64)
+	if teamIDErr != nil {
+		log.Printf("%v", teamIDErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	company, companyErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_COMPANY_ID)
+	if companyErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID, companyIDErr := strconv.ParseUint(*company, 10, 64)
+	if companyIDErr != nil {
+		log.Printf("%v", companyIDErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 最大面接回数取得
+	teamRow, teamRowErr := s.t.GetByPrimary(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: teamID,
+		},
+	})
+	if teamRowErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &dto.Analysis{
+		Analysis:       *req,
+		CompanyID:      companyID,
+		TeamID:         teamID,
+		NumOfInterview: teamRow.NumOfInterview,
+	}, nil
+}
+
+// 割合 ※母数0の場合は0
+func rate(num int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(num) / float64(total)
+}
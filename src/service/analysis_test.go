@@ -0,0 +1,155 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// 集計結果の固定返却 ※集計条件を記録
+type mockAnalysisRepo struct {
+	repository.IAnalysisRepository
+	scopes     []dto.Analysis
+	interviews map[uint]entity.AnalysisInterview
+	sites      []entity.AnalysisPassRate
+}
+
+func (r *mockAnalysisRepo) Interview(m *dto.Analysis, numOfInterview uint) (*entity.AnalysisInterview, error) {
+	r.scopes = append(r.scopes, *m)
+	row := r.interviews[numOfInterview]
+	row.NumOfInterview = numOfInterview
+	return &row, nil
+}
+
+func (r *mockAnalysisRepo) Site(m *dto.Analysis) ([]entity.AnalysisPassRate, error) {
+	r.scopes = append(r.scopes, *m)
+	return r.sites, nil
+}
+
+type mockAnalysisTeamRepo struct {
+	repository.ITeamRepository
+	numOfInterview uint
+}
+
+func (r *mockAnalysisTeamRepo) GetByPrimary(m *ddl.Team) (*entity.Team, error) {
+	return &entity.Team{Team: ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{ID: m.ID},
+		NumOfInterview:           r.numOfInterview,
+	}}, nil
+}
+
+func newAnalysisTestService(r *mockAnalysisRepo, numOfInterview uint) *AnalysisService {
+	redis := newMemoryRedis()
+	for key, value := range map[string]string{
+		static.REDIS_USER_TEAM_ID:    "2",
+		static.REDIS_USER_COMPANY_ID: "1",
+	} {
+		value := value
+		_ = redis.Set(context.Background(), "user_1", key, &value, 0)
+	}
+	return &AnalysisService{
+		r:     r,
+		t:     &mockAnalysisTeamRepo{numOfInterview: numOfInterview},
+		redis: redis,
+		v:     validator.NewAnalysisValidator(),
+	}
+}
+
+func TestAnalysisService_Site(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     request.Analysis
+		sites   []entity.AnalysisPassRate
+		want    []float64
+		wantErr *response.Error
+	}{
+		// ok 採用率 ※応募者数に対する採用数
+		{"ok", request.Analysis{}, []entity.AnalysisPassRate{
+			{HashKey: "a", Total: 4, Hired: 1, Failed: 2},
+			{HashKey: "b", Total: 2, Hired: 2},
+		}, []float64{0.25, 1}, nil},
+		// ok 応募者なし ※0除算しない
+		{"ok_zero", request.Analysis{}, []entity.AnalysisPassRate{
+			{HashKey: "a"},
+		}, []float64{0}, nil},
+		// ok 集計対象なし ※空配列
+		{"ok_empty", request.Analysis{}, nil, []float64{}, nil},
+		// ng 期間の前後逆転
+		{"ng_term", request.Analysis{
+			CreatedAtFrom: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAtTo:   time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		}, nil, nil, &response.Error{Status: http.StatusBadRequest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &mockAnalysisRepo{sites: tt.sites}
+			s := newAnalysisTestService(r, 3)
+			tt.req.UserHashKey = "user_1"
+
+			res, err := s.Site(&tt.req)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Site() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := []float64{}
+			for _, row := range res.List {
+				got = append(got, row.HireRate)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Site() HireRate = %v, want %v", got, tt.want)
+			}
+			// ログインユーザーのチーム・企業、チームの最大面接回数で集計
+			if len(r.scopes) != 1 || r.scopes[0].TeamID != 2 || r.scopes[0].CompanyID != 1 || r.scopes[0].NumOfInterview != 3 {
+				t.Errorf("scopes = %+v", r.scopes)
+			}
+		})
+	}
+}
+
+func TestAnalysisService_Interview(t *testing.T) {
+	tests := []struct {
+		name           string
+		numOfInterview uint
+		interviews     map[uint]entity.AnalysisInterview
+		want           []entity.AnalysisInterview
+	}{
+		// ok 面接回数毎 ※通過率は結果入力済み(通過・不通過)に対する割合
+		{"ok", 2, map[uint]entity.AnalysisInterview{
+			1: {Reached: 5, Passed: 3, Failed: 1, InProgress: 1},
+			2: {Reached: 3, Passed: 0, Failed: 0, InProgress: 3},
+		}, []entity.AnalysisInterview{
+			{NumOfInterview: 1, Reached: 5, Passed: 3, Failed: 1, InProgress: 1, PassRate: 0.75},
+			{NumOfInterview: 2, Reached: 3, InProgress: 3, PassRate: 0},
+		}},
+		// ok 面接なし
+		{"ok_none", 0, nil, []entity.AnalysisInterview{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &mockAnalysisRepo{interviews: tt.interviews}
+			s := newAnalysisTestService(r, tt.numOfInterview)
+
+			res, err := s.Interview(&request.Analysis{
+				Abstract: request.Abstract{UserHashKey: "user_1"},
+			})
+			if err != nil {
+				t.Fatalf("Interview() error = %+v", err)
+			}
+			if !reflect.DeepEqual(res.List, tt.want) {
+				t.Errorf("Interview() = %+v, want %+v", res.List, tt.want)
+			}
+		})
+	}
+}
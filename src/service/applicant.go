@@ -10,17 +10,24 @@ import (
 	"api/src/repository"
 	"api/src/validator"
 	"context"
+	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"mime/multipart"
 	"net/http"
 	"strconv"
 	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
 )
 
 type IApplicantService interface {
 	// 検索
 	Search(req *request.SearchApplicant) (*response.SearchApplicant, *response.Error)
+	// エクスポート ※openで出力先を取得し、全件を書き込む
+	Export(req *request.ExportApplicant, open func(fileName string, contentType string) (io.Writer, error)) *response.Error
 	// 取得
 	Get(req *request.GetApplicant) (*response.GetApplicant, *response.Error)
 	// サイト一覧取得
@@ -29,6 +36,12 @@ type IApplicantService interface {
 	GetStatusList(req *request.ApplicantStatusList) (*response.ApplicantStatusList, *response.Error)
 	// 応募者ダウンロード
 	Download(req *request.ApplicantDownload) (*response.ApplicantDownload, *response.Error)
+	// 応募者取込(CSV/TSV)
+	Import(req *request.ApplicantImport, fileHeader *multipart.FileHeader) (*response.ApplicantImport, *response.Error)
+	// 応募者アップロード履歴
+	SearchUploadHistory(req *request.SearchUploadHistory) (*response.SearchUploadHistory, *response.Error)
+	// 応募者取込取消
+	RollbackUpload(req *request.RollbackUpload) (*response.RollbackUpload, *response.Error)
 	// 予約表表示
 	ReserveTable(req *request.ReserveTable) (*response.ReserveTable, *response.Error)
 	// 書類アップロード(S3)
@@ -71,7 +84,11 @@ type ApplicantService struct {
 	redis repository.IRedisRepository
 	v     validator.IApplicantValidator
 	d     repository.IDBRepository
-	o     repository.IOuterIFRepository
+	hd    repository.IHolidayRepository
+	ol    repository.IOperationLogRepository
+	h     repository.IUploadHistoryRepository
+	n     repository.INoticeRepository
+	mail  repository.IMailRepository
 }
 
 func NewApplicantService(
@@ -86,9 +103,13 @@ func NewApplicantService(
 	redis repository.IRedisRepository,
 	v validator.IApplicantValidator,
 	d repository.IDBRepository,
-	o repository.IOuterIFRepository,
+	hd repository.IHolidayRepository,
+	ol repository.IOperationLogRepository,
+	h repository.IUploadHistoryRepository,
+	n repository.INoticeRepository,
+	mail repository.IMailRepository,
 ) IApplicantService {
-	return &ApplicantService{r, u, t, s, manu, m, a, g, redis, v, d, o}
+	return &ApplicantService{r, u, t, s, manu, m, a, g, redis, v, d, hd, ol, h, n, mail}
 }
 
 // 検索
@@ -166,6 +187,137 @@ func (s *ApplicantService) Search(req *request.SearchApplicant) (*response.Searc
 	}, nil
 }
 
+// エクスポート ※openで出力先を取得し、全件を書き込む
+func (s *ApplicantService) Export(req *request.ExportApplicant, open func(fileName string, contentType string) (io.Writer, error)) *response.Error {
+	// バリデーション
+	if err := s.v.Export(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// Redisから取得
+	ctx := context.Background()
+	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*team, 10, 64)
+	if teamIDErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	req.TeamID = teamID
+
+	company, companyErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_COMPANY_ID)
+	if companyErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID, companyParseErr := strconv.ParseUint(*company, 10, 64)
+	if companyParseErr != nil {
+		log.Printf("%v", companyParseErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	req.CompanyID = companyID
+
+	// 日時の出力タイムゾーン ※チーム既定の面接予約枠設定
+	window, windowErr := getBookingWindow(s.t, teamID, 0)
+	if windowErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 出力先
+	fileName := "applicant_" + time.Now().Format("20060102150405") + "." + req.Format
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == static.EXPORT_FORMAT_XLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w, openErr := open(fileName, contentType)
+	if openErr != nil {
+		log.Printf("%v", openErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	var writer exportWriter
+	var writerErr error
+	if req.Format == static.EXPORT_FORMAT_XLSX {
+		writer, writerErr = newXLSXExportWriter(w)
+	} else {
+		writer, writerErr = newCSVExportWriter(w)
+	}
+	if writerErr != nil {
+		log.Printf("%v", writerErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ヘッダー
+	if err := writer.Write([]string{
+		"媒体側ID",
+		"氏名",
+		"メールアドレス",
+		"TEL",
+		"年齢",
+		"サイト",
+		"ステータス",
+		"面接回数",
+		"書類選考",
+		"種別",
+		"原稿",
+		"面接予定日時",
+		"Google Meet URL",
+		"面接官",
+		"履歴書",
+		"職務経歴書",
+		"登録日時",
+	}); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 全件出力
+	if err := s.r.SearchAll(&dto.SearchApplicant{
+		SearchApplicant: req.SearchApplicant,
+		Users:           req.Users,
+	}, static.EXPORT_BATCH_SIZE, func(applicants []*entity.SearchApplicant) error {
+		for _, applicant := range applicants {
+			if err := writer.Write(exportApplicantRow(applicant, window.Location)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
 // 取得
 func (s *ApplicantService) Get(req *request.GetApplicant) (*response.GetApplicant, *response.Error) {
 	// バリデーション
@@ -293,6 +445,151 @@ func (s *ApplicantService) Download(req *request.ApplicantDownload) (*response.A
 		}
 	}
 
+	// サイトID取得
+	site, siteErr := s.m.SelectSite(&ddl.Site{
+		AbstractMasterModel: ddl.AbstractMasterModel{
+			HashKey: req.SiteHashKey,
+		},
+	})
+	if siteErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// アップロード内容
+	csv, csvErr := encodeDownloadCSV(req.Applicants)
+	if csvErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return s.insertApplicants(req, site, &dto.UploadHistory{
+		CSV:      csv,
+		TotalNum: len(req.Applicants),
+	})
+}
+
+// 応募者取込(CSV/TSV)
+func (s *ApplicantService) Import(req *request.ApplicantImport, fileHeader *multipart.FileHeader) (*response.ApplicantImport, *response.Error) {
+	if fileHeader.Size > static.IMPORT_MAX_FILE_SIZE {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_APPLICANT_IMPORT_TOO_LARGE,
+		}
+	}
+
+	// 媒体判定
+	sites, sitesErr := s.m.ListSite()
+	if sitesErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	site := detectImportSite(sites, fileHeader.Filename)
+	if site == nil {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_APPLICANT_IMPORT_UNKNOWN_SITE,
+		}
+	}
+
+	// ファイル読み込み
+	file, openErr := fileHeader.Open()
+	if openErr != nil {
+		log.Printf("%v", openErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	defer file.Close()
+	data, readErr := io.ReadAll(file)
+	if readErr != nil {
+		log.Printf("%v", readErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	decoded, decodeErr := decodeImportFile(data)
+	if decodeErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_APPLICANT_IMPORT_INVALID_FORMAT,
+		}
+	}
+	records, parseErr := parseImportFile(fileHeader.Filename, decoded)
+	if parseErr != nil || len(records) < static.IMPORT_HEADER_ROWS ||
+		len(records[0]) < int(site.NumOfColumn) {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_APPLICANT_IMPORT_INVALID_FORMAT,
+		}
+	}
+
+	// 行毎に変換・バリデーション
+	res := response.ApplicantImport{
+		SiteHashKey: site.HashKey,
+		SiteName:    site.SiteName,
+		Total:       len(records) - static.IMPORT_HEADER_ROWS,
+		Errors:      []entity.ApplicantImportError{},
+	}
+	var applicants []request.ApplicantDownloadSub
+	outerIDs := map[string]bool{}
+	for i, record := range records[static.IMPORT_HEADER_ROWS:] {
+		row := convertImportRow(site, record)
+
+		errs := map[string]string{}
+		if err := s.v.DownloadSub(&row); err != nil {
+			if fieldErrs, ok := err.(validation.Errors); ok {
+				for field, fieldErr := range fieldErrs {
+					errs[field] = fieldErr.Error()
+				}
+			} else {
+				errs["row"] = err.Error()
+			}
+		}
+		if row.OuterID != "" && outerIDs[row.OuterID] {
+			errs["outer_id"] = "duplicated in file"
+		}
+		outerIDs[row.OuterID] = true
+
+		if len(errs) > 0 {
+			res.Errors = append(res.Errors, entity.ApplicantImportError{
+				Row:     i + static.IMPORT_HEADER_ROWS + 1,
+				OuterID: row.OuterID,
+				Errors:  errs,
+			})
+			continue
+		}
+		applicants = append(applicants, row)
+	}
+
+	// 一括登録
+	if len(applicants) > 0 {
+		download, err := s.insertApplicants(&request.ApplicantDownload{
+			Abstract:    req.Abstract,
+			SiteHashKey: site.HashKey,
+			Applicants:  applicants,
+		}, site, &dto.UploadHistory{
+			FileName: fileHeader.Filename,
+			CSV:      string(decoded),
+			TotalNum: res.Total,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res.UpdateNum = download.UpdateNum
+		res.DuplNum = len(applicants) - download.UpdateNum
+		res.CommitID = download.CommitID
+	}
+
+	return &res, nil
+}
+
+// 応募者一括登録 ※媒体側IDが重複する応募者は除外し、アップロード履歴を記録
+func (s *ApplicantService) insertApplicants(req *request.ApplicantDownload, site *entity.Site, history *dto.UploadHistory) (*response.ApplicantDownload, *response.Error) {
 	// チーム、企業取得
 	ctx := context.Background()
 	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
@@ -320,24 +617,25 @@ func (s *ApplicantService) Download(req *request.ApplicantDownload) (*response.A
 			Status: http.StatusInternalServerError,
 		}
 	}
-
-	// ステータス取得
-	list, listErr := s.r.ListStatus(&ddl.SelectStatus{
-		TeamID: teamID,
-	})
-	if listErr != nil {
+	user, userErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_ID)
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	userID, userParseErr := strconv.ParseUint(*user, 10, 64)
+	if userParseErr != nil {
+		log.Printf("%v", userParseErr)
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// サイトID取得
-	site, siteErr := s.m.SelectSite(&ddl.Site{
-		AbstractMasterModel: ddl.AbstractMasterModel{
-			HashKey: req.SiteHashKey,
-		},
+	// ステータス取得
+	list, listErr := s.r.ListStatus(&ddl.SelectStatus{
+		TeamID: teamID,
 	})
-	if siteErr != nil {
+	if listErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
@@ -356,7 +654,8 @@ func (s *ApplicantService) Download(req *request.ApplicantDownload) (*response.A
 
 	for i := range req.Applicants {
 		for _, row2 := range manuscripts {
-			if row2.HashKey == req.Applicants[i].ManuscriptHash {
+			if row2.HashKey == req.Applicants[i].ManuscriptHash ||
+				(req.Applicants[i].Manuscript != "" && row2.Content == req.Applicants[i].Manuscript) {
 				req.Applicants[i].ManuscriptID = row2.ID
 				break
 			}
@@ -414,6 +713,7 @@ func (s *ApplicantService) Download(req *request.ApplicantDownload) (*response.A
 	// 一括登録
 	var applicants []*ddl.Applicant
 	var applicants2 []*dto.ApplicantManuscriptAssociation
+	var inserted []entity.Applicant
 	var applicantManuscriptAssociations []*ddl.ManuscriptApplicantAssociation
 	if len(request.Applicants) > 0 {
 		for _, row := range request.Applicants {
@@ -473,6 +773,7 @@ func (s *ApplicantService) Download(req *request.ApplicantDownload) (*response.A
 				Status: http.StatusInternalServerError,
 			}
 		}
+		inserted = entities
 
 		for _, row := range applicants2 {
 			for _, row2 := range entities {
@@ -500,45 +801,352 @@ func (s *ApplicantService) Download(req *request.ApplicantDownload) (*response.A
 		}
 	}
 
+	// アップロード履歴登録
+	_, historyHash, historyHashErr := GenerateHash(1, 25)
+	if historyHashErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if err := s.h.Insert(tx, &ddl.HistoryOfUploadApplicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   static.PRE_UPLOAD_HISTORY + "_" + *historyHash,
+			CompanyID: companyID,
+		},
+		CommitID:   *commitID,
+		TeamID:     teamID,
+		UserID:     userID,
+		SiteID:     site.ID,
+		FileName:   history.FileName,
+		CSV:        history.CSV,
+		TotalNum:   uint(history.TotalNum),
+		InsertNum:  uint(len(request.Applicants)),
+		DuplNum:    uint(len(req.Applicants) - len(request.Applicants)),
+		InitStatus: list[0].ID,
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_DOWNLOAD,
+		Target:      *commitID,
+		Detail:      map[string]interface{}{"site": site.HashKey, "num": len(req.Applicants), "inserted": len(request.Applicants)},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
+	// イベント配信
+	publishTeamEvent(s.redis, teamID, &response.StreamEvent{
+		Type:   static.STREAM_EVENT_APPLICANT_CREATE,
+		Target: *commitID,
+		Num:    len(request.Applicants),
+	})
+
+	// 招待リンク送信
+	s.sendInvitations(teamID, inserted)
+
 	return &response.ApplicantDownload{
 		UpdateNum: len(request.Applicants),
+		CommitID:  *commitID,
 	}, nil
 }
 
-// 予約表表示 ※TODO 要方針決定
-func (s *ApplicantService) ReserveTable(req *request.ReserveTable) (*response.ReserveTable, *response.Error) {
-	const WEEKS = 7
-	const RESERVE_DURATION = 2 * WEEKS
-	var schedules []entity.Schedule
+// 応募者アップロード履歴
+func (s *ApplicantService) SearchUploadHistory(req *request.SearchUploadHistory) (*response.SearchUploadHistory, *response.Error) {
+	// チーム取得
+	ctx := context.Background()
+	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*team, 10, 64)
+	if teamIDErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
 
+	histories, err := s.h.Search(&ddl.HistoryOfUploadApplicant{
+		TeamID: teamID,
+	})
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.UploadHistory{}
+	for _, row := range histories {
+		row.ID = 0
+		res = append(res, row)
+	}
+
+	return &response.SearchUploadHistory{
+		List: res,
+	}, nil
+}
+
+// 応募者取込取消 ※選考が進んでいる応募者がいない場合のみ
+func (s *ApplicantService) RollbackUpload(req *request.RollbackUpload) (*response.RollbackUpload, *response.Error) {
 	// バリデーション
-	if err := s.v.ReserveTable(req); err != nil {
+	if err := s.v.RollbackUpload(req); err != nil {
 		log.Printf("%v", err)
 		return nil, &response.Error{
 			Status: http.StatusBadRequest,
 		}
 	}
 
-	// TZをAsia/Tokyoに
-	jst, jstErr := time.LoadLocation("Asia/Tokyo")
-	if jstErr != nil {
-		log.Printf("%v", jstErr)
+	// チーム取得
+	ctx := context.Background()
+	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*team, 10, 64)
+	if teamIDErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// 日本の休日取得
-	holidays, hErr := s.o.HolidaysJp(time.Now().Year())
-	if hErr != nil {
+	tx, txErr := s.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 履歴取得 ※行ロックにより同時の取消を待たせ、取消済みを確実に判定
+	history, historyErr := s.h.Get(tx, &ddl.HistoryOfUploadApplicant{
+		CommitID: req.CommitID,
+		TeamID:   teamID,
+	})
+	if historyErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		if historyErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusNotFound,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if history.RollbackFlg == static.ON {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_APPLICANT_ROLLBACK_ALREADY,
+		}
+	}
+
+	// 削除対象取得 ※行ロックにより確認後の予約・割り振りを待たせる
+	ids, idsErr := s.r.GetIDsByCommitID(tx, &ddl.Applicant{
+		CommitID: history.CommitID,
+		TeamID:   teamID,
+	})
+	if idsErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 選考が進んでいる応募者の確認
+	count, countErr := s.r.CountProgressedByCommitID(tx, &dto.CheckRollbackUpload{
+		CommitID:   history.CommitID,
+		TeamID:     teamID,
+		InitStatus: history.InitStatus,
+	})
+	if countErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_APPLICANT_ROLLBACK_PROGRESSED,
+		}
+	}
+
+	if len(ids) > 0 {
+		// 原稿紐づけ削除
+		if err := s.manu.DeleteApplicantAssociation(tx, ids); err != nil {
+			if err := s.d.TxRollback(tx); err != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+
+		// 種別紐づけ削除
+		if err := s.r.DeleteTypeAssociation(tx, ids); err != nil {
+			if err := s.d.TxRollback(tx); err != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+
+		// お知らせ削除
+		if err := s.n.DeleteByApplicant(tx, ids); err != nil {
+			if err := s.d.TxRollback(tx); err != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+
+		// 操作ログの応募者切り離し ※ログ自体は残す
+		if err := s.ol.DetachApplicant(tx, ids); err != nil {
+			if err := s.d.TxRollback(tx); err != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+
+		// 応募者削除
+		if err := s.r.DeletesByPrimary(tx, ids); err != nil {
+			if err := s.d.TxRollback(tx); err != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+	}
+
+	// 取消フラグ更新
+	if err := s.h.Rollback(tx, &ddl.HistoryOfUploadApplicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: history.ID,
+		},
+		RollbackFlg: static.ON,
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      teamID,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_ROLLBACK_UPLOAD,
+		Target:      history.CommitID,
+		Detail:      map[string]interface{}{"num": len(ids)},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// イベント配信
+	publishTeamEvent(s.redis, teamID, &response.StreamEvent{
+		Type:   static.STREAM_EVENT_APPLICANT_DELETE,
+		Target: history.CommitID,
+		Num:    len(ids),
+	})
+
+	return &response.RollbackUpload{
+		DeleteNum: len(ids),
+	}, nil
+}
+
+// 予約表表示 ※予約枠はチーム・面接回数毎の設定に従う
+func (s *ApplicantService) ReserveTable(req *request.ReserveTable) (*response.ReserveTable, *response.Error) {
+	var schedules []entity.Schedule
+
+	// バリデーション
+	if err := s.v.ReserveTable(req); err != nil {
+		log.Printf("%v", err)
 		return nil, &response.Error{
-			Status: http.StatusInternalServerError,
+			Status: http.StatusBadRequest,
 		}
 	}
 
@@ -565,6 +1173,24 @@ func (s *ApplicantService) ReserveTable(req *request.ReserveTable) (*response.Re
 		}
 	}
 
+	// 面接予約枠取得
+	window, windowErr := getBookingWindow(s.t, applicant.TeamID, applicant.NumOfInterview)
+	if windowErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 受付期間の休業日取得
+	now := time.Now()
+	times := bookingDays(window, now)
+	holidays, hErr := getClosedDays(s.hd, s.t, applicant.CompanyID, applicant.TeamID, times[0], times[len(times)-1])
+	if hErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// 面接参加可能者取得
 	models, modelsErr := s.t.GetAssignPossibleSchedule(&ddl.TeamAssignPossible{
 		TeamID:         applicant.TeamID,
@@ -594,155 +1220,73 @@ func (s *ApplicantService) ReserveTable(req *request.ReserveTable) (*response.Re
 		res.End = applicantSchedule.End
 	}
 
-	// 整形
+	// 整形 ※繰り返し予定は受付期間(前後の間隔を含む)の各回に展開
+	from := times[0].Add(-window.Buffer)
+	to := times[len(times)-1].AddDate(0, 0, 1).Add(window.Buffer)
+	locations := make(map[uint64]*time.Location)
 	for _, model := range models {
-		// TZを日本に
-		var schedulesJST []entity.Schedule
 		for _, row := range model.Schedules {
-			row.Start = row.Start.In(jst)
-			row.End = row.End.In(jst)
-			schedulesJST = append(schedulesJST, *row)
-		}
-
-		// スケジュールの頻度が「毎日」と「毎週」の場合、コピー
-		start := time.Now().AddDate(0, 0, WEEKS).In(jst)
-		s := time.Date(
-			start.Year(),
-			start.Month(),
-			start.Day(),
-			0,
-			0,
-			0,
-			0,
-			jst,
-		)
-		for _, row := range schedulesJST {
-			if row.FreqID == uint(static.FREQ_NONE) || row.FreqID == uint(static.FREQ_MONTHLY) || row.FreqID == uint(static.FREQ_YEARLY) {
-				schedules = append(schedules, row)
-				continue
-			}
-
-			for i := 0; i < RESERVE_DURATION; i++ {
-				s_0 := time.Date(
-					s.AddDate(0, 0, i).Year(),
-					s.AddDate(0, 0, i).Month(),
-					s.AddDate(0, 0, i).Day(),
-					row.Start.Hour(),
-					row.Start.Minute(),
-					row.Start.Second(),
-					row.Start.Nanosecond(),
-					jst,
-				)
-				e_0 := time.Date(
-					s.AddDate(0, 0, i).Year(),
-					s.AddDate(0, 0, i).Month(),
-					s.AddDate(0, 0, i).Day(),
-					row.End.Hour(),
-					row.End.Minute(),
-					row.End.Second(),
-					row.End.Nanosecond(),
-					jst,
-				)
-				if row.FreqID == uint(static.FREQ_DAILY) || (row.FreqID == uint(static.FREQ_WEEKLY) && s_0.Weekday() == row.Start.Weekday()) {
-					row.Start = s_0
-					row.End = e_0
-					schedules = append(schedules, row)
+			loc, locErr := scheduleLocation(s.t, row.TeamID, locations)
+			if locErr != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
 				}
 			}
+			for _, occurrence := range expandSchedule(&row.Schedule, row.Exceptions, from, to, loc) {
+				schedule := *row
+				schedule.Start = occurrence.Start.In(window.Location)
+				schedule.End = occurrence.End.In(window.Location)
+				schedules = append(schedules, schedule)
+			}
 		}
 	}
 
-	// 各日チェック
-	start := time.Now().AddDate(0, 0, WEEKS).In(jst)
-	var times []time.Time
+	var users []string
+	for _, model := range models {
+		users = append(users, model.UserHashKey)
+	}
+
+	// 各枠チェック
 	var reserveTime []dto.ReserveTableSub
-	for i := 0; i < RESERVE_DURATION; i++ {
-		isReserve := true
-
-		s_0 := time.Date(
-			start.AddDate(0, 0, i).Year(),
-			start.AddDate(0, 0, i).Month(),
-			start.AddDate(0, 0, i).Day(),
-			0,
-			0,
-			0,
-			0,
-			jst,
-		)
-
-		times = append(times, s_0)
-
-		for _, holiday := range holidays {
-			y1, m1, d1 := s_0.Date()
-			y2, m2, d2 := holiday.Date()
-			if y1 == y2 && m1 == m2 && d1 == d2 {
-				isReserve = false
-				break
-			}
+	for _, slot := range bookingSlots(window, now, holidays) {
+		if !slot.Open {
+			reserveTime = append(reserveTime, dto.ReserveTableSub{
+				Time:      slot.Start,
+				IsReserve: false,
+			})
+			continue
 		}
 
-		for d := s_0.Add(9 * time.Hour); d.Day() == s_0.Day() && d.Hour() <= 20; d = d.Add(30 * time.Minute) {
-			if !isReserve {
-				reserveTime = append(reserveTime, dto.ReserveTableSub{
-					Time:      d,
-					IsReserve: false,
-				})
-			} else {
-				var tempUsers []string
-				for _, schedule := range schedules {
-					if res.ID == schedule.ID {
-						continue
-					}
-
-					// 時刻が対象範囲の場合
-					if d.After(schedule.Start.Add(-1*time.Minute)) && d.Before(schedule.End.Add(1*time.Minute)) {
-						for _, user := range schedule.Users {
-							tempUsers = append(tempUsers, user.HashKey)
-						}
-					}
-				}
-
-				var users []string
-				for _, model := range models {
-					users = append(users, model.UserHashKey)
-				}
-
-				seen := make(map[string]bool)
-				unableUsers := []string{}
-
-				for _, str := range tempUsers {
-					if !seen[str] {
-						seen[str] = true
-						unableUsers = append(unableUsers, str)
-					}
-				}
-
-				var count int = 0
-				for _, a := range users {
-					found := false
-					for _, b := range unableUsers {
-						if a == b {
-							found = true
-							break
-						}
-					}
-
-					if !found {
-						count++
-					}
+		// 面接時間(前後の間隔を含む)が予定と重なる参加可能者
+		unableUsers := make(map[string]bool)
+		for _, schedule := range schedules {
+			if res.ID == schedule.ID {
+				continue
+			}
+			if bookingOverlaps(slot.Start, slot.End, schedule.Start, schedule.End, window.Buffer) {
+				for _, user := range schedule.Users {
+					unableUsers[user.HashKey] = true
 				}
+			}
+		}
 
-				reserveTime = append(reserveTime, dto.ReserveTableSub{
-					Time:      d,
-					IsReserve: count >= int(setting.UserMin),
-				})
+		var count int = 0
+		for _, user := range users {
+			if !unableUsers[user] {
+				count++
 			}
 		}
+
+		reserveTime = append(reserveTime, dto.ReserveTableSub{
+			Time:      slot.Start,
+			IsReserve: count >= int(setting.UserMin),
+		})
 	}
 
 	return &response.ReserveTable{
 		Dates:             times,
 		Options:           reserveTime,
+		Duration:          uint(window.Duration / time.Minute),
 		Schedule:          res.Start,
 		ScheduleHashKey:   res.HashKey,
 		IsResume:          applicant.NumOfInterview == 1 && applicant.ResumeExtension == "",
@@ -781,6 +1325,60 @@ func (s *ApplicantService) S3Upload(req *request.FileUpload, fileHeader *multipa
 		}
 	}
 
+	tx, txErr := s.d.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 通知
+	noticed, noticeErr := writeNotice(tx, s.n, s.redis, applicant, &dto.Notice{
+		Type: static.NOTICE_APPLICANT_DOCUMENT_UPLOAD,
+	})
+	if noticeErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		ApplicantID: applicant.ID,
+		CompanyID:   applicant.CompanyID,
+		TeamID:      applicant.TeamID,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_DOCUMENT_UPLOAD,
+		Target:      applicant.HashKey,
+		Detail:      map[string]interface{}{"document": req.NamePre, "extension": req.Extension},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.d.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// イベント配信
+	publishNotice(s.redis, noticed)
+	publishTeamEvent(s.redis, applicant.TeamID, &response.StreamEvent{
+		Type:   static.STREAM_EVENT_APPLICANT_STATUS,
+		Target: applicant.HashKey,
+	})
+
 	return nil
 }
 
@@ -929,17 +1527,12 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		}
 	}
 
-	// 応募者取得
-	applicant, applicantErr := s.r.Get(&ddl.Applicant{
-		AbstractTransactionModel: ddl.AbstractTransactionModel{
-			HashKey: req.ApplicantHashKey,
-		},
-	})
-	if applicantErr != nil {
-		return &response.Error{
-			Status: http.StatusInternalServerError,
-		}
+	// 応募者・面接官取得
+	target, targetErr := s.getBookingTarget(req.ApplicantHashKey)
+	if targetErr != nil {
+		return targetErr
 	}
+	applicant := target.applicant
 
 	// チーム取得
 	team, teamErr := s.t.GetByPrimary(&ddl.Team{
@@ -964,16 +1557,31 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		}
 	}
 
-	// 面接官取得
-	interviewers, interviewersError := s.r.GetUserAssociation(&ddl.ApplicantUserAssociation{
-		ApplicantID: applicant.ID,
-	})
-	if interviewersError != nil {
+	// 面接予約枠取得
+	window, windowErr := getBookingWindow(s.t, applicant.TeamID, applicant.NumOfInterview)
+	if windowErr != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
+	// 予約可能な枠か ※受付時間・刻み・受付締切・受付期間・休日
+	desiredDay := req.DesiredAt.In(window.Location)
+	holidays, hErr := getClosedDays(s.hd, s.t, applicant.CompanyID, applicant.TeamID, desiredDay, desiredDay)
+	if hErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if !bookingSlotOpen(window, req.DesiredAt, time.Now(), holidays) {
+		log.Printf("Out of booking slot.")
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_APPLICANT_OUT_OF_BOOKING_SLOT,
+		}
+	}
+	desiredEnd := req.DesiredAt.Add(window.Duration)
+
 	// イベント取得
 	events, eventsErr := s.t.SelectEventAssociation(&ddl.TeamEvent{
 		TeamID: applicant.TeamID,
@@ -1032,21 +1640,6 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		}
 	}
 
-	// 面接官割り振り可能判定
-	var userHashKeys []string
-	for _, user := range team.Users {
-		userHashKeys = append(userHashKeys, user.HashKey)
-	}
-	service, serviceErr := s.CheckAssignableUser(&request.CheckAssignableUser{
-		Start:    req.DesiredAt,
-		HashKeys: userHashKeys,
-	}, true)
-	if serviceErr != nil {
-		return &response.Error{
-			Status: serviceErr.Status,
-		}
-	}
-
 	// 面接参加可能者取得
 	models, modelsErr := s.t.GetAssignPossibleSchedule(&ddl.TeamAssignPossible{
 		TeamID:         applicant.TeamID,
@@ -1058,21 +1651,20 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		}
 	}
 
-	var ableUsers []*response.CheckAssignableUserSub
-	for _, s := range service.List {
-		flg := false
-		for _, m := range models {
-			if m.UserID == s.User.ID {
-				flg = true
-				break
-			}
-		}
+	// 面接官割り振り可能判定
+	if _, err := s.bookingInterviewers(team, models, req.DesiredAt, desiredEnd, window, target, setting.UserMin, static.CODE_APPLICANT_CANNOT_ASSIGN_USER); err != nil {
+		return err
+	}
 
-		if flg {
-			userCopy := s // コピーを作成
-			ableUsers = append(ableUsers, &userCopy)
-		}
+	// 予約ロック ※同時予約による面接官の重複割り当てを防止、コミットまで保持
+	// 応募者はロック取得後に再取得し、同じ応募者の同時予約で予定が重複しないようにする
+	lock, target, users, lockErr := s.lockBookingSlot(applicant.CompanyID, req.ApplicantHashKey, team, models, req.DesiredAt, desiredEnd, window, setting.UserMin)
+	if lockErr != nil {
+		return lockErr
 	}
+	defer lock.Unlock()
+	applicant = target.applicant
+	interviewers := target.interviewers
 
 	tx, txErr := s.d.TxStart()
 	if txErr != nil {
@@ -1087,6 +1679,11 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		_, hash, hashErr := GenerateHash(1, 25)
 		if hashErr != nil {
 			log.Printf("%v", hashErr)
+			if err := s.d.TxRollback(tx); err != nil {
+				return &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
 			return &response.Error{
 				Status: http.StatusInternalServerError,
 			}
@@ -1101,7 +1698,7 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 			InterviewFlg: uint(static.USER_INTERVIEW),
 			FreqID:       static.FREQ_NONE,
 			Start:        req.DesiredAt,
-			End:          req.DesiredAt.Add(time.Hour),
+			End:          desiredEnd,
 			Title:        req.Title,
 			TeamID:       applicant.TeamID,
 		})
@@ -1138,7 +1735,7 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 				ID: applicant.ScheduleID,
 			},
 			Start: req.DesiredAt,
-			End:   req.DesiredAt.Add(time.Hour),
+			End:   desiredEnd,
 			Title: req.Title,
 		}); err != nil {
 			if err := s.d.TxRollback(tx); err != nil {
@@ -1222,6 +1819,11 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 	if len(tEvents) > 0 {
 		if len(tEvents) != 1 {
 			log.Printf("not unique event each rule.")
+			if err := s.d.TxRollback(tx); err != nil {
+				return &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
 			return &response.Error{
 				Status: http.StatusInternalServerError,
 			}
@@ -1273,21 +1875,6 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		}
 	}
 
-	// 面接官割り振り
-	var users []entity.User
-	for _, s := range ableUsers {
-		if s.DuplFlg == static.DUPLICATION_SAFE {
-			users = append(users, s.User)
-		}
-	}
-	if len(users) < int(setting.UserMin) {
-		log.Printf("Not assignable.")
-		return &response.Error{
-			Status: http.StatusConflict,
-			Code:   static.CODE_APPLICANT_CANNOT_ASSIGN_USER,
-		}
-	}
-
 	// 手動
 	if len(interviewers) == 0 && applicant.ScheduleID == 0 && len(autoRules) == 0 && team.RuleID == static.ASSIGN_RULE_MANUAL {
 		var applicantUsers []*ddl.ApplicantUserAssociation
@@ -1454,15 +2041,165 @@ func (s *ApplicantService) InsertDesiredAt(req *request.InsertDesiredAt) *respon
 		}
 	}
 
+	// 通知
+	noticed, noticeErr := writeNotice(tx, s.n, s.redis, applicant, &dto.Notice{
+		Type: static.NOTICE_APPLICANT_DESIRED_AT,
+	})
+	if noticeErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		ApplicantID: applicant.ID,
+		CompanyID:   applicant.CompanyID,
+		TeamID:      applicant.TeamID,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_DESIRED_AT,
+		Target:      applicant.HashKey,
+		Detail:      map[string]interface{}{"desired_at": req.DesiredAt},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ロック保持確認 ※処理が長引き失われた場合は中断
+	if !lock.Held() {
+		log.Printf("Booking lock lost.")
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
+	lock.Unlock()
+
+	// イベント配信
+	publishNotice(s.redis, noticed)
+	publishTeamEvent(s.redis, applicant.TeamID, &response.StreamEvent{
+		Type:   static.STREAM_EVENT_SCHEDULE_BOOK,
+		Target: applicant.HashKey,
+	})
+
+	// 面接招待送信 ※予約自体は確定済みのため失敗はログのみ
+	if scheduleID == 0 {
+		scheduleID = applicant.ScheduleID
+	}
+	s.sendInterviewInvite(applicant, scheduleID)
 
 	return nil
 }
 
+// 招待リンク送信 ※予約画面へ直接ログインできるリンク、失敗時も取込は確定済みのためログのみ
+func (s *ApplicantService) sendInvitations(teamID uint64, applicants []entity.Applicant) {
+	if len(applicants) == 0 {
+		return
+	}
+
+	team, teamErr := s.t.GetByPrimary(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: teamID,
+		},
+	})
+	if teamErr != nil {
+		return
+	}
+
+	for _, applicant := range applicants {
+		url, err := issueMagicLink(s.redis, applicant.HashKey, team.HashKey, static.MAGIC_LINK_INVITE_TTL)
+		if err != nil {
+			log.Printf("%v", err)
+			continue
+		}
+		if err := s.mail.Send(&dto.Mail{
+			CompanyID: applicant.CompanyID,
+			Kind:      static.MAIL_KIND_INVITATION_APPLICANT,
+			To:        applicant.Email,
+			Subject:   static.MAIL_SUBJECT_INVITATION,
+			Body:      fmt.Sprintf(static.MAIL_BODY_INVITATION, applicant.Name, team.Name, url),
+		}); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+}
+
+// 面接招待送信(iCalendar添付)
+func (s *ApplicantService) sendInterviewInvite(applicant *entity.Applicant, scheduleID uint64) {
+	schedule, scheduleErr := s.s.GetByPrimary(&ddl.Schedule{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: scheduleID,
+		},
+	})
+	if scheduleErr != nil {
+		return
+	}
+
+	// TZをAsia/Tokyoに
+	jst, jstErr := time.LoadLocation("Asia/Tokyo")
+	if jstErr != nil {
+		log.Printf("%v", jstErr)
+		return
+	}
+
+	w := newICSWriter(static.ICS_METHOD_PUBLISH, "")
+	w.Event(&icsEvent{
+		UID:       schedule.HashKey,
+		Summary:   schedule.Title,
+		URL:       applicant.GoogleMeetURL,
+		Start:     schedule.Start,
+		End:       schedule.End,
+		UpdatedAt: schedule.UpdatedAt,
+	})
+
+	url := ""
+	if isHTTPURL(applicant.GoogleMeetURL) {
+		url = fmt.Sprintf(static.MAIL_BODY_INTERVIEW_URL, applicant.GoogleMeetURL)
+	}
+	if err := s.mail.Send(&dto.Mail{
+		CompanyID: applicant.CompanyID,
+		Kind:      static.MAIL_KIND_INTERVIEW_INVITE,
+		To:        applicant.Email,
+		Subject:   static.MAIL_SUBJECT_INTERVIEW,
+		Body: fmt.Sprintf(
+			static.MAIL_BODY_INTERVIEW,
+			applicant.Name,
+			schedule.Title,
+			schedule.Start.In(jst).Format(static.MAIL_DATE_FORMAT),
+			url,
+		),
+		Attachments: []dto.MailAttachment{
+			{
+				FileName:    static.ICS_INVITE_FILE_NAME,
+				ContentType: static.ICS_CONTENT_TYPE + "; method=" + static.ICS_METHOD_PUBLISH,
+				Data:        w.Bytes(),
+			},
+		},
+	}); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
 // GoogleMeetUrl発行
 func (s *ApplicantService) GetGoogleMeetUrl(req *request.GetGoogleMeetUrl) (*response.GetGoogleMeetUrl, *response.Error) {
 	// バリデーション
@@ -1540,6 +2277,13 @@ func (s *ApplicantService) GetGoogleMeetUrl(req *request.GetGoogleMeetUrl) (*res
 			Status: http.StatusInternalServerError,
 		}
 	}
+	// 招待状・メールに埋め込むためhttp(s)のURLのみ受け付ける
+	if !isHTTPURL(*googleMeetUrl) {
+		log.Printf("unexpected google meet url: %q", *googleMeetUrl)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
 
 	tx, txErr := s.d.TxStart()
 	if txErr != nil {
@@ -1580,6 +2324,23 @@ func (s *ApplicantService) GetGoogleMeetUrl(req *request.GetGoogleMeetUrl) (*res
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_CREATE_MEET_URL,
+		Target:      applicant.HashKey,
+		Detail:      nil,
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
@@ -1838,6 +2599,23 @@ func (s *ApplicantService) UpdateStatus(req *request.UpdateStatus) *response.Err
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_SETTING_STATUS,
+		Target:      "",
+		Detail:      map[string]interface{}{"status": req.Status, "association": req.Association, "events": req.Events, "events_of_interview": req.EventsOfInterview},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -1946,11 +2724,30 @@ func (s *ApplicantService) AssignUser(req *request.AssignUser) *response.Error {
 		}
 	}
 
+	// 面接予約枠取得 ※前後の間隔
+	window, windowErr := getBookingWindow(s.t, applicant.TeamID, applicant.NumOfInterview)
+	if windowErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 予約ロック ※面接可能判定から登録まで同時予約・割り振りを直列化、コミットまで保持
+	lock, lockErr := lockBooking(s.redis, applicant.CompanyID, static.BOOKING_LOCK_WAIT)
+	if lockErr != nil {
+		log.Printf("%v", lockErr)
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+	defer lock.Unlock()
+
 	// 面接可能判定2
-	service, serviceErr := s.CheckAssignableUser(&request.CheckAssignableUser{
+	service, serviceErr := s.checkAssignableUser(&request.CheckAssignableUser{
 		Start:    schedule.Start,
+		End:      schedule.End,
 		HashKeys: req.HashKeys,
-	}, true)
+	}, window, true)
 	if serviceErr != nil {
 		return &response.Error{
 			Status: serviceErr.Status,
@@ -2043,11 +2840,70 @@ func (s *ApplicantService) AssignUser(req *request.AssignUser) *response.Error {
 		}
 	}
 
+	// 通知
+	var noticeUsers []uint64
+	for _, row := range users2 {
+		noticeUsers = append(noticeUsers, row.UserID)
+	}
+	noticed, noticeErr := writeNotice(tx, s.n, s.redis, applicant, &dto.Notice{
+		UserHashKey: req.UserHashKey,
+		Type:        static.NOTICE_APPLICANT_ASSIGN_USER,
+		ToUserIDs:   noticeUsers,
+	})
+	if noticeErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_ASSIGN_USER,
+		Target:      req.HashKey,
+		Detail:      map[string]interface{}{"users": req.HashKeys},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ロック保持確認 ※処理が長引き失われた場合は中断
+	if !lock.Held() {
+		log.Printf("Booking lock lost.")
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
+	lock.Unlock()
+
+	// イベント配信
+	publishNotice(s.redis, noticed)
+	publishTeamEvent(s.redis, applicant.TeamID, &response.StreamEvent{
+		Type:   static.STREAM_EVENT_APPLICANT_ASSIGN,
+		Target: applicant.HashKey,
+	})
 
 	return nil
 }
@@ -2062,141 +2918,292 @@ func (s *ApplicantService) CheckAssignableUser(req *request.CheckAssignableUser,
 		}
 	}
 
-	// TZをAsia/Tokyoに
-	jst, jstErr := time.LoadLocation("Asia/Tokyo")
-	if jstErr != nil {
-		log.Printf("%v", jstErr)
+	// 対象チーム・面接回数 ※応募者指定時は応募者の面接回数、未指定時は操作者のチーム既定
+	var teamID uint64
+	var numOfInterview uint
+	if req.ApplicantHashKey != "" {
+		applicant, applicantErr := s.r.Get(&ddl.Applicant{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey: req.ApplicantHashKey,
+			},
+		})
+		if applicantErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		teamID = applicant.TeamID
+		numOfInterview = applicant.NumOfInterview
+	} else {
+		ctx := context.Background()
+		team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+		if teamErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		id, idErr := strconv.ParseUint(*team, 10, 64)
+		if idErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		teamID = id
+	}
+
+	// 面接予約枠取得
+	window, windowErr := getBookingWindow(s.t, teamID, numOfInterview)
+	if windowErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// ユーザー取得
-	users, usersErr := s.u.GetByHashKeys(req.HashKeys)
-	if usersErr != nil {
+	// 終了時刻未指定の場合は面接時間から算出
+	if req.End.IsZero() {
+		req.End = req.Start.Add(window.Duration)
+	}
+
+	return s.checkAssignableUser(req, window, domainFlg)
+}
+
+// 予約対象
+type bookingTarget struct {
+	applicant *entity.Applicant
+	// 割り当て済み面接官
+	interviewers []entity.ApplicantUserAssociation
+	// 予定の重複判定から除外する予定 ※変更時の応募者自身の予定
+	removeScheduleHashKeys []string
+}
+
+// 予約対象取得
+func (s *ApplicantService) getBookingTarget(applicantHashKey string) (*bookingTarget, *response.Error) {
+	applicant, applicantErr := s.r.Get(&ddl.Applicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: applicantHashKey,
+		},
+	})
+	if applicantErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	var list []response.CheckAssignableUserSub
-	for _, user := range users {
-		// ユーザー単位予定取得
-		models, modelsErr := s.s.GetScheduleByUser(&dto.GetScheduleByUser{
-			ScheduleAssociation: ddl.ScheduleAssociation{
-				UserID: user.ID,
+	interviewers, interviewersErr := s.r.GetUserAssociation(&ddl.ApplicantUserAssociation{
+		ApplicantID: applicant.ID,
+	})
+	if interviewersErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	var removeScheduleHashKeys []string
+	if applicant.ScheduleID > 0 {
+		schedule, scheduleErr := s.s.GetByPrimary(&ddl.Schedule{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				ID: applicant.ScheduleID,
 			},
-			RemoveScheduleHashKeys: req.RemoveScheduleHashKeys,
 		})
-		if modelsErr != nil {
+		if scheduleErr != nil {
 			return nil, &response.Error{
 				Status: http.StatusInternalServerError,
 			}
 		}
+		removeScheduleHashKeys = append(removeScheduleHashKeys, schedule.HashKey)
+	}
 
-		// 整形
-		var schedules []entity.Schedule
-		var schedulesJST []entity.Schedule
-
-		start := req.Start.In(jst)
-		end := start.Add(1 * time.Hour)
+	return &bookingTarget{
+		applicant:              applicant,
+		interviewers:           interviewers,
+		removeScheduleHashKeys: removeScheduleHashKeys,
+	}, nil
+}
 
-		for _, model := range models {
-			model.Start = model.Start.In(jst)
-			model.End = model.End.In(jst)
-			schedulesJST = append(schedulesJST, entity.Schedule{
-				Schedule: model.Schedule,
+// 予約枠の面接官判定 ※割り当て済みの場合は全員が枠で空いていること、未割り当ての場合は割り振り可能な面接官が最低人数以上
+func (s *ApplicantService) bookingInterviewers(
+	team *entity.Team,
+	possibles []entity.AssignPossibleSchedule,
+	start time.Time,
+	end time.Time,
+	window *dto.BookingWindow,
+	target *bookingTarget,
+	userMin uint,
+	code uint,
+) ([]entity.User, *response.Error) {
+	if len(target.interviewers) > 0 {
+		var hashKeys []string
+		for _, row := range target.interviewers {
+			user, userErr := s.u.GetByPrimary(&ddl.User{
+				AbstractTransactionModel: ddl.AbstractTransactionModel{
+					ID: row.UserID,
+				},
 			})
-		}
-
-		for _, scheduleJST := range schedulesJST {
-			// なしの場合 (変更なし)
-			if scheduleJST.FreqID == uint(static.FREQ_NONE) {
-				if (end.After(scheduleJST.Start) || end.Equal(scheduleJST.Start)) &&
-					(start.Before(scheduleJST.End) || start.Equal(scheduleJST.End)) {
-					schedules = append(schedules, scheduleJST)
+			if userErr != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
 				}
 			}
+			hashKeys = append(hashKeys, user.HashKey)
+		}
 
-			// 毎日の場合 (変更なし)
-			if scheduleJST.FreqID == uint(static.FREQ_DAILY) {
-				scheduleStart := time.Date(start.Year(), start.Month(), start.Day(), scheduleJST.Start.Hour(), scheduleJST.Start.Minute(), scheduleJST.Start.Second(), 0, jst)
-				scheduleEnd := time.Date(start.Year(), start.Month(), start.Day(), scheduleJST.End.Hour(), scheduleJST.End.Minute(), scheduleJST.End.Second(), 0, jst)
-
-				if scheduleEnd.Before(scheduleStart) {
-					scheduleEnd = scheduleEnd.Add(24 * time.Hour)
-				}
-
-				if (end.After(scheduleStart) || end.Equal(scheduleStart)) &&
-					(start.Before(scheduleEnd) || start.Equal(scheduleEnd)) {
-					schedules = append(schedules, scheduleJST)
+		service, serviceErr := s.checkAssignableUser(&request.CheckAssignableUser{
+			Start:                  start,
+			End:                    end,
+			HashKeys:               hashKeys,
+			RemoveScheduleHashKeys: target.removeScheduleHashKeys,
+		}, window, true)
+		if serviceErr != nil {
+			return nil, &response.Error{
+				Status: serviceErr.Status,
+			}
+		}
+		for _, row := range service.List {
+			if row.DuplFlg != static.DUPLICATION_SAFE {
+				log.Printf("Assigned interviewer not available.")
+				return nil, &response.Error{
+					Status: http.StatusConflict,
+					Code:   code,
 				}
 			}
+		}
+		return nil, nil
+	}
 
-			// 毎週の場合 (変更なし)
-			if scheduleJST.FreqID == uint(static.FREQ_WEEKLY) {
-				if start.Weekday() == scheduleJST.Start.Weekday() {
-					scheduleStart := time.Date(start.Year(), start.Month(), start.Day(), scheduleJST.Start.Hour(), scheduleJST.Start.Minute(), scheduleJST.Start.Second(), 0, jst)
-					scheduleEnd := time.Date(start.Year(), start.Month(), start.Day(), scheduleJST.End.Hour(), scheduleJST.End.Minute(), scheduleJST.End.Second(), 0, jst)
+	users, usersErr := s.assignableInterviewers(team, possibles, start, end, window, target.removeScheduleHashKeys)
+	if usersErr != nil {
+		return nil, usersErr
+	}
+	if len(users) < int(userMin) {
+		log.Printf("Not assignable.")
+		return nil, &response.Error{
+			Status: http.StatusConflict,
+			Code:   code,
+		}
+	}
+	return users, nil
+}
 
-					if scheduleEnd.Before(scheduleStart) {
-						scheduleEnd = scheduleEnd.Add(24 * time.Hour)
-					}
+// 割り振り可能な面接官 ※チームのユーザーのうち予定の重複がなく、面接参加可能者に含まれるユーザー
+func (s *ApplicantService) assignableInterviewers(
+	team *entity.Team,
+	possibles []entity.AssignPossibleSchedule,
+	start time.Time,
+	end time.Time,
+	window *dto.BookingWindow,
+	removeScheduleHashKeys []string,
+) ([]entity.User, *response.Error) {
+	var userHashKeys []string
+	for _, user := range team.Users {
+		userHashKeys = append(userHashKeys, user.HashKey)
+	}
+	service, serviceErr := s.checkAssignableUser(&request.CheckAssignableUser{
+		Start:                  start,
+		End:                    end,
+		HashKeys:               userHashKeys,
+		RemoveScheduleHashKeys: removeScheduleHashKeys,
+	}, window, true)
+	if serviceErr != nil {
+		return nil, &response.Error{
+			Status: serviceErr.Status,
+		}
+	}
 
-					if (end.After(scheduleStart) || end.Equal(scheduleStart)) &&
-						(start.Before(scheduleEnd) || start.Equal(scheduleEnd)) {
-						schedules = append(schedules, scheduleJST)
-					}
-				}
+	var res []entity.User
+	for _, row := range service.List {
+		if row.DuplFlg != static.DUPLICATION_SAFE {
+			continue
+		}
+		for _, m := range possibles {
+			if m.UserID == row.User.ID {
+				res = append(res, row.User)
+				break
 			}
+		}
+	}
+	return res, nil
+}
 
-			// 毎月の場合 (存在しない日付の場合は何もしない)
-			if scheduleJST.FreqID == uint(static.FREQ_MONTHLY) {
-				scheduleDay := scheduleJST.Start.Day()
+// 予約ロック取得 ※取得後に予約対象を再取得して面接官割り振り可能を再判定、判定後に他の予約で枠が埋まった場合は409
+func (s *ApplicantService) lockBookingSlot(
+	companyID uint64,
+	applicantHashKey string,
+	team *entity.Team,
+	possibles []entity.AssignPossibleSchedule,
+	start time.Time,
+	end time.Time,
+	window *dto.BookingWindow,
+	userMin uint,
+) (*bookingLock, *bookingTarget, []entity.User, *response.Error) {
+	lock, lockErr := lockBooking(s.redis, companyID, static.BOOKING_LOCK_WAIT)
+	if lockErr != nil {
+		log.Printf("%v", lockErr)
+		return nil, nil, nil, &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
 
-				if start.Day() == scheduleDay {
-					scheduleStart := time.Date(start.Year(), start.Month(), scheduleDay, scheduleJST.Start.Hour(), scheduleJST.Start.Minute(), scheduleJST.Start.Second(), 0, jst)
-					scheduleEnd := time.Date(start.Year(), start.Month(), scheduleDay, scheduleJST.End.Hour(), scheduleJST.End.Minute(), scheduleJST.End.Second(), 0, jst)
+	target, targetErr := s.getBookingTarget(applicantHashKey)
+	if targetErr != nil {
+		lock.Unlock()
+		return nil, nil, nil, targetErr
+	}
 
-					// 存在しない日付の場合は無視
-					if scheduleStart.Month() == start.Month() {
-						if scheduleEnd.Before(scheduleStart) {
-							scheduleEnd = scheduleEnd.Add(24 * time.Hour)
-						}
+	users, usersErr := s.bookingInterviewers(team, possibles, start, end, window, target, userMin, static.CODE_APPLICANT_SLOT_TAKEN)
+	if usersErr != nil {
+		lock.Unlock()
+		return nil, nil, nil, usersErr
+	}
+	return lock, target, users, nil
+}
 
-						if (end.After(scheduleStart) || end.Equal(scheduleStart)) &&
-							(start.Before(scheduleEnd) || start.Equal(scheduleEnd)) {
-							schedules = append(schedules, scheduleJST)
-						}
-					}
-				}
-			}
+// 面接官割り振り可能判定 ※開始～終了(前後の間隔を含む)で予定の重複を判定
+func (s *ApplicantService) checkAssignableUser(
+	req *request.CheckAssignableUser,
+	window *dto.BookingWindow,
+	domainFlg bool,
+) (*response.CheckAssignableUser, *response.Error) {
+	// ユーザー取得
+	users, usersErr := s.u.GetByHashKeys(req.HashKeys)
+	if usersErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
 
-			// 毎年の場合 (修正: 2/29のみ特別処理、その他の存在しない日付は考慮しない)
-			if scheduleJST.FreqID == uint(static.FREQ_YEARLY) {
-				scheduleMonth := scheduleJST.Start.Month()
-				scheduleDay := scheduleJST.Start.Day()
+	var list []response.CheckAssignableUserSub
+	locations := make(map[uint64]*time.Location)
+	for _, user := range users {
+		// ユーザー単位予定取得
+		models, modelsErr := s.s.GetScheduleByUser(&dto.GetScheduleByUser{
+			ScheduleAssociation: ddl.ScheduleAssociation{
+				UserID: user.ID,
+			},
+			RemoveScheduleHashKeys: req.RemoveScheduleHashKeys,
+		})
+		if modelsErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
 
-				// 2月29日の特別処理
-				if scheduleMonth == time.February && scheduleDay == 29 {
-					if !isLeapYear(start.Year()) {
-						scheduleDay = 1
-						scheduleMonth = time.March
-					}
+		// 開始～終了(前後の間隔を含む)に回がある予定 ※繰り返し予定は展開して判定
+		var schedules []entity.Schedule
+		start := req.Start.In(window.Location)
+		end := req.End.In(window.Location)
+		for _, model := range models {
+			loc, locErr := scheduleLocation(s.t, model.TeamID, locations)
+			if locErr != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
 				}
-
-				if start.Month() == scheduleMonth && start.Day() == scheduleDay {
-					scheduleStart := time.Date(start.Year(), scheduleMonth, scheduleDay, scheduleJST.Start.Hour(), scheduleJST.Start.Minute(), scheduleJST.Start.Second(), 0, jst)
-					scheduleEnd := time.Date(start.Year(), scheduleMonth, scheduleDay, scheduleJST.End.Hour(), scheduleJST.End.Minute(), scheduleJST.End.Second(), 0, jst)
-
-					if scheduleEnd.Before(scheduleStart) {
-						scheduleEnd = scheduleEnd.Add(24 * time.Hour)
-					}
-
-					if (end.After(scheduleStart) || end.Equal(scheduleStart)) &&
-						(start.Before(scheduleEnd) || start.Equal(scheduleEnd)) {
-						schedules = append(schedules, scheduleJST)
-					}
+			}
+			for _, occurrence := range expandSchedule(&model.Schedule, model.Exceptions, start.Add(-window.Buffer), end.Add(window.Buffer), loc) {
+				if bookingOverlaps(start, end, occurrence.Start, occurrence.End, window.Buffer) {
+					schedules = append(schedules, entity.Schedule{
+						Schedule: model.Schedule,
+					})
+					break
 				}
 			}
 		}
@@ -2348,6 +3355,23 @@ func (s *ApplicantService) CreateApplicantType(req *request.CreateApplicantType)
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_CREATE_TYPE,
+		Target:      "",
+		Detail:      map[string]interface{}{"name": req.Name, "rule": req.RuleHash, "occupation": req.OccupationHash},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -2469,6 +3493,23 @@ func (s *ApplicantService) CreateApplicantTypeAssociation(req *request.CreateApp
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_UPDATE_TYPE,
+		Target:      req.TypeHash,
+		Detail:      map[string]interface{}{"applicants": req.Applicants},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -2529,12 +3570,37 @@ func (s *ApplicantService) UpdateSelectStatus(req *request.UpdateSelectStatus) *
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_UPDATE_STATUS,
+		Target:      req.StatusHash,
+		Detail:      map[string]interface{}{"applicants": req.Applicants},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
+	// イベント配信
+	for _, applicant := range req.Applicants {
+		publishTeamEvent(s.redis, status.TeamID, &response.StreamEvent{
+			Type:   static.STREAM_EVENT_APPLICANT_STATUS,
+			Target: applicant,
+		})
+	}
+
 	return nil
 }
 
@@ -2737,11 +3803,51 @@ func (s *ApplicantService) InputResult(req *request.InputResult) *response.Error
 		}
 	}
 
+	// 通知
+	noticed, noticeErr := writeNotice(tx, s.n, s.redis, applicant, &dto.Notice{
+		UserHashKey: req.UserHashKey,
+		Type:        static.NOTICE_APPLICANT_INPUT_RESULT,
+	})
+	if noticeErr != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.ol, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_APPLICANT_INPUT_RESULT,
+		Target:      req.HashKey,
+		Detail:      map[string]interface{}{"process": req.ProcessHash, "document_pass_flg": req.DocumentPassFlg},
+	}); err != nil {
+		if err := s.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.d.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
+	// イベント配信
+	publishNotice(s.redis, noticed)
+	publishTeamEvent(s.redis, applicant.TeamID, &response.StreamEvent{
+		Type:   static.STREAM_EVENT_APPLICANT_STATUS,
+		Target: applicant.HashKey,
+	})
+
 	return nil
 }
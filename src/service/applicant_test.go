@@ -0,0 +1,387 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 取消の処理順 ※トランザクション外の実行は「!」を付けて記録
+type rollbackSteps struct {
+	db    *mockDB
+	steps []string
+}
+
+func (s *rollbackSteps) add(tx *gorm.DB, step string) {
+	if tx == nil || tx != s.db.tx || s.db.started == s.db.committed+s.db.rolledBack {
+		step = "!" + step
+	}
+	s.steps = append(s.steps, step)
+}
+
+type rollbackApplicantRepo struct {
+	repository.IApplicantRepository
+	*rollbackSteps
+	ids        []uint64
+	progressed int64
+	deleted    []uint64
+}
+
+func (r *rollbackApplicantRepo) GetIDsByCommitID(tx *gorm.DB, m *ddl.Applicant) ([]uint64, error) {
+	r.add(tx, "lock")
+	return r.ids, nil
+}
+
+func (r *rollbackApplicantRepo) CountProgressedByCommitID(tx *gorm.DB, m *dto.CheckRollbackUpload) (*int64, error) {
+	r.add(tx, "count")
+	return &r.progressed, nil
+}
+
+func (r *rollbackApplicantRepo) DeleteTypeAssociation(tx *gorm.DB, m []uint64) error {
+	r.add(tx, "type")
+	return nil
+}
+
+func (r *rollbackApplicantRepo) DeletesByPrimary(tx *gorm.DB, m []uint64) error {
+	r.add(tx, "applicant")
+	r.deleted = m
+	return nil
+}
+
+type rollbackManuscriptRepo struct {
+	repository.IManuscriptRepository
+	*rollbackSteps
+}
+
+func (r *rollbackManuscriptRepo) DeleteApplicantAssociation(tx *gorm.DB, m []uint64) error {
+	r.add(tx, "manuscript")
+	return nil
+}
+
+type rollbackNoticeRepo struct {
+	repository.INoticeRepository
+	*rollbackSteps
+	deleted []uint64
+}
+
+func (r *rollbackNoticeRepo) DeleteByApplicant(tx *gorm.DB, applicantIDs []uint64) error {
+	r.add(tx, "notice")
+	r.deleted = applicantIDs
+	return nil
+}
+
+type rollbackOperationLogRepo struct {
+	repository.IOperationLogRepository
+	*rollbackSteps
+	detached []uint64
+}
+
+func (r *rollbackOperationLogRepo) DetachApplicant(tx *gorm.DB, applicantIDs []uint64) error {
+	r.add(tx, "log")
+	r.detached = applicantIDs
+	return nil
+}
+
+func (r *rollbackOperationLogRepo) Insert(tx *gorm.DB, m *ddl.OperationLog) error {
+	r.add(tx, "write_log")
+	return nil
+}
+
+type rollbackHistoryRepo struct {
+	repository.IUploadHistoryRepository
+	*rollbackSteps
+	history ddl.HistoryOfUploadApplicant
+}
+
+func (r *rollbackHistoryRepo) Get(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) (*entity.UploadHistory, error) {
+	r.add(tx, "history_lock")
+	if m.CommitID != r.history.CommitID || m.TeamID != r.history.TeamID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &entity.UploadHistory{HistoryOfUploadApplicant: r.history}, nil
+}
+
+func (r *rollbackHistoryRepo) Rollback(tx *gorm.DB, m *ddl.HistoryOfUploadApplicant) error {
+	r.add(tx, "history")
+	return nil
+}
+
+func TestApplicantService_RollbackUpload(t *testing.T) {
+	tests := []struct {
+		name        string
+		commitID    string
+		rollbackFlg uint
+		ids         []uint64
+		progressed  int64
+		wantErr     *response.Error
+		wantSteps   []string
+	}{
+		// ok 応募者・お知らせを削除し、操作ログは切り離して残す ※全てトランザクション内、ロック後に確認
+		{"ok_delete", "c1", static.OFF, []uint64{1, 2}, 0, nil, []string{
+			"history_lock", "lock", "count", "manuscript", "type", "notice", "log", "applicant", "history", "write_log",
+		}},
+		// ok 削除対象なし
+		{"ok_empty", "c1", static.OFF, nil, 0, nil, []string{
+			"history_lock", "lock", "count", "history", "write_log",
+		}},
+		// ng 選考が進んでいる応募者あり ※ロック後に確認し、削除しない
+		{"ng_progressed", "c1", static.OFF, []uint64{1, 2}, 1, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_APPLICANT_ROLLBACK_PROGRESSED,
+		}, []string{"history_lock", "lock", "count"}},
+		// ng 取消済み ※行ロック後に確認
+		{"ng_already", "c1", static.ON, []uint64{1, 2}, 0, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_APPLICANT_ROLLBACK_ALREADY,
+		}, []string{"history_lock"}},
+		// ng 他チーム・存在しないコミットID
+		{"ng_not_found", "c2", static.OFF, []uint64{1, 2}, 0, &response.Error{
+			Status: http.StatusNotFound,
+		}, []string{"history_lock"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newMockDB()
+			steps := &rollbackSteps{db: db}
+			redis := newMemoryRedis()
+			for key, value := range map[string]string{
+				static.REDIS_USER_TEAM_ID:    "1",
+				static.REDIS_USER_COMPANY_ID: "1",
+				static.REDIS_USER_ID:         "10",
+			} {
+				value := value
+				_ = redis.Set(context.Background(), "user_1", key, &value, 0)
+			}
+			applicants := &rollbackApplicantRepo{rollbackSteps: steps, ids: tt.ids, progressed: tt.progressed}
+			notices := &rollbackNoticeRepo{rollbackSteps: steps}
+			logs := &rollbackOperationLogRepo{rollbackSteps: steps}
+			s := &ApplicantService{
+				r:     applicants,
+				manu:  &rollbackManuscriptRepo{rollbackSteps: steps},
+				redis: redis,
+				v:     validator.NewApplicantValidator(),
+				d:     db,
+				ol:    logs,
+				h: &rollbackHistoryRepo{rollbackSteps: steps, history: ddl.HistoryOfUploadApplicant{
+					CommitID:    "c1",
+					TeamID:      1,
+					RollbackFlg: tt.rollbackFlg,
+				}},
+				n: notices,
+			}
+
+			res, err := s.RollbackUpload(&request.RollbackUpload{
+				Abstract: request.Abstract{UserHashKey: "user_1"},
+				CommitID: tt.commitID,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("RollbackUpload() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(steps.steps, tt.wantSteps) {
+				t.Errorf("steps = %v, want %v", steps.steps, tt.wantSteps)
+			}
+			if !db.closed() {
+				t.Errorf("transaction not closed: %+v", db)
+			}
+			if err != nil {
+				if db.committed != 0 || applicants.deleted != nil {
+					t.Errorf("committed = %d, deleted = %v, want none", db.committed, applicants.deleted)
+				}
+				return
+			}
+
+			if res.DeleteNum != len(tt.ids) {
+				t.Errorf("DeleteNum = %d, want %d", res.DeleteNum, len(tt.ids))
+			}
+			if len(tt.ids) > 0 {
+				for _, got := range [][]uint64{applicants.deleted, notices.deleted, logs.detached} {
+					if !reflect.DeepEqual(got, tt.ids) {
+						t.Errorf("deleted = %v, want %v", got, tt.ids)
+					}
+				}
+			}
+		})
+	}
+}
+
+// 予約の再判定用 ※予定はロック取得までに他の予約で登録されたもの
+type slotUserRepo struct {
+	repository.IUserRepository
+	users []entity.User
+}
+
+func (r *slotUserRepo) GetByHashKeys(m []string) ([]entity.User, error) {
+	var res []entity.User
+	for _, user := range r.users {
+		for _, hashKey := range m {
+			if user.HashKey == hashKey {
+				res = append(res, user)
+			}
+		}
+	}
+	return res, nil
+}
+
+func (r *slotUserRepo) GetByPrimary(m *ddl.User) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.ID == m.ID {
+			return &user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type slotScheduleRepo struct {
+	repository.IScheduleRepository
+	booked map[uint64][]entity.Schedule2
+}
+
+func (r *slotScheduleRepo) GetScheduleByUser(m *dto.GetScheduleByUser) ([]entity.Schedule2, error) {
+	var res []entity.Schedule2
+	for _, row := range r.booked[m.UserID] {
+		removed := false
+		for _, hashKey := range m.RemoveScheduleHashKeys {
+			if row.HashKey == hashKey {
+				removed = true
+			}
+		}
+		if !removed {
+			res = append(res, row)
+		}
+	}
+	return res, nil
+}
+
+func (r *slotScheduleRepo) GetByPrimary(m *ddl.Schedule) (*entity.Schedule, error) {
+	return &entity.Schedule{Schedule: ddl.Schedule{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{ID: m.ID, HashKey: "schedule_" + strconv.FormatUint(m.ID, 10)},
+	}}, nil
+}
+
+// 予約対象の応募者 ※ロック取得後の再取得で参照する現在の状態
+type slotApplicantRepo struct {
+	repository.IApplicantRepository
+	scheduleID   uint64
+	interviewers []uint64
+}
+
+func (r *slotApplicantRepo) Get(m *ddl.Applicant) (*entity.Applicant, error) {
+	return &entity.Applicant{
+		Applicant:  ddl.Applicant{AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 1, HashKey: m.HashKey, CompanyID: 1}},
+		ScheduleID: r.scheduleID,
+	}, nil
+}
+
+func (r *slotApplicantRepo) GetUserAssociation(m *ddl.ApplicantUserAssociation) ([]entity.ApplicantUserAssociation, error) {
+	var res []entity.ApplicantUserAssociation
+	for _, userID := range r.interviewers {
+		res = append(res, entity.ApplicantUserAssociation{ApplicantUserAssociation: ddl.ApplicantUserAssociation{ApplicantID: m.ApplicantID, UserID: userID}})
+	}
+	return res, nil
+}
+
+type slotTeamRepo struct {
+	repository.ITeamRepository
+}
+
+func (r *slotTeamRepo) ListBookingWindow(m *ddl.TeamBookingWindow) ([]*ddl.TeamBookingWindow, error) {
+	return []*ddl.TeamBookingWindow{{TeamID: m.TeamID, TimeZone: "UTC", Duration: 60}}, nil
+}
+
+func (r *slotTeamRepo) ListBookingHours(m *ddl.TeamBookingHours) ([]*ddl.TeamBookingHours, error) {
+	return nil, nil
+}
+
+func TestApplicantService_lockBookingSlot(t *testing.T) {
+	start := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	users := []entity.User{
+		{User: ddl.User{AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 1, HashKey: "user_1"}}},
+		{User: ddl.User{AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 2, HashKey: "user_2"}}},
+	}
+	team := &entity.Team{Users: []*ddl.User{&users[0].User, &users[1].User}}
+	possibles := []entity.AssignPossibleSchedule{{UserID: 1}, {UserID: 2}}
+	window := &dto.BookingWindow{Location: time.UTC, Duration: time.Hour}
+	interview := func(scheduleID uint64) []entity.Schedule2 {
+		return []entity.Schedule2{{Schedule: ddl.Schedule{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{ID: scheduleID, HashKey: "schedule_" + strconv.FormatUint(scheduleID, 10)},
+			TeamID:                   1,
+			InterviewFlg:             uint(static.USER_INTERVIEW),
+			FreqID:                   static.FREQ_NONE,
+			Start:                    start,
+			End:                      end,
+		}}}
+	}
+
+	tests := []struct {
+		name   string
+		booked map[uint64][]entity.Schedule2
+		// ロック取得時点の応募者の予定・割り当て済み面接官
+		scheduleID   uint64
+		interviewers []uint64
+		userMin      uint
+		want         int
+		wantErr      *response.Error
+	}{
+		// ok 他の予約なし
+		{"ok", nil, 0, nil, 2, 2, nil},
+		// ok 他の予約で埋まっても最低人数を満たす
+		{"ok_enough", map[uint64][]entity.Schedule2{1: interview(1)}, 0, nil, 1, 1, nil},
+		// ng 判定後に他の予約で枠が埋まった
+		{"ng_slot_taken", map[uint64][]entity.Schedule2{1: interview(1)}, 0, nil, 2, 0, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_APPLICANT_SLOT_TAKEN,
+		}},
+		// ok 判定後に同じ応募者の予約で予定が登録された ※変更として扱い自身の予定は除外
+		{"ok_rebooked", map[uint64][]entity.Schedule2{1: interview(9), 2: interview(9)}, 9, []uint64{1, 2}, 2, 0, nil},
+		// ng 変更時に割り当て済み面接官が他の予定で埋まっている
+		{"ng_assigned_busy", map[uint64][]entity.Schedule2{1: interview(9), 2: interview(3)}, 9, []uint64{1, 2}, 2, 0, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_APPLICANT_SLOT_TAKEN,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := &memoryLockRedis{keys: make(map[string]string)}
+			s := &ApplicantService{
+				r:     &slotApplicantRepo{scheduleID: tt.scheduleID, interviewers: tt.interviewers},
+				u:     &slotUserRepo{users: users},
+				t:     &slotTeamRepo{},
+				s:     &slotScheduleRepo{booked: tt.booked},
+				redis: redis,
+			}
+
+			lock, target, got, err := s.lockBookingSlot(1, "applicant_1", team, possibles, start, end, window, tt.userMin)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("lockBookingSlot() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if err != nil {
+				// 枠が埋まった場合はロックを解放
+				if len(redis.keys) != 0 {
+					t.Errorf("lock not released: %v", redis.keys)
+				}
+				return
+			}
+			defer lock.Unlock()
+			if !lock.Held() || len(got) != tt.want {
+				t.Errorf("lockBookingSlot() held = %v, users = %d, want %d", lock.Held(), len(got), tt.want)
+			}
+			if target.applicant.ScheduleID != tt.scheduleID || len(target.interviewers) != len(tt.interviewers) {
+				t.Errorf("lockBookingSlot() target = %+v, want schedule %d", target.applicant, tt.scheduleID)
+			}
+		})
+	}
+}
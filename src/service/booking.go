@@ -0,0 +1,281 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 面接予約枠取得 ※面接回数毎の設定 → チーム既定 → システム既定の順に採用
+func getBookingWindow(r repository.ITeamRepository, teamID uint64, numOfInterview uint) (*dto.BookingWindow, error) {
+	windows, windowsErr := r.ListBookingWindow(&ddl.TeamBookingWindow{
+		TeamID:         teamID,
+		NumOfInterview: numOfInterview,
+	})
+	if windowsErr != nil {
+		return nil, windowsErr
+	}
+	hours, hoursErr := r.ListBookingHours(&ddl.TeamBookingHours{
+		TeamID:         teamID,
+		NumOfInterview: numOfInterview,
+	})
+	if hoursErr != nil {
+		return nil, hoursErr
+	}
+
+	w, err := newBookingWindow(selectBookingWindow(teamID, numOfInterview, windows, hours))
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return w, nil
+}
+
+// 面接予約枠設定の選択 ※受付時間は採用した設定と同じ階層のもの
+func selectBookingWindow(
+	teamID uint64,
+	numOfInterview uint,
+	windows []*ddl.TeamBookingWindow,
+	hours []*ddl.TeamBookingHours,
+) (*ddl.TeamBookingWindow, []*ddl.TeamBookingHours) {
+	var selected *ddl.TeamBookingWindow
+	for _, row := range windows {
+		if row.NumOfInterview == numOfInterview {
+			selected = row
+			break
+		}
+		if row.NumOfInterview == 0 {
+			selected = row
+		}
+	}
+	if selected == nil {
+		return defaultBookingWindow(teamID, numOfInterview)
+	}
+
+	var selectedHours []*ddl.TeamBookingHours
+	for _, row := range hours {
+		if row.NumOfInterview == selected.NumOfInterview {
+			selectedHours = append(selectedHours, row)
+		}
+	}
+	return selected, selectedHours
+}
+
+// システム既定の面接予約枠設定 ※毎日同じ受付時間
+func defaultBookingWindow(teamID uint64, numOfInterview uint) (*ddl.TeamBookingWindow, []*ddl.TeamBookingHours) {
+	var hours []*ddl.TeamBookingHours
+	for weekday := uint(time.Sunday); weekday <= uint(time.Saturday); weekday++ {
+		hours = append(hours, &ddl.TeamBookingHours{
+			TeamID:         teamID,
+			NumOfInterview: numOfInterview,
+			Weekday:        weekday,
+			StartMinute:    static.BOOKING_DEFAULT_START_MINUTE,
+			EndMinute:      static.BOOKING_DEFAULT_END_MINUTE,
+		})
+	}
+	return &ddl.TeamBookingWindow{
+		TeamID:         teamID,
+		NumOfInterview: numOfInterview,
+		TimeZone:       static.BOOKING_DEFAULT_TIME_ZONE,
+		Duration:       static.BOOKING_DEFAULT_DURATION,
+		SlotInterval:   static.BOOKING_DEFAULT_SLOT_INTERVAL,
+		LeadTime:       static.BOOKING_DEFAULT_LEAD_TIME,
+		Horizon:        static.BOOKING_DEFAULT_HORIZON,
+		Buffer:         static.BOOKING_DEFAULT_BUFFER,
+	}, hours
+}
+
+// 面接予約枠設定の変換
+func newBookingWindow(m *ddl.TeamBookingWindow, hours []*ddl.TeamBookingHours) (*dto.BookingWindow, error) {
+	loc, err := time.LoadLocation(m.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	res := dto.BookingWindow{
+		Location:     loc,
+		Duration:     time.Duration(m.Duration) * time.Minute,
+		SlotInterval: time.Duration(m.SlotInterval) * time.Minute,
+		LeadTime:     time.Duration(m.LeadTime) * time.Hour,
+		Horizon:      int(m.Horizon),
+		Buffer:       time.Duration(m.Buffer) * time.Minute,
+	}
+	for _, row := range hours {
+		if row.Weekday <= uint(time.Saturday) {
+			res.Hours[row.Weekday] = row
+		}
+	}
+	return &res, nil
+}
+
+// 受付期間の日付一覧 ※受付締切を過ぎた最初の日から受付期間の日数
+func bookingDays(w *dto.BookingWindow, now time.Time) []time.Time {
+	first := now.Add(w.LeadTime).In(w.Location)
+
+	var res []time.Time
+	for i := 0; i < w.Horizon; i++ {
+		// 夏時間の切り替わりを考慮し、日付・時刻は現地時間で組み立てる
+		res = append(res, time.Date(first.Year(), first.Month(), first.Day()+i, 0, 0, 0, 0, w.Location))
+	}
+	return res
+}
+
+// 予約枠一覧 ※各日の受付時間内に面接が収まる枠
+func bookingSlots(w *dto.BookingWindow, now time.Time, holidays []time.Time) []dto.BookingSlot {
+	earliest := now.Add(w.LeadTime)
+	duration := int(w.Duration / time.Minute)
+	interval := int(w.SlotInterval / time.Minute)
+
+	var res []dto.BookingSlot
+	for _, day := range bookingDays(w, now) {
+		hours := w.Hours[day.Weekday()]
+		if hours == nil || interval <= 0 {
+			continue
+		}
+		holiday := isBookingHoliday(day, holidays)
+		for minute := int(hours.StartMinute); minute+duration <= int(hours.EndMinute); minute += interval {
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, minute, 0, 0, w.Location)
+			res = append(res, dto.BookingSlot{
+				Start: start,
+				End:   start.Add(w.Duration),
+				Open:  !holiday && !start.Before(earliest),
+			})
+		}
+	}
+	return res
+}
+
+// 予約可能な枠か
+func bookingSlotOpen(w *dto.BookingWindow, start time.Time, now time.Time, holidays []time.Time) bool {
+	for _, slot := range bookingSlots(w, now, holidays) {
+		if slot.Start.Equal(start) {
+			return slot.Open
+		}
+	}
+	return false
+}
+
+// 予定との重複判定 ※前後の間隔を含め、境界の接触も重複とみなす
+func bookingOverlaps(start time.Time, end time.Time, busyStart time.Time, busyEnd time.Time, buffer time.Duration) bool {
+	return !end.Before(busyStart.Add(-buffer)) && !start.After(busyEnd.Add(buffer))
+}
+
+// 休日判定
+func isBookingHoliday(day time.Time, holidays []time.Time) bool {
+	y1, m1, d1 := day.Date()
+	for _, holiday := range holidays {
+		y2, m2, d2 := holiday.Date()
+		if y1 == y2 && m1 == m2 && d1 == d2 {
+			return true
+		}
+	}
+	return false
+}
+
+// 予約ロック
+type bookingLock struct {
+	r     repository.IRedisRepository
+	key   string
+	token string
+	// 有効期限(UnixNano) ※延長に失敗し続けた場合の失効判定
+	expires atomic.Int64
+	// 他に取得された
+	lost atomic.Bool
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// 予約ロック取得 ※同一企業の予約を直列化、取得できるまでwait待機
+// 保持中は有効期限を延長し、解放漏れはロック有効期限で自動解放
+func lockBooking(r repository.IRedisRepository, companyID uint64, wait time.Duration) (*bookingLock, error) {
+	token, tokenErr := newSessionToken(static.SESSION_ID_BYTES)
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+
+	ctx := context.Background()
+	key := static.REDIS_BOOKING_LOCK_PRE + strconv.FormatUint(companyID, 10)
+	deadline := time.Now().Add(wait)
+	for {
+		requestedAt := time.Now()
+		ok, lockErr := r.Lock(ctx, key, token, static.BOOKING_LOCK_TTL)
+		if lockErr != nil {
+			return nil, lockErr
+		}
+		if ok {
+			l := &bookingLock{
+				r:     r,
+				key:   key,
+				token: token,
+				stop:  make(chan struct{}),
+				done:  make(chan struct{}),
+			}
+			l.expires.Store(requestedAt.Add(static.BOOKING_LOCK_TTL).UnixNano())
+			go l.keepAlive()
+			return l, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("booking lock timeout: %s", key)
+		}
+		time.Sleep(static.BOOKING_LOCK_RETRY_INTERVAL)
+	}
+}
+
+// 保持中か ※コミット直前に確認し、失われていた場合は中断する
+func (l *bookingLock) Held() bool {
+	return !l.lost.Load() && time.Now().UnixNano() < l.expires.Load()
+}
+
+// 解放 ※複数回呼び出し可、他に取得されたロックは解放しない
+func (l *bookingLock) Unlock() {
+	l.once.Do(func() {
+		close(l.stop)
+		<-l.done
+		if err := l.r.Unlock(context.Background(), l.key, l.token); err != nil {
+			log.Printf("booking lock release failed: %s: %v", l.key, err)
+		}
+	})
+}
+
+// 解放まで定期的に延長
+func (l *bookingLock) keepAlive() {
+	defer close(l.done)
+	ticker := time.NewTicker(static.BOOKING_LOCK_RENEW_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			if !l.extend() {
+				return
+			}
+		}
+	}
+}
+
+// 延長 ※失われていた場合はfalse、通信エラーは次回に再試行
+func (l *bookingLock) extend() bool {
+	requestedAt := time.Now()
+	ok, err := l.r.Extend(context.Background(), l.key, l.token, static.BOOKING_LOCK_TTL)
+	if err != nil {
+		log.Printf("booking lock renew failed: %s: %v", l.key, err)
+		return true
+	}
+	if !ok {
+		log.Printf("booking lock lost: %s", l.key)
+		l.lost.Store(true)
+		return false
+	}
+	l.expires.Store(requestedAt.Add(static.BOOKING_LOCK_TTL).UnixNano())
+	return true
+}
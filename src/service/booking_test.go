@@ -0,0 +1,343 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSelectBookingWindow(t *testing.T) {
+	team := &ddl.TeamBookingWindow{TeamID: 1, NumOfInterview: 0, TimeZone: "Asia/Tokyo", Duration: 45}
+	round := &ddl.TeamBookingWindow{TeamID: 1, NumOfInterview: 2, TimeZone: "Asia/Tokyo", Duration: 90}
+	hours := []*ddl.TeamBookingHours{
+		{TeamID: 1, NumOfInterview: 0, Weekday: 1, StartMinute: 600, EndMinute: 1080},
+		{TeamID: 1, NumOfInterview: 2, Weekday: 3, StartMinute: 540, EndMinute: 720},
+		{TeamID: 1, NumOfInterview: 2, Weekday: 4, StartMinute: 540, EndMinute: 720},
+	}
+
+	tests := []struct {
+		name         string
+		num          uint
+		windows      []*ddl.TeamBookingWindow
+		wantDuration uint
+		wantHours    int
+	}{
+		// ok 面接回数毎の設定を優先
+		{"ok_round", 2, []*ddl.TeamBookingWindow{team, round}, 90, 2},
+		// ok 面接回数毎の設定がない場合はチーム既定
+		{"ok_team", 1, []*ddl.TeamBookingWindow{team}, 45, 1},
+		// ok 設定がない場合はシステム既定(毎日)
+		{"ok_default", 1, nil, static.BOOKING_DEFAULT_DURATION, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, windowHours := selectBookingWindow(1, tt.num, tt.windows, hours)
+			if window.Duration != tt.wantDuration {
+				t.Errorf("selectBookingWindow() duration = %v, want %v", window.Duration, tt.wantDuration)
+			}
+			if len(windowHours) != tt.wantHours {
+				t.Errorf("selectBookingWindow() hours = %v, want %v", len(windowHours), tt.wantHours)
+			}
+		})
+	}
+}
+
+func TestBookingSlots(t *testing.T) {
+	w, err := newBookingWindow(&ddl.TeamBookingWindow{
+		TimeZone:     "Asia/Tokyo",
+		Duration:     60,
+		SlotInterval: 30,
+		LeadTime:     24,
+		Horizon:      3,
+	}, []*ddl.TeamBookingHours{
+		// 月曜 10:00～12:00、水曜 9:00～10:30
+		{Weekday: 1, StartMinute: 600, EndMinute: 720},
+		{Weekday: 3, StartMinute: 540, EndMinute: 630},
+	})
+	if err != nil {
+		t.Fatalf("newBookingWindow() error = %v", err)
+	}
+
+	// 2024/07/14(日) 10:30 → 受付開始 2024/07/15(月・海の日) 10:30
+	now := time.Date(2024, 7, 14, 10, 30, 0, 0, w.Location)
+	holiday := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	days := bookingDays(w, now)
+	if len(days) != 3 || days[0].Day() != 15 || days[2].Day() != 17 {
+		t.Fatalf("bookingDays() = %v", days)
+	}
+
+	tests := []struct {
+		name     string
+		holidays []time.Time
+		want     map[string]bool
+	}{
+		// ok 受付締切前・面接時間が受付時間を超える枠は除外
+		{"ok", nil, map[string]bool{
+			"07-15 10:00": false,
+			"07-15 10:30": true,
+			"07-15 11:00": true,
+			"07-17 09:00": true,
+			"07-17 09:30": true,
+		}},
+		// ok 休日は受付不可
+		{"ok_holiday", []time.Time{holiday}, map[string]bool{
+			"07-15 10:00": false,
+			"07-15 10:30": false,
+			"07-15 11:00": false,
+			"07-17 09:00": true,
+			"07-17 09:30": true,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]bool{}
+			for _, slot := range bookingSlots(w, now, tt.holidays) {
+				if slot.End.Sub(slot.Start) != time.Hour {
+					t.Errorf("bookingSlots() duration = %v", slot.End.Sub(slot.Start))
+				}
+				got[slot.Start.Format("01-02 15:04")] = slot.Open
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("bookingSlots() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if open, ok := got[k]; !ok || open != v {
+					t.Errorf("bookingSlots()[%s] = %v, want %v", k, open, v)
+				}
+			}
+		})
+	}
+}
+
+func TestBookingSlotOpen(t *testing.T) {
+	w, err := newBookingWindow(defaultBookingWindow(1, 1))
+	if err != nil {
+		t.Fatalf("newBookingWindow() error = %v", err)
+	}
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, w.Location)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  bool
+	}{
+		// ok 受付期間内の枠
+		{"ok", time.Date(2024, 7, 9, 10, 30, 0, 0, w.Location), true},
+		// ok UTC指定
+		{"ok_utc", time.Date(2024, 7, 9, 1, 30, 0, 0, time.UTC), true},
+		// ng 刻みに合わない
+		{"ng_interval", time.Date(2024, 7, 9, 10, 15, 0, 0, w.Location), false},
+		// ng 受付締切前
+		{"ng_lead_time", time.Date(2024, 7, 8, 11, 30, 0, 0, w.Location), false},
+		// ng 面接が受付終了を超える
+		{"ng_end", time.Date(2024, 7, 9, 19, 30, 0, 0, w.Location), false},
+		// ng 受付期間外
+		{"ng_horizon", time.Date(2024, 7, 22, 10, 0, 0, 0, w.Location), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bookingSlotOpen(w, tt.start, now, nil); got != tt.want {
+				t.Errorf("bookingSlotOpen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingOverlaps(t *testing.T) {
+	base := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		start  time.Time
+		buffer time.Duration
+		want   bool
+	}{
+		// ok 重複なし
+		{"ok_after", base.Add(2 * time.Hour), 0, false},
+		// ng 重複
+		{"ng_overlap", base.Add(30 * time.Minute), 0, true},
+		// ng 境界の接触
+		{"ng_touch", base.Add(time.Hour), 0, true},
+		// ng 間隔内
+		{"ng_buffer", base.Add(time.Hour + 10*time.Minute), 15 * time.Minute, true},
+		// ok 間隔外
+		{"ok_buffer", base.Add(time.Hour + 20*time.Minute), 15 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bookingOverlaps(tt.start, tt.start.Add(time.Hour), base, base.Add(time.Hour), tt.buffer)
+			if got != tt.want {
+				t.Errorf("bookingOverlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// ロック(Redis)のメモリ実装 ※ロック以外は未使用
+type memoryLockRedis struct {
+	repository.IRedisRepository
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+func (r *memoryLockRedis) Lock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.keys[key]; ok {
+		return false, nil
+	}
+	r.keys[key] = token
+	return true, nil
+}
+
+func (r *memoryLockRedis) Unlock(ctx context.Context, key string, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys[key] == token {
+		delete(r.keys, key)
+	}
+	return nil
+}
+
+func (r *memoryLockRedis) Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.keys[key] == token, nil
+}
+
+// 期限切れで他に取得された状態を再現
+func (r *memoryLockRedis) steal(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[key] = "other"
+}
+
+func TestLockBookingConcurrent(t *testing.T) {
+	r := &memoryLockRedis{keys: make(map[string]string)}
+	start := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	// 同じ枠への同時予約 ※判定から登録までの間に他の予約が入り得る状態を再現
+	var booked [][2]time.Time
+	var ok, taken int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lock, err := lockBooking(r, 1, 5*time.Second)
+			if err != nil {
+				t.Errorf("lockBooking() error = %v", err)
+				return
+			}
+			defer lock.Unlock()
+
+			free := true
+			for _, row := range booked {
+				if bookingOverlaps(start, end, row[0], row[1], 0) {
+					free = false
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !free {
+				taken++
+				return
+			}
+			booked = append(booked, [2]time.Time{start, end})
+			ok++
+		}()
+	}
+	wg.Wait()
+
+	if ok != 1 || taken != 19 {
+		t.Errorf("booked = %d, taken = %d, want 1, 19", ok, taken)
+	}
+}
+
+func TestLockBooking(t *testing.T) {
+	r := &memoryLockRedis{keys: make(map[string]string)}
+
+	lock, err := lockBooking(r, 1, time.Second)
+	if err != nil {
+		t.Fatalf("lockBooking() error = %v", err)
+	}
+
+	// ng 同一企業は待機時間を過ぎるとエラー
+	if _, err := lockBooking(r, 1, 100*time.Millisecond); err == nil {
+		t.Errorf("lockBooking() same company error = nil")
+	}
+	// ok 他企業は待機しない
+	other, otherErr := lockBooking(r, 2, 0)
+	if otherErr != nil {
+		t.Errorf("lockBooking() other company error = %v", otherErr)
+	} else {
+		other.Unlock()
+	}
+
+	// ok 解放後は取得可能、解放の再呼び出しは他の取得に影響しない
+	lock.Unlock()
+	next, nextErr := lockBooking(r, 1, 0)
+	if nextErr != nil {
+		t.Fatalf("lockBooking() after unlock error = %v", nextErr)
+	}
+	lock.Unlock()
+	if _, err := lockBooking(r, 1, 0); err == nil {
+		t.Errorf("lockBooking() released by stale unlock")
+	}
+	next.Unlock()
+}
+
+func TestBookingLock_extend(t *testing.T) {
+	r := &memoryLockRedis{keys: make(map[string]string)}
+	key := static.REDIS_BOOKING_LOCK_PRE + "1"
+
+	lock, err := lockBooking(r, 1, 0)
+	if err != nil {
+		t.Fatalf("lockBooking() error = %v", err)
+	}
+	defer lock.Unlock()
+
+	// ok 保持中は延長
+	expires := lock.expires.Load()
+	time.Sleep(time.Millisecond)
+	if !lock.extend() || !lock.Held() || lock.expires.Load() <= expires {
+		t.Errorf("extend() held = %v, expires = %d, want after %d", lock.Held(), lock.expires.Load(), expires)
+	}
+
+	// ng 期限切れで他に取得された場合は延長せず、保持していない
+	r.steal(key)
+	if lock.extend() || lock.Held() {
+		t.Errorf("extend() after lost held = %v", lock.Held())
+	}
+
+	// ng 他に取得されたロックは解放しない
+	lock.Unlock()
+	if r.keys[key] != "other" {
+		t.Errorf("Unlock() released lock of other = %v", r.keys)
+	}
+}
+
+func TestBookingLock_expired(t *testing.T) {
+	r := &memoryLockRedis{keys: make(map[string]string)}
+
+	lock, err := lockBooking(r, 1, 0)
+	if err != nil {
+		t.Fatalf("lockBooking() error = %v", err)
+	}
+	defer lock.Unlock()
+
+	// ng 延長できないまま有効期限を過ぎた場合は保持していない
+	lock.expires.Store(time.Now().Add(-time.Second).UnixNano())
+	if lock.Held() {
+		t.Errorf("Held() after expires = true")
+	}
+}
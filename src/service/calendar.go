@@ -0,0 +1,250 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ICalendarService interface {
+	// 購読トークン発行 ※発行済みの場合は再発行
+	IssueToken(req *request.IssueCalendarToken) (*response.IssueCalendarToken, *response.Error)
+	// 購読トークン削除
+	DeleteToken(req *request.DeleteCalendarToken) *response.Error
+	// フィード
+	Feed(req *request.CalendarFeed) ([]byte, *response.Error)
+}
+
+type CalendarService struct {
+	r     repository.ICalendarRepository
+	t     repository.ITeamRepository
+	redis repository.IRedisRepository
+	db    repository.IDBRepository
+}
+
+func NewCalendarService(
+	r repository.ICalendarRepository,
+	t repository.ITeamRepository,
+	redis repository.IRedisRepository,
+	db repository.IDBRepository,
+) ICalendarService {
+	return &CalendarService{r, t, redis, db}
+}
+
+// 購読トークン発行 ※発行済みの場合は再発行
+func (s *CalendarService) IssueToken(req *request.IssueCalendarToken) (*response.IssueCalendarToken, *response.Error) {
+	userID, userErr := s.userID(req.UserHashKey)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	// トークン生成 ※DBにはハッシュ値のみ保存
+	buf := make([]byte, static.CALENDAR_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 既存トークン削除
+	if err := s.r.DeleteToken(tx, &ddl.UserCalendarToken{
+		UserID: userID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	if err := s.r.InsertToken(tx, &ddl.UserCalendarToken{
+		UserID: userID,
+		Token:  calendarTokenHash(token),
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.IssueCalendarToken{
+		Token: token,
+		Path:  static.CALENDAR_FEED_PATH + token,
+	}, nil
+}
+
+// 購読トークン削除
+func (s *CalendarService) DeleteToken(req *request.DeleteCalendarToken) *response.Error {
+	userID, userErr := s.userID(req.UserHashKey)
+	if userErr != nil {
+		return userErr
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.r.DeleteToken(tx, &ddl.UserCalendarToken{
+		UserID: userID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// フィード
+func (s *CalendarService) Feed(req *request.CalendarFeed) ([]byte, *response.Error) {
+	if req.Token == "" {
+		return nil, &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+
+	// トークン照合
+	token, tokenErr := s.r.GetToken(&ddl.UserCalendarToken{
+		Token: calendarTokenHash(req.Token),
+	})
+	if tokenErr != nil {
+		if tokenErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusNotFound,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 予定取得
+	now := time.Now()
+	from := now.AddDate(0, 0, -static.CALENDAR_FEED_PAST_DAYS)
+	to := now.AddDate(0, 0, static.CALENDAR_FEED_FUTURE_DAYS)
+	schedules, schedulesErr := s.r.ListSchedule(&dto.ListCalendarSchedule{
+		UserID: token.UserID,
+		From:   from,
+		To:     to,
+	})
+	if schedulesErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 繰り返し予定は掲載期間の各回に展開
+	locations := make(map[uint64]*time.Location)
+	w := newICSWriter(static.ICS_METHOD_PUBLISH, static.ICS_CALENDAR_NAME)
+	for i := range schedules {
+		row := &schedules[i]
+		loc, locErr := scheduleLocation(s.t, row.TeamID, locations)
+		if locErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		for _, occurrence := range expandSchedule(&row.Schedule, row.Exceptions, from, to, loc) {
+			w.Event(calendarEvent(row, &occurrence))
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// ログインユーザーID取得
+func (s *CalendarService) userID(userHashKey string) (uint64, *response.Error) {
+	ctx := context.Background()
+	user, userErr := s.redis.Get(ctx, userHashKey, static.REDIS_USER_ID)
+	if userErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	userID, userIDErr := strconv.ParseUint(*user, 10, 64)
+	if userIDErr != nil {
+		log.Printf("%v", userIDErr)
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return userID, nil
+}
+
+// 購読トークンハッシュ化
+func calendarTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// 予定→iCalendarイベント変換 ※繰り返し予定は回毎に本来の開始時刻でUIDを分ける
+func calendarEvent(m *entity.CalendarSchedule, o *dto.ScheduleOccurrence) *icsEvent {
+	uid := m.HashKey
+	if isRecurring(m.FreqID) {
+		uid += "_" + icsTime(o.OriginalStart)
+	}
+	e := &icsEvent{
+		UID:       uid,
+		Summary:   m.Title,
+		URL:       m.GoogleMeetURL,
+		Start:     o.Start,
+		End:       o.End,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.ApplicantName != "" {
+		e.Description = fmt.Sprintf(static.ICS_DESCRIPTION_INTERVIEW, m.ApplicantName)
+	}
+	return e
+}
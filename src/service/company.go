@@ -2,13 +2,19 @@ package service
 
 import (
 	"api/src/model/ddl"
+	"api/src/model/dto"
 	"api/src/model/request"
 	"api/src/model/response"
 	"api/src/model/static"
 	"api/src/repository"
 	"api/src/validator"
+	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type ICompanyService interface {
@@ -16,6 +22,22 @@ type ICompanyService interface {
 	Create(req *request.CreateCompany) (*response.CreateCompany, *response.Error)
 	// 検索
 	Search(req *request.SearchCompany) (*response.SearchCompany, *response.Error)
+	// セキュリティ設定取得
+	GetSecurity(req *request.GetCompanySecurity) (*response.GetCompanySecurity, *response.Error)
+	// セキュリティ設定更新
+	UpdateSecurity(req *request.UpdateCompanySecurity) *response.Error
+	// シングルサインオン設定取得
+	GetOIDC(req *request.GetCompanyOIDC) (*response.GetCompanyOIDC, *response.Error)
+	// シングルサインオン設定更新
+	UpdateOIDC(req *request.UpdateCompanyOIDC) *response.Error
+	// 休日一覧
+	ListHoliday(req *request.ListCompanyHoliday) (*response.ListCompanyHoliday, *response.Error)
+	// 休日登録
+	SaveHoliday(req *request.SaveCompanyHoliday) *response.Error
+	// 休日削除
+	DeleteHoliday(req *request.DeleteCompanyHoliday) *response.Error
+	// 休日取込
+	ImportHoliday(req *request.ImportCompanyHoliday, fileHeader *multipart.FileHeader) (*response.ImportCompanyHoliday, *response.Error)
 }
 
 type CompanyService struct {
@@ -26,6 +48,8 @@ type CompanyService struct {
 	team    repository.ITeamRepository
 	v       validator.ICompanyValidator
 	db      repository.IDBRepository
+	mail    repository.IMailRepository
+	holiday repository.IHolidayRepository
 }
 
 func NewCompanyService(
@@ -36,8 +60,10 @@ func NewCompanyService(
 	team repository.ITeamRepository,
 	v validator.ICompanyValidator,
 	db repository.IDBRepository,
+	mail repository.IMailRepository,
+	holiday repository.IHolidayRepository,
 ) ICompanyService {
-	return &CompanyService{company, master, role, user, team, v, db}
+	return &CompanyService{company, master, role, user, team, v, db, mail, holiday}
 }
 
 // 登録
@@ -169,7 +195,8 @@ func (c *CompanyService) Create(req *request.CreateCompany) (*response.CreateCom
 		}
 	}
 
-	password, hashPassword, passwordErr := GenerateHash(8, 16)
+	// 初回パスワード発行 ※企業設定前のため既定のポリシー
+	password, hashPassword, passwordErr := GeneratePassword(passwordPolicy(nil))
 	if passwordErr != nil {
 		log.Printf("%v", passwordErr)
 		return nil, &response.Error{
@@ -202,6 +229,21 @@ func (c *CompanyService) Create(req *request.CreateCompany) (*response.CreateCom
 		}
 	}
 
+	// パスワード履歴登録
+	if err := c.user.InsertPasswordHistory(tx, &ddl.UserPasswordHistory{
+		UserID:   user.ID,
+		Password: *hashPassword,
+	}); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// チーム用モデル生成
 	_, teamHash, teamHashErr := GenerateHash(1, 25)
 	if teamHashErr != nil {
@@ -341,7 +383,16 @@ func (c *CompanyService) Create(req *request.CreateCompany) (*response.CreateCom
 		}
 	}
 
-	log.Print(*password)
+	// 初回パスワード送信 ※失敗時も登録は確定済みのため、履歴に残しレスポンスで返却する
+	if err := c.mail.Send(&dto.Mail{
+		CompanyID: company.ID,
+		Kind:      static.MAIL_KIND_INIT_PASSWORD_COMPANY,
+		To:        req.Email,
+		Subject:   static.MAIL_SUBJECT_INIT_PASSWORD,
+		Body:      fmt.Sprintf(static.MAIL_BODY_INIT_PASSWORD, userModel.Name, req.Email, *password),
+	}); err != nil {
+		log.Printf("%v", err)
+	}
 
 	return &response.CreateCompany{
 		Password: *password,
@@ -370,3 +421,566 @@ func (c *CompanyService) Search(req *request.SearchCompany) (*response.SearchCom
 		List: res,
 	}, nil
 }
+
+// セキュリティ設定取得
+func (c *CompanyService) GetSecurity(req *request.GetCompanySecurity) (*response.GetCompanySecurity, *response.Error) {
+	// バリデーション
+	if err := c.v.GetSecurity(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	security, securityErr := c.company.GetSecurity(&ddl.CompanySecurity{
+		CompanyID: user.CompanyID,
+	})
+	if securityErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.GetCompanySecurity{
+		CompanySecurity: *security,
+	}, nil
+}
+
+// セキュリティ設定更新 ※認証アプリ必須化は次回ログインから適用
+func (c *CompanyService) UpdateSecurity(req *request.UpdateCompanySecurity) *response.Error {
+	// バリデーション
+	if err := c.v.UpdateSecurity(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := c.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.company.SaveSecurity(tx, &ddl.CompanySecurity{
+		CompanyID:          user.CompanyID,
+		RequireTOTP:        req.RequireTOTP,
+		LockoutThreshold:   req.LockoutThreshold,
+		LockoutSeconds:     req.LockoutSeconds,
+		LockoutMaxSeconds:  req.LockoutMaxSeconds,
+		PasswordMinLength:  req.PasswordMinLength,
+		PasswordMinClasses: req.PasswordMinClasses,
+		PasswordHistory:    req.PasswordHistory,
+		UpdatedAt:          time.Now(),
+	}); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// シングルサインオン設定取得
+func (c *CompanyService) GetOIDC(req *request.GetCompanyOIDC) (*response.GetCompanyOIDC, *response.Error) {
+	// バリデーション
+	if err := c.v.GetOIDC(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	oidc, oidcErr := c.company.GetOIDC(&ddl.CompanyOIDC{
+		CompanyID: user.CompanyID,
+	})
+	if oidcErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	domains, domainsErr := c.company.ListOIDCDomain(&ddl.CompanyOIDCDomain{
+		CompanyID: user.CompanyID,
+	})
+	if domainsErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := response.GetCompanyOIDC{
+		Enabled:         oidc.Enabled,
+		Issuer:          oidc.Issuer,
+		ClientID:        oidc.ClientID,
+		HasClientSecret: oidc.ClientSecret != "",
+		DisablePassword: oidc.DisablePassword,
+		Domains:         []string{},
+		RedirectURI:     oidcRedirectURI(),
+	}
+	for _, d := range domains {
+		res.Domains = append(res.Domains, d.Domain)
+	}
+
+	return &res, nil
+}
+
+// シングルサインオン設定更新 ※クライアントシークレット未入力の場合は既存を維持
+func (c *CompanyService) UpdateOIDC(req *request.UpdateCompanyOIDC) *response.Error {
+	// バリデーション
+	if err := c.v.UpdateOIDC(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	current, currentErr := c.company.GetOIDC(&ddl.CompanyOIDC{
+		CompanyID: user.CompanyID,
+	})
+	if currentErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// クライアントシークレット暗号化
+	secret := current.ClientSecret
+	if req.ClientSecret != "" {
+		encrypted, err := encryptSecret(static.OIDC_ENCRYPTION_KEY, req.ClientSecret)
+		if err != nil {
+			log.Printf("%v", err)
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		secret = encrypted
+	}
+	if req.Enabled && secret == "" {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_COMPANY_SSO_CLIENT_EMPTY,
+		}
+	}
+
+	// 許可ドメイン ※小文字で重複排除
+	var domains []string
+	var rows []ddl.CompanyOIDCDomain
+	seen := map[string]bool{}
+	for _, d := range req.Domains {
+		domain := strings.ToLower(d)
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+		rows = append(rows, ddl.CompanyOIDCDomain{
+			Domain:    domain,
+			CompanyID: user.CompanyID,
+		})
+	}
+
+	// 他企業で登録済みのドメインは不可
+	if err := c.company.IsDuplOIDCDomain(user.CompanyID, domains); err != nil {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_COMPANY_SSO_DOMAIN_DUPL,
+		}
+	}
+
+	tx, txErr := c.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.company.SaveOIDC(tx, &ddl.CompanyOIDC{
+		CompanyID:       user.CompanyID,
+		Enabled:         req.Enabled,
+		Issuer:          req.Issuer,
+		ClientID:        req.ClientID,
+		ClientSecret:    secret,
+		DisablePassword: req.DisablePassword,
+		UpdatedAt:       time.Now(),
+	}); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.company.DeleteOIDCDomain(tx, &ddl.CompanyOIDCDomain{
+		CompanyID: user.CompanyID,
+	}); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.company.InsertOIDCDomain(tx, rows); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 休日一覧 ※企業休日と同梱の日本の祝日
+func (c *CompanyService) ListHoliday(req *request.ListCompanyHoliday) (*response.ListCompanyHoliday, *response.Error) {
+	// バリデーション
+	if err := c.v.ListHoliday(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	from := time.Date(req.Year, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(req.Year, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	list, listErr := c.holiday.ListCompany(&dto.HolidayRange{
+		CompanyID: user.CompanyID,
+		From:      from,
+		To:        to,
+	})
+	if listErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.ListCompanyHoliday{
+		List: list,
+		Jp:   c.holiday.ListJp(from, to),
+	}, nil
+}
+
+// 休日登録
+func (c *CompanyService) SaveHoliday(req *request.SaveCompanyHoliday) *response.Error {
+	// バリデーション
+	if err := c.v.SaveHoliday(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+	date, dateErr := parseHolidayDate(req.Date)
+	if dateErr != nil {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := c.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.holiday.InsertsCompany(tx, []*ddl.CompanyHoliday{
+		{
+			CompanyID: user.CompanyID,
+			Date:      date,
+			Kind:      req.Kind,
+			Name:      holidayName(req.Name),
+		},
+	}); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	c.holiday.ClearCache(user.CompanyID)
+
+	return nil
+}
+
+// 休日削除
+func (c *CompanyService) DeleteHoliday(req *request.DeleteCompanyHoliday) *response.Error {
+	// バリデーション
+	if err := c.v.DeleteHoliday(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+	date, dateErr := parseHolidayDate(req.Date)
+	if dateErr != nil {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := c.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.holiday.DeleteCompany(tx, &ddl.CompanyHoliday{
+		CompanyID: user.CompanyID,
+		Date:      date,
+	}); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	c.holiday.ClearCache(user.CompanyID)
+
+	return nil
+}
+
+// 休日取込 ※同日の登録がある場合は上書き、ファイル内で重複する日付は後勝ち
+func (c *CompanyService) ImportHoliday(req *request.ImportCompanyHoliday, fileHeader *multipart.FileHeader) (*response.ImportCompanyHoliday, *response.Error) {
+	// バリデーション
+	if err := c.v.ImportHoliday(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+	if fileHeader.Size > static.HOLIDAY_IMPORT_MAX_FILE_SIZE {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_COMPANY_HOLIDAY_IMPORT_TOO_LARGE,
+		}
+	}
+
+	// ファイル読み込み
+	file, openErr := fileHeader.Open()
+	if openErr != nil {
+		log.Printf("%v", openErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	defer file.Close()
+	data, readErr := io.ReadAll(file)
+	if readErr != nil {
+		log.Printf("%v", readErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	holidays, parseErr := parseHolidayFile(fileHeader.Filename, data)
+	if parseErr != nil {
+		log.Printf("%v", parseErr)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_COMPANY_HOLIDAY_IMPORT_INVALID_FORMAT,
+		}
+	}
+	if len(holidays) == 0 {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_COMPANY_HOLIDAY_IMPORT_INVALID_FORMAT,
+		}
+	}
+	if len(holidays) > static.HOLIDAY_IMPORT_MAX_ROWS {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_COMPANY_HOLIDAY_IMPORT_TOO_LARGE,
+		}
+	}
+
+	// 操作者の企業
+	user, userErr := c.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 日付で重複排除 ※同一文内で同じキーを複数回更新できないため
+	var rows []*ddl.CompanyHoliday
+	index := map[time.Time]int{}
+	for _, row := range holidays {
+		m := &ddl.CompanyHoliday{
+			CompanyID: user.CompanyID,
+			Date:      row.Date,
+			Kind:      req.Kind,
+			Name:      row.Name,
+		}
+		if i, ok := index[row.Date]; ok {
+			rows[i] = m
+			continue
+		}
+		index[row.Date] = len(rows)
+		rows = append(rows, m)
+	}
+
+	tx, txErr := c.db.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.holiday.InsertsCompany(tx, rows); err != nil {
+		if err := c.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := c.db.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	c.holiday.ClearCache(user.CompanyID)
+
+	return &response.ImportCompanyHoliday{
+		Count: len(rows),
+	}, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 実行スケジュール(cron形式) ※分 時 日 月 曜日、各フィールドの該当値をビットで保持
+type cronSpec struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// 日・曜日がいずれも指定されている場合はどちらかに一致で実行(cron互換)
+	domAny bool
+	dowAny bool
+}
+
+// cron形式のフィールド範囲
+var cronFields = [5]struct {
+	name string
+	min  int
+	max  int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// 実行スケジュールの解析 ※「*」「*/n」「a-b」「a-b/n」「a,b」に対応、曜日の7は日曜
+func parseCron(spec string) (*cronSpec, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron spec: %s", spec)
+	}
+
+	var bits [5]uint64
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %s: %w", cronFields[i].name, err)
+		}
+		bits[i] = b
+	}
+	// 曜日の7は0(日曜)に寄せる
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &cronSpec{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+// フィールドの解析
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var res uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step: %s", part)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case expr == "*":
+			lo, hi = min, max
+		case strings.Contains(expr, "-"):
+			loStr, hiStr, _ := strings.Cut(expr, "-")
+			a, aErr := strconv.Atoi(loStr)
+			b, bErr := strconv.Atoi(hiStr)
+			if aErr != nil || bErr != nil {
+				return 0, fmt.Errorf("invalid range: %s", part)
+			}
+			lo, hi = a, b
+		default:
+			n, err := strconv.Atoi(expr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value: %s", part)
+			}
+			lo, hi = n, n
+			// 「5/15」は5から最大値まで
+			if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("out of range: %s", part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			res |= 1 << uint(v)
+		}
+	}
+	return res, nil
+}
+
+// 実行時刻か ※分単位、tのタイムゾーンで判定
+func (c *cronSpec) match(t time.Time) bool {
+	if c.minute&(1<<uint(t.Minute())) == 0 ||
+		c.hour&(1<<uint(t.Hour())) == 0 ||
+		c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"api/src/model/static"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		// ok 毎分
+		{"ok_every_minute", "* * * * *", false},
+		// ok 間隔・範囲・列挙
+		{"ok_fields", "*/10 9-18/2 1,15 1-12 1-5", false},
+		// ok 曜日の7は日曜
+		{"ok_sunday_7", "0 0 * * 7", false},
+		// ok ジョブの実行スケジュール
+		{"ok_schedule_maintenance", static.JOB_SPEC_SCHEDULE_MAINTENANCE, false},
+		{"ok_interview_reminder", static.JOB_SPEC_INTERVIEW_REMINDER, false},
+		{"ok_stale_cleanup", static.JOB_SPEC_STALE_CLEANUP, false},
+		// ng フィールド数
+		{"ng_fields", "* * * *", true},
+		// ng 範囲外
+		{"ng_minute", "60 * * * *", true},
+		{"ng_day_of_month", "0 0 0 * *", true},
+		// ng 逆順の範囲
+		{"ng_range", "0 18-9 * * *", true},
+		// ng 間隔
+		{"ng_step", "*/0 * * * *", true},
+		// ng 数値以外
+		{"ng_value", "0 0 * JAN *", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCron(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCron() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronSpecMatch(t *testing.T) {
+	// 2024-01-15は月曜
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		t    time.Time
+		want bool
+	}{
+		// ok 時刻指定
+		{"ok_daily", "15 3 * * *", at(15, 3, 15), true},
+		// ok 間隔
+		{"ok_step", "*/10 * * * *", at(15, 9, 40), true},
+		// ok 開始値からの間隔
+		{"ok_start_step", "5/20 * * * *", at(15, 9, 45), true},
+		// ok 曜日の範囲
+		{"ok_weekday", "0 9 * * 1-5", at(15, 9, 0), true},
+		// ok 日・曜日の両方指定はいずれかに一致
+		{"ok_dom_or_dow", "0 0 1 * 1", at(15, 0, 0), true},
+		// ok 曜日の7は日曜
+		{"ok_sunday_7", "0 0 * * 7", at(14, 0, 0), true},
+		// ng 分が不一致
+		{"ng_minute", "15 3 * * *", at(15, 3, 16), false},
+		// ng 間隔外
+		{"ng_step", "*/10 * * * *", at(15, 9, 41), false},
+		// ng 曜日外
+		{"ng_weekday", "0 9 * * 1-5", at(14, 9, 0), false},
+		// ng 日のみ指定は曜日を問わず日で判定
+		{"ng_dom_only", "0 0 1 * *", at(15, 0, 0), false},
+		// ng 月が不一致
+		{"ng_month", "0 0 * 2 *", at(15, 0, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseCron(tt.spec)
+			if err != nil {
+				t.Fatalf("parseCron() error = %v", err)
+			}
+			if got := c.match(tt.t); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"api/src/model/entity"
+	"api/src/model/static"
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 表形式出力
+type exportWriter interface {
+	// 1行出力
+	Write(record []string) error
+	// 終端処理
+	Close() error
+}
+
+// CSV出力 ※Excelで開けるようBOM付きUTF-8
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) (exportWriter, error) {
+	if _, err := w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return nil, err
+	}
+	return &csvExportWriter{csv.NewWriter(w)}, nil
+}
+
+func (c *csvExportWriter) Write(record []string) error {
+	cells := make([]string, len(record))
+	for i, value := range record {
+		cells[i] = csvEscapeFormula(value)
+	}
+	return c.w.Write(cells)
+}
+
+// 数式として解釈される値の無効化 ※先頭に「'」を付与(CSVインジェクション対策)
+func csvEscapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (c *csvExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// XLSX出力 ※シート1枚、全セル文字列のみの最小構成
+type xlsxExportWriter struct {
+	z     *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+func newXLSXExportWriter(w io.Writer) (exportWriter, error) {
+	z := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	// シートは最後に作成し、行ごとに書き込む
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	return &xlsxExportWriter{z, sheet, 0}, nil
+}
+
+func (x *xlsxExportWriter) Write(record []string) error {
+	x.row++
+	if _, err := fmt.Fprintf(x.sheet, `<row r="%d">`, x.row); err != nil {
+		return err
+	}
+	for i, value := range record {
+		if _, err := fmt.Fprintf(x.sheet, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, xlsxColumn(i), x.row); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(x.sheet, []byte(value)); err != nil {
+			return err
+		}
+		if _, err := x.sheet.WriteString(`</t></is></c>`); err != nil {
+			return err
+		}
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxExportWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.z.Close()
+}
+
+// 列番号(0始まり)から列名(A, B, ..., AA)へ変換
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// 応募者エクスポート行 ※日時はチームのタイムゾーンで出力
+func exportApplicantRow(a *entity.SearchApplicant, loc *time.Location) []string {
+	var users []string
+	for _, user := range a.Users {
+		users = append(users, user.Name)
+	}
+
+	start := ""
+	if !a.Start.IsZero() {
+		start = a.Start.In(loc).Format(static.EXPORT_DATE_FORMAT)
+	}
+
+	documentPass := "選考中"
+	if a.DocumentPassFlg == static.DOCUMENT_PASS {
+		documentPass = "通過"
+	} else if a.DocumentPassFlg == static.DOCUMENT_FAIL {
+		documentPass = "不通過"
+	}
+
+	return []string{
+		a.OuterID,
+		a.Name,
+		a.Email,
+		a.Tel,
+		formatUint(a.Age),
+		a.SiteName,
+		a.StatusName,
+		formatUint(a.NumOfInterview),
+		documentPass,
+		a.Type,
+		a.Content,
+		start,
+		a.GoogleMeetURL,
+		strings.Join(users, ","),
+		exportDocument(a.ResumeExtension),
+		exportDocument(a.CurriculumVitaeExtension),
+		a.CreatedAt.In(loc).Format(static.EXPORT_DATE_FORMAT),
+	}
+}
+
+// 書類有無
+func exportDocument(extension string) string {
+	if extension == "" {
+		return "未提出"
+	}
+	return "提出済"
+}
+
+// 数値の文字列変換 ※0の場合は空
+func formatUint(v uint) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCSVExportWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		record []string
+		want   []string
+	}{
+		// ok 通常の値 ※区切り文字・改行・引用符はCSVとしてエスケープ
+		{"ok", []string{"山田 太郎", "a,b", "改行\nあり", `"引用"`, ""}, []string{"山田 太郎", "a,b", "改行\nあり", `"引用"`, ""}},
+		// ok 数式として解釈される先頭文字は「'」を付与
+		{"ok_formula", []string{"=HYPERLINK(\"x\")", "+81-90", "-1", "@SUM(A1)", "\tx", "\rx"}, []string{"'=HYPERLINK(\"x\")", "'+81-90", "'-1", "'@SUM(A1)", "'\tx", "'\rx"}},
+		// ok 先頭以外の記号は変換しない
+		{"ok_inner", []string{"a=b", "090-1234", "x@example.com"}, []string{"a=b", "090-1234", "x@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := newCSVExportWriter(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Write(tt.record); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.HasPrefix(buf.Bytes(), []byte("\xEF\xBB\xBF")) {
+				t.Fatalf("csv = %q, want BOM", buf.String())
+			}
+			got, err := csv.NewReader(bytes.NewReader(buf.Bytes()[3:])).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("csv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// XLSXシートの読み込み ※セル参照と値
+type xlsxTestSheet struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R    string `xml:"r,attr"`
+			Type string `xml:"t,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestXLSXExportWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newXLSXExportWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := [][]string{
+		{"氏名", "メモ"},
+		// 数式・XML特殊文字は文字列のまま
+		{"=1+1", "<a>&\"b\""},
+	}
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if files[name] == nil {
+			t.Fatalf("xlsx missing %s", name)
+		}
+	}
+
+	f, err := files["xl/worksheets/sheet1.xml"].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	body, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sheet xlsxTestSheet
+	if err := xml.Unmarshal(body, &sheet); err != nil {
+		t.Fatalf("sheet1.xml = %s: %v", body, err)
+	}
+
+	if len(sheet.Rows) != len(records) {
+		t.Fatalf("rows = %d, want %d", len(sheet.Rows), len(records))
+	}
+	wantRefs := [][]string{{"A1", "B1"}, {"A2", "B2"}}
+	for i, row := range sheet.Rows {
+		for j, c := range row.Cells {
+			if c.R != wantRefs[i][j] || c.Type != "inlineStr" || c.Text != records[i][j] {
+				t.Errorf("cell[%d][%d] = %+v, want %s %q", i, j, c, wantRefs[i][j], records[i][j])
+			}
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, tt := range tests {
+		if got := xlsxColumn(tt.i); got != tt.want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestExportApplicantRow(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &entity.SearchApplicant{
+		Applicant: ddl.Applicant{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				CreatedAt: time.Date(2024, time.April, 1, 15, 30, 0, 0, time.UTC),
+			},
+			Name: "山田 太郎",
+		},
+		Start: time.Date(2024, time.April, 10, 1, 0, 0, 0, time.UTC),
+		Users: []*ddl.User{{Name: "面接官A"}, {Name: "面接官B"}},
+	}
+
+	tests := []struct {
+		name      string
+		loc       *time.Location
+		wantStart string
+		wantAt    string
+	}{
+		// ok チームのタイムゾーン(日付を跨ぐ)
+		{"ok_tokyo", tokyo, "2024/04/10 10:00", "2024/04/02 00:30"},
+		// ok 夏時間
+		{"ok_new_york", newYork, "2024/04/09 21:00", "2024/04/01 11:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := exportApplicantRow(a, tt.loc)
+			if len(row) != 17 {
+				t.Fatalf("len(row) = %d, want 17", len(row))
+			}
+			if row[11] != tt.wantStart || row[16] != tt.wantAt {
+				t.Errorf("start = %s, created_at = %s, want %s, %s", row[11], row[16], tt.wantStart, tt.wantAt)
+			}
+			if row[1] != a.Name || row[13] != "面接官A,面接官B" {
+				t.Errorf("row = %q", row)
+			}
+		})
+	}
+
+	// 面接予定なしは空
+	if row := exportApplicantRow(&entity.SearchApplicant{}, tokyo); row[11] != "" {
+		t.Errorf("start = %s, want empty", row[11])
+	}
+}
@@ -1,11 +1,22 @@
 package service
 
 import (
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/static"
+	"bytes"
 	"crypto/rand"
+	"encoding/csv"
 	"log"
 	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/width"
 )
 
 // ハッシュ生成
@@ -38,7 +49,130 @@ func GenerateHash(minLength, maxLength int) (*string, *string, error) {
 	return &str, &hash, nil
 }
 
-// 閏年判定
-func isLeapYear(year int) bool {
-	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+// 取込ファイル名から媒体判定 ※ファイル名キーワードで始まる媒体、複数該当時は最長一致
+func detectImportSite(sites []entity.Site, fileName string) *entity.Site {
+	name := strings.ToLower(filepath.Base(fileName))
+	var res *entity.Site
+	for i := range sites {
+		prefix := strings.ToLower(sites[i].FileName)
+		if prefix == "" || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if res == nil || len(prefix) > len(res.FileName) {
+			res = &sites[i]
+		}
+	}
+	return res
+}
+
+// 取込ファイル文字コード変換 ※UTF-8(BOM付き含む)、Shift_JISに対応
+func decodeImportFile(data []byte) ([]byte, error) {
+	bom := []byte{0xEF, 0xBB, 0xBF}
+	if bytes.HasPrefix(data, bom) {
+		return data[len(bom):], nil
+	}
+	if utf8.Valid(data) {
+		return data, nil
+	}
+	decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(data)
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// 取込ファイル解析 ※UTF-8変換済みのCSV/TSV
+func parseImportFile(fileName string, data []byte) ([][]string, error) {
+	// 区切り文字判定
+	comma := ','
+	if strings.EqualFold(filepath.Ext(fileName), static.IMPORT_EXT_TSV) {
+		comma = '\t'
+	} else {
+		firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+		if bytes.Count(firstLine, []byte("\t")) > bytes.Count(firstLine, []byte(",")) {
+			comma = '\t'
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// 取込ファイル行から応募者変換
+func convertImportRow(site *entity.Site, record []string) request.ApplicantDownloadSub {
+	column := func(index uint) string {
+		if int(index) >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	// 氏名
+	name := column(site.NameIndex)
+	if site.NameCheckType == static.NAME_CHECK_TYPE_SPLIT {
+		name = strings.TrimSpace(name + " " + column(site.NameIndex+1))
+	}
+
+	// TEL ※携帯が空文字の場合は自宅
+	tel := column(site.TELIndex)
+	if sub, ok := static.TelSubIndex[site.ID]; ok && tel == "" {
+		tel = column(sub)
+	}
+	tel = strings.NewReplacer("-", "", " ", "", "(", "", ")", "").Replace(width.Narrow.String(tel))
+
+	// 年齢 ※数字以外を除去
+	age, _ := strconv.ParseInt(strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, width.Narrow.String(column(site.AgeIndex))), 10, 64)
+
+	return request.ApplicantDownloadSub{
+		OuterID:    column(site.OuterIDIndex),
+		Name:       name,
+		Email:      width.Narrow.String(column(site.EmailIndex)),
+		Tel:        tel,
+		Age:        age,
+		Manuscript: column(site.ManuscriptIndex),
+	}
+}
+
+// 応募者ダウンロード内容のCSV変換 ※アップロード履歴用
+func encodeDownloadCSV(rows []request.ApplicantDownloadSub) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"outer_id", "name", "email", "tel", "age", "manuscript_hash"}); err != nil {
+		log.Printf("%v", err)
+		return "", err
+	}
+	for _, row := range rows {
+		if err := writer.Write([]string{
+			row.OuterID,
+			row.Name,
+			row.Email,
+			row.Tel,
+			strconv.FormatInt(row.Age, 10),
+			row.ManuscriptHash,
+		}); err != nil {
+			log.Printf("%v", err)
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("%v", err)
+		return "", err
+	}
+	return buf.String(), nil
 }
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/static"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// 媒体マスタ ※初期データと同じ設定
+func importSites() []entity.Site {
+	return []entity.Site{
+		{Site: ddl.Site{
+			AbstractMasterModel: ddl.AbstractMasterModel{ID: static.RECRUIT},
+			SiteName:            "リクナビNEXT",
+			FileName:            static.FILE_NAME_RECRUIT,
+			OuterIDIndex:        static.INDEX_RECRUIT_OUTER_ID,
+			NameIndex:           static.INDEX_RECRUIT_NAME,
+			EmailIndex:          static.INDEX_RECRUIT_EMAIL,
+			TELIndex:            static.INDEX_RECRUIT_TEL,
+			AgeIndex:            static.INDEX_RECRUIT_AGE,
+			ManuscriptIndex:     static.INDEX_RECRUIT_MANUSCRIPT,
+			NameCheckType:       static.NAME_CHECK_TYPE_FULL,
+			NumOfColumn:         static.COLUMNS_RECRUIT,
+		}},
+		{Site: ddl.Site{
+			AbstractMasterModel: ddl.AbstractMasterModel{ID: static.MYNAVI},
+			SiteName:            "マイナビ",
+			FileName:            static.FILE_NAME_MYNAVI,
+			OuterIDIndex:        static.INDEX_MYNAVI_OUTER_ID,
+			NameIndex:           static.INDEX_MYNAVI_NAME,
+			EmailIndex:          static.INDEX_MYNAVI_EMAIL,
+			TELIndex:            static.INDEX_MYNAVI_TEL,
+			AgeIndex:            static.INDEX_MYNAVI_AGE,
+			ManuscriptIndex:     static.INDEX_MYNAVI_MANUSCRIPT,
+			NumOfColumn:         static.COLUMNS_MYNAVI,
+		}},
+		{Site: ddl.Site{
+			AbstractMasterModel: ddl.AbstractMasterModel{ID: static.DODA},
+			SiteName:            "DODA",
+			FileName:            static.FILE_NAME_DODA,
+			OuterIDIndex:        static.INDEX_DODA_OUTER_ID,
+			NameIndex:           static.INDEX_DODA_NAME,
+			EmailIndex:          static.INDEX_DODA_EMAIL,
+			TELIndex:            static.INDEX_DODA_TEL,
+			AgeIndex:            static.INDEX_DODA_AGE,
+			ManuscriptIndex:     static.INDEX_DODA_MANUSCRIPT,
+			NumOfColumn:         static.COLUMNS_DODA,
+		}},
+	}
+}
+
+// 取込ファイル作成 ※見出し行＋データ行、指定のindexに値を設定
+func importSampleFile(site *entity.Site, sep string, rows ...map[uint]string) string {
+	num := site.NumOfColumn
+	for _, row := range rows {
+		for index := range row {
+			if index+1 > num {
+				num = index + 1
+			}
+		}
+	}
+
+	var b strings.Builder
+	header := make([]string, num)
+	for i := range header {
+		header[i] = "項目"
+	}
+	b.WriteString(strings.Join(header, sep) + "\r\n")
+	for _, row := range rows {
+		record := make([]string, num)
+		for index, value := range row {
+			record[index] = value
+		}
+		b.WriteString(strings.Join(record, sep) + "\r\n")
+	}
+	return b.String()
+}
+
+func TestDetectImportSite(t *testing.T) {
+	sites := importSites()
+	// 名称が他の媒体のキーワードで始まる媒体
+	sites = append(sites, entity.Site{Site: ddl.Site{
+		AbstractMasterModel: ddl.AbstractMasterModel{ID: 4},
+		FileName:            static.FILE_NAME_MYNAVI + "_tenshoku",
+	}})
+
+	tests := []struct {
+		name     string
+		fileName string
+		want     uint
+	}{
+		// ok 各媒体
+		{"ok_recruit", "oubosha_20240401.csv", static.RECRUIT},
+		{"ok_mynavi", "mynavi_list.csv", static.MYNAVI},
+		{"ok_doda", "Senko20240401.tsv", static.DODA},
+		// ok 大文字・小文字を区別しない
+		{"ok_case", "SENKO_20240401.csv", static.DODA},
+		// ok ディレクトリは無視
+		{"ok_dir", "senko/oubosha.csv", static.RECRUIT},
+		// ok 複数該当時は最長一致
+		{"ok_longest", "mynavi_tenshoku_0401.csv", 4},
+		// ng キーワードが先頭以外
+		{"ng_contains", "list_mynavi.csv", 0},
+		// ng 空のファイル名
+		{"ng_empty", "", 0},
+		// ng 拡張子のみ
+		{"ng_ext", ".csv", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectImportSite(sites, tt.fileName)
+			if tt.want == 0 {
+				if got != nil {
+					t.Errorf("detectImportSite() = %v, want nil", got.ID)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.want {
+				t.Errorf("detectImportSite() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeImportFile(t *testing.T) {
+	sjis, _ := japanese.ShiftJIS.NewEncoder().String("山田 太郎,ﾔﾏﾀﾞ")
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		// ok UTF-8
+		{"ok_utf8", "山田 太郎,ﾔﾏﾀﾞ", "山田 太郎,ﾔﾏﾀﾞ"},
+		// ok UTF-8(BOM付き) ※BOMを除去
+		{"ok_bom", "\xEF\xBB\xBF山田 太郎,ﾔﾏﾀﾞ", "山田 太郎,ﾔﾏﾀﾞ"},
+		// ok Shift_JIS
+		{"ok_sjis", sjis, "山田 太郎,ﾔﾏﾀﾞ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeImportFile([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("decodeImportFile() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("decodeImportFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		data     string
+		want     [][]string
+	}{
+		// ok CSV
+		{"ok_csv", "a.csv", "id,name\r\n1,山田\r\n", [][]string{{"id", "name"}, {"1", "山田"}}},
+		// ok CSV 引用符内の区切り文字・改行
+		{"ok_quoted", "a.csv", "id,name\n1,\"山田,\n太郎\"\n", [][]string{{"id", "name"}, {"1", "山田,\n太郎"}}},
+		// ok TSV 拡張子で判定 ※カンマを含む値
+		{"ok_tsv_ext", "a.TSV", "id\tname\n1\t山田,太郎\n", [][]string{{"id", "name"}, {"1", "山田,太郎"}}},
+		// ok TSV 拡張子がCSVでも1行目のタブが多い場合
+		{"ok_tsv_sniff", "a.csv", "id\tname\tmemo,x\n1\t山田\tb\n", [][]string{{"id", "name", "memo,x"}, {"1", "山田", "b"}}},
+		// ok 列数が行毎に異なる
+		{"ok_ragged", "a.csv", "id,name,age\n1,山田\n", [][]string{{"id", "name", "age"}, {"1", "山田"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportFile(tt.fileName, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseImportFile() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseImportFile() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if strings.Join(got[i], "|") != strings.Join(tt.want[i], "|") {
+					t.Errorf("parseImportFile()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertImportRow(t *testing.T) {
+	sites := importSites()
+	recruit, mynavi, doda := &sites[0], &sites[1], &sites[2]
+
+	tests := []struct {
+		name string
+		site *entity.Site
+		row  map[uint]string
+		want request.ApplicantDownloadSub
+	}{
+		// ok リクナビNEXT 氏名1カラム・全角英数字
+		{"ok_recruit", recruit, map[uint]string{
+			static.INDEX_RECRUIT_OUTER_ID:   "R0001",
+			static.INDEX_RECRUIT_NAME:       " 山田 太郎 ",
+			static.INDEX_RECRUIT_EMAIL:      "ｔａｒｏ＠example.com",
+			static.INDEX_RECRUIT_TEL:        "０９０-１２３４-５６７８",
+			static.INDEX_RECRUIT_AGE:        "２８歳",
+			static.INDEX_RECRUIT_MANUSCRIPT: "営業職",
+		}, request.ApplicantDownloadSub{
+			OuterID:    "R0001",
+			Name:       "山田 太郎",
+			Email:      "taro@example.com",
+			Tel:        "09012345678",
+			Age:        28,
+			Manuscript: "営業職",
+		}},
+		// ok マイナビ 性・名が別カラム
+		{"ok_mynavi", mynavi, map[uint]string{
+			static.INDEX_MYNAVI_OUTER_ID:   "M0001",
+			static.INDEX_MYNAVI_NAME:       "鈴木",
+			static.INDEX_MYNAVI_NAME + 1:   "花子",
+			static.INDEX_MYNAVI_EMAIL:      "hanako@example.com",
+			static.INDEX_MYNAVI_TEL:        "(080) 1111 2222",
+			static.INDEX_MYNAVI_TEL_SUB:    "0311112222",
+			static.INDEX_MYNAVI_AGE:        "25",
+			static.INDEX_MYNAVI_MANUSCRIPT: "事務職",
+		}, request.ApplicantDownloadSub{
+			OuterID:    "M0001",
+			Name:       "鈴木 花子",
+			Email:      "hanako@example.com",
+			Tel:        "08011112222",
+			Age:        25,
+			Manuscript: "事務職",
+		}},
+		// ok マイナビ 携帯が空の場合は自宅
+		{"ok_mynavi_tel_sub", mynavi, map[uint]string{
+			static.INDEX_MYNAVI_NAME:     "鈴木",
+			static.INDEX_MYNAVI_NAME + 1: "花子",
+			static.INDEX_MYNAVI_TEL_SUB:  "03-1111-2222",
+		}, request.ApplicantDownloadSub{
+			Name: "鈴木 花子",
+			Tel:  "0311112222",
+		}},
+		// ok DODA 携帯が空の場合は自宅・名が空
+		{"ok_doda_tel_sub", doda, map[uint]string{
+			static.INDEX_DODA_OUTER_ID: "D0001",
+			static.INDEX_DODA_NAME:     "佐藤",
+			static.INDEX_DODA_TEL_SUB:  "０４５-１１１-２２２２",
+		}, request.ApplicantDownloadSub{
+			OuterID: "D0001",
+			Name:    "佐藤",
+			Tel:     "0451112222",
+		}},
+		// ok リクナビNEXT 自宅の代替なし
+		{"ok_recruit_no_sub", recruit, map[uint]string{
+			static.INDEX_RECRUIT_NAME: "山田 太郎",
+		}, request.ApplicantDownloadSub{
+			Name: "山田 太郎",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := make([]string, tt.site.NumOfColumn+1)
+			for index, value := range tt.row {
+				record[index] = value
+			}
+			if got := convertImportRow(tt.site, record); got != tt.want {
+				t.Errorf("convertImportRow() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// ng 列数不足 ※存在しない列は空文字
+	if got := convertImportRow(mynavi, []string{"M0001", "鈴木"}); got.OuterID != "M0001" || got.Name != "鈴木" || got.Email != "" {
+		t.Errorf("convertImportRow() = %+v, want short record", got)
+	}
+}
+
+// 媒体毎のサンプルファイルを判定から変換まで通して取込
+func TestImportSampleFile(t *testing.T) {
+	sites := importSites()
+	sjis := func(s string) string {
+		res, _ := japanese.ShiftJIS.NewEncoder().String(s)
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		data     string
+		want     []request.ApplicantDownloadSub
+	}{
+		// ok リクナビNEXT UTF-8(BOM付き) CSV
+		{"ok_recruit", "oubosha_0401.csv", "\xEF\xBB\xBF" + importSampleFile(&sites[0], ",", map[uint]string{
+			static.INDEX_RECRUIT_OUTER_ID: "R0001",
+			static.INDEX_RECRUIT_NAME:     "山田 太郎",
+			static.INDEX_RECRUIT_EMAIL:    "taro@example.com",
+			static.INDEX_RECRUIT_TEL:      "090-1234-5678",
+			static.INDEX_RECRUIT_AGE:      "28",
+		}), []request.ApplicantDownloadSub{
+			{OuterID: "R0001", Name: "山田 太郎", Email: "taro@example.com", Tel: "09012345678", Age: 28},
+		}},
+		// ok マイナビ Shift_JIS CSV 2行
+		{"ok_mynavi", "mynavi_0401.csv", sjis(importSampleFile(&sites[1], ",", map[uint]string{
+			static.INDEX_MYNAVI_OUTER_ID: "M0001",
+			static.INDEX_MYNAVI_NAME:     "鈴木",
+			static.INDEX_MYNAVI_NAME + 1: "花子",
+			static.INDEX_MYNAVI_TEL:      "080-1111-2222",
+		}, map[uint]string{
+			static.INDEX_MYNAVI_OUTER_ID: "M0002",
+			static.INDEX_MYNAVI_NAME:     "高橋",
+			static.INDEX_MYNAVI_NAME + 1: "一郎",
+			static.INDEX_MYNAVI_TEL_SUB:  "03-1111-2222",
+		})), []request.ApplicantDownloadSub{
+			{OuterID: "M0001", Name: "鈴木 花子", Tel: "08011112222"},
+			{OuterID: "M0002", Name: "高橋 一郎", Tel: "0311112222"},
+		}},
+		// ok DODA Shift_JIS 拡張子CSVのTSV
+		{"ok_doda", "Senko_0401.csv", sjis(importSampleFile(&sites[2], "\t", map[uint]string{
+			static.INDEX_DODA_OUTER_ID: "D0001",
+			static.INDEX_DODA_NAME:     "佐藤",
+			static.INDEX_DODA_NAME + 1: "次郎",
+			static.INDEX_DODA_AGE:      "３０歳",
+		})), []request.ApplicantDownloadSub{
+			{OuterID: "D0001", Name: "佐藤 次郎", Age: 30},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := detectImportSite(sites, tt.fileName)
+			if site == nil {
+				t.Fatalf("detectImportSite() = nil")
+			}
+			decoded, decodeErr := decodeImportFile([]byte(tt.data))
+			if decodeErr != nil {
+				t.Fatalf("decodeImportFile() error = %v", decodeErr)
+			}
+			records, parseErr := parseImportFile(tt.fileName, decoded)
+			if parseErr != nil {
+				t.Fatalf("parseImportFile() error = %v", parseErr)
+			}
+			if len(records[0]) < int(site.NumOfColumn) {
+				t.Fatalf("columns = %d, want >= %d", len(records[0]), site.NumOfColumn)
+			}
+			rows := records[static.IMPORT_HEADER_ROWS:]
+			if len(rows) != len(tt.want) {
+				t.Fatalf("rows = %d, want %d", len(rows), len(tt.want))
+			}
+			for i, record := range rows {
+				if got := convertImportRow(site, record); got != tt.want[i] {
+					t.Errorf("convertImportRow()[%d] = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
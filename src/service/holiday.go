@@ -0,0 +1,248 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"api/src/repository"
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 期間内の休業日取得 ※期間は日付で両端を含み、年を跨いでもよい
+// 日本の祝日・チームの定休日・企業の休業日を休業とし、企業の特別営業日はいずれよりも優先
+func getClosedDays(
+	hd repository.IHolidayRepository,
+	t repository.ITeamRepository,
+	companyID uint64,
+	teamID uint64,
+	from time.Time,
+	to time.Time,
+) ([]time.Time, error) {
+	company, companyErr := hd.ListCompany(&dto.HolidayRange{
+		CompanyID: companyID,
+		From:      from,
+		To:        to,
+	})
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	weekdays, weekdaysErr := t.ListWeekday(&ddl.TeamWeekday{
+		TeamID: teamID,
+	})
+	if weekdaysErr != nil {
+		return nil, weekdaysErr
+	}
+	closed, _ := closedWeekdays(weekdays)
+
+	return closedDays(from, to, hd.ListJp(from, to), company, closed), nil
+}
+
+// 定休日の曜日 ※チームの設定がない場合は土日
+func closedWeekdays(rows []*ddl.TeamWeekday) ([7]bool, bool) {
+	var res [7]bool
+	if len(rows) == 0 {
+		res[time.Sunday] = true
+		res[time.Saturday] = true
+		return res, true
+	}
+	for _, row := range rows {
+		if row.Weekday <= uint(time.Saturday) {
+			res[row.Weekday] = row.Closed
+		}
+	}
+	return res, false
+}
+
+// 休業日の判定
+func closedDays(
+	from time.Time,
+	to time.Time,
+	jp []dto.Holiday,
+	company []entity.CompanyHoliday,
+	weekdays [7]bool,
+) []time.Time {
+	kinds := make(map[string]uint)
+	for _, row := range company {
+		kinds[row.Date.Format("2006-01-02")] = row.Kind
+	}
+	holidays := make(map[string]bool)
+	for _, row := range jp {
+		holidays[row.Date.Format("2006-01-02")] = true
+	}
+
+	var res []time.Time
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		switch {
+		case kinds[key] == static.HOLIDAY_KIND_WORKING:
+			continue
+		case kinds[key] == static.HOLIDAY_KIND_CLOSED, holidays[key], weekdays[day.Weekday()]:
+			res = append(res, day)
+		}
+	}
+	return res
+}
+
+// 日付の解析 ※YYYY-MM-DD・YYYY/M/D・YYYYMMDD
+func parseHolidayDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02", "2006/1/2", "20060102"} {
+		if d, err := time.Parse(layout, s); err == nil {
+			return d, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s", s)
+}
+
+// 休日ファイルの解析 ※拡張子または内容でiCalendar・CSVを判定
+func parseHolidayFile(fileName string, data []byte) ([]dto.Holiday, error) {
+	decoded, err := decodeImportFile(data)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(fileName), static.HOLIDAY_IMPORT_EXT_ICS) ||
+		bytes.HasPrefix(bytes.TrimSpace(decoded), []byte("BEGIN:VCALENDAR")) {
+		return parseHolidayICS(decoded)
+	}
+	return parseHolidayCSV(decoded)
+}
+
+// CSVの解析 ※1列目が日付、2列目が名称、先頭行が日付でない場合は見出しとして除外
+func parseHolidayCSV(data []byte) ([]dto.Holiday, error) {
+	records, err := parseImportFile(".csv", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []dto.Holiday
+	for i, record := range records {
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		date, dateErr := parseHolidayDate(record[0])
+		if dateErr != nil {
+			if i == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: %w", i+1, dateErr)
+		}
+		var name string
+		if len(record) > 1 {
+			name = record[1]
+		}
+		res = append(res, dto.Holiday{
+			Date: date,
+			Name: holidayName(name),
+		})
+	}
+	return res, nil
+}
+
+// iCalendar(RFC 5545)の解析 ※VEVENTの開始日～終了日、繰り返し(RRULE)は展開しない
+func parseHolidayICS(data []byte) ([]dto.Holiday, error) {
+	var res []dto.Holiday
+	var inEvent bool
+	var start, end time.Time
+	var allDay bool
+	var summary string
+
+	for _, line := range icsUnfold(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		params := strings.Split(name, ";")
+		switch strings.ToUpper(params[0]) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end, allDay, summary = time.Time{}, time.Time{}, false, ""
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("DTSTART is required")
+			}
+			// 終日の終了日は翌日(含まない)、時刻指定の場合は開始日のみ
+			last := start
+			if allDay && end.After(start) {
+				last = end.AddDate(0, 0, -1)
+			}
+			if last.Sub(start) > 366*24*time.Hour {
+				return nil, fmt.Errorf("event too long: %s", summary)
+			}
+			for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+				res = append(res, dto.Holiday{
+					Date: day,
+					Name: holidayName(summary),
+				})
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			// 日付部分のみ使用 ※時刻指定の場合はTZIDに関わらず記載の日付
+			if len(value) < 8 {
+				return nil, fmt.Errorf("invalid %s: %s", params[0], value)
+			}
+			d, err := time.Parse("20060102", value[:8])
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(params[0], "DTSTART") {
+				start = d
+				allDay = len(value) == 8
+			} else {
+				end = d
+			}
+		case "SUMMARY":
+			if inEvent {
+				summary = icsUnescape(value)
+			}
+		}
+	}
+	return res, nil
+}
+
+// 継続行の結合 ※空白・タブ始まりの行は前の行の続き
+func icsUnfold(data []byte) []string {
+	var res []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(res) > 0 {
+			res[len(res)-1] += line[1:]
+			continue
+		}
+		res = append(res, line)
+	}
+	return res
+}
+
+// テキスト値のエスケープ解除
+func icsUnescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// 名称の整形 ※最大文字数で切り詰め
+func holidayName(s string) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= static.HOLIDAY_NAME_MAX_LENGTH {
+		return s
+	}
+	return string([]rune(s)[:static.HOLIDAY_NAME_MAX_LENGTH])
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"testing"
+	"time"
+)
+
+func TestClosedDays(t *testing.T) {
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	jp := []dto.Holiday{{Date: date(5, 3)}, {Date: date(5, 6)}}
+	company := []entity.CompanyHoliday{
+		{CompanyHoliday: ddl.CompanyHoliday{Date: date(5, 1), Kind: static.HOLIDAY_KIND_CLOSED}},
+		{CompanyHoliday: ddl.CompanyHoliday{Date: date(5, 6), Kind: static.HOLIDAY_KIND_WORKING}},
+		{CompanyHoliday: ddl.CompanyHoliday{Date: date(5, 11), Kind: static.HOLIDAY_KIND_WORKING}},
+	}
+
+	tests := []struct {
+		name     string
+		weekdays []*ddl.TeamWeekday
+		want     []string
+	}{
+		// ok 既定(土日休み) ※特別営業日は祝日・定休日より優先
+		{"ok_default", nil, []string{"05-01", "05-03", "05-04", "05-05", "05-12"}},
+		// ok 水曜定休
+		{"ok_team", []*ddl.TeamWeekday{
+			{Weekday: 0, Closed: false},
+			{Weekday: 3, Closed: true},
+			{Weekday: 6, Closed: false},
+		}, []string{"05-01", "05-03", "05-08"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weekdays, _ := closedWeekdays(tt.weekdays)
+			// 2024/04/30(火) ～ 2024/05/12(日) ※現地時間の日付で判定
+			from := time.Date(2024, 4, 30, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+			to := time.Date(2024, 5, 12, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+			got := closedDays(from, to, jp, company, weekdays)
+			if len(got) != len(tt.want) {
+				t.Fatalf("closedDays() = %v, want %v", got, tt.want)
+			}
+			for i, day := range got {
+				if d := day.Format("01-02"); d != tt.want[i] {
+					t.Errorf("closedDays()[%d] = %v, want %v", i, d, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseHolidayFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		data     string
+		want     []string
+		wantErr  bool
+	}{
+		// ok CSV 見出し行あり・日付形式混在
+		{"ok_csv", "holidays.csv", "日付,名称\n2024/12/30,年末休業\n2024-12-31,年末休業\n20250102,\n", []string{"2024-12-30", "2024-12-31", "2025-01-02"}, false},
+		// ng CSV 2行目以降の日付不正
+		{"ng_csv", "holidays.csv", "2024-12-30,年末休業\n12/31,年末休業\n", nil, true},
+		// ok iCalendar 終日(終了日を含まない)・時刻指定・継続行
+		{"ok_ics", "holidays.ics", "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20241230\r\nDTEND;VALUE=DATE:20250101\r\nSUMMARY:年末\r\n 休業\r\nEND:VEVENT\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Asia/Tokyo:20250106T090000\r\nDTEND;TZID=Asia/Tokyo:20250106T180000\r\nSUMMARY:棚卸し\\, 休業\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", []string{"2024-12-30", "2024-12-31", "2025-01-06"}, false},
+		// ok 拡張子によらず内容で判定
+		{"ok_ics_content", "holidays.txt", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20250101\nSUMMARY:元日\nEND:VEVENT\nEND:VCALENDAR\n", []string{"2025-01-01"}, false},
+		// ng iCalendar 開始日なし
+		{"ng_ics", "holidays.ics", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:元日\nEND:VEVENT\nEND:VCALENDAR\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHolidayFile(tt.fileName, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHolidayFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseHolidayFile() = %v, want %v", got, tt.want)
+			}
+			for i, row := range got {
+				if d := row.Date.Format("2006-01-02"); d != tt.want[i] {
+					t.Errorf("parseHolidayFile()[%d] = %v, want %v", i, d, tt.want[i])
+				}
+			}
+			if tt.name == "ok_ics" && (got[0].Name != "年末休業" || got[2].Name != "棚卸し, 休業") {
+				t.Errorf("parseHolidayFile() names = %v, %v", got[0].Name, got[2].Name)
+			}
+		})
+	}
+}
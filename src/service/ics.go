@@ -0,0 +1,121 @@
+package service
+
+import (
+	"api/src/model/static"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar(RFC 5545)出力
+type icsWriter struct {
+	b strings.Builder
+}
+
+// iCalendarイベント
+type icsEvent struct {
+	// 識別子 ※予定ハッシュキー
+	UID string
+	// 件名
+	Summary string
+	// 詳細
+	Description string
+	// 場所(URL)
+	URL string
+	// 開始時刻
+	Start time.Time
+	// 終了時刻
+	End time.Time
+	// 更新日時
+	UpdatedAt time.Time
+}
+
+func newICSWriter(method string, name string) *icsWriter {
+	w := &icsWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", static.ICS_PRODID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", method)
+	if name != "" {
+		w.line("X-WR-CALNAME", icsEscape(name))
+	}
+	return w
+}
+
+// イベント出力
+func (w *icsWriter) Event(e *icsEvent) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID+"@"+static.ICS_UID_DOMAIN)
+	w.line("DTSTAMP", icsTime(time.Now()))
+	if !e.UpdatedAt.IsZero() {
+		w.line("LAST-MODIFIED", icsTime(e.UpdatedAt))
+	}
+	w.line("DTSTART", icsTime(e.Start))
+	w.line("DTEND", icsTime(e.End))
+	w.line("SUMMARY", icsEscape(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", icsEscape(e.Description))
+	}
+	// URLはhttp(s)のみ ※不正な値は出力しない
+	if isHTTPURL(e.URL) {
+		w.line("LOCATION", icsEscape(e.URL))
+		w.line("URL", e.URL)
+	}
+	w.line("END", "VEVENT")
+}
+
+// 終端処理
+func (w *icsWriter) Bytes() []byte {
+	w.line("END", "VCALENDAR")
+	return []byte(w.b.String())
+}
+
+// 1行出力 ※75オクテット毎に折り返し(継続行は空白始まり)
+// 値に残った改行はプロパティの注入になるため除去
+func (w *icsWriter) line(name string, value string) {
+	s := name + ":" + icsLineBreak.Replace(value)
+	limit := static.ICS_LINE_OCTETS
+	for len(s) > limit {
+		cut := limit
+		// マルチバイト文字の途中で分割しない
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// 継続行は先頭の空白分短くする
+		limit = static.ICS_LINE_OCTETS - 1
+	}
+	w.b.WriteString(s + "\r\n")
+}
+
+// TEXT値のエスケープ
+func icsEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\r", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// 改行除去
+var icsLineBreak = strings.NewReplacer("\r", "", "\n", "")
+
+// http(s)の絶対URLか
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// 日時(UTC)
+func icsTime(t time.Time) string {
+	return t.UTC().Format(static.ICS_DATE_FORMAT)
+}
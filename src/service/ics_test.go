@@ -0,0 +1,201 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestICSEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		// ok エスケープなし
+		{"ok", "面接 1回目", "面接 1回目"},
+		// ok 区切り文字
+		{"ok_separator", `a\b;c,d`, `a\\b\;c\,d`},
+		// ok 改行(CRLF・LF・CR単独)
+		{"ok_break", "a\r\nb\nc\rd", `a\nb\nc\nd`},
+		// ok プロパティ注入
+		{"ok_inject", "x\rATTENDEE:mailto:evil@example.com", `x\nATTENDEE:mailto:evil@example.com`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := icsEscape(tt.s); got != tt.want {
+				t.Errorf("icsEscape() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestICSWriter_line(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		// ok 折り返しなし
+		{"ok_short", "abc"},
+		// ok ASCIIのみ
+		{"ok_ascii", strings.Repeat("a", 200)},
+		// ok マルチバイト(3オクテット)
+		{"ok_multibyte", strings.Repeat("面", 60)},
+		// ok マルチバイト(4オクテット)とASCIIの混在 ※境界をずらす
+		{"ok_mixed", "a" + strings.Repeat("𠮷b", 40)},
+		// ok 改行は除去
+		{"ok_break", "a\r\nb\rc\nd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &icsWriter{}
+			w.line("SUMMARY", tt.value)
+			out := w.b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line() = %q, want CRLF terminated", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			var unfolded string
+			for i, l := range lines {
+				if len(l) > static.ICS_LINE_OCTETS {
+					t.Errorf("line[%d] = %d octets, want <= %d", i, len(l), static.ICS_LINE_OCTETS)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line[%d] = %q, split inside multibyte character", i, l)
+				}
+				if strings.ContainsAny(l, "\r\n") {
+					t.Errorf("line[%d] = %q, contains line break", i, l)
+				}
+				if i > 0 {
+					if !strings.HasPrefix(l, " ") {
+						t.Errorf("line[%d] = %q, want continuation space", i, l)
+					}
+					l = l[1:]
+				}
+				unfolded += l
+			}
+
+			want := "SUMMARY:" + strings.NewReplacer("\r", "", "\n", "").Replace(tt.value)
+			if unfolded != want {
+				t.Errorf("unfolded = %q, want %q", unfolded, want)
+			}
+		})
+	}
+}
+
+func TestICSWriter_Event(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    []string
+		notWant []string
+	}{
+		// ok http(s)のURL
+		{"ok_url", "https://meet.google.com/abc-defg-hij", []string{
+			"LOCATION:https://meet.google.com/abc-defg-hij\r\n",
+			"URL:https://meet.google.com/abc-defg-hij\r\n",
+		}, nil},
+		// ng 改行による注入 ※出力しない
+		{"ng_inject", "https://meet.google.com/x\r\nATTENDEE:mailto:evil@example.com", nil, []string{
+			"LOCATION", "URL", "ATTENDEE",
+		}},
+		// ng http(s)以外
+		{"ng_scheme", "javascript:alert(1)", nil, []string{"LOCATION", "URL"}},
+		// ng 相対URL
+		{"ng_relative", "meet.google.com/abc", nil, []string{"LOCATION", "URL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newICSWriter(static.ICS_METHOD_PUBLISH, "")
+			w.Event(&icsEvent{
+				UID:     "schedule_1",
+				Summary: "面接\r\nX-INJECT:1",
+				URL:     tt.url,
+				Start:   time.Date(2024, time.April, 1, 1, 0, 0, 0, time.UTC),
+				End:     time.Date(2024, time.April, 1, 2, 0, 0, 0, time.UTC),
+			})
+			out := string(w.Bytes())
+
+			if !strings.Contains(out, "SUMMARY:面接\\nX-INJECT:1\r\n") {
+				t.Errorf("ics = %q, want escaped summary", out)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("ics = %q, want contains %q", out, want)
+				}
+			}
+			for _, l := range strings.Split(out, "\r\n") {
+				for _, name := range tt.notWant {
+					if strings.HasPrefix(l, name) {
+						t.Errorf("ics line = %q, want no %s", l, name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarEvent(t *testing.T) {
+	start := time.Date(2024, time.April, 8, 1, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		freqID   uint
+		o        dto.ScheduleOccurrence
+		wantUID  string
+		wantDesc string
+	}{
+		// ok 単発の予定 ※予定ハッシュキー
+		{"ok_once", static.FREQ_NONE, dto.ScheduleOccurrence{
+			Start: start, End: start.Add(time.Hour), OriginalStart: start,
+		}, "schedule_1", ""},
+		// ok 繰り返し予定 ※回毎に本来の開始時刻を付与
+		{"ok_weekly", static.FREQ_WEEKLY, dto.ScheduleOccurrence{
+			Start: start, End: start.Add(time.Hour), OriginalStart: start,
+		}, "schedule_1_20240408T010000Z", ""},
+		// ok 繰り返し予定の時刻変更 ※変更後も本来の開始時刻でUIDは不変
+		{"ok_weekly_moved", static.FREQ_WEEKLY, dto.ScheduleOccurrence{
+			Start: start.Add(3 * time.Hour), End: start.Add(4 * time.Hour), OriginalStart: start,
+		}, "schedule_1_20240408T010000Z", ""},
+		// ok 毎月
+		{"ok_monthly", static.FREQ_MONTHLY, dto.ScheduleOccurrence{
+			Start: start.AddDate(0, 1, 0), End: start.AddDate(0, 1, 0).Add(time.Hour), OriginalStart: start.AddDate(0, 1, 0),
+		}, "schedule_1_20240508T010000Z", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &entity.CalendarSchedule{
+				Schedule: ddl.Schedule{
+					AbstractTransactionModel: ddl.AbstractTransactionModel{HashKey: "schedule_1"},
+					Title:                    "面接",
+					FreqID:                   tt.freqID,
+				},
+			}
+			o := tt.o
+			e := calendarEvent(m, &o)
+			if e.UID != tt.wantUID {
+				t.Errorf("calendarEvent().UID = %s, want %s", e.UID, tt.wantUID)
+			}
+			if !e.Start.Equal(tt.o.Start) || !e.End.Equal(tt.o.End) {
+				t.Errorf("calendarEvent() = %v - %v, want %v - %v", e.Start, e.End, tt.o.Start, tt.o.End)
+			}
+			if e.Description != tt.wantDesc {
+				t.Errorf("calendarEvent().Description = %q, want %q", e.Description, tt.wantDesc)
+			}
+		})
+	}
+
+	// 面接予定は応募者氏名を詳細に
+	e := calendarEvent(&entity.CalendarSchedule{
+		Schedule:      ddl.Schedule{FreqID: static.FREQ_NONE},
+		ApplicantName: "山田 太郎",
+	}, &dto.ScheduleOccurrence{Start: start, End: start, OriginalStart: start})
+	if e.Description != "応募者: 山田 太郎" {
+		t.Errorf("calendarEvent().Description = %q", e.Description)
+	}
+}
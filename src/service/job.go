@@ -0,0 +1,353 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type IJobService interface {
+	// 常駐実行 ※ctx終了まで毎分実行スケジュールを確認し、実行中のジョブの終了を待って戻る
+	Start(ctx context.Context)
+	// 単発実行 ※他で実行中の場合は実行しない
+	Run(ctx context.Context, name string) error
+	// 実行履歴検索
+	SearchRun(req *request.SearchJobRun) (*response.SearchJobRun, *response.Error)
+}
+
+// ジョブ
+type job struct {
+	// ジョブ名
+	name string
+	// 実行スケジュール
+	spec *cronSpec
+	// 処理 ※処理件数を返却
+	run func(now time.Time) (int64, error)
+}
+
+type JobService struct {
+	r       repository.IJobRepository
+	s       repository.IScheduleRepository
+	u       repository.IUserRepository
+	lockout repository.ILoginLockoutRepository
+	t       repository.ITeamRepository
+	mail    repository.IMailRepository
+	redis   repository.IRedisRepository
+	v       validator.IJobValidator
+	jobs    []*job
+	loc     *time.Location
+	host    string
+	wg      sync.WaitGroup
+}
+
+func NewJobService(
+	r repository.IJobRepository,
+	s repository.IScheduleRepository,
+	u repository.IUserRepository,
+	lockout repository.ILoginLockoutRepository,
+	t repository.ITeamRepository,
+	mail repository.IMailRepository,
+	redis repository.IRedisRepository,
+	v validator.IJobValidator,
+) IJobService {
+	loc, locErr := time.LoadLocation(static.JOB_TIME_ZONE)
+	if locErr != nil {
+		log.Printf("%v", locErr)
+		loc = time.Local
+	}
+	host, hostErr := os.Hostname()
+	if hostErr != nil {
+		log.Printf("%v", hostErr)
+	}
+
+	res := &JobService{
+		r:       r,
+		s:       s,
+		u:       u,
+		lockout: lockout,
+		t:       t,
+		mail:    mail,
+		redis:   redis,
+		v:       v,
+		loc:     loc,
+		host:    host + ":" + strconv.Itoa(os.Getpid()),
+	}
+	res.jobs = []*job{
+		newJob(static.JOB_SCHEDULE_MAINTENANCE, static.JOB_SPEC_SCHEDULE_MAINTENANCE, res.scheduleMaintenance),
+		newJob(static.JOB_INTERVIEW_REMINDER, static.JOB_SPEC_INTERVIEW_REMINDER, res.interviewReminder),
+		newJob(static.JOB_STALE_CLEANUP, static.JOB_SPEC_STALE_CLEANUP, res.staleCleanup),
+	}
+	return res
+}
+
+// ジョブ生成 ※実行スケジュールは定数のため不正な場合は起動時に停止
+func newJob(name string, spec string, run func(now time.Time) (int64, error)) *job {
+	c, err := parseCron(spec)
+	if err != nil {
+		panic(fmt.Sprintf("job %s: %v", name, err))
+	}
+	return &job{
+		name: name,
+		spec: c,
+		run:  run,
+	}
+}
+
+// 常駐実行
+func (s *JobService) Start(ctx context.Context) {
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.wg.Wait()
+			return
+		case <-timer.C:
+		}
+
+		for _, j := range s.jobs {
+			if !j.spec.match(next.In(s.loc)) {
+				continue
+			}
+			s.wg.Add(1)
+			go func(j *job, at time.Time) {
+				defer s.wg.Done()
+				s.dispatch(ctx, j, at)
+			}(j, next)
+		}
+	}
+}
+
+// 単発実行
+func (s *JobService) Run(ctx context.Context, name string) error {
+	for _, j := range s.jobs {
+		if j.name == name {
+			return s.execute(ctx, j, static.JOB_TRIGGER_MANUAL)
+		}
+	}
+	return fmt.Errorf("unknown job: %s", name)
+}
+
+// 実行履歴検索
+func (s *JobService) SearchRun(req *request.SearchJobRun) (*response.SearchJobRun, *response.Error) {
+	// バリデーション
+	if err := s.v.SearchRun(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 検索
+	runs, num, searchErr := s.r.SearchRun(&dto.SearchJobRun{
+		SearchJobRun: *req,
+	})
+	if searchErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if runs == nil {
+		runs = []entity.JobRun{}
+	}
+
+	return &response.SearchJobRun{
+		List: runs,
+		Num:  num,
+	}, nil
+}
+
+// スケジュール実行 ※同じ実行予定時刻は全インスタンスで1回のみ
+func (s *JobService) dispatch(ctx context.Context, j *job, at time.Time) {
+	ok, lockErr := s.redis.Lock(
+		ctx,
+		static.REDIS_JOB_SLOT_PRE+j.name+"_"+strconv.FormatInt(at.Unix(), 10),
+		s.host,
+		static.JOB_SLOT_TTL,
+	)
+	if lockErr != nil || !ok {
+		return
+	}
+	if err := s.execute(ctx, j, static.JOB_TRIGGER_SCHEDULE); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+// ジョブ実行 ※実行中ロックを取得できない場合は実行しない、結果を実行履歴に記録
+func (s *JobService) execute(ctx context.Context, j *job, trigger uint) error {
+	token, tokenErr := newSessionToken(static.SESSION_ID_BYTES)
+	if tokenErr != nil {
+		log.Printf("%v", tokenErr)
+		return tokenErr
+	}
+
+	key := static.REDIS_JOB_LOCK_PRE + j.name
+	ok, lockErr := s.redis.Lock(ctx, key, token, static.JOB_LOCK_TTL)
+	if lockErr != nil {
+		return lockErr
+	}
+	if !ok {
+		return fmt.Errorf("job %s is already running", j.name)
+	}
+	// 終了処理は停止要求後も行う
+	defer s.redis.Unlock(context.Background(), key, token)
+
+	run := &ddl.JobRun{
+		Name:      j.name,
+		Trigger:   trigger,
+		Status:    static.JOB_STATUS_RUNNING,
+		Host:      s.host,
+		StartedAt: time.Now(),
+	}
+	if err := s.r.InsertRun(run); err != nil {
+		return err
+	}
+
+	count, runErr := callJob(j, run.StartedAt)
+
+	finishedAt := time.Now()
+	run.Count = count
+	run.FinishedAt = &finishedAt
+	run.Status = static.JOB_STATUS_SUCCESS
+	if runErr != nil {
+		log.Printf("job %s: %v", j.name, runErr)
+		run.Status = static.JOB_STATUS_FAILED
+		run.Message = jobMessage(runErr)
+	}
+	if err := s.r.UpdateRun(run); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// ジョブ処理の呼び出し ※panicは失敗として扱い、常駐処理は継続
+func callJob(j *job, now time.Time) (count int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return j.run(now)
+}
+
+// エラー内容 ※最大文字数で切り詰め
+func jobMessage(err error) string {
+	s := err.Error()
+	if utf8.RuneCountInString(s) <= static.JOB_MESSAGE_MAX_LENGTH {
+		return s
+	}
+	return string([]rune(s)[:static.JOB_MESSAGE_MAX_LENGTH])
+}
+
+// 予定メンテナンス ※繰り返し予定は閲覧時に展開するため、保持期間を過ぎた例外・送信履歴のみ削除
+func (s *JobService) scheduleMaintenance(now time.Time) (int64, error) {
+	before := now.AddDate(0, 0, -static.JOB_SCHEDULE_RETENTION_DAYS)
+
+	exceptions, exceptionsErr := s.s.DeleteExceptionBefore(before)
+	if exceptionsErr != nil {
+		return 0, exceptionsErr
+	}
+	reminders, remindersErr := s.s.DeleteReminderBefore(before)
+	if remindersErr != nil {
+		return exceptions, remindersErr
+	}
+	return exceptions + reminders, nil
+}
+
+// 面接リマインド送信 ※送信登録できた(他で送信していない)面接のみ送信
+func (s *JobService) interviewReminder(now time.Time) (int64, error) {
+	rows, rowsErr := s.s.ListReminder(now, now.Add(static.JOB_REMINDER_LEAD))
+	if rowsErr != nil {
+		return 0, rowsErr
+	}
+
+	var sent int64
+	var failed int
+	locations := make(map[uint64]*time.Location)
+	for _, row := range rows {
+		loc, locErr := scheduleLocation(s.t, row.TeamID, locations)
+		if locErr != nil {
+			return sent, locErr
+		}
+
+		claimed, claimErr := s.s.InsertReminder(&ddl.ScheduleReminder{
+			ScheduleID:  row.ID,
+			ApplicantID: row.ApplicantID,
+			Start:       row.Start,
+		})
+		if claimErr != nil {
+			return sent, claimErr
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		url := ""
+		if row.GoogleMeetURL != "" {
+			url = fmt.Sprintf(static.MAIL_BODY_INTERVIEW_URL, row.GoogleMeetURL)
+		}
+		// 送信待ち登録失敗は送信登録済みのため再送しない
+		if err := s.mail.Send(&dto.Mail{
+			CompanyID: row.CompanyID,
+			Kind:      static.MAIL_KIND_INTERVIEW_REMINDER,
+			To:        row.ApplicantEmail,
+			Subject:   static.MAIL_SUBJECT_REMINDER,
+			Body: fmt.Sprintf(
+				static.MAIL_BODY_REMINDER,
+				row.ApplicantName,
+				row.Title,
+				row.Start.In(loc).Format(static.MAIL_DATE_FORMAT),
+				url,
+			),
+		}); err != nil {
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	if failed > 0 {
+		return sent, fmt.Errorf("%d reminder(s) failed to send", failed)
+	}
+	return sent, nil
+}
+
+// 期限切れデータ削除
+func (s *JobService) staleCleanup(now time.Time) (int64, error) {
+	sessions, sessionsErr := s.u.DeleteExpiredSessionToken(
+		now.AddDate(0, 0, -static.JOB_SESSION_RETENTION_DAYS),
+	)
+	if sessionsErr != nil {
+		return 0, sessionsErr
+	}
+	lockouts, lockoutsErr := s.lockout.DeleteHistoryBefore(
+		now.AddDate(0, 0, -static.JOB_LOCKOUT_RETENTION_DAYS),
+	)
+	if lockoutsErr != nil {
+		return sessions, lockoutsErr
+	}
+	runs, runsErr := s.r.DeleteRunBefore(
+		now.AddDate(0, 0, -static.JOB_RUN_RETENTION_DAYS),
+	)
+	if runsErr != nil {
+		return sessions + lockouts, runsErr
+	}
+	return sessions + lockouts + runs, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 企業のログイン試行制限ポリシー ※未設定の項目は既定値
+func lockoutPolicy(security *entity.CompanySecurity) *dto.LockoutPolicy {
+	policy := &dto.LockoutPolicy{
+		Threshold:   static.LOCKOUT_THRESHOLD,
+		Duration:    static.LOCKOUT_DURATION,
+		MaxDuration: static.LOCKOUT_MAX_DURATION,
+	}
+	if security == nil {
+		return policy
+	}
+	if security.LockoutThreshold > 0 {
+		policy.Threshold = security.LockoutThreshold
+	}
+	if security.LockoutSeconds > 0 {
+		policy.Duration = time.Duration(security.LockoutSeconds) * time.Second
+	}
+	if security.LockoutMaxSeconds > 0 {
+		policy.MaxDuration = time.Duration(security.LockoutMaxSeconds) * time.Second
+	}
+	if policy.MaxDuration < policy.Duration {
+		policy.MaxDuration = policy.Duration
+	}
+	return policy
+}
+
+// IPアドレスのログイン試行制限ポリシー
+func ipLockoutPolicy() *dto.LockoutPolicy {
+	return &dto.LockoutPolicy{
+		Threshold:   static.IP_LOCKOUT_THRESHOLD,
+		Duration:    static.IP_LOCKOUT_DURATION,
+		MaxDuration: static.IP_LOCKOUT_MAX_DURATION,
+	}
+}
+
+// IPアドレスの制限対象 ※取得できない場合は空
+func ipTarget(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return static.LOCKOUT_TARGET_IP + ip
+}
+
+// ロック期間 ※段階毎に倍増し最大ロック期間で打ち止め
+func lockoutDuration(policy *dto.LockoutPolicy, level int64) time.Duration {
+	duration := policy.Duration
+	for i := int64(1); i < level && duration < policy.MaxDuration; i++ {
+		duration *= 2
+	}
+	if duration > policy.MaxDuration {
+		duration = policy.MaxDuration
+	}
+	return duration
+}
+
+// ロック中エラー
+func lockedError(target string) *response.Error {
+	code := static.CODE_ACCOUNT_LOCKED
+	if strings.HasPrefix(target, static.LOCKOUT_TARGET_IP) {
+		code = static.CODE_IP_LOCKED
+	}
+	return &response.Error{
+		Status: http.StatusTooManyRequests,
+		Code:   code,
+	}
+}
+
+// ロック中確認 ※空の対象は無視
+func checkLockout(r repository.IRedisRepository, targets ...string) *response.Error {
+	ctx := context.Background()
+	for _, target := range targets {
+		if target == "" {
+			continue
+		}
+		_, err := r.Get(ctx, static.REDIS_LOCKOUT_PRE+target, static.REDIS_LOCKOUT_UNTIL)
+		if err == nil {
+			return lockedError(target)
+		}
+		if err != redis.Nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+	}
+	return nil
+}
+
+// 認証失敗記録 ※しきい値に達した場合はロックしロック中エラーを返却
+func recordFailure(
+	r repository.IRedisRepository,
+	lockout repository.ILoginLockoutRepository,
+	target string,
+	policy *dto.LockoutPolicy,
+	companyID uint64,
+	ip string,
+) *response.Error {
+	if target == "" {
+		return nil
+	}
+	ctx := context.Background()
+
+	failures, err := r.Incr(ctx, static.REDIS_LOCKOUT_FAIL_PRE+target, static.LOCKOUT_WINDOW)
+	if err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if failures < int64(policy.Threshold) {
+		return nil
+	}
+
+	// ロック ※解除後は再度しきい値まで試行可
+	level, err := r.Incr(ctx, static.REDIS_LOCKOUT_LEVEL_PRE+target, static.LOCKOUT_LEVEL_TTL)
+	if err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	duration := lockoutDuration(policy, level)
+	until := time.Now().Add(duration)
+	value := strconv.FormatInt(until.Unix(), 10)
+	if err := r.Set(ctx, static.REDIS_LOCKOUT_PRE+target, static.REDIS_LOCKOUT_UNTIL, &value, duration); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if err := r.Delete(ctx, static.REDIS_LOCKOUT_FAIL_PRE+target); err != nil {
+		log.Printf("%v", err)
+	}
+
+	// 履歴 ※記録に失敗してもロックは有効
+	if err := lockout.InsertHistory(&ddl.LoginLockoutHistory{
+		CompanyID:   companyID,
+		Event:       static.LOCKOUT_EVENT_LOCK,
+		Target:      target,
+		IPAddress:   ip,
+		Failures:    uint(failures),
+		LockedUntil: &until,
+	}); err != nil {
+		log.Printf("%v", err)
+	}
+
+	return lockedError(target)
+}
+
+// 認証成功 ※失敗回数とロック段階をリセット
+func clearFailure(r repository.IRedisRepository, target string) {
+	ctx := context.Background()
+	for _, key := range []string{
+		static.REDIS_LOCKOUT_FAIL_PRE + target,
+		static.REDIS_LOCKOUT_LEVEL_PRE + target,
+	} {
+		if err := r.Delete(ctx, key); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+}
+
+// ロック解除 ※管理者操作、ロック中だった対象のみ履歴を記録
+func unlock(
+	r repository.IRedisRepository,
+	lockout repository.ILoginLockoutRepository,
+	targets []string,
+	companyID uint64,
+	operatorID uint64,
+) error {
+	ctx := context.Background()
+	for _, target := range targets {
+		_, lockedErr := r.Get(ctx, static.REDIS_LOCKOUT_PRE+target, static.REDIS_LOCKOUT_UNTIL)
+		if lockedErr != nil && lockedErr != redis.Nil {
+			return lockedErr
+		}
+
+		if err := r.Delete(ctx, static.REDIS_LOCKOUT_PRE+target); err != nil {
+			return err
+		}
+		clearFailure(r, target)
+
+		if lockedErr == nil {
+			if err := lockout.InsertHistory(&ddl.LoginLockoutHistory{
+				CompanyID:  companyID,
+				Event:      static.LOCKOUT_EVENT_UNLOCK,
+				Target:     target,
+				OperatorID: operatorID,
+			}); err != nil {
+				log.Printf("%v", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"testing"
+	"time"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	policy := lockoutPolicy(&entity.CompanySecurity{})
+	tests := []struct {
+		name  string
+		level int64
+		want  time.Duration
+	}{
+		// ok 初回
+		{"ok_level_1", 1, static.LOCKOUT_DURATION},
+		// ok 段階毎に倍増
+		{"ok_level_2", 2, 2 * static.LOCKOUT_DURATION},
+		{"ok_level_3", 3, 4 * static.LOCKOUT_DURATION},
+		// ok 最大ロック期間で打ち止め
+		{"ok_level_max", 20, static.LOCKOUT_MAX_DURATION},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lockoutDuration(policy, tt.level); got != tt.want {
+				t.Errorf("lockoutDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockoutPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		security        *entity.CompanySecurity
+		wantThreshold   uint
+		wantDuration    time.Duration
+		wantMaxDuration time.Duration
+	}{
+		// ok 未設定は既定値
+		{"ok_default", nil, static.LOCKOUT_THRESHOLD, static.LOCKOUT_DURATION, static.LOCKOUT_MAX_DURATION},
+		// ok 企業設定 ※最大ロック期間がロック期間未満の場合はロック期間に合わせる
+		{
+			"ok_company",
+			&entity.CompanySecurity{
+				CompanySecurity: ddl.CompanySecurity{
+					LockoutThreshold:  10,
+					LockoutSeconds:    120,
+					LockoutMaxSeconds: 60,
+				},
+			},
+			10, 2 * time.Minute, 2 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lockoutPolicy(tt.security)
+			if got.Threshold != tt.wantThreshold || got.Duration != tt.wantDuration || got.MaxDuration != tt.wantMaxDuration {
+				t.Errorf("lockoutPolicy() = %+v, want (%v, %v, %v)", got, tt.wantThreshold, tt.wantDuration, tt.wantMaxDuration)
+			}
+		})
+	}
+}
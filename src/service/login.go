@@ -2,6 +2,7 @@ package service
 
 import (
 	"api/src/model/ddl"
+	"api/src/model/dto"
 	"api/src/model/entity"
 	"api/src/model/request"
 	"api/src/model/response"
@@ -9,10 +10,12 @@ import (
 	"api/src/repository"
 	"api/src/validator"
 	"context"
+	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
-	"math/rand"
+	"math/big"
 	"net/http"
 	"os"
 	"strconv"
@@ -22,6 +25,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type ILoginService interface {
@@ -31,26 +35,58 @@ type ILoginService interface {
 	CodeGenerate(req *request.CodeGenerate) *response.Error
 	// MFA
 	MFA(req *request.MFA) (*response.MFA, *response.Error)
+	// 認証アプリ取得
+	GetTOTP(req *request.GetTOTP) (*response.GetTOTP, *response.Error)
+	// 認証アプリ登録
+	TOTPSetup(req *request.TOTPSetup) (*response.TOTPSetup, *response.Error)
+	// 認証アプリ確認
+	TOTPConfirm(req *request.TOTPConfirm) (*response.TOTPConfirm, *response.Error)
+	// 認証アプリ登録トークン発行(ログイン時)
+	IssueTOTPSetupToken(hashKey *string) (*http.Cookie, *response.Error)
+	// 認証アプリ登録(ログイン時)
+	MFATOTPSetup(req *request.MFATOTPSetup) (*response.TOTPSetup, *response.Error)
+	// 認証アプリ確認(ログイン時) ※登録トークン破棄用Cookieを返却
+	MFATOTPConfirm(req *request.MFATOTPConfirm) (*response.TOTPConfirm, *http.Cookie, *response.Error)
+	// 認証アプリ解除
+	TOTPDisable(req *request.TOTPDisable) *response.Error
+	// リカバリーコード再発行
+	RegenerateRecoveryCode(req *request.RegenerateRecoveryCode) (*response.RegenerateRecoveryCode, *response.Error)
 	// JWTトークン作成
 	JWT(hashKey *string, name string, secret string) (*http.Cookie, *response.Error)
+	// アクセストークン作成
+	AccessToken(hashKey *string, sessionID *string, name string, secret string) (*http.Cookie, *response.Error)
+	// セッション開始
+	CreateSession(hashKey *string, name string, secret string) ([]*http.Cookie, *response.Error)
+	// セッション更新
+	RefreshSession(req *request.RefreshSession, name string, secret string) ([]*http.Cookie, *response.Error)
 	// JWT検証
-	JWTDecode(cookie *http.Cookie, secret string) *response.Error
+	JWTDecode(cookie *http.Cookie, secret string) (*dto.JWTClaims, *response.Error)
 	// ユーザー存在確認
 	UserCheck(req *request.JWTDecode) *response.Error
-	// パスワード変更
-	PasswordChange(req *request.PasswordChange) *response.Error
+	// パスワード変更トークン発行(ログイン時)
+	IssuePasswordChangeToken(hashKey *string) (*http.Cookie, *response.Error)
+	// パスワード変更 ※変更トークン破棄用Cookieを返却
+	PasswordChange(req *request.PasswordChange) (*http.Cookie, *response.Error)
+	// シングルサインオン開始
+	SSOStart(req *request.SSOStart) (*response.SSOStart, *response.Error)
+	// シングルサインオン認証
+	SSOCallback(req *request.SSOCallback) (*response.SSOCallback, *response.Error)
+	// パスワード再設定メール送信
+	PasswordResetRequest(req *request.PasswordResetRequest) *response.Error
+	// パスワード再設定
+	PasswordReset(req *request.PasswordReset) *response.Error
 	// ログアウト
-	Logout(req *request.Logout, token string) (*http.Cookie, *response.Error)
+	Logout(req *request.Logout, token string) ([]*http.Cookie, *response.Error)
+	// 全端末ログアウト
+	LogoutAll(req *request.LogoutAll, token string) ([]*http.Cookie, *response.Error)
 	// ログイン種別取得
 	GetLoginType(req *request.GetLoginType) (*response.GetLoginType, *response.Error)
 	// チーム存在確認(応募者)
 	ConfirmTeamApplicant(req *request.ConfirmTeamApplicant) *response.Error
-	// ログイン(応募者)
-	LoginApplicant(req *request.LoginApplicant) (*response.LoginApplicant, *response.Error)
-	// MFA 認証コード生成(応募者)
-	CodeGenerateApplicant(req *request.CodeGenerateApplicant) *response.Error
-	// MFA(応募者)
-	MFAApplicant(req *request.MFAApplicant) *response.Error
+	// ログイン(応募者) ※ログインリンクをメール送信
+	LoginApplicant(req *request.LoginApplicant) *response.Error
+	// ログインリンク検証(応募者)
+	MagicLinkApplicant(req *request.MagicLinkApplicant) (*response.LoginApplicant, *response.Error)
 	// ログアウト(応募者)
 	LogoutApplicant(req *request.LogoutApplicant, token string) (*http.Cookie, *response.Error)
 	// 応募者チェック
@@ -65,6 +101,10 @@ type LoginService struct {
 	v         validator.ILoginValidator
 	v_0       validator.IUserValidator
 	d         repository.IDBRepository
+	mail      repository.IMailRepository
+	company   repository.ICompanyRepository
+	lockout   repository.ILoginLockoutRepository
+	oidc      repository.IOIDCRepository
 }
 
 func NewLoginService(
@@ -75,8 +115,12 @@ func NewLoginService(
 	v validator.ILoginValidator,
 	v_0 validator.IUserValidator,
 	d repository.IDBRepository,
+	mail repository.IMailRepository,
+	company repository.ICompanyRepository,
+	lockout repository.ILoginLockoutRepository,
+	oidc repository.IOIDCRepository,
 ) ILoginService {
-	return &LoginService{login, team, applicant, redis, v, v_0, d}
+	return &LoginService{login, team, applicant, redis, v, v_0, d, mail, company, lockout, oidc}
 }
 
 // ログイン認証
@@ -89,6 +133,12 @@ func (l *LoginService) Login(req *request.Login) (*response.Login, *response.Err
 		}
 	}
 
+	// ロック確認
+	target := static.LOCKOUT_TARGET_LOGIN + strings.ToLower(req.Email)
+	if err := checkLockout(l.redis, target, ipTarget(req.ClientIP)); err != nil {
+		return nil, err
+	}
+
 	// ログイン認証
 	users, loginErr := l.login.Login(&req.User)
 	if loginErr != nil {
@@ -97,10 +147,10 @@ func (l *LoginService) Login(req *request.Login) (*response.Login, *response.Err
 		}
 	}
 	if len(users) != 1 {
-		return nil, &response.Error{
+		return nil, l.authFailed(target, req.ClientIP, 0, &response.Error{
 			Status: http.StatusUnauthorized,
 			Code:   static.CODE_LOGIN_AUTH,
-		}
+		})
 	}
 
 	user := users[0]
@@ -109,83 +159,41 @@ func (l *LoginService) Login(req *request.Login) (*response.Login, *response.Err
 		[]byte(req.Password),
 	); err != nil {
 		log.Printf("%v", err)
-		return nil, &response.Error{
+		return nil, l.authFailed(target, req.ClientIP, user.CompanyID, &response.Error{
 			Status: http.StatusUnauthorized,
 			Code:   static.CODE_LOGIN_AUTH,
-		}
-	}
-
-	// チーム一覧取得
-	teams, teamErr := l.team.ListTeamAssociation(&ddl.TeamAssociation{UserID: user.ID})
-	if teamErr != nil {
-		return nil, &response.Error{
-			Status: http.StatusInternalServerError,
-		}
+		})
 	}
+	clearFailure(l.redis, target)
 
-	// Redisに保存
-	ctx := context.Background()
-	if err := l.redis.Set(
-		ctx,
-		user.HashKey,
-		static.REDIS_USER_HASH_KEY,
-		&user.HashKey,
-		24*time.Hour,
-	); err != nil {
+	// シングルサインオン必須の企業はパスワードログイン不可
+	oidc, oidcErr := l.company.GetOIDC(&ddl.CompanyOIDC{
+		CompanyID: user.CompanyID,
+	})
+	if oidcErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
-	roleID := strconv.FormatUint(uint64(user.RoleID), 10)
-	if err := l.redis.Set(
-		ctx,
-		user.HashKey,
-		static.REDIS_USER_ROLE,
-		&roleID,
-		24*time.Hour,
-	); err != nil {
+	if oidc.Enabled && oidc.DisablePassword {
 		return nil, &response.Error{
-			Status: http.StatusInternalServerError,
+			Status: http.StatusForbidden,
+			Code:   static.CODE_LOGIN_SSO_REQUIRED,
 		}
 	}
-	loginType := strconv.FormatUint(uint64(user.UserType), 10)
-	if err := l.redis.Set(
-		ctx,
-		user.HashKey,
-		static.REDIS_USER_LOGIN_TYPE,
-		&loginType,
-		24*time.Hour,
-	); err != nil {
-		return nil, &response.Error{
-			Status: http.StatusInternalServerError,
-		}
+
+	// ログインの一時的セッション保存
+	if err := l.saveLoginSession(&user.User); err != nil {
+		return nil, err
 	}
-	companyID := strconv.FormatUint(uint64(user.CompanyID), 10)
-	if err := l.redis.Set(
-		ctx,
-		user.HashKey,
-		static.REDIS_USER_COMPANY_ID,
-		&companyID,
-		24*time.Hour,
-	); err != nil {
+
+	// 認証アプリ登録状況
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
-	if len(teams) > 0 {
-		teamID := strconv.FormatUint(teams[0].TeamID, 10)
-		if err := l.redis.Set(
-			ctx,
-			user.HashKey,
-			static.REDIS_USER_TEAM_ID,
-			&teamID,
-			24*time.Hour,
-		); err != nil {
-			return nil, &response.Error{
-				Status: http.StatusInternalServerError,
-			}
-		}
-	}
 
 	return &response.Login{
 		User: entity.User{
@@ -197,6 +205,7 @@ func (l *LoginService) Login(req *request.Login) (*response.Login, *response.Err
 				Email: user.Email,
 			},
 		},
+		IsTOTP: totp != nil,
 	}, nil
 }
 
@@ -220,28 +229,80 @@ func (l *LoginService) CodeGenerate(req *request.CodeGenerate) *response.Error {
 		}
 	}
 
+	// 送信先取得
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 認証アプリ登録済みの場合はメール送信しない
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if totp != nil {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_LOGIN_TOTP_ENABLED,
+		}
+	}
+
 	// 認証コード生成
-	code := fmt.Sprintf("%06d", rand.Intn(int(math.Pow(10, 6))))
+	code, codeErr := mfaCode()
+	if codeErr != nil {
+		log.Printf("%v", codeErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
 
-	// Redisに保存
+	// Redisに保存 ※再生成時は以前のコードを置き換え
 	if err := l.redis.Set(
 		ctx,
-		req.HashKey,
+		mfaCodeKey(req.HashKey),
 		static.REDIS_CODE,
 		&code,
-		5*time.Minute,
+		static.MFA_CODE_TTL,
 	); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// メール送信 TODO
-	log.Print(code)
+	// メール送信
+	if err := l.mail.Send(&dto.Mail{
+		CompanyID: user.CompanyID,
+		Kind:      static.MAIL_KIND_MFA_CODE,
+		To:        user.Email,
+		Subject:   static.MAIL_SUBJECT_MFA_CODE,
+		Body:      fmt.Sprintf(static.MAIL_BODY_MFA_CODE, code),
+	}); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
 
 	return nil
 }
 
+// 認証コード生成 ※暗号学的乱数による数字のみ
+func mfaCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(math.Pow10(static.MFA_CODE_DIGITS))))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", static.MFA_CODE_DIGITS, n.Int64()), nil
+}
+
+// 認証コードキー(Redis)
+func mfaCodeKey(hashKey string) string {
+	return static.REDIS_MFA_CODE_PRE + hashKey
+}
+
 // MFA
 func (l *LoginService) MFA(req *request.MFA) (*response.MFA, *response.Error) {
 	// バリデーション
@@ -253,234 +314,2222 @@ func (l *LoginService) MFA(req *request.MFA) (*response.MFA, *response.Error) {
 	}
 
 	// ユーザー取得＆パスワード変更必要性チェック
-	user, userErr := l.login.Get(&req.User)
+	user, userErr := l.activeUser(req.HashKey)
 	if userErr != nil {
+		return nil, userErr
+	}
+
+	// ロック確認
+	target := static.LOCKOUT_TARGET_MFA + user.HashKey
+	if err := checkLockout(l.redis, target, ipTarget(req.ClientIP)); err != nil {
+		return nil, err
+	}
+
+	// 認証アプリ登録状況
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
+	// ログインの一時的セッション存在確認
 	ctx := context.Background()
-	code, codeErr := l.redis.Get(
-		ctx,
-		req.HashKey,
-		static.REDIS_CODE,
-	)
-	if codeErr != nil {
-		if codeErr == redis.Nil {
-			// Redisに保存
-			if err := l.redis.Set(
-				ctx,
-				req.HashKey,
-				static.REDIS_USER_HASH_KEY,
-				&req.HashKey,
-				24*time.Hour,
-			); err != nil {
-				return nil, &response.Error{
-					Status: http.StatusInternalServerError,
-				}
-			}
-
-			return nil, &response.Error{
-				Status: http.StatusUnauthorized,
-				Code:   static.CODE_EXPIRED,
-			}
-		}
+	if _, err := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_HASH_KEY); err != nil {
 		return nil, &response.Error{
-			Status: http.StatusInternalServerError,
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
 		}
 	}
 
-	if req.Code == *code {
-		// 有効期限更新
-		if err := l.redis.Set(
-			ctx,
-			req.HashKey,
-			static.REDIS_USER_HASH_KEY,
-			&req.HashKey,
-			24*time.Hour,
-		); err != nil {
-			return nil, &response.Error{
-				Status: http.StatusInternalServerError,
-			}
+	if totp != nil {
+		// 認証アプリ or リカバリーコードで認証
+		if err := l.verifySecondFactor(user, totp, req.Code, req.RecoveryCode, req.ClientIP); err != nil {
+			return nil, err
 		}
-
-		// ログイン種別取得
-		login, loginTypeErr := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_LOGIN_TYPE)
-		if loginTypeErr != nil {
-			return nil, &response.Error{
-				Status: http.StatusInternalServerError,
+	} else {
+		// 認証コード取り出し ※一度のみ使用可、誤りの場合も失効するため再生成が必要
+		code, codeErr := l.redis.Pop(
+			ctx,
+			mfaCodeKey(req.HashKey),
+			static.REDIS_CODE,
+		)
+		if codeErr != nil {
+			if codeErr == redis.Nil {
+				return nil, &response.Error{
+					Status: http.StatusUnauthorized,
+					Code:   static.CODE_EXPIRED,
+				}
 			}
-		}
-		loginType, loginTypeParseErr := strconv.ParseUint(*login, 10, 64)
-		if loginTypeParseErr != nil {
-			log.Printf("%v", loginTypeParseErr)
 			return nil, &response.Error{
 				Status: http.StatusInternalServerError,
 			}
 		}
-		var path string
-		if loginType == uint64(static.LOGIN_TYPE_ADMIN) {
-			path = "admin"
-		} else {
-			path = "management"
-		}
 
-		return &response.MFA{
-			Path:             path,
-			IsPasswordChange: user.Password == user.InitPassword,
-		}, nil
-	} else {
-		log.Printf("invalid code")
-		return nil, &response.Error{
-			Status: http.StatusUnauthorized,
-			Code:   static.CODE_INVALID_CODE,
+		if req.Code != *code {
+			log.Printf("invalid code")
+			return nil, l.authFailed(target, req.ClientIP, user.CompanyID, &response.Error{
+				Status: http.StatusUnauthorized,
+				Code:   static.CODE_INVALID_CODE,
+			})
 		}
+		clearFailure(l.redis, target)
 	}
-}
-
-// JWTトークン作成
-func (l *LoginService) JWT(hashKey *string, name string, secret string) (*http.Cookie, *response.Error) {
-	// Token作成
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": hashKey,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
-	})
 
-	// 署名
-	tokenString, err := token.SignedString([]byte(os.Getenv(secret)))
-	if err != nil {
+	// 有効期限更新
+	if err := l.redis.Set(
+		ctx,
+		req.HashKey,
+		static.REDIS_USER_HASH_KEY,
+		&req.HashKey,
+		24*time.Hour,
+	); err != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// Cookie作成
-	cookie := http.Cookie{
-		Name:     name,
-		Value:    tokenString,
-		Expires:  time.Now().Add(24 * time.Hour),
-		HttpOnly: true,  // JavaScriptからのアクセスを禁止する場合はtrueに
-		Secure:   false, // HTTPSでのみ送信 開発環境ではfalseに
-		SameSite: http.SameSiteDefaultMode,
-		Path:     "/",
+	// MFA完了 ※初回パスワードの場合は変更完了までセッションを開始しない
+	isPasswordChange := user.Password == user.InitPassword
+	status := static.MFA_AUTHENTICATED
+	if isPasswordChange {
+		status = static.PASSWORD_CHANGE
+	}
+	if err := l.setLoginStatus(req.HashKey, status); err != nil {
+		return nil, err
 	}
 
-	return &cookie, nil
-}
-
-// JWT検証
-func (l *LoginService) JWTDecode(cookie *http.Cookie, secret string) *response.Error {
-	token, err := jwt.Parse(cookie.Value, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			log.Print(static.MESSAGE_UNEXPECTED_COOKIE)
-			return nil, fmt.Errorf(static.MESSAGE_UNEXPECTED_COOKIE)
+	// 企業で認証アプリ必須かつ未登録の場合は登録完了までセッションを開始しない
+	isTOTPSetup := false
+	if totp == nil {
+		security, securityErr := l.company.GetSecurity(&ddl.CompanySecurity{
+			CompanyID: user.CompanyID,
+		})
+		if securityErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
 		}
-		return []byte(os.Getenv(secret)), nil
-	})
-	if err != nil {
-		log.Printf("%v", err)
-		return &response.Error{
-			Status: http.StatusUnauthorized,
+		if security.RequireTOTP {
+			setup := static.TOTP_SETUP_PENDING
+			if err := l.redis.Set(
+				ctx,
+				req.HashKey,
+				static.REDIS_TOTP_SETUP,
+				&setup,
+				24*time.Hour,
+			); err != nil {
+				return nil, &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			isTOTPSetup = true
 		}
 	}
 
-	if _, ok := token.Claims.(jwt.MapClaims); !ok || !token.Valid {
-		log.Print(static.MESSAGE_UNEXPECTED_COOKIE)
-		return &response.Error{
-			Status: http.StatusUnauthorized,
+	// ログイン種別取得
+	login, loginTypeErr := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_LOGIN_TYPE)
+	if loginTypeErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	loginType, loginTypeParseErr := strconv.ParseUint(*login, 10, 64)
+	if loginTypeParseErr != nil {
+		log.Printf("%v", loginTypeParseErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
 		}
 	}
 
-	return nil
+	return &response.MFA{
+		Path:             loginPath(loginType),
+		IsPasswordChange: isPasswordChange,
+		IsTOTPSetup:      isTOTPSetup,
+		HashKey:          user.HashKey,
+	}, nil
 }
 
-// ユーザー存在確認
-func (l *LoginService) UserCheck(req *request.JWTDecode) *response.Error {
+// 認証アプリ取得
+func (l *LoginService) GetTOTP(req *request.GetTOTP) (*response.GetTOTP, *response.Error) {
 	// バリデーション
-	if err := l.v.JWTDecode(req); err != nil {
+	if err := l.v.GetTOTP(req); err != nil {
 		log.Printf("%v", err)
-		return &response.Error{
+		return nil, &response.Error{
 			Status: http.StatusBadRequest,
 		}
 	}
 
-	_, loginErr := l.login.Get(&ddl.User{
-		AbstractTransactionModel: ddl.AbstractTransactionModel{
-			HashKey: req.HashKey,
-		},
-	})
-	if loginErr != nil {
-		return &response.Error{
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// ログインの一時的セッション存在確認
-	ctx := context.Background()
-	hash, hashErr := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_HASH_KEY)
-	if hashErr != nil {
-		return &response.Error{
-			Status: http.StatusUnauthorized,
-			Code:   static.CODE_LOGIN_REQUIRED,
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// 有効期限更新
-	if err := l.redis.Set(
-		ctx,
-		req.HashKey,
-		static.REDIS_USER_HASH_KEY,
-		hash,
-		24*time.Hour,
-	); err != nil {
-		return &response.Error{
+	security, securityErr := l.company.GetSecurity(&ddl.CompanySecurity{
+		CompanyID: user.CompanyID,
+	})
+	if securityErr != nil {
+		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	return nil
+	return &response.GetTOTP{
+		Enabled:  totp != nil,
+		Required: security.RequireTOTP,
+	}, nil
 }
 
-// パスワード変更
-func (l *LoginService) PasswordChange(req *request.PasswordChange) *response.Error {
+// 認証アプリ登録 ※確認が完了するまで無効
+func (l *LoginService) TOTPSetup(req *request.TOTPSetup) (*response.TOTPSetup, *response.Error) {
 	// バリデーション
-	if err := l.v.PasswordChange(req); err != nil {
+	if err := l.v.TOTPSetup(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録済みの場合は解除してから登録
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if totp != nil {
+		return nil, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_TOTP_ENABLED,
+		}
+	}
+
+	// シークレット生成＆暗号化
+	secret, secretErr := newTOTPSecret()
+	if secretErr != nil {
+		log.Printf("%v", secretErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	encrypted, encryptErr := encryptTOTPSecret(secret)
+	if encryptErr != nil {
+		log.Printf("%v", encryptErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 確認待ちのシークレットは置き換え
+	if err := l.login.DeleteTOTP(tx, []uint64{user.ID}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.login.InsertTOTP(tx, &ddl.UserTOTP{
+		UserID: user.ID,
+		Secret: encrypted,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.TOTPSetup{
+		Secret: secret,
+		URI:    totpURI(user.Email, secret),
+	}, nil
+}
+
+// 認証アプリ確認 ※有効化しリカバリーコードを発行
+func (l *LoginService) TOTPConfirm(req *request.TOTPConfirm) (*response.TOTPConfirm, *response.Error) {
+	// バリデーション
+	if err := l.v.TOTPConfirm(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	totp, totpErr := l.login.GetTOTP(&ddl.UserTOTP{
+		UserID: user.ID,
+	})
+	if totpErr != nil {
+		if totpErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusBadRequest,
+				Code:   static.CODE_TOTP_NOT_STARTED,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if totp.ConfirmedAt != nil {
+		return nil, &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_TOTP_ENABLED,
+		}
+	}
+
+	// ロック確認
+	target := static.LOCKOUT_TARGET_MFA + user.HashKey
+	if err := checkLockout(l.redis, target); err != nil {
+		return nil, err
+	}
+
+	// 認証コード検証
+	secret, secretErr := decryptTOTPSecret(totp.Secret)
+	if secretErr != nil {
+		log.Printf("%v", secretErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	step, ok := verifyTOTP(secret, req.Code, time.Now())
+	if !ok {
+		log.Printf("invalid code")
+		return nil, l.authFailed(target, "", user.CompanyID, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_INVALID_CODE,
+		})
+	}
+	clearFailure(l.redis, target)
+
+	// リカバリーコード生成
+	codes, codesErr := newRecoveryCodes()
+	if codesErr != nil {
+		log.Printf("%v", codesErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 有効化 ※確認に使用したコードは再利用不可
+	now := time.Now()
+	if err := l.login.ConfirmTOTP(tx, &ddl.UserTOTP{
+		UserID:      user.ID,
+		LastStep:    step,
+		ConfirmedAt: &now,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.replaceRecoveryCode(tx, user.ID, codes); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.TOTPConfirm{
+		RecoveryCodes:    codes,
+		IsPasswordChange: user.Password == user.InitPassword,
+	}, nil
+}
+
+// 認証アプリ登録トークン発行(ログイン時) ※MFA完了したユーザーに紐付け、最新のトークンのみ有効
+func (l *LoginService) IssueTOTPSetupToken(hashKey *string) (*http.Cookie, *response.Error) {
+	return l.issueLoginToken(totpSetupToken, *hashKey)
+}
+
+// 認証アプリ登録(ログイン時) ※企業で必須かつ未登録のユーザーがMFA完了後に登録
+func (l *LoginService) MFATOTPSetup(req *request.MFATOTPSetup) (*response.TOTPSetup, *response.Error) {
+	// リクエストボディのユーザーは使用しない
+	hashKey, _, err := l.totpSetupUser(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	req.HashKey = hashKey
+
+	return l.TOTPSetup(&req.TOTPSetup)
+}
+
+// 認証アプリ確認(ログイン時) ※登録完了で登録トークンを失効
+func (l *LoginService) MFATOTPConfirm(req *request.MFATOTPConfirm) (*response.TOTPConfirm, *http.Cookie, *response.Error) {
+	// リクエストボディのユーザーは使用しない
+	hashKey, hash, err := l.totpSetupUser(req.Token)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.HashKey = hashKey
+
+	res, err := l.TOTPConfirm(&req.TOTPConfirm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 登録トークン失効
+	if err := l.revokeLoginToken(totpSetupToken, hashKey, hash); err != nil {
+		return nil, nil, err
+	}
+
+	// 登録待ち解除
+	setup := static.TOTP_SETUP_NONE
+	if err := l.redis.Set(
+		context.Background(),
+		hashKey,
+		static.REDIS_TOTP_SETUP,
+		&setup,
+		0,
+	); err != nil {
+		return nil, nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return res, expiredCookie(totpSetupToken.cookie, totpSetupToken.path), nil
+}
+
+// 認証アプリ登録トークンからユーザー特定 ※ユーザーハッシュキー、トークンのハッシュ値を返却
+func (l *LoginService) totpSetupUser(token string) (string, string, *response.Error) {
+	hashKey, hash, err := l.loginTokenUser(totpSetupToken, token)
+	if err != nil {
+		return "", "", err
+	}
+	if err := l.checkTOTPSetup(hashKey); err != nil {
+		return "", "", err
+	}
+	return hashKey, hash, nil
+}
+
+// ログイン時の一時トークン ※MFA完了後、セッション開始前の手続き専用
+type loginToken struct {
+	// Cookie名
+	cookie string
+	// Cookieパス ※手続きのパスのみに送信
+	path string
+	// 有効期限
+	ttl time.Duration
+	// トークン長(バイト)
+	bytes int
+	// トークン(Redis)接頭辞 ※トークンのハッシュ値→ユーザー
+	pre string
+	// ユーザー毎の最新トークン(Redis)
+	field string
+}
+
+// 認証アプリ登録トークン
+var totpSetupToken = loginToken{
+	cookie: static.TOTP_SETUP_TOKEN_COOKIE,
+	path:   static.TOTP_SETUP_TOKEN_PATH,
+	ttl:    static.TOTP_SETUP_TOKEN_TTL,
+	bytes:  static.TOTP_SETUP_TOKEN_BYTES,
+	pre:    static.REDIS_TOTP_SETUP_TOKEN_PRE,
+	field:  static.REDIS_TOTP_SETUP_TOKEN,
+}
+
+// パスワード変更トークン
+var passwordChangeToken = loginToken{
+	cookie: static.PASSWORD_CHANGE_TOKEN_COOKIE,
+	path:   static.PASSWORD_CHANGE_TOKEN_PATH,
+	ttl:    static.PASSWORD_CHANGE_TOKEN_TTL,
+	bytes:  static.PASSWORD_CHANGE_TOKEN_BYTES,
+	pre:    static.REDIS_PASSWORD_CHANGE_TOKEN_PRE,
+	field:  static.REDIS_PASSWORD_CHANGE_TOKEN,
+}
+
+// ログイン時の一時トークン発行 ※以前に発行したトークンは無効
+func (l *LoginService) issueLoginToken(t loginToken, hashKey string) (*http.Cookie, *response.Error) {
+	token, tokenErr := newSessionToken(t.bytes)
+	if tokenErr != nil {
+		log.Printf("%v", tokenErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	hash := sessionTokenHash(token)
+
+	ctx := context.Background()
+	if err := l.redis.Set(
+		ctx,
+		t.pre+hash,
+		static.REDIS_USER_HASH_KEY,
+		&hashKey,
+		t.ttl,
+	); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	// ログインの一時的セッションの有効期限は変更しない
+	if err := l.redis.Set(
+		ctx,
+		hashKey,
+		t.field,
+		&hash,
+		0,
+	); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &http.Cookie{
+		Name:     t.cookie,
+		Value:    token,
+		Expires:  time.Now().Add(t.ttl),
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteStrictMode,
+		Path:     t.path,
+	}, nil
+}
+
+// ログイン時の一時トークンからユーザー特定 ※ユーザーハッシュキー、トークンのハッシュ値を返却
+func (l *LoginService) loginTokenUser(t loginToken, token string) (string, string, *response.Error) {
+	unauthorized := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
+	}
+	if token == "" {
+		return "", "", unauthorized
+	}
+	hash := sessionTokenHash(token)
+
+	ctx := context.Background()
+	hashKey, hashKeyErr := l.redis.Get(ctx, t.pre+hash, static.REDIS_USER_HASH_KEY)
+	if hashKeyErr != nil {
+		return "", "", unauthorized
+	}
+
+	// 最新のトークンか ※再発行済み・使用済みは不可
+	current, currentErr := l.redis.Get(ctx, *hashKey, t.field)
+	if currentErr != nil || *current != hash {
+		return "", "", unauthorized
+	}
+	return *hashKey, hash, nil
+}
+
+// ログイン時の一時トークン失効
+func (l *LoginService) revokeLoginToken(t loginToken, hashKey string, hash string) *response.Error {
+	ctx := context.Background()
+	if err := l.redis.Delete(ctx, t.pre+hash); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	used := ""
+	if err := l.redis.Set(
+		ctx,
+		hashKey,
+		t.field,
+		&used,
+		0,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return nil
+}
+
+// 認証アプリ解除 ※企業で必須の場合は不可
+func (l *LoginService) TOTPDisable(req *request.TOTPDisable) *response.Error {
+	// バリデーション
+	if err := l.v.TOTPDisable(req); err != nil {
 		log.Printf("%v", err)
 		return &response.Error{
 			Status: http.StatusBadRequest,
 		}
 	}
 
-	// 初期パスワード一致確認
-	user, confirmErr := l.login.Get(&req.User)
-	if confirmErr != nil {
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	security, securityErr := l.company.GetSecurity(&ddl.CompanySecurity{
+		CompanyID: user.CompanyID,
+	})
+	if securityErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if security.RequireTOTP {
+		return &response.Error{
+			Status: http.StatusForbidden,
+			Code:   static.CODE_TOTP_REQUIRED,
+		}
+	}
+
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if totp == nil {
 		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_TOTP_DISABLED,
+		}
+	}
+
+	// 解除には認証アプリ or リカバリーコードが必要
+	if err := l.verifySecondFactor(user, totp, req.Code, req.RecoveryCode, ""); err != nil {
+		return err
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.login.DeleteTOTP(tx, []uint64{user.ID}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.login.DeleteRecoveryCode(tx, []uint64{user.ID}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// リカバリーコード再発行 ※未使用分も含め置き換え
+func (l *LoginService) RegenerateRecoveryCode(req *request.RegenerateRecoveryCode) (*response.RegenerateRecoveryCode, *response.Error) {
+	// バリデーション
+	if err := l.v.RegenerateRecoveryCode(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	totp, totpErr := l.confirmedTOTP(user.ID)
+	if totpErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if totp == nil {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_TOTP_DISABLED,
+		}
+	}
+
+	// 再発行には認証アプリのコードが必要
+	if err := l.verifySecondFactor(user, totp, req.Code, "", ""); err != nil {
+		return nil, err
+	}
+
+	codes, codesErr := newRecoveryCodes()
+	if codesErr != nil {
+		log.Printf("%v", codesErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.replaceRecoveryCode(tx, user.ID, codes); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.RegenerateRecoveryCode{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// 有効な認証アプリ取得 ※未登録・確認待ちの場合はnil
+func (l *LoginService) confirmedTOTP(userID uint64) (*entity.UserTOTP, error) {
+	totp, err := l.login.GetTOTP(&ddl.UserTOTP{
+		UserID: userID,
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if totp.ConfirmedAt == nil {
+		return nil, nil
+	}
+	return totp, nil
+}
+
+// 認証アプリ or リカバリーコード検証 ※いずれも一度のみ使用可、失敗は試行制限の対象
+func (l *LoginService) verifySecondFactor(
+	user *entity.User,
+	totp *entity.UserTOTP,
+	code string,
+	recoveryCode string,
+	ip string,
+) *response.Error {
+	// ロック確認
+	target := static.LOCKOUT_TARGET_MFA + user.HashKey
+	if err := checkLockout(l.redis, target, ipTarget(ip)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	invalid := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_INVALID_CODE,
+	}
+
+	if recoveryCode != "" {
+		count, err := l.login.UseRecoveryCode(&ddl.UserRecoveryCode{
+			UserID: totp.UserID,
+			Code:   recoveryCodeHash(recoveryCode),
+			UsedAt: &now,
+		})
+		if err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		if count == 0 {
+			log.Printf("invalid recovery code")
+			return l.authFailed(target, ip, user.CompanyID, invalid)
+		}
+		clearFailure(l.redis, target)
+		return nil
+	}
+
+	secret, secretErr := decryptTOTPSecret(totp.Secret)
+	if secretErr != nil {
+		log.Printf("%v", secretErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	step, ok := verifyTOTP(secret, code, now)
+	if !ok {
+		log.Printf("invalid code")
+		return l.authFailed(target, ip, user.CompanyID, invalid)
+	}
+
+	// 使用済みステップ以前のコードは拒否(リプレイ対策)
+	count, err := l.login.UseTOTP(&ddl.UserTOTP{
+		UserID:   totp.UserID,
+		LastStep: step,
+	})
+	if err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if count == 0 {
+		log.Printf("totp code already used")
+		return l.authFailed(target, ip, user.CompanyID, invalid)
+	}
+	clearFailure(l.redis, target)
+	return nil
+}
+
+// 認証失敗 ※対象とIPアドレスの失敗を記録し、ロックした場合はロック中エラーを返却
+func (l *LoginService) authFailed(target string, ip string, companyID uint64, authErr *response.Error) *response.Error {
+	var security *entity.CompanySecurity
+	if companyID > 0 {
+		res, err := l.company.GetSecurity(&ddl.CompanySecurity{
+			CompanyID: companyID,
+		})
+		if err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		security = res
+	}
+
+	targetErr := recordFailure(l.redis, l.lockout, target, lockoutPolicy(security), companyID, ip)
+	ipErr := recordFailure(l.redis, l.lockout, ipTarget(ip), ipLockoutPolicy(), companyID, ip)
+	if targetErr != nil {
+		return targetErr
+	}
+	if ipErr != nil {
+		return ipErr
+	}
+	return authErr
+}
+
+// リカバリーコード置き換え ※ハッシュのみ保持
+func (l *LoginService) replaceRecoveryCode(tx *gorm.DB, userID uint64, codes []string) error {
+	if err := l.login.DeleteRecoveryCode(tx, []uint64{userID}); err != nil {
+		return err
+	}
+
+	var rows []ddl.UserRecoveryCode
+	for _, code := range codes {
+		rows = append(rows, ddl.UserRecoveryCode{
+			UserID: userID,
+			Code:   recoveryCodeHash(code),
+		})
+	}
+	return l.login.InsertRecoveryCode(tx, rows)
+}
+
+// ログインの一時的セッション保存 ※ユーザー情報・所属チームをRedisに保持
+func (l *LoginService) saveLoginSession(user *ddl.User) *response.Error {
+	// チーム一覧取得
+	teams, teamErr := l.team.ListTeamAssociation(&ddl.TeamAssociation{UserID: user.ID})
+	if teamErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Redisに保存
+	ctx := context.Background()
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_USER_HASH_KEY,
+		&user.HashKey,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	userID := strconv.FormatUint(user.ID, 10)
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_USER_ID,
+		&userID,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	roleID := strconv.FormatUint(uint64(user.RoleID), 10)
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_USER_ROLE,
+		&roleID,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	loginType := strconv.FormatUint(uint64(user.UserType), 10)
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_USER_LOGIN_TYPE,
+		&loginType,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID := strconv.FormatUint(uint64(user.CompanyID), 10)
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_USER_COMPANY_ID,
+		&companyID,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(teams) > 0 {
+		teamID := strconv.FormatUint(teams[0].TeamID, 10)
+		if err := l.redis.Set(
+			ctx,
+			user.HashKey,
+			static.REDIS_USER_TEAM_ID,
+			&teamID,
+			24*time.Hour,
+		); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+	}
+	// 認証アプリ登録待ち解除 ※MFA完了まで登録させない
+	setup := static.TOTP_SETUP_NONE
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_TOTP_SETUP,
+		&setup,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	// MFA未完了 ※MFA完了までセッションを開始させない
+	status := strconv.Itoa(int(static.MFA_UNAUTHENTICATED))
+	if err := l.redis.Set(
+		ctx,
+		user.HashKey,
+		static.REDIS_USER_LOGIN_STATUS,
+		&status,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 遷移パス ※ログイン種別毎
+func loginPath(loginType uint64) string {
+	if loginType == uint64(static.LOGIN_TYPE_ADMIN) {
+		return "admin"
+	}
+	return "management"
+}
+
+// ログイン可能ユーザー取得 ※無効化済みユーザーはセッションの発行・継続不可
+func (l *LoginService) activeUser(hashKey string) (*entity.User, *response.Error) {
+	user, userErr := l.login.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: hashKey,
+		},
+	})
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if user.DeactivatedAt != nil {
+		log.Printf("user is deactivated: %s", hashKey)
+		return nil, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+	return user, nil
+}
+
+// 認証アプリ登録待ち確認 ※MFA完了済みの場合のみ登録可
+func (l *LoginService) checkTOTPSetup(hashKey string) *response.Error {
+	setup, err := l.redis.Get(context.Background(), hashKey, static.REDIS_TOTP_SETUP)
+	if err != nil || *setup != static.TOTP_SETUP_PENDING {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+	return nil
+}
+
+// ログイン状態更新 ※ログインの一時的セッションの有効期限は変更しない
+func (l *LoginService) setLoginStatus(hashKey string, status static.LoginStatus) *response.Error {
+	value := strconv.Itoa(int(status))
+	if err := l.redis.Set(
+		context.Background(),
+		hashKey,
+		static.REDIS_USER_LOGIN_STATUS,
+		&value,
+		0,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return nil
+}
+
+// MFA完了確認 ※ログインの一時的セッションがMFA完了済みかつ認証アプリ登録待ちでない場合のみ可
+func (l *LoginService) checkMFAAuthenticated(hashKey string) *response.Error {
+	unauthorized := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
+	}
+
+	ctx := context.Background()
+	if _, err := l.redis.Get(ctx, hashKey, static.REDIS_USER_HASH_KEY); err != nil {
+		return unauthorized
+	}
+	status, statusErr := l.redis.Get(ctx, hashKey, static.REDIS_USER_LOGIN_STATUS)
+	if statusErr != nil || *status != strconv.Itoa(int(static.MFA_AUTHENTICATED)) {
+		return unauthorized
+	}
+	setup, setupErr := l.redis.Get(ctx, hashKey, static.REDIS_TOTP_SETUP)
+	if setupErr == nil && *setup == static.TOTP_SETUP_PENDING {
+		return unauthorized
+	}
+	return nil
+}
+
+// JWTトークン作成
+func (l *LoginService) JWT(hashKey *string, name string, secret string) (*http.Cookie, *response.Error) {
+	return l.sign(jwt.MapClaims{
+		"user_id": hashKey,
+	}, name, secret, 24*time.Hour)
+}
+
+// アクセストークン作成 ※ユーザー、短命でセッションIDを含む
+func (l *LoginService) AccessToken(hashKey *string, sessionID *string, name string, secret string) (*http.Cookie, *response.Error) {
+	return l.sign(jwt.MapClaims{
+		"user_id": hashKey,
+		"sid":     sessionID,
+	}, name, secret, static.ACCESS_TOKEN_TTL)
+}
+
+// 署名＆Cookie作成
+func (l *LoginService) sign(claims jwt.MapClaims, name string, secret string, ttl time.Duration) (*http.Cookie, *response.Error) {
+	// Token作成
+	expires := time.Now().Add(ttl)
+	claims["exp"] = expires.Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	// 署名
+	tokenString, err := token.SignedString([]byte(os.Getenv(secret)))
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Cookie作成
+	cookie := http.Cookie{
+		Name:     name,
+		Value:    tokenString,
+		Expires:  expires,
+		HttpOnly: true,  // JavaScriptからのアクセスを禁止する場合はtrueに
+		Secure:   false, // HTTPSでのみ送信 開発環境ではfalseに
+		SameSite: http.SameSiteDefaultMode,
+		Path:     "/",
+	}
+
+	return &cookie, nil
+}
+
+// JWT検証 ※トークンの主体(ハッシュキー)とセッションIDを返却
+func (l *LoginService) JWTDecode(cookie *http.Cookie, secret string) (*dto.JWTClaims, *response.Error) {
+	token, err := jwt.Parse(cookie.Value, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			log.Print(static.MESSAGE_UNEXPECTED_COOKIE)
+			return nil, fmt.Errorf(static.MESSAGE_UNEXPECTED_COOKIE)
+		}
+		return []byte(os.Getenv(secret)), nil
+	})
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusUnauthorized,
+		}
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		log.Print(static.MESSAGE_UNEXPECTED_COOKIE)
+		return nil, &response.Error{
+			Status: http.StatusUnauthorized,
+		}
+	}
+
+	// 主体
+	hashKey, ok := claims["user_id"].(string)
+	if !ok || hashKey == "" {
+		log.Print(static.MESSAGE_UNEXPECTED_COOKIE)
+		return nil, &response.Error{
+			Status: http.StatusUnauthorized,
+		}
+	}
+
+	sessionID, _ := claims["sid"].(string)
+
+	return &dto.JWTClaims{
+		HashKey:   hashKey,
+		SessionID: sessionID,
+	}, nil
+}
+
+// ユーザー存在確認
+func (l *LoginService) UserCheck(req *request.JWTDecode) *response.Error {
+	// バリデーション
+	if err := l.v.JWTDecode(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 無効化済みユーザーは発行済みのトークンでもアクセス不可
+	if _, err := l.activeUser(req.HashKey); err != nil {
+		return err
+	}
+
+	// ログインの一時的セッション存在確認
+	ctx := context.Background()
+	hash, hashErr := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_HASH_KEY)
+	if hashErr != nil {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+
+	// 端末毎セッションが失効していないか ※失効時は即時にアクセス不可
+	owner, ownerErr := l.redis.Get(ctx, sessionKey(req.SessionID), static.REDIS_USER_HASH_KEY)
+	if req.SessionID == "" || ownerErr != nil || *owner != req.HashKey {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+
+	// 有効期限更新
+	if err := l.redis.Set(
+		ctx,
+		req.HashKey,
+		static.REDIS_USER_HASH_KEY,
+		hash,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// パスワード変更 ※初回パスワードのユーザーがMFA完了後に変更、完了で変更トークンを失効
+func (l *LoginService) PasswordChange(req *request.PasswordChange) (*http.Cookie, *response.Error) {
+	// リクエストボディのユーザーは使用しない
+	hashKey, hash, tokenErr := l.passwordChangeUser(req.Token)
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+	req.HashKey = hashKey
+
+	// バリデーション
+	if err := l.v.PasswordChange(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 初期パスワード一致確認
+	user, confirmErr := l.activeUser(req.HashKey)
+	if confirmErr != nil {
+		return nil, confirmErr
+	}
+
+	// ロック確認
+	target := static.LOCKOUT_TARGET_PASSWORD_CHANGE + user.HashKey
+	if err := checkLockout(l.redis, target, ipTarget(req.ClientIP)); err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword(
+		[]byte(user.InitPassword),
+		[]byte(req.InitPassword),
+	); err != nil {
+		log.Printf("%v", err)
+		return nil, l.authFailed(target, req.ClientIP, user.CompanyID, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_INIT_PASSWORD_INCORRECT,
+		})
+	}
+	clearFailure(l.redis, target)
+
+	// パスワードポリシー・再利用確認
+	policy, policyErr := companyPasswordPolicy(l.company, user.CompanyID)
+	if policyErr != nil {
+		return nil, policyErr
+	}
+	if err := checkPasswordPolicy(policy, req.Password); err != nil {
+		return nil, err
+	}
+	if err := checkPasswordReuse(l.login, user, policy, req.Password); err != nil {
+		return nil, err
+	}
+
+	// パスワードハッシュ化
+	buffer, bufferErr := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if bufferErr != nil {
+		log.Printf("%v", bufferErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	req.Password = string(buffer)
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワード変更
+	if err := l.login.Update(tx, &ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			UpdatedAt: time.Now(),
+		},
+		Password: req.Password,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワード履歴登録
+	if err := l.login.InsertPasswordHistory(tx, &ddl.UserPasswordHistory{
+		UserID:   user.ID,
+		Password: req.Password,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 変更トークン失効
+	if err := l.revokeLoginToken(passwordChangeToken, hashKey, hash); err != nil {
+		return nil, err
+	}
+
+	// パスワード変更完了 ※セッション開始可
+	if err := l.setLoginStatus(hashKey, static.MFA_AUTHENTICATED); err != nil {
+		return nil, err
+	}
+
+	return expiredCookie(passwordChangeToken.cookie, passwordChangeToken.path), nil
+}
+
+// パスワード変更トークン発行(ログイン時) ※MFA完了したユーザーに紐付け、最新のトークンのみ有効
+func (l *LoginService) IssuePasswordChangeToken(hashKey *string) (*http.Cookie, *response.Error) {
+	return l.issueLoginToken(passwordChangeToken, *hashKey)
+}
+
+// パスワード変更トークンからユーザー特定 ※MFA完了済みかつ認証アプリ登録待ちでない場合のみ可
+func (l *LoginService) passwordChangeUser(token string) (string, string, *response.Error) {
+	hashKey, hash, err := l.loginTokenUser(passwordChangeToken, token)
+	if err != nil {
+		return "", "", err
+	}
+
+	unauthorized := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
+	}
+	ctx := context.Background()
+	status, statusErr := l.redis.Get(ctx, hashKey, static.REDIS_USER_LOGIN_STATUS)
+	if statusErr != nil || *status != strconv.Itoa(int(static.PASSWORD_CHANGE)) {
+		return "", "", unauthorized
+	}
+	setup, setupErr := l.redis.Get(ctx, hashKey, static.REDIS_TOTP_SETUP)
+	if setupErr == nil && *setup == static.TOTP_SETUP_PENDING {
+		return "", "", unauthorized
+	}
+	return hashKey, hash, nil
+}
+
+// シングルサインオン開始 ※メールアドレスのドメインから企業を特定し、認可URLを返却
+func (l *LoginService) SSOStart(req *request.SSOStart) (*response.SSOStart, *response.Error) {
+	// バリデーション
+	if err := l.v.SSOStart(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	notConfigured := &response.Error{
+		Status: http.StatusBadRequest,
+		Code:   static.CODE_SSO_NOT_CONFIGURED,
+	}
+
+	domain, domainErr := l.company.GetOIDCDomain(&ddl.CompanyOIDCDomain{
+		Domain: emailDomain(req.Email),
+	})
+	if domainErr != nil {
+		if domainErr == gorm.ErrRecordNotFound {
+			return nil, notConfigured
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	oidc, oidcErr := l.company.GetOIDC(&ddl.CompanyOIDC{
+		CompanyID: domain.CompanyID,
+	})
+	if oidcErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if !oidc.Enabled {
+		return nil, notConfigured
+	}
+
+	provider, providerErr := l.oidc.Discover(oidc.Issuer)
+	if providerErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusBadGateway,
+		}
+	}
+
+	// state・nonce・code_verifier生成
+	var values []string
+	for _, n := range []int{static.OIDC_STATE_BYTES, static.OIDC_NONCE_BYTES, static.OIDC_VERIFIER_BYTES} {
+		value, err := newSessionToken(n)
+		if err != nil {
+			log.Printf("%v", err)
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		values = append(values, value)
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	// コールバックまで保持
+	buf, bufErr := json.Marshal(&dto.OIDCState{
+		CompanyID: domain.CompanyID,
+		Nonce:     nonce,
+		Verifier:  verifier,
+	})
+	if bufErr != nil {
+		log.Printf("%v", bufErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	value := string(buf)
+	if err := l.redis.Set(
+		context.Background(),
+		static.REDIS_OIDC_STATE_PRE+state,
+		static.REDIS_OIDC_STATE,
+		&value,
+		static.OIDC_STATE_TTL,
+	); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.SSOStart{
+		URL: l.oidc.AuthCodeURL(&dto.OIDCExchange{
+			Provider:    provider,
+			ClientID:    oidc.ClientID,
+			RedirectURI: oidcRedirectURI(),
+		}, state, nonce, pkceChallenge(verifier)),
+	}, nil
+}
+
+// シングルサインオン認証 ※IDトークンを検証し、ログインの一時的セッションを保存
+func (l *LoginService) SSOCallback(req *request.SSOCallback) (*response.SSOCallback, *response.Error) {
+	// バリデーション
+	if err := l.v.SSOCallback(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	invalidState := &response.Error{
+		Status: http.StatusBadRequest,
+		Code:   static.CODE_SSO_INVALID_STATE,
+	}
+
+	// 認可リクエスト取得 ※一度のみ使用可
+	value, valueErr := l.redis.Pop(
+		context.Background(),
+		static.REDIS_OIDC_STATE_PRE+req.State,
+		static.REDIS_OIDC_STATE,
+	)
+	if valueErr != nil {
+		if valueErr == redis.Nil {
+			return nil, invalidState
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	var state dto.OIDCState
+	if err := json.Unmarshal([]byte(*value), &state); err != nil {
+		log.Printf("%v", err)
+		return nil, invalidState
+	}
+
+	oidc, oidcErr := l.company.GetOIDC(&ddl.CompanyOIDC{
+		CompanyID: state.CompanyID,
+	})
+	if oidcErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if !oidc.Enabled {
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_SSO_NOT_CONFIGURED,
+		}
+	}
+
+	secret, secretErr := decryptSecret(static.OIDC_ENCRYPTION_KEY, oidc.ClientSecret)
+	if secretErr != nil {
+		log.Printf("%v", secretErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	provider, providerErr := l.oidc.Discover(oidc.Issuer)
+	if providerErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusBadGateway,
+		}
+	}
+
+	authErr := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_SSO_AUTH,
+	}
+
+	// 認可コード交換
+	idToken, idTokenErr := l.oidc.Exchange(&dto.OIDCExchange{
+		Provider:     provider,
+		ClientID:     oidc.ClientID,
+		ClientSecret: secret,
+		RedirectURI:  oidcRedirectURI(),
+		Code:         req.Code,
+		Verifier:     state.Verifier,
+	})
+	if idTokenErr != nil {
+		return nil, authErr
+	}
+
+	// IDトークン検証
+	claims, claimsErr := verifyIDToken(l.oidc, provider, oidc.ClientID, idToken, state.Nonce, time.Now())
+	if claimsErr != nil {
+		log.Printf("%v", claimsErr)
+		return nil, authErr
+	}
+
+	// 許可ドメイン確認
+	domain, domainErr := l.company.GetOIDCDomain(&ddl.CompanyOIDCDomain{
+		Domain: emailDomain(claims.Email),
+	})
+	if domainErr != nil && domainErr != gorm.ErrRecordNotFound {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if domainErr != nil || domain.CompanyID != state.CompanyID {
+		log.Printf("sso domain not allowed: %s", claims.Email)
+		return nil, &response.Error{
+			Status: http.StatusForbidden,
+			Code:   static.CODE_SSO_DOMAIN_NOT_ALLOWED,
+		}
+	}
+
+	user, userErr := l.ssoUser(state.CompanyID, claims)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	// ログインの一時的セッション保存 ※MFAはプロバイダ側で実施済み
+	if err := l.saveLoginSession(&user.User); err != nil {
+		return nil, err
+	}
+	if err := l.setLoginStatus(user.HashKey, static.MFA_AUTHENTICATED); err != nil {
+		return nil, err
+	}
+
+	return &response.SSOCallback{
+		User: entity.User{
+			User: ddl.User{
+				AbstractTransactionModel: ddl.AbstractTransactionModel{
+					HashKey: user.HashKey,
+				},
+				Name:  user.Name,
+				Email: user.Email,
+			},
+		},
+		Path: loginPath(uint64(user.UserType)),
+	}, nil
+}
+
+// シングルサインオンのユーザー特定 ※初回はメールアドレスで既存ユーザーに紐づけ
+func (l *LoginService) ssoUser(companyID uint64, claims *dto.OIDCClaims) (*entity.User, *response.Error) {
+	notFound := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_SSO_USER_NOT_FOUND,
+	}
+
+	// 紐づけ済み
+	link, linkErr := l.login.GetOIDC(&ddl.UserOIDC{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+	})
+	if linkErr == nil {
+		user, userErr := l.login.GetByPrimary(&ddl.User{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				ID: link.UserID,
+			},
+		})
+		if userErr != nil {
+			if userErr == gorm.ErrRecordNotFound {
+				return nil, notFound
+			}
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		if user.CompanyID != companyID {
+			log.Printf("sso user belongs to another company: %s", claims.Subject)
+			return nil, notFound
+		}
+		if user.DeactivatedAt != nil {
+			log.Printf("sso user is deactivated: %s", claims.Subject)
+			return nil, notFound
+		}
+		return user, nil
+	}
+	if linkErr != gorm.ErrRecordNotFound {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// メールアドレスで既存ユーザーを検索 ※自動作成はしない
+	users, usersErr := l.login.Login(&ddl.User{
+		Email: claims.Email,
+	})
+	if usersErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(users) != 1 || users[0].CompanyID != companyID {
+		log.Printf("sso user not found: %s", claims.Email)
+		return nil, notFound
+	}
+
+	user, userErr := l.login.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: users[0].HashKey,
+		},
+	})
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 別のアカウントに紐づけ済みの場合は不可
+	if _, err := l.login.GetOIDC(&ddl.UserOIDC{
+		UserID: user.ID,
+	}); err != gorm.ErrRecordNotFound {
+		if err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		log.Printf("sso user already linked: %s", claims.Email)
+		return nil, notFound
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.login.InsertOIDC(tx, &ddl.UserOIDC{
+		UserID:  user.ID,
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return user, nil
+}
+
+// パスワード再設定メール送信 ※アカウント列挙対策のため、未登録でも成功を返却
+func (l *LoginService) PasswordResetRequest(req *request.PasswordResetRequest) *response.Error {
+	// バリデーション
+	if err := l.v.PasswordResetRequest(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 連続送信抑止
+	ctx := context.Background()
+	count, countErr := l.redis.Incr(
+		ctx,
+		static.REDIS_PASSWORD_RESET_SENT_PRE+strings.ToLower(req.Email),
+		static.PASSWORD_RESET_INTERVAL,
+	)
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if count > 1 {
+		log.Printf("password reset already requested")
+		return nil
+	}
+
+	users, usersErr := l.login.Login(&ddl.User{
+		Email: req.Email,
+	})
+	if usersErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(users) != 1 {
+		log.Printf("password reset for unknown email")
+		return nil
+	}
+	user := users[0]
+
+	// 再設定トークン発行 ※Redisにはハッシュのみ保持
+	token, tokenErr := newSessionToken(static.PASSWORD_RESET_TOKEN_BYTES)
+	if tokenErr != nil {
+		log.Printf("%v", tokenErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if err := l.redis.Set(
+		ctx,
+		static.REDIS_PASSWORD_RESET_PRE+sessionTokenHash(token),
+		static.REDIS_PASSWORD_RESET_USER,
+		&user.HashKey,
+		static.PASSWORD_RESET_TTL,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// メール送信
+	if err := l.mail.Send(&dto.Mail{
+		CompanyID: user.CompanyID,
+		Kind:      static.MAIL_KIND_PASSWORD_RESET,
+		To:        req.Email,
+		Subject:   static.MAIL_SUBJECT_PASSWORD_RESET,
+		Body: fmt.Sprintf(
+			static.MAIL_BODY_PASSWORD_RESET,
+			user.Name,
+			os.Getenv("FE_CSR_URL")+static.PASSWORD_RESET_PATH+token,
+		),
+	}); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// パスワード再設定 ※トークンは一度のみ使用可、全端末のセッションを失効
+func (l *LoginService) PasswordReset(req *request.PasswordReset) *response.Error {
+	// バリデーション
+	if err := l.v.PasswordReset(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	invalid := &response.Error{
+		Status: http.StatusBadRequest,
+		Code:   static.CODE_PASSWORD_RESET_INVALID_TOKEN,
+	}
+
+	// トークン確認
+	ctx := context.Background()
+	key := static.REDIS_PASSWORD_RESET_PRE + sessionTokenHash(req.Token)
+	hashKey, hashKeyErr := l.redis.Get(ctx, key, static.REDIS_PASSWORD_RESET_USER)
+	if hashKeyErr != nil {
+		if hashKeyErr == redis.Nil {
+			return invalid
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	user, userErr := l.login.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: *hashKey,
+		},
+	})
+	if userErr != nil {
+		if userErr == gorm.ErrRecordNotFound {
+			return invalid
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワードポリシー・再利用確認 ※違反時はトークンを消費しない
+	policy, policyErr := companyPasswordPolicy(l.company, user.CompanyID)
+	if policyErr != nil {
+		return policyErr
+	}
+	if err := checkPasswordPolicy(policy, req.Password); err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(l.login, user, policy, req.Password); err != nil {
+		return err
+	}
+
+	// トークン消費 ※同時に使用された場合は一方のみ成功
+	if _, err := l.redis.Pop(ctx, key, static.REDIS_PASSWORD_RESET_USER); err != nil {
+		if err == redis.Nil {
+			return invalid
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワードハッシュ化
+	buffer, bufferErr := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if bufferErr != nil {
+		log.Printf("%v", bufferErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	password := string(buffer)
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワード変更
+	if err := l.login.Update(tx, &ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   user.HashKey,
+			UpdatedAt: time.Now(),
+		},
+		Password: password,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワード履歴登録
+	if err := l.login.InsertPasswordHistory(tx, &ddl.UserPasswordHistory{
+		UserID:   user.ID,
+		Password: password,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// セッション失効
+	sessionIDs, revokeErr := revokeSessions(tx, l.login, []uint64{user.ID})
+	if revokeErr != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Redis破棄
+	clearSessions(l.redis, sessionIDs, []string{user.HashKey})
+
+	return nil
+}
+
+// ログアウト
+func (l *LoginService) Logout(req *request.Logout, token string) ([]*http.Cookie, *response.Error) {
+	// バリデーション
+	if err := l.v.Logout(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 当該端末のセッションのみ失効
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	now := time.Now()
+	if err := l.login.RevokeSession(tx, &ddl.UserSessionToken{
+		SessionID: req.SessionID,
+		RevokedAt: &now,
+	}); err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Redis破棄
+	ctx := context.Background()
+	if err := l.redis.Delete(ctx, sessionKey(req.SessionID)); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Cookie破棄
+	return []*http.Cookie{
+		expiredCookie(token, "/"),
+		expiredCookie(static.REFRESH_TOKEN_COOKIE, static.REFRESH_TOKEN_PATH),
+	}, nil
+}
+
+// 全端末ログアウト
+func (l *LoginService) LogoutAll(req *request.LogoutAll, token string) ([]*http.Cookie, *response.Error) {
+	user, userErr := l.login.Get(&req.User)
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	sessionIDs, revokeErr := revokeSessions(tx, l.login, []uint64{user.ID})
+	if revokeErr != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Redis破棄
+	clearSessions(l.redis, sessionIDs, []string{req.HashKey})
+
+	// Cookie破棄
+	return []*http.Cookie{
+		expiredCookie(token, "/"),
+		expiredCookie(static.REFRESH_TOKEN_COOKIE, static.REFRESH_TOKEN_PATH),
+	}, nil
+}
+
+// セッション開始 ※アクセストークンとリフレッシュトークンを発行、MFA完了状態は一度のみ使用可
+func (l *LoginService) CreateSession(hashKey *string, name string, secret string) ([]*http.Cookie, *response.Error) {
+	// ログインの一時的セッションのMFA完了確認 ※リクエストのユーザーのみでは開始しない
+	if err := l.checkMFAAuthenticated(*hashKey); err != nil {
+		return nil, err
+	}
+
+	user, userErr := l.activeUser(*hashKey)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	sessionID, sessionIDErr := newSessionToken(static.SESSION_ID_BYTES)
+	if sessionIDErr != nil {
+		log.Printf("%v", sessionIDErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	cookies, err := l.issueSession(tx, user.ID, hashKey, &sessionID, name, secret)
+	if err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, err
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// MFA完了状態を使用済みに ※再度のセッション開始にはMFAが必要
+	if err := l.setLoginStatus(*hashKey, static.MFA_UNAUTHENTICATED); err != nil {
+		return nil, err
+	}
+
+	return cookies, nil
+}
+
+// セッション更新 ※リフレッシュトークンをローテーション、使用済みトークンの再利用はセッションごと失効
+func (l *LoginService) RefreshSession(req *request.RefreshSession, name string, secret string) ([]*http.Cookie, *response.Error) {
+	session, sessionErr := l.login.GetSessionToken(&ddl.UserSessionToken{
+		Token: sessionTokenHash(req.Token),
+	})
+	if sessionErr != nil {
+		if sessionErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusUnauthorized,
+				Code:   static.CODE_LOGIN_REQUIRED,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 再利用検知
+	if session.UsedAt != nil || session.RevokedAt != nil {
+		log.Printf("refresh token reused: session %s", session.SessionID)
+		return nil, l.revokeSession(session.SessionID)
+	}
+	if session.ExpiredAt.Before(time.Now()) {
+		return nil, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+
+	// ユーザーセッション存在確認
+	ctx := context.Background()
+	if _, err := l.redis.Get(ctx, session.UserHashKey, static.REDIS_USER_HASH_KEY); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+
+	// 無効化済みユーザーはリフレッシュトークンが有効期限内でも更新不可
+	if _, err := l.activeUser(session.UserHashKey); err != nil {
+		return nil, err
+	}
+
+	tx, txErr := l.d.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 使用済みに更新 ※同時リクエストで先に使用された場合も再利用とみなす
+	now := time.Now()
+	count, useErr := l.login.UseSessionToken(tx, &ddl.UserSessionToken{
+		ID:     session.ID,
+		UsedAt: &now,
+	})
+	if useErr != nil || count == 0 {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		if useErr != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		log.Printf("refresh token reused: session %s", session.SessionID)
+		return nil, l.revokeSession(session.SessionID)
+	}
+
+	cookies, err := l.issueSession(tx, session.UserID, &session.UserHashKey, &session.SessionID, name, secret)
+	if err != nil {
+		if err := l.d.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, err
+	}
+
+	if err := l.d.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ユーザーセッション有効期限更新
+	if err := l.redis.Set(
+		ctx,
+		session.UserHashKey,
+		static.REDIS_USER_HASH_KEY,
+		&session.UserHashKey,
+		static.REFRESH_TOKEN_TTL,
+	); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return cookies, nil
+}
+
+// リフレッシュトークン登録＆Cookie作成
+func (l *LoginService) issueSession(
+	tx *gorm.DB,
+	userID uint64,
+	hashKey *string,
+	sessionID *string,
+	name string,
+	secret string,
+) ([]*http.Cookie, *response.Error) {
+	token, tokenErr := newSessionToken(static.REFRESH_TOKEN_BYTES)
+	if tokenErr != nil {
+		log.Printf("%v", tokenErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	expires := time.Now().Add(static.REFRESH_TOKEN_TTL)
+	if err := l.login.InsertSessionToken(tx, &ddl.UserSessionToken{
+		UserID:    userID,
+		SessionID: *sessionID,
+		Token:     sessionTokenHash(token),
+		ExpiredAt: expires,
+	}); err != nil {
+		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	if err := bcrypt.CompareHashAndPassword(
-		[]byte(user.InitPassword),
-		[]byte(req.InitPassword),
+	// 端末毎セッション
+	ctx := context.Background()
+	if err := l.redis.Set(
+		ctx,
+		sessionKey(*sessionID),
+		static.REDIS_USER_HASH_KEY,
+		hashKey,
+		static.REFRESH_TOKEN_TTL,
 	); err != nil {
-		log.Printf("%v", err)
-		return &response.Error{
-			Status: http.StatusUnauthorized,
-			Code:   static.CODE_INIT_PASSWORD_INCORRECT,
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// パスワードハッシュ化
-	buffer, bufferErr := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
-	if bufferErr != nil {
-		log.Printf("%v", bufferErr)
-		return &response.Error{
-			Status: http.StatusInternalServerError,
-		}
+	access, accessErr := l.AccessToken(hashKey, sessionID, name, secret)
+	if accessErr != nil {
+		return nil, accessErr
 	}
-	req.Password = string(buffer)
 
+	return []*http.Cookie{access, refreshCookie(token, expires)}, nil
+}
+
+// セッション失効 ※再利用検知時
+func (l *LoginService) revokeSession(sessionID string) *response.Error {
 	tx, txErr := l.d.TxStart()
 	if txErr != nil {
 		return &response.Error{
@@ -488,13 +2537,10 @@ func (l *LoginService) PasswordChange(req *request.PasswordChange) *response.Err
 		}
 	}
 
-	// パスワード変更
-	if err := l.login.Update(tx, &ddl.User{
-		AbstractTransactionModel: ddl.AbstractTransactionModel{
-			HashKey:   req.HashKey,
-			UpdatedAt: time.Now(),
-		},
-		Password: req.Password,
+	now := time.Now()
+	if err := l.login.RevokeSession(tx, &ddl.UserSessionToken{
+		SessionID: sessionID,
+		RevokedAt: &now,
 	}); err != nil {
 		if err := l.d.TxRollback(tx); err != nil {
 			return &response.Error{
@@ -512,39 +2558,12 @@ func (l *LoginService) PasswordChange(req *request.PasswordChange) *response.Err
 		}
 	}
 
-	return nil
-}
-
-// ログアウト
-func (l *LoginService) Logout(req *request.Logout, token string) (*http.Cookie, *response.Error) {
-	// バリデーション
-	if err := l.v.Logout(req); err != nil {
-		log.Printf("%v", err)
-		return nil, &response.Error{
-			Status: http.StatusBadRequest,
-		}
-	}
-
-	// Redis破棄
-	ctx := context.Background()
-	if err := l.redis.Delete(ctx, req.HashKey); err != nil {
-		return nil, &response.Error{
-			Status: http.StatusInternalServerError,
-		}
-	}
+	clearSessions(l.redis, []string{sessionID}, nil)
 
-	// Cookie破棄(使えないcookieに更新)
-	cookie := http.Cookie{
-		Name:     token,
-		Value:    "",
-		Expires:  time.Unix(0, 0),
-		HttpOnly: true,
-		Secure:   false,
-		SameSite: http.SameSiteDefaultMode,
-		Path:     "/",
+	return &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
 	}
-
-	return &cookie, nil
 }
 
 // ログイン種別取得
@@ -603,12 +2622,12 @@ func (l *LoginService) ConfirmTeamApplicant(req *request.ConfirmTeamApplicant) *
 	return nil
 }
 
-// ログイン(応募者)
-func (l *LoginService) LoginApplicant(req *request.LoginApplicant) (*response.LoginApplicant, *response.Error) {
+// ログイン(応募者) ※アカウント列挙対策のため、未登録でも成功を返却
+func (l *LoginService) LoginApplicant(req *request.LoginApplicant) *response.Error {
 	// バリデーション
 	if err := l.v.LoginApplicant(req); err != nil {
 		log.Printf("%v", err)
-		return nil, &response.Error{
+		return &response.Error{
 			Status: http.StatusBadRequest,
 		}
 	}
@@ -620,20 +2639,157 @@ func (l *LoginService) LoginApplicant(req *request.LoginApplicant) (*response.Lo
 		},
 	})
 	if teamErr != nil {
-		return nil, &response.Error{
+		if teamErr == gorm.ErrRecordNotFound {
+			return &response.Error{
+				Status: http.StatusNotFound,
+				Code:   static.CODE_CONFIRM_TEAM_NOT_EXIST,
+			}
+		}
+		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 	req.TeamID = team.ID
 
-	// ログイン認証
+	// 連続送信抑止
+	ctx := context.Background()
+	count, countErr := l.redis.Incr(
+		ctx,
+		static.REDIS_MAGIC_LINK_SENT_PRE+team.HashKey+":"+strings.ToLower(req.Email),
+		static.MAGIC_LINK_INTERVAL,
+	)
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if count > 1 {
+		log.Printf("login link already requested")
+		return nil
+	}
+
+	// 応募者取得
 	applicant, err := l.applicant.GetByEmail(&req.Applicant)
 	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 応募者チェック ※選考終了済み等の場合は送信しない
+	if err := l.CheckApplicant(&request.CheckApplicant{
+		Applicant: ddl.Applicant{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey: applicant.HashKey,
+			},
+		},
+	}); err != nil {
+		log.Printf("login link for unavailable applicant")
+		return nil
+	}
+
+	// ログインリンク発行
+	url, urlErr := issueMagicLink(l.redis, applicant.HashKey, team.HashKey, static.MAGIC_LINK_LOGIN_TTL)
+	if urlErr != nil {
+		log.Printf("%v", urlErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// メール送信
+	if err := l.mail.Send(&dto.Mail{
+		CompanyID: applicant.CompanyID,
+		Kind:      static.MAIL_KIND_LOGIN_LINK_APPLICANT,
+		To:        applicant.Email,
+		Subject:   static.MAIL_SUBJECT_LOGIN_LINK,
+		Body:      fmt.Sprintf(static.MAIL_BODY_LOGIN_LINK, applicant.Name, url),
+	}); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// ログインリンク検証(応募者) ※リンクは一度のみ使用可、発行時の応募者・チームに限定
+func (l *LoginService) MagicLinkApplicant(req *request.MagicLinkApplicant) (*response.LoginApplicant, *response.Error) {
+	// バリデーション
+	if err := l.v.MagicLinkApplicant(req); err != nil {
+		log.Printf("%v", err)
 		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// ロック確認 ※不正なリンクはIPアドレス単位で試行制限
+	if err := checkLockout(l.redis, ipTarget(req.ClientIP)); err != nil {
+		return nil, err
+	}
+	invalid := func() *response.Error {
+		return l.authFailed("", req.ClientIP, 0, &response.Error{
 			Status: http.StatusUnauthorized,
-			Code:   static.CODE_LOGIN_AUTH,
+			Code:   static.CODE_MAGIC_LINK_INVALID,
+		})
+	}
+
+	// 署名・有効期限検証
+	link, linkErr := parseMagicLink(req.Token, time.Now())
+	if linkErr != nil {
+		log.Printf("%v", linkErr)
+		return nil, invalid()
+	}
+
+	// 使用済み確認 ※同時に使用された場合は一方のみ成功
+	ctx := context.Background()
+	hashKey, popErr := l.redis.Pop(ctx, static.REDIS_MAGIC_LINK_PRE+link.Nonce, static.REDIS_MAGIC_LINK_APPLICANT)
+	if popErr != nil {
+		if popErr == redis.Nil {
+			log.Printf("magic link already used")
+			return nil, invalid()
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *hashKey != link.ApplicantHashKey {
+		return nil, invalid()
+	}
+
+	// 応募者・チーム取得 ※発行後にチームが変わった場合は無効
+	applicant, applicantErr := l.applicant.Get(&ddl.Applicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: link.ApplicantHashKey,
+		},
+	})
+	if applicantErr != nil {
+		if applicantErr == gorm.ErrRecordNotFound {
+			return nil, invalid()
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	team, teamErr := l.team.Get(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: link.TeamHashKey,
+		},
+	})
+	if teamErr != nil {
+		if teamErr == gorm.ErrRecordNotFound {
+			return nil, invalid()
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
 		}
 	}
+	if applicant.TeamID != team.ID {
+		return nil, invalid()
+	}
 
 	// 応募者チェック
 	if err := l.CheckApplicant(&request.CheckApplicant{
@@ -650,7 +2806,6 @@ func (l *LoginService) LoginApplicant(req *request.LoginApplicant) (*response.Lo
 	}
 
 	// Redisに保存
-	ctx := context.Background()
 	if err := l.redis.Set(
 		ctx,
 		applicant.HashKey,
@@ -700,113 +2855,6 @@ func (l *LoginService) LoginApplicant(req *request.LoginApplicant) (*response.Lo
 	}, nil
 }
 
-// MFA 認証コード生成(応募者)
-func (l *LoginService) CodeGenerateApplicant(req *request.CodeGenerateApplicant) *response.Error {
-	// バリデーション
-	if err := l.v.CodeGenerateApplicant(req); err != nil {
-		log.Printf("%v", err)
-		return &response.Error{
-			Status: http.StatusBadRequest,
-		}
-	}
-
-	// ログインの一時的セッション存在確認
-	ctx := context.Background()
-	_, err := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_HASH_KEY)
-	if err != nil {
-		return &response.Error{
-			Status: http.StatusUnauthorized,
-			Code:   static.CODE_LOGIN_REQUIRED,
-		}
-	}
-
-	// 認証コード生成
-	code := fmt.Sprintf("%06d", rand.Intn(int(math.Pow(10, 6))))
-
-	// Redisに保存
-	if err := l.redis.Set(
-		ctx,
-		req.HashKey,
-		static.REDIS_CODE,
-		&code,
-		5*time.Minute,
-	); err != nil {
-		return &response.Error{
-			Status: http.StatusInternalServerError,
-		}
-	}
-
-	// メール送信 TODO
-	log.Print(code)
-
-	return nil
-}
-
-// MFA(応募者)
-func (l *LoginService) MFAApplicant(req *request.MFAApplicant) *response.Error {
-	// バリデーション
-	if err := l.v.MFAApplicant(req); err != nil {
-		log.Printf("%v", err)
-		return &response.Error{
-			Status: http.StatusBadRequest,
-		}
-	}
-
-	ctx := context.Background()
-	code, codeErr := l.redis.Get(
-		ctx,
-		req.HashKey,
-		static.REDIS_CODE,
-	)
-	if codeErr != nil {
-		if codeErr == redis.Nil {
-			// Redisに保存
-			if err := l.redis.Set(
-				ctx,
-				req.HashKey,
-				static.REDIS_USER_HASH_KEY,
-				&req.HashKey,
-				24*time.Hour,
-			); err != nil {
-				return &response.Error{
-					Status: http.StatusInternalServerError,
-				}
-			}
-
-			return &response.Error{
-				Status: http.StatusUnauthorized,
-				Code:   static.CODE_EXPIRED,
-			}
-		}
-		return &response.Error{
-			Status: http.StatusInternalServerError,
-		}
-	}
-
-	if req.Code == *code {
-		// 有効期限更新
-		if err := l.redis.Set(
-			ctx,
-			req.HashKey,
-			static.REDIS_USER_HASH_KEY,
-			&req.HashKey,
-			24*time.Hour,
-		); err != nil {
-			return &response.Error{
-				Status: http.StatusInternalServerError,
-			}
-		}
-
-		return nil
-	} else {
-		log.Printf("invalid code")
-		return &response.Error{
-			Status: http.StatusUnauthorized,
-			Code:   static.CODE_INVALID_CODE,
-		}
-	}
-}
-
 // ログアウト(応募者)
 func (l *LoginService) LogoutApplicant(req *request.LogoutApplicant, token string) (*http.Cookie, *response.Error) {
 	// バリデーション
@@ -849,6 +2897,16 @@ func (l *LoginService) CheckApplicant(req *request.CheckApplicant) *response.Err
 		}
 	}
 
+	// ログインの一時的セッション存在確認 ※ログアウト・期限切れの場合はJWTが有効でも不可
+	ctx := context.Background()
+	hash, hashErr := l.redis.Get(ctx, req.HashKey, static.REDIS_USER_HASH_KEY)
+	if hashErr != nil {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+
 	// 応募者取得
 	applicant, applicantErr := l.applicant.Get(&ddl.Applicant{
 		AbstractTransactionModel: ddl.AbstractTransactionModel{
@@ -879,5 +2937,18 @@ func (l *LoginService) CheckApplicant(req *request.CheckApplicant) *response.Err
 		}
 	}
 
+	// 有効期限更新 ※JWTの更新に合わせる
+	if err := l.redis.Set(
+		ctx,
+		req.HashKey,
+		static.REDIS_USER_HASH_KEY,
+		hash,
+		24*time.Hour,
+	); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,719 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// 認証アプリ登録用ユーザー ※user_1: 登録待ち(被害者)、user_2: 登録待ち(攻撃者)
+type mockTOTPUserRepo struct {
+	repository.IUserRepository
+	users map[string]*entity.User
+	totps map[uint64]*ddl.UserTOTP
+}
+
+func newMockTOTPUserRepo() *mockTOTPUserRepo {
+	r := &mockTOTPUserRepo{
+		users: make(map[string]*entity.User),
+		totps: make(map[uint64]*ddl.UserTOTP),
+	}
+	for i, hashKey := range []string{"user_1", "user_2"} {
+		r.users[hashKey] = &entity.User{User: ddl.User{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{ID: uint64(i + 1), HashKey: hashKey, CompanyID: 1},
+			Email:                    hashKey + "@example.com",
+			Password:                 "hashed",
+			InitPassword:             "init",
+		}}
+	}
+	return r
+}
+
+func (r *mockTOTPUserRepo) Get(m *ddl.User) (*entity.User, error) {
+	user, ok := r.users[m.HashKey]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+func (r *mockTOTPUserRepo) GetTOTP(m *ddl.UserTOTP) (*entity.UserTOTP, error) {
+	totp, ok := r.totps[m.UserID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &entity.UserTOTP{UserTOTP: *totp}, nil
+}
+
+func (r *mockTOTPUserRepo) DeleteTOTP(tx *gorm.DB, m []uint64) error {
+	for _, id := range m {
+		delete(r.totps, id)
+	}
+	return nil
+}
+
+func (r *mockTOTPUserRepo) InsertTOTP(tx *gorm.DB, m *ddl.UserTOTP) error {
+	r.totps[m.UserID] = m
+	return nil
+}
+
+func (r *mockTOTPUserRepo) ConfirmTOTP(tx *gorm.DB, m *ddl.UserTOTP) error {
+	r.totps[m.UserID].LastStep = m.LastStep
+	r.totps[m.UserID].ConfirmedAt = m.ConfirmedAt
+	return nil
+}
+
+func (r *mockTOTPUserRepo) DeleteRecoveryCode(tx *gorm.DB, m []uint64) error {
+	return nil
+}
+
+func (r *mockTOTPUserRepo) InsertRecoveryCode(tx *gorm.DB, m []ddl.UserRecoveryCode) error {
+	return nil
+}
+
+func (r *mockTOTPUserRepo) InsertSessionToken(tx *gorm.DB, m *ddl.UserSessionToken) error {
+	return nil
+}
+
+// ログイン済み(MFA完了・認証アプリ登録待ち)の状態
+func newTOTPTestService(t *testing.T) (*LoginService, *memoryRedis, *mockTOTPUserRepo) {
+	t.Setenv(static.TOTP_ENCRYPTION_KEY, "test_key")
+	redis := newMemoryRedis()
+	for _, hashKey := range []string{"user_1", "user_2"} {
+		hashKey := hashKey
+		setup := static.TOTP_SETUP_PENDING
+		status := strconv.Itoa(int(static.MFA_AUTHENTICATED))
+		_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_HASH_KEY, &hashKey, 0)
+		_ = redis.Set(context.Background(), hashKey, static.REDIS_TOTP_SETUP, &setup, 0)
+		_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_LOGIN_STATUS, &status, 0)
+	}
+	r := newMockTOTPUserRepo()
+	return &LoginService{
+		login: r,
+		redis: redis,
+		v:     validator.NewLoginValidator(),
+		d:     newMockDB(),
+	}, redis, r
+}
+
+// 登録したシークレットの現在の認証コード
+func currentTOTPCode(t *testing.T, secret string) string {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return totpCode(key, totpStep(time.Now()))
+}
+
+func TestLoginService_IssueTOTPSetupToken(t *testing.T) {
+	s, _, _ := newTOTPTestService(t)
+	hashKey := "user_1"
+
+	cookie, err := s.IssueTOTPSetupToken(&hashKey)
+	if err != nil {
+		t.Fatalf("IssueTOTPSetupToken() error = %+v", err)
+	}
+	// 登録専用の短命なHttpOnly Cookie
+	if cookie.Name != static.TOTP_SETUP_TOKEN_COOKIE || cookie.Value == "" || !cookie.HttpOnly ||
+		cookie.Path != static.TOTP_SETUP_TOKEN_PATH || cookie.Expires.After(time.Now().Add(static.TOTP_SETUP_TOKEN_TTL)) {
+		t.Errorf("cookie = %+v", cookie)
+	}
+}
+
+func TestLoginService_MFATOTPSetup(t *testing.T) {
+	unauthorized := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
+	}
+
+	tests := []struct {
+		name string
+		// トークン取得 ※MFA完了時に発行
+		token    func(t *testing.T, s *LoginService, r *memoryRedis) string
+		bodyUser string
+		wantUser string
+		wantErr  *response.Error
+	}{
+		// ok 発行したトークンのユーザーに登録
+		{"ok", func(t *testing.T, s *LoginService, r *memoryRedis) string {
+			return issueTOTPSetupToken(t, s, "user_1")
+		}, "", "user_1", nil},
+		// ok リクエストボディのユーザーは無視
+		{"ok_body_ignored", func(t *testing.T, s *LoginService, r *memoryRedis) string {
+			return issueTOTPSetupToken(t, s, "user_2")
+		}, "user_1", "user_2", nil},
+		// ng トークンなし ※登録待ちのユーザーをハッシュキーのみで指定
+		{"ng_no_token", func(t *testing.T, s *LoginService, r *memoryRedis) string {
+			return ""
+		}, "user_1", "", unauthorized},
+		// ng 発行していないトークン
+		{"ng_unknown_token", func(t *testing.T, s *LoginService, r *memoryRedis) string {
+			return "forged"
+		}, "user_1", "", unauthorized},
+		// ng 再発行前のトークン
+		{"ng_superseded", func(t *testing.T, s *LoginService, r *memoryRedis) string {
+			token := issueTOTPSetupToken(t, s, "user_1")
+			issueTOTPSetupToken(t, s, "user_1")
+			return token
+		}, "", "", unauthorized},
+		// ng 登録待ちでない(再ログイン等で解除済み)
+		{"ng_not_pending", func(t *testing.T, s *LoginService, r *memoryRedis) string {
+			token := issueTOTPSetupToken(t, s, "user_1")
+			setup := static.TOTP_SETUP_NONE
+			_ = r.Set(context.Background(), "user_1", static.REDIS_TOTP_SETUP, &setup, 0)
+			return token
+		}, "", "", unauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, redis, users := newTOTPTestService(t)
+			req := &request.MFATOTPSetup{Token: tt.token(t, s, redis)}
+			req.HashKey = tt.bodyUser
+
+			res, err := s.MFATOTPSetup(req)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("MFATOTPSetup() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(users.totps) != 0 {
+					t.Errorf("totps = %v, want none", users.totps)
+				}
+				return
+			}
+			if res.Secret == "" {
+				t.Errorf("MFATOTPSetup() = %+v", res)
+			}
+			want := users.users[tt.wantUser].ID
+			if _, ok := users.totps[want]; !ok || len(users.totps) != 1 {
+				t.Errorf("totps = %v, want user %d only", users.totps, want)
+			}
+		})
+	}
+}
+
+func TestLoginService_MFATOTPConfirm(t *testing.T) {
+	s, redis, users := newTOTPTestService(t)
+	token := issueTOTPSetupToken(t, s, "user_1")
+
+	setup, err := s.MFATOTPSetup(&request.MFATOTPSetup{Token: token})
+	if err != nil {
+		t.Fatalf("MFATOTPSetup() error = %+v", err)
+	}
+	code := currentTOTPCode(t, setup.Secret)
+
+	// ng 他ユーザーのハッシュキーを指定してもトークンなしでは不可
+	attack := &request.MFATOTPConfirm{}
+	attack.HashKey = "user_1"
+	attack.Code = code
+	if _, _, err := s.MFATOTPConfirm(attack); err == nil || err.Status != http.StatusUnauthorized {
+		t.Fatalf("MFATOTPConfirm() without token error = %+v, want 401", err)
+	}
+
+	// ok 登録完了 ※ユーザーはトークンから特定、リカバリーコード発行
+	req := &request.MFATOTPConfirm{Token: token}
+	req.HashKey = "user_2"
+	req.Code = code
+	res, expired, confirmErr := s.MFATOTPConfirm(req)
+	if confirmErr != nil {
+		t.Fatalf("MFATOTPConfirm() error = %+v", confirmErr)
+	}
+	if req.HashKey != "user_1" || len(res.RecoveryCodes) != static.RECOVERY_CODE_COUNT {
+		t.Errorf("MFATOTPConfirm() hashKey = %s, res = %+v", req.HashKey, res)
+	}
+	if users.totps[1].ConfirmedAt == nil {
+		t.Errorf("totp = %+v, want confirmed", users.totps[1])
+	}
+	if expired.Name != static.TOTP_SETUP_TOKEN_COOKIE || expired.Value != "" || !expired.Expires.Before(time.Now()) {
+		t.Errorf("expired cookie = %+v", expired)
+	}
+	if got, _ := redis.Get(context.Background(), "user_1", static.REDIS_TOTP_SETUP); *got != static.TOTP_SETUP_NONE {
+		t.Errorf("totp setup = %s, want none", *got)
+	}
+
+	// ng 使用済みトークン
+	reuse := &request.MFATOTPConfirm{Token: token}
+	reuse.Code = code
+	if _, _, err := s.MFATOTPConfirm(reuse); err == nil || err.Status != http.StatusUnauthorized {
+		t.Errorf("MFATOTPConfirm() reuse error = %+v, want 401", err)
+	}
+	if _, err := s.MFATOTPSetup(&request.MFATOTPSetup{Token: token}); err == nil || err.Status != http.StatusUnauthorized {
+		t.Errorf("MFATOTPSetup() reuse error = %+v, want 401", err)
+	}
+}
+
+func TestLoginService_CreateSession(t *testing.T) {
+	tests := []struct {
+		name string
+		// ログインの一時的セッション ※ハッシュキーがない場合は未ログイン
+		session map[string]string
+		// 無効化済みユーザー
+		deactivated bool
+		wantErr     bool
+	}{
+		// ok MFA完了
+		{"ok", map[string]string{
+			static.REDIS_USER_HASH_KEY:     "user_1",
+			static.REDIS_USER_LOGIN_STATUS: strconv.Itoa(int(static.MFA_AUTHENTICATED)),
+			static.REDIS_TOTP_SETUP:        static.TOTP_SETUP_NONE,
+		}, false, false},
+		// ng MFA未完了 ※パスワード認証のみ
+		{"ng_unauthenticated", map[string]string{
+			static.REDIS_USER_HASH_KEY:     "user_1",
+			static.REDIS_USER_LOGIN_STATUS: strconv.Itoa(int(static.MFA_UNAUTHENTICATED)),
+			static.REDIS_TOTP_SETUP:        static.TOTP_SETUP_NONE,
+		}, false, true},
+		// ng 初回パスワード変更前
+		{"ng_password_change", map[string]string{
+			static.REDIS_USER_HASH_KEY:     "user_1",
+			static.REDIS_USER_LOGIN_STATUS: strconv.Itoa(int(static.PASSWORD_CHANGE)),
+			static.REDIS_TOTP_SETUP:        static.TOTP_SETUP_NONE,
+		}, false, true},
+		// ng 認証アプリ登録待ち
+		{"ng_totp_setup", map[string]string{
+			static.REDIS_USER_HASH_KEY:     "user_1",
+			static.REDIS_USER_LOGIN_STATUS: strconv.Itoa(int(static.MFA_AUTHENTICATED)),
+			static.REDIS_TOTP_SETUP:        static.TOTP_SETUP_PENDING,
+		}, false, true},
+		// ng 未ログイン
+		{"ng_no_session", map[string]string{}, false, true},
+		// ng 無効化済み ※MFA完了後に無効化
+		{"ng_deactivated", map[string]string{
+			static.REDIS_USER_HASH_KEY:     "user_1",
+			static.REDIS_USER_LOGIN_STATUS: strconv.Itoa(int(static.MFA_AUTHENTICATED)),
+			static.REDIS_TOTP_SETUP:        static.TOTP_SETUP_NONE,
+		}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "test_secret")
+			redis := newMemoryRedis()
+			for key, value := range tt.session {
+				value := value
+				_ = redis.Set(context.Background(), "user_1", key, &value, 0)
+			}
+			users := newMockTOTPUserRepo()
+			if tt.deactivated {
+				now := time.Now()
+				users.users["user_1"].DeactivatedAt = &now
+			}
+			db := newMockDB()
+			s := &LoginService{
+				login: users,
+				redis: redis,
+				d:     db,
+			}
+
+			hashKey := "user_1"
+			cookies, err := s.CreateSession(&hashKey, "jwt_token", "JWT_SECRET")
+			if tt.wantErr {
+				if err == nil || err.Status != http.StatusUnauthorized || len(cookies) != 0 || db.started != 0 {
+					t.Errorf("CreateSession() error = %+v, cookies = %v, want 401", err, cookies)
+				}
+				return
+			}
+			if err != nil || len(cookies) != 2 || db.committed != 1 {
+				t.Fatalf("CreateSession() error = %+v, cookies = %v", err, cookies)
+			}
+
+			// ng MFA完了状態の再利用
+			if _, err := s.CreateSession(&hashKey, "jwt_token", "JWT_SECRET"); err == nil || err.Status != http.StatusUnauthorized {
+				t.Errorf("CreateSession() reuse error = %+v, want 401", err)
+			}
+		})
+	}
+}
+
+func TestLoginService_UserCheck(t *testing.T) {
+	deactivatedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name          string
+		deactivatedAt *time.Time
+		wantErr       *response.Error
+	}{
+		// ok
+		{"ok", nil, nil},
+		// ng 無効化済み ※発行済みのトークン・セッションが残っていても不可
+		{"ng_deactivated", &deactivatedAt, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newMockTOTPUserRepo()
+			users.users["user_1"].DeactivatedAt = tt.deactivatedAt
+			redis := newMemoryRedis()
+			hashKey := "user_1"
+			_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_HASH_KEY, &hashKey, 0)
+			_ = redis.Set(context.Background(), sessionKey("session_1"), static.REDIS_USER_HASH_KEY, &hashKey, 0)
+			s := &LoginService{
+				login: users,
+				redis: redis,
+				v:     validator.NewLoginValidator(),
+			}
+
+			req := &request.JWTDecode{SessionID: "session_1"}
+			req.HashKey = hashKey
+			if err := s.UserCheck(req); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UserCheck() error = %+v, want %+v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// パスワード変更用ユーザー ※初回パスワードのまま
+type mockPasswordChangeUserRepo struct {
+	*mockTOTPUserRepo
+	updated []string
+}
+
+func newMockPasswordChangeUserRepo(t *testing.T, initPassword string) *mockPasswordChangeUserRepo {
+	hash, err := bcrypt.GenerateFromPassword([]byte(initPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &mockPasswordChangeUserRepo{mockTOTPUserRepo: newMockTOTPUserRepo()}
+	for _, user := range r.users {
+		user.Password = string(hash)
+		user.InitPassword = string(hash)
+	}
+	return r
+}
+
+func (r *mockPasswordChangeUserRepo) Update(tx *gorm.DB, m *ddl.User) error {
+	r.updated = append(r.updated, m.HashKey)
+	return nil
+}
+
+func (r *mockPasswordChangeUserRepo) InsertPasswordHistory(tx *gorm.DB, m *ddl.UserPasswordHistory) error {
+	return nil
+}
+
+func (r *mockPasswordChangeUserRepo) ListPasswordHistory(m *ddl.UserPasswordHistory, limit int) ([]entity.UserPasswordHistory, error) {
+	return nil, nil
+}
+
+type mockLoginCompanyRepo struct {
+	repository.ICompanyRepository
+}
+
+func (r *mockLoginCompanyRepo) GetSecurity(m *ddl.CompanySecurity) (*entity.CompanySecurity, error) {
+	return &entity.CompanySecurity{}, nil
+}
+
+func TestLoginService_PasswordChange(t *testing.T) {
+	unauthorized := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
+	}
+
+	tests := []struct {
+		name string
+		// ログイン状態 ※user_1はMFA完了時に変更トークン発行
+		status   static.LoginStatus
+		setup    string
+		useToken bool
+		wantErr  *response.Error
+	}{
+		// ok MFA完了後 ※リクエストボディのユーザー(user_2)は使用しない
+		{"ok", static.PASSWORD_CHANGE, static.TOTP_SETUP_NONE, true, nil},
+		// ng 変更トークンなし
+		{"ng_no_token", static.PASSWORD_CHANGE, static.TOTP_SETUP_NONE, false, unauthorized},
+		// ng MFA未完了
+		{"ng_unauthenticated", static.MFA_UNAUTHENTICATED, static.TOTP_SETUP_NONE, true, unauthorized},
+		// ng 認証アプリ登録待ち
+		{"ng_totp_setup", static.PASSWORD_CHANGE, static.TOTP_SETUP_PENDING, true, unauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "test_secret")
+			redis := newMemoryRedis()
+			for _, hashKey := range []string{"user_1", "user_2"} {
+				hashKey := hashKey
+				status := strconv.Itoa(int(tt.status))
+				setup := tt.setup
+				_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_HASH_KEY, &hashKey, 0)
+				_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_LOGIN_STATUS, &status, 0)
+				_ = redis.Set(context.Background(), hashKey, static.REDIS_TOTP_SETUP, &setup, 0)
+			}
+			users := newMockPasswordChangeUserRepo(t, "Init#Pass1")
+			s := &LoginService{
+				login:   users,
+				redis:   redis,
+				v:       validator.NewLoginValidator(),
+				d:       newMockDB(),
+				company: &mockLoginCompanyRepo{},
+			}
+			hashKey := "user_1"
+			cookie, err := s.IssuePasswordChangeToken(&hashKey)
+			if err != nil {
+				t.Fatalf("IssuePasswordChangeToken() error = %+v", err)
+			}
+			if cookie.Name != static.PASSWORD_CHANGE_TOKEN_COOKIE || !cookie.HttpOnly || cookie.Path != static.PASSWORD_CHANGE_TOKEN_PATH {
+				t.Errorf("cookie = %+v", cookie)
+			}
+
+			req := &request.PasswordChange{}
+			req.HashKey = "user_2"
+			req.InitPassword = "Init#Pass1"
+			req.Password = "New#Pass2word"
+			if tt.useToken {
+				req.Token = cookie.Value
+			}
+			expired, err := s.PasswordChange(req)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("PasswordChange() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if err != nil {
+				if users.updated != nil {
+					t.Errorf("updated = %v, want none", users.updated)
+				}
+				return
+			}
+
+			if req.HashKey != "user_1" || !reflect.DeepEqual(users.updated, []string{"user_1"}) {
+				t.Errorf("hashKey = %s, updated = %v, want user_1", req.HashKey, users.updated)
+			}
+			if expired.Name != static.PASSWORD_CHANGE_TOKEN_COOKIE || expired.Value != "" || !expired.Expires.Before(time.Now()) {
+				t.Errorf("expired cookie = %+v", expired)
+			}
+
+			// ok 変更完了後はセッション開始可
+			if _, err := s.CreateSession(&req.HashKey, "jwt_token", "JWT_SECRET"); err != nil {
+				t.Errorf("CreateSession() error = %+v", err)
+			}
+
+			// ng 使用済みトークン
+			reuse := &request.PasswordChange{Token: cookie.Value}
+			reuse.InitPassword = "Init#Pass1"
+			reuse.Password = "New#Pass3word"
+			if _, err := s.PasswordChange(reuse); !reflect.DeepEqual(err, unauthorized) {
+				t.Errorf("PasswordChange() reuse error = %+v, want %+v", err, unauthorized)
+			}
+		})
+	}
+}
+
+type mockLoginLockoutRepo struct {
+	repository.ILoginLockoutRepository
+}
+
+func (r *mockLoginLockoutRepo) InsertHistory(m *ddl.LoginLockoutHistory) error {
+	return nil
+}
+
+func TestLoginService_PasswordChange_lockout(t *testing.T) {
+	redis := newMemoryRedis()
+	hashKey := "user_1"
+	status := strconv.Itoa(int(static.PASSWORD_CHANGE))
+	_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_HASH_KEY, &hashKey, 0)
+	_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_LOGIN_STATUS, &status, 0)
+	users := newMockPasswordChangeUserRepo(t, "Init#Pass1")
+	s := &LoginService{
+		login:   users,
+		redis:   redis,
+		v:       validator.NewLoginValidator(),
+		d:       newMockDB(),
+		company: &mockLoginCompanyRepo{},
+		lockout: &mockLoginLockoutRepo{},
+	}
+	cookie, err := s.IssuePasswordChangeToken(&hashKey)
+	if err != nil {
+		t.Fatalf("IssuePasswordChangeToken() error = %+v", err)
+	}
+	change := func(initPassword string) *response.Error {
+		req := &request.PasswordChange{Token: cookie.Value, ClientIP: "192.0.2.1"}
+		req.InitPassword = initPassword
+		req.Password = "New#Pass2word"
+		_, err := s.PasswordChange(req)
+		return err
+	}
+
+	// ng 初期パスワード誤り ※しきい値でロック
+	for i := 1; i <= int(static.LOCKOUT_THRESHOLD); i++ {
+		want := &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_INIT_PASSWORD_INCORRECT,
+		}
+		if i == int(static.LOCKOUT_THRESHOLD) {
+			want = &response.Error{
+				Status: http.StatusTooManyRequests,
+				Code:   static.CODE_ACCOUNT_LOCKED,
+			}
+		}
+		if err := change("Wrong#Pass1"); !reflect.DeepEqual(err, want) {
+			t.Fatalf("PasswordChange() attempt %d error = %+v, want %+v", i, err, want)
+		}
+	}
+
+	// ng ロック中は正しい初期パスワードでも不可
+	if err := change("Init#Pass1"); err == nil || err.Status != http.StatusTooManyRequests {
+		t.Errorf("PasswordChange() locked error = %+v, want 429", err)
+	}
+	if users.updated != nil {
+		t.Errorf("updated = %v, want none", users.updated)
+	}
+}
+
+func TestLoginService_MFA(t *testing.T) {
+	expired := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_EXPIRED,
+	}
+
+	tests := []struct {
+		name    string
+		session bool
+		code    string
+		wantErr *response.Error
+		// 同じ認証コードでの再試行
+		wantRetryErr *response.Error
+	}{
+		// ok 認証コードは使用済み
+		{"ok", true, "123456", nil, expired},
+		// ng 誤りの場合も認証コードは失効
+		{"ng_invalid", true, "654321", &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_INVALID_CODE,
+		}, expired},
+		// ng ログインの一時的セッションなし ※認証コードは失効させない
+		{"ng_no_session", false, "123456", &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := newMemoryRedis()
+			if tt.session {
+				hashKey := "user_1"
+				loginType := strconv.Itoa(int(static.LOGIN_TYPE_MANAGEMENT))
+				_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_HASH_KEY, &hashKey, 0)
+				_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_LOGIN_TYPE, &loginType, 0)
+			}
+			code := "123456"
+			_ = redis.Set(context.Background(), mfaCodeKey("user_1"), static.REDIS_CODE, &code, 0)
+			s := &LoginService{
+				login:   newMockTOTPUserRepo(),
+				redis:   redis,
+				v:       validator.NewLoginValidator(),
+				company: &mockLoginCompanyRepo{},
+			}
+
+			req := &request.MFA{Code: tt.code}
+			req.HashKey = "user_1"
+			res, err := s.MFA(req)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("MFA() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if err == nil {
+				status, _ := redis.Get(context.Background(), "user_1", static.REDIS_USER_LOGIN_STATUS)
+				if res.HashKey != "user_1" || *status != strconv.Itoa(int(static.MFA_AUTHENTICATED)) {
+					t.Errorf("MFA() res = %+v, status = %s", res, *status)
+				}
+			}
+			if tt.wantRetryErr == nil {
+				if _, err := redis.Get(context.Background(), mfaCodeKey("user_1"), static.REDIS_CODE); err != nil {
+					t.Errorf("code consumed, want kept")
+				}
+				return
+			}
+
+			retry := &request.MFA{Code: code}
+			retry.HashKey = "user_1"
+			if _, err := s.MFA(retry); !reflect.DeepEqual(err, tt.wantRetryErr) {
+				t.Errorf("MFA() retry error = %+v, want %+v", err, tt.wantRetryErr)
+			}
+		})
+	}
+}
+
+func TestMfaCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := mfaCode()
+		if err != nil {
+			t.Fatalf("mfaCode() error = %v", err)
+		}
+		if len(code) != static.MFA_CODE_DIGITS || strings.Trim(code, "0123456789") != "" {
+			t.Fatalf("mfaCode() = %s", code)
+		}
+		seen[code] = true
+	}
+	if len(seen) < 90 {
+		t.Errorf("mfaCode() unique = %d, want random", len(seen))
+	}
+}
+
+type mockCheckApplicantRepo struct {
+	repository.IApplicantRepository
+}
+
+func (r *mockCheckApplicantRepo) Get(m *ddl.Applicant) (*entity.Applicant, error) {
+	return &entity.Applicant{Applicant: ddl.Applicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{HashKey: m.HashKey},
+	}}, nil
+}
+
+func TestLoginService_CheckApplicant(t *testing.T) {
+	tests := []struct {
+		name    string
+		logout  bool
+		wantErr *response.Error
+	}{
+		// ok ログイン中
+		{"ok", false, nil},
+		// ng ログアウト済み ※JWTは有効期限内
+		{"ng_logout", true, &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := newMemoryRedis()
+			s := &LoginService{
+				applicant: &mockCheckApplicantRepo{},
+				redis:     redis,
+				v:         validator.NewLoginValidator(),
+			}
+			hashKey := "applicant_1"
+			_ = redis.Set(context.Background(), hashKey, static.REDIS_USER_HASH_KEY, &hashKey, 0)
+			if tt.logout {
+				req := &request.LogoutApplicant{}
+				req.HashKey = hashKey
+				if _, err := s.LogoutApplicant(req, "jwt_token2"); err != nil {
+					t.Fatalf("LogoutApplicant() error = %+v", err)
+				}
+			}
+
+			req := &request.CheckApplicant{}
+			req.HashKey = hashKey
+			if err := s.CheckApplicant(req); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("CheckApplicant() error = %+v, want %+v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func issueTOTPSetupToken(t *testing.T, s *LoginService, hashKey string) string {
+	cookie, err := s.IssueTOTPSetupToken(&hashKey)
+	if err != nil {
+		t.Fatalf("IssueTOTPSetupToken() error = %+v", err)
+	}
+	return cookie.Value
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"api/src/model/dto"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var magicLinkEncoding = base64.RawURLEncoding
+
+// 署名鍵 ※環境変数から取得
+func magicLinkKey() ([]byte, error) {
+	key := os.Getenv(static.MAGIC_LINK_SECRET)
+	if key == "" {
+		return nil, errors.New("magic link secret is not set")
+	}
+	return []byte(key), nil
+}
+
+// 署名(HMAC-SHA256)
+func magicLinkSignature(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// トークン生成 ※ペイロード.署名 のBase64URL
+func signMagicLink(link *dto.MagicLink) (string, error) {
+	key, err := magicLinkKey()
+	if err != nil {
+		return "", err
+	}
+	payload := magicLinkEncoding.EncodeToString([]byte(strings.Join([]string{
+		link.ApplicantHashKey,
+		link.TeamHashKey,
+		strconv.FormatInt(link.ExpiredAt.Unix(), 10),
+		link.Nonce,
+	}, ":")))
+	return payload + "." + magicLinkEncoding.EncodeToString(magicLinkSignature(key, payload)), nil
+}
+
+// トークン検証 ※署名・有効期限のみ、使用済みかはRedisで確認する
+func parseMagicLink(token string, now time.Time) (*dto.MagicLink, error) {
+	key, err := magicLinkKey()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errors.New("invalid magic link format")
+	}
+	sig, err := magicLinkEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, magicLinkSignature(key, payload)) {
+		return nil, errors.New("invalid magic link signature")
+	}
+
+	raw, err := magicLinkEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(string(raw), ":")
+	if len(fields) != 4 {
+		return nil, errors.New("invalid magic link payload")
+	}
+	expiredAt, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	link := &dto.MagicLink{
+		ApplicantHashKey: fields[0],
+		TeamHashKey:      fields[1],
+		ExpiredAt:        time.Unix(expiredAt, 0),
+		Nonce:            fields[3],
+	}
+	if !now.Before(link.ExpiredAt) {
+		return nil, errors.New("magic link expired")
+	}
+	return link, nil
+}
+
+// マジックリンク発行 ※未使用としてRedisに登録し、応募者画面のURLを返却
+func issueMagicLink(
+	r repository.IRedisRepository,
+	applicantHashKey string,
+	teamHashKey string,
+	ttl time.Duration,
+) (string, error) {
+	nonce, err := newSessionToken(static.MAGIC_LINK_NONCE_BYTES)
+	if err != nil {
+		return "", err
+	}
+	token, err := signMagicLink(&dto.MagicLink{
+		ApplicantHashKey: applicantHashKey,
+		TeamHashKey:      teamHashKey,
+		ExpiredAt:        time.Now().Add(ttl),
+		Nonce:            nonce,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.Set(
+		context.Background(),
+		static.REDIS_MAGIC_LINK_PRE+nonce,
+		static.REDIS_MAGIC_LINK_APPLICANT,
+		&applicantHashKey,
+		ttl,
+	); err != nil {
+		return "", err
+	}
+
+	return os.Getenv("FE_APPLICANT_CSR_URL") + static.MAGIC_LINK_PATH + token, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"api/src/model/dto"
+	"api/src/model/static"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMagicLink(t *testing.T) {
+	t.Setenv(static.MAGIC_LINK_SECRET, "test_key")
+
+	now := time.Unix(1700000000, 0)
+	token, err := signMagicLink(&dto.MagicLink{
+		ApplicantHashKey: "applicant_abc",
+		TeamHashKey:      "team_def",
+		ExpiredAt:        now.Add(static.MAGIC_LINK_LOGIN_TTL),
+		Nonce:            "0123456789abcdef",
+	})
+	if err != nil {
+		t.Fatalf("signMagicLink() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		now     time.Time
+		wantErr bool
+	}{
+		// ok 有効期限内
+		{"ok", token, now, false},
+		// ng 有効期限切れ
+		{"ng_expired", token, now.Add(static.MAGIC_LINK_LOGIN_TTL), true},
+		// ng ペイロード改ざん
+		{"ng_payload", payload + "A." + signature, now, true},
+		// ng 署名なし
+		{"ng_no_signature", payload, now, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := parseMagicLink(tt.token, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMagicLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (link.ApplicantHashKey != "applicant_abc" || link.TeamHashKey != "team_def" || link.Nonce != "0123456789abcdef") {
+				t.Errorf("parseMagicLink() = %+v", link)
+			}
+		})
+	}
+
+	// ng 別の鍵で署名されたトークン
+	t.Setenv(static.MAGIC_LINK_SECRET, "other_key")
+	if _, err := parseMagicLink(token, now); err == nil {
+		t.Errorf("parseMagicLink() with other key error = nil")
+	}
+}
@@ -0,0 +1,1000 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IMailTemplateService interface {
+	// テンプレート登録
+	Create(req *request.CreateMailTemplate) *response.Error
+	// テンプレート更新
+	Update(req *request.UpdateMailTemplate) *response.Error
+	// テンプレート削除
+	Delete(req *request.DeleteMailTemplate) *response.Error
+	// テンプレート取得
+	Get(req *request.GetMailTemplate) (*response.GetMailTemplate, *response.Error)
+	// テンプレート検索
+	Search(req *request.SearchMailTemplate) (*response.SearchMailTemplate, *response.Error)
+	// 変数登録
+	CreateVariable(req *request.CreateVariable) *response.Error
+	// 変数更新
+	UpdateVariable(req *request.UpdateVariable) *response.Error
+	// 変数削除
+	DeleteVariable(req *request.DeleteVariable) *response.Error
+	// 変数検索
+	SearchVariable(req *request.SearchVariable) (*response.SearchVariable, *response.Error)
+	// プレビュー
+	Preview(req *request.PreviewMail) (*response.PreviewMail, *response.Error)
+	// 送信_応募者
+	Send(req *request.SendMail) (*response.SendMail, *response.Error)
+}
+
+type MailTemplateService struct {
+	template  repository.IMailTemplateRepository
+	applicant repository.IApplicantRepository
+	team      repository.ITeamRepository
+	mail      repository.IMailRepository
+	db        repository.IDBRepository
+	redis     repository.IRedisRepository
+	v         validator.IMailTemplateValidator
+}
+
+func NewMailTemplateService(
+	template repository.IMailTemplateRepository,
+	applicant repository.IApplicantRepository,
+	team repository.ITeamRepository,
+	mail repository.IMailRepository,
+	db repository.IDBRepository,
+	redis repository.IRedisRepository,
+	v validator.IMailTemplateValidator,
+) IMailTemplateService {
+	return &MailTemplateService{template, applicant, team, mail, db, redis, v}
+}
+
+// テンプレート登録
+func (s *MailTemplateService) Create(req *request.CreateMailTemplate) *response.Error {
+	// バリデーション
+	if err := s.v.Create(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// テンプレート名重複チェック
+	count, countErr := s.template.CountDuplTitle(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+		Title: req.Title,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_MAIL_TEMPLATE_DUPL_TITLE,
+		}
+	}
+
+	// 使用変数抽出
+	variables, variablesErr := s.template.SearchVariable(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+	})
+	if variablesErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ハッシュキー生成
+	_, hashKey, hashErr := GenerateHash(1, 25)
+	if hashErr != nil {
+		log.Printf("%v", hashErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	template, templateErr := s.template.Insert(tx, &ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   static.PRE_MAIL_TEMPLATE + "_" + *hashKey,
+			CompanyID: companyID,
+		},
+		Title:    req.Title,
+		Subject:  req.Subject,
+		Template: req.Template,
+		Desc:     req.Desc,
+	})
+	if templateErr != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 変数紐づけ登録
+	if err := s.insertsPreview(tx, &template.MailTemplate, variables); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// テンプレート更新
+func (s *MailTemplateService) Update(req *request.UpdateMailTemplate) *response.Error {
+	// バリデーション
+	if err := s.v.Update(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// テンプレート取得
+	template, templateErr := s.template.Get(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			CompanyID: companyID,
+		},
+	})
+	if templateErr != nil {
+		return &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+	if template.EditFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_MAIL_TEMPLATE_CANNOT_EDIT,
+		}
+	}
+
+	// テンプレート名重複チェック
+	count, countErr := s.template.CountDuplTitle(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID:        template.ID,
+			CompanyID: companyID,
+		},
+		Title: req.Title,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_MAIL_TEMPLATE_DUPL_TITLE,
+		}
+	}
+
+	// 使用変数抽出
+	variables, variablesErr := s.template.SearchVariable(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+	})
+	if variablesErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 更新
+	model := ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID:        template.ID,
+			HashKey:   template.HashKey,
+			CompanyID: companyID,
+		},
+		Title:    req.Title,
+		Subject:  req.Subject,
+		Template: req.Template,
+		Desc:     req.Desc,
+	}
+	if err := s.template.Update(tx, &model); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 変数紐づけ再登録
+	if err := s.template.DeletePreviewByTemplate(tx, &ddl.MailPreview{
+		TemplateID: template.ID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if err := s.insertsPreview(tx, &model, variables); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// テンプレート削除
+func (s *MailTemplateService) Delete(req *request.DeleteMailTemplate) *response.Error {
+	// バリデーション
+	if err := s.v.Delete(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// テンプレート取得
+	template, templateErr := s.template.Get(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			CompanyID: companyID,
+		},
+	})
+	if templateErr != nil {
+		return &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+	if template.DeleteFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_MAIL_TEMPLATE_CANNOT_DELETE,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 変数紐づけ削除
+	if err := s.template.DeletePreviewByTemplate(tx, &ddl.MailPreview{
+		TemplateID: template.ID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 削除
+	if err := s.template.Delete(tx, &template.MailTemplate); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// テンプレート取得
+func (s *MailTemplateService) Get(req *request.GetMailTemplate) (*response.GetMailTemplate, *response.Error) {
+	// バリデーション
+	if err := s.v.Get(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	// テンプレート取得
+	template, templateErr := s.template.Get(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			CompanyID: companyID,
+		},
+	})
+	if templateErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+
+	// 使用変数取得
+	variables, variablesErr := s.template.ListVariableByTemplate(&ddl.MailPreview{
+		TemplateID: template.ID,
+	})
+	if variablesErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	template.ID = 0
+	for i := range variables {
+		variables[i].ID = 0
+	}
+
+	return &response.GetMailTemplate{
+		MailTemplateDetail: entity.MailTemplateDetail{
+			MailTemplate: template.MailTemplate,
+			Variables:    variables,
+		},
+	}, nil
+}
+
+// テンプレート検索
+func (s *MailTemplateService) Search(req *request.SearchMailTemplate) (*response.SearchMailTemplate, *response.Error) {
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	templates, templatesErr := s.template.Search(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+	})
+	if templatesErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	for i := range templates {
+		templates[i].ID = 0
+	}
+
+	return &response.SearchMailTemplate{
+		List: templates,
+	}, nil
+}
+
+// 変数登録
+func (s *MailTemplateService) CreateVariable(req *request.CreateVariable) *response.Error {
+	// バリデーション
+	if err := s.v.CreateVariable(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// 変数名重複チェック
+	count, countErr := s.template.CountDuplVariableTitle(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+		Title: req.Title,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_VARIABLE_DUPL_TITLE,
+		}
+	}
+
+	// ハッシュキー生成
+	_, hashKey, hashErr := GenerateHash(1, 25)
+	if hashErr != nil {
+		log.Printf("%v", hashErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	if _, err := s.template.InsertVariable(tx, &ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   static.PRE_VARIABLE + "_" + *hashKey,
+			CompanyID: companyID,
+		},
+		Title:    req.Title,
+		JsonName: req.JsonName,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 変数更新
+func (s *MailTemplateService) UpdateVariable(req *request.UpdateVariable) *response.Error {
+	// バリデーション
+	if err := s.v.UpdateVariable(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// 変数取得
+	variable, variableErr := s.template.GetVariable(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			CompanyID: companyID,
+		},
+	})
+	if variableErr != nil {
+		return &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+	if variable.EditFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_VARIABLE_CANNOT_EDIT,
+		}
+	}
+
+	// 使用中の変数は名称変更不可(テンプレート内の埋め込みが壊れるため)
+	if variable.Title != req.Title {
+		used, usedErr := s.template.CountPreviewByVariable(&ddl.MailPreview{
+			VariableID: variable.ID,
+		})
+		if usedErr != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		if *used > 0 {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+				Code:   static.CODE_VARIABLE_CANNOT_EDIT,
+			}
+		}
+	}
+
+	// 変数名重複チェック
+	count, countErr := s.template.CountDuplVariableTitle(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID:        variable.ID,
+			CompanyID: companyID,
+		},
+		Title: req.Title,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_VARIABLE_DUPL_TITLE,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 更新
+	if err := s.template.UpdateVariable(tx, &ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: variable.ID,
+		},
+		Title:    req.Title,
+		JsonName: req.JsonName,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 変数削除
+func (s *MailTemplateService) DeleteVariable(req *request.DeleteVariable) *response.Error {
+	// バリデーション
+	if err := s.v.DeleteVariable(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// 変数取得
+	variable, variableErr := s.template.GetVariable(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			CompanyID: companyID,
+		},
+	})
+	if variableErr != nil {
+		return &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+	if variable.DeleteFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_VARIABLE_CANNOT_DELETE,
+		}
+	}
+
+	// テンプレートで使用中の変数は削除不可
+	count, countErr := s.template.CountPreviewByVariable(&ddl.MailPreview{
+		VariableID: variable.ID,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_VARIABLE_CANNOT_DELETE_TEMPLATE,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 削除
+	if err := s.template.DeleteVariable(tx, &variable.Variable); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 変数検索
+func (s *MailTemplateService) SearchVariable(req *request.SearchVariable) (*response.SearchVariable, *response.Error) {
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	variables, variablesErr := s.template.SearchVariable(&ddl.Variable{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+	})
+	if variablesErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	for i := range variables {
+		variables[i].ID = 0
+	}
+
+	return &response.SearchVariable{
+		List: variables,
+	}, nil
+}
+
+// プレビュー
+func (s *MailTemplateService) Preview(req *request.PreviewMail) (*response.PreviewMail, *response.Error) {
+	// バリデーション
+	if err := s.v.Preview(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	// テンプレート取得
+	template, variables, templateErr := s.getTemplateWithVariables(req.TemplateHash, companyID)
+	if templateErr != nil {
+		return nil, templateErr
+	}
+
+	// 描画
+	mail, mailErr := s.render(template, variables, req.ApplicantHash, companyID)
+	if mailErr != nil {
+		return nil, mailErr
+	}
+
+	return &response.PreviewMail{
+		RenderedMail: *mail,
+	}, nil
+}
+
+// 送信_応募者
+func (s *MailTemplateService) Send(req *request.SendMail) (*response.SendMail, *response.Error) {
+	// バリデーション
+	if err := s.v.Send(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := s.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	// テンプレート取得
+	template, variables, templateErr := s.getTemplateWithVariables(req.TemplateHash, companyID)
+	if templateErr != nil {
+		return nil, templateErr
+	}
+
+	// 描画チェック(送信前に全件確認し、一部のみ送信されることを防ぐ)
+	var mails []*entity.RenderedMail
+	for _, hash := range req.Applicants {
+		mail, mailErr := s.render(template, variables, hash, companyID)
+		if mailErr != nil {
+			return nil, mailErr
+		}
+		mails = append(mails, mail)
+	}
+
+	// 送信待ち登録 ※送信は送信処理で非同期に行う
+	var ms []*dto.Mail
+	for _, mail := range mails {
+		ms = append(ms, &dto.Mail{
+			CompanyID: companyID,
+			Kind:      static.MAIL_KIND_TEMPLATE,
+			To:        mail.To,
+			Subject:   mail.Subject,
+			Body:      mail.Body,
+		})
+	}
+	if err := s.mail.SendAll(ms); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.SendMail{
+		Success: req.Applicants,
+		Queued:  len(ms),
+	}, nil
+}
+
+// 企業ID取得
+func (s *MailTemplateService) companyID(userHashKey string) (uint64, *response.Error) {
+	ctx := context.Background()
+	company, companyErr := s.redis.Get(ctx, userHashKey, static.REDIS_USER_COMPANY_ID)
+	if companyErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID, companyIDErr := strconv.ParseUint(*company, 10, 64)
+	if companyIDErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return companyID, nil
+}
+
+// テンプレート＆使用変数取得
+func (s *MailTemplateService) getTemplateWithVariables(hash string, companyID uint64) (*entity.MailTemplate, []entity.Variable, *response.Error) {
+	template, templateErr := s.template.Get(&ddl.MailTemplate{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   hash,
+			CompanyID: companyID,
+		},
+	})
+	if templateErr != nil {
+		return nil, nil, &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+
+	variables, variablesErr := s.template.ListVariableByTemplate(&ddl.MailPreview{
+		TemplateID: template.ID,
+	})
+	if variablesErr != nil {
+		return nil, nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return template, variables, nil
+}
+
+// 応募者情報でテンプレート描画
+func (s *MailTemplateService) render(
+	template *entity.MailTemplate,
+	variables []entity.Variable,
+	applicantHash string,
+	companyID uint64,
+) (*entity.RenderedMail, *response.Error) {
+	// 応募者取得
+	applicant, applicantErr := s.applicant.Get(&ddl.Applicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: applicantHash,
+		},
+	})
+	if applicantErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusNotFound,
+		}
+	}
+	if applicant.CompanyID != companyID {
+		return nil, &response.Error{
+			Status: http.StatusForbidden,
+		}
+	}
+
+	// チーム取得
+	team, teamErr := s.team.GetByPrimary(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: applicant.TeamID,
+		},
+	})
+	if teamErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 面接日時
+	interviewDate := ""
+	if !applicant.Start.IsZero() {
+		jst, jstErr := time.LoadLocation("Asia/Tokyo")
+		if jstErr != nil {
+			log.Printf("%v", jstErr)
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		interviewDate = applicant.Start.In(jst).Format(static.MAIL_DATE_FORMAT)
+	}
+
+	values := map[string]string{
+		static.MAIL_VARIABLE_NAME:            applicant.Name,
+		static.MAIL_VARIABLE_EMAIL:           applicant.Email,
+		static.MAIL_VARIABLE_TEAM:            team.Name,
+		static.MAIL_VARIABLE_INTERVIEW_DATE:  interviewDate,
+		static.MAIL_VARIABLE_GOOGLE_MEET_URL: applicant.GoogleMeetURL,
+	}
+
+	return &entity.RenderedMail{
+		To:      applicant.Email,
+		Subject: renderMailTemplate(template.Subject, variables, values),
+		Body:    renderMailTemplate(template.Template, variables, values),
+	}, nil
+}
+
+// テンプレート変数紐づけ登録
+func (s *MailTemplateService) insertsPreview(tx *gorm.DB, template *ddl.MailTemplate, variables []entity.Variable) error {
+	var list []*ddl.MailPreview
+	for _, variable := range usedMailVariables(template, variables) {
+		_, hashKey, hashErr := GenerateHash(1, 25)
+		if hashErr != nil {
+			log.Printf("%v", hashErr)
+			return hashErr
+		}
+		list = append(list, &ddl.MailPreview{
+			TemplateID: template.ID,
+			VariableID: variable.ID,
+			HashKey:    static.PRE_MAIL_PREVIEW + "_" + *hashKey,
+			Title:      variable.Title,
+			CompanyID:  template.CompanyID,
+		})
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return s.template.InsertsPreview(tx, list)
+}
+
+// テンプレートで使用している変数抽出
+func usedMailVariables(template *ddl.MailTemplate, variables []entity.Variable) []entity.Variable {
+	var res []entity.Variable
+	for _, variable := range variables {
+		placeholder := static.MAIL_VARIABLE_PREFIX + variable.Title + static.MAIL_VARIABLE_SUFFIX
+		if strings.Contains(template.Subject, placeholder) || strings.Contains(template.Template, placeholder) {
+			res = append(res, variable)
+		}
+	}
+	return res
+}
+
+// 変数置換
+func renderMailTemplate(text string, variables []entity.Variable, values map[string]string) string {
+	var pairs []string
+	for _, variable := range variables {
+		pairs = append(
+			pairs,
+			static.MAIL_VARIABLE_PREFIX+variable.Title+static.MAIL_VARIABLE_SUFFIX,
+			values[variable.JsonName],
+		)
+	}
+	if len(pairs) == 0 {
+		return text
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
@@ -13,6 +13,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type IManuscriptService interface {
@@ -29,14 +30,15 @@ type IManuscriptService interface {
 }
 
 type ManuscriptService struct {
-	manuscript repository.IManuscriptRepository
-	master     repository.IMasterRepository
-	user       repository.IUserRepository
-	team       repository.ITeamRepository
-	applicant  repository.IApplicantRepository
-	db         repository.IDBRepository
-	redis      repository.IRedisRepository
-	validate   validator.IManuscriptValidator
+	manuscript   repository.IManuscriptRepository
+	master       repository.IMasterRepository
+	user         repository.IUserRepository
+	team         repository.ITeamRepository
+	applicant    repository.IApplicantRepository
+	db           repository.IDBRepository
+	redis        repository.IRedisRepository
+	validate     validator.IManuscriptValidator
+	operationLog repository.IOperationLogRepository
 }
 
 func NewManuscriptService(
@@ -48,8 +50,9 @@ func NewManuscriptService(
 	db repository.IDBRepository,
 	redis repository.IRedisRepository,
 	validate validator.IManuscriptValidator,
+	operationLog repository.IOperationLogRepository,
 ) IManuscriptService {
-	return &ManuscriptService{manuscript, master, user, team, applicant, db, redis, validate}
+	return &ManuscriptService{manuscript, master, user, team, applicant, db, redis, validate, operationLog}
 }
 
 // 検索
@@ -239,6 +242,23 @@ func (s *ManuscriptService) Create(req *request.CreateManuscript) *response.Erro
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_MANUSCRIPT_CREATE,
+		Target:      manuscript.HashKey,
+		Detail:      map[string]interface{}{"teams": req.Teams, "sites": req.Sites},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -317,6 +337,23 @@ func (s *ManuscriptService) CreateApplicantAssociation(req *request.CreateApplic
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_MANUSCRIPT_ASSIGN_APPLICANT,
+		Target:      manuscript.HashKey,
+		Detail:      map[string]interface{}{"applicants": req.Applicants},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := s.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -395,6 +432,23 @@ func (s *ManuscriptService) Delete(req *request.DeleteManuscriptRequest) *respon
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_MANUSCRIPT_DELETE,
+		Target:      strings.Join(req.ManuscriptHashKeys, ","),
+		Detail:      map[string]interface{}{"num": len(req.ManuscriptHashKeys)},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// トランザクションのコミット
 	if err := s.db.TxCommit(tx); err != nil {
 		return &response.Error{
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type INoticeService interface {
+	// 検索
+	Search(req *request.SearchNotice) (*response.SearchNotice, *response.Error)
+	// 既読
+	Read(req *request.ReadNotice) *response.Error
+	// 未読数
+	CountUnread(req *request.CountUnreadNotice) (*response.CountUnreadNotice, *response.Error)
+}
+
+type NoticeService struct {
+	r     repository.INoticeRepository
+	redis repository.IRedisRepository
+	db    repository.IDBRepository
+	v     validator.INoticeValidator
+}
+
+func NewNoticeService(
+	r repository.INoticeRepository,
+	redis repository.IRedisRepository,
+	db repository.IDBRepository,
+	v validator.INoticeValidator,
+) INoticeService {
+	return &NoticeService{r, redis, db, v}
+}
+
+// 検索
+func (s *NoticeService) Search(req *request.SearchNotice) (*response.SearchNotice, *response.Error) {
+	// バリデーション
+	if err := s.v.Search(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	userID, userErr := s.userID(req.UserHashKey)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	notices, num, err := s.r.Search(&dto.SearchNotice{
+		SearchNotice: *req,
+		ToUserID:     userID,
+	})
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.Notice{}
+	for _, row := range notices {
+		res = append(res, entity.Notice{
+			Notice: ddl.Notice{
+				AbstractTransactionModel: ddl.AbstractTransactionModel{
+					HashKey:   row.HashKey,
+					CreatedAt: row.CreatedAt,
+				},
+				Type:   row.Type,
+				ReadAt: row.ReadAt,
+			},
+			NoticeName:    row.NoticeName,
+			FromUserHash:  row.FromUserHash,
+			FromUserName:  row.FromUserName,
+			ApplicantHash: row.ApplicantHash,
+			ApplicantName: row.ApplicantName,
+		})
+	}
+
+	return &response.SearchNotice{
+		List: res,
+		Num:  num,
+	}, nil
+}
+
+// 既読
+func (s *NoticeService) Read(req *request.ReadNotice) *response.Error {
+	// バリデーション
+	if err := s.v.Read(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	userID, userErr := s.userID(req.UserHashKey)
+	if userErr != nil {
+		return userErr
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	now := time.Now()
+	if err := s.r.Read(tx, &ddl.Notice{
+		ToUserID: userID,
+		ReadAt:   &now,
+	}, req.HashKeys); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 未読数
+func (s *NoticeService) CountUnread(req *request.CountUnreadNotice) (*response.CountUnreadNotice, *response.Error) {
+	userID, userErr := s.userID(req.UserHashKey)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	count, err := s.r.CountUnread(&ddl.Notice{
+		ToUserID: userID,
+	})
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.CountUnreadNotice{
+		Num: *count,
+	}, nil
+}
+
+// ログインユーザーID取得
+func (s *NoticeService) userID(userHashKey string) (uint64, *response.Error) {
+	ctx := context.Background()
+	user, userErr := s.redis.Get(ctx, userHashKey, static.REDIS_USER_ID)
+	if userErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	userID, userIDErr := strconv.ParseUint(*user, 10, 64)
+	if userIDErr != nil {
+		log.Printf("%v", userIDErr)
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return userID, nil
+}
+
+// 通知書き込み ※呼び出し元のトランザクション内で実行、操作ユーザー本人には通知しない
+// 通知先ユーザーIDを返却
+func writeNotice(
+	tx *gorm.DB,
+	r repository.INoticeRepository,
+	redis repository.IRedisRepository,
+	applicant *entity.Applicant,
+	m *dto.Notice,
+) ([]uint64, error) {
+	var fromUserID *uint64
+	if m.UserHashKey != "" {
+		ctx := context.Background()
+		user, userErr := redis.Get(ctx, m.UserHashKey, static.REDIS_USER_ID)
+		if userErr != nil {
+			return nil, userErr
+		}
+		userID, userIDErr := strconv.ParseUint(*user, 10, 64)
+		if userIDErr != nil {
+			log.Printf("%v", userIDErr)
+			return nil, userIDErr
+		}
+		fromUserID = &userID
+	}
+
+	toUserIDs := m.ToUserIDs
+	if len(toUserIDs) == 0 {
+		IDs, err := r.ListRecipient(tx, &applicant.Applicant)
+		if err != nil {
+			return nil, err
+		}
+		toUserIDs = IDs
+	}
+
+	var notices []*ddl.Notice
+	var noticed []uint64
+	done := map[uint64]bool{}
+	for _, toUserID := range toUserIDs {
+		if done[toUserID] || (fromUserID != nil && *fromUserID == toUserID) {
+			continue
+		}
+		done[toUserID] = true
+		noticed = append(noticed, toUserID)
+
+		_, hash, hashErr := GenerateHash(1, 25)
+		if hashErr != nil {
+			return nil, hashErr
+		}
+		notices = append(notices, &ddl.Notice{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey:   static.PRE_NOTICE + "_" + *hash,
+				CompanyID: applicant.CompanyID,
+			},
+			Type:        m.Type,
+			FromUserID:  fromUserID,
+			ToUserID:    toUserID,
+			ApplicantID: applicant.ID,
+		})
+	}
+	if len(notices) == 0 {
+		return nil, nil
+	}
+
+	if err := r.Inserts(tx, notices); err != nil {
+		return nil, err
+	}
+	return noticed, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// お知らせ登録の記録
+type mockNoticeRepo struct {
+	repository.INoticeRepository
+	recipients []uint64
+	inserted   []*ddl.Notice
+}
+
+func (r *mockNoticeRepo) ListRecipient(tx *gorm.DB, m *ddl.Applicant) ([]uint64, error) {
+	return r.recipients, nil
+}
+
+func (r *mockNoticeRepo) Inserts(tx *gorm.DB, m []*ddl.Notice) error {
+	r.inserted = append(r.inserted, m...)
+	return nil
+}
+
+func TestWriteNotice(t *testing.T) {
+	redis := newMemoryRedis()
+	userID := "2"
+	_ = redis.Set(context.Background(), "user_2", static.REDIS_USER_ID, &userID, 0)
+	applicant := &entity.Applicant{Applicant: ddl.Applicant{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 100, CompanyID: 1},
+	}}
+	from := uint64(2)
+
+	tests := []struct {
+		name       string
+		m          *dto.Notice
+		recipients []uint64
+		want       []uint64
+		wantFrom   *uint64
+		wantErr    bool
+	}{
+		// ok 通知先指定 ※重複・操作ユーザー本人は除外
+		{"ok_to_users", &dto.Notice{
+			UserHashKey: "user_2",
+			Type:        static.NOTICE_APPLICANT_ASSIGN_USER,
+			ToUserIDs:   []uint64{3, 2, 3, 4},
+		}, []uint64{9}, []uint64{3, 4}, &from, false},
+		// ok 通知先未指定は面接官・チーム所属ユーザー
+		{"ok_recipient", &dto.Notice{
+			UserHashKey: "user_2",
+			Type:        static.NOTICE_APPLICANT_INPUT_RESULT,
+		}, []uint64{2, 5}, []uint64{5}, &from, false},
+		// ok 応募者による操作 ※通知元なし、全員に通知
+		{"ok_applicant", &dto.Notice{
+			Type: static.NOTICE_APPLICANT_DESIRED_AT,
+		}, []uint64{2, 5}, []uint64{2, 5}, nil, false},
+		// ok 通知先が本人のみ ※登録しない
+		{"ok_self_only", &dto.Notice{
+			UserHashKey: "user_2",
+			Type:        static.NOTICE_APPLICANT_DOCUMENT_UPLOAD,
+		}, []uint64{2}, nil, nil, false},
+		// ok 通知先なし
+		{"ok_empty", &dto.Notice{
+			Type: static.NOTICE_APPLICANT_DOCUMENT_UPLOAD,
+		}, nil, nil, nil, false},
+		// ng 操作ユーザーのセッションなし
+		{"ng_session", &dto.Notice{
+			UserHashKey: "user_x",
+			Type:        static.NOTICE_APPLICANT_ASSIGN_USER,
+		}, []uint64{5}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &mockNoticeRepo{recipients: tt.recipients}
+			got, err := writeNotice(nil, r, redis, applicant, tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeNotice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("writeNotice() = %v, want %v", got, tt.want)
+			}
+			if len(r.inserted) != len(tt.want) {
+				t.Fatalf("inserted = %d, want %d", len(r.inserted), len(tt.want))
+			}
+			for i, n := range r.inserted {
+				if n.ToUserID != tt.want[i] || n.Type != tt.m.Type ||
+					n.ApplicantID != applicant.ID || n.CompanyID != applicant.CompanyID ||
+					!strings.HasPrefix(n.HashKey, static.PRE_NOTICE+"_") {
+					t.Errorf("inserted[%d] = %+v", i, n)
+				}
+				if !reflect.DeepEqual(n.FromUserID, tt.wantFrom) {
+					t.Errorf("inserted[%d].FromUserID = %v, want %v", i, n.FromUserID, tt.wantFrom)
+				}
+			}
+		})
+	}
+}
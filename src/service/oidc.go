@@ -0,0 +1,104 @@
+package service
+
+import (
+	"api/src/model/dto"
+	"api/src/model/static"
+	"api/src/repository"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// IDトークンのクレーム
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	// nonce
+	Nonce string `json:"nonce"`
+	// 認可された当事者 ※audが複数の場合
+	AuthorizedParty string `json:"azp"`
+	// メールアドレス
+	Email string `json:"email"`
+	// メールアドレス確認済み ※未送信のプロバイダもあるため、falseの場合のみ拒否
+	EmailVerified *bool `json:"email_verified"`
+}
+
+// code_challenge算出 ※S256
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// リダイレクトURI ※プロバイダに登録するフロントのURL
+func oidcRedirectURI() string {
+	return os.Getenv("FE_CSR_URL") + static.OIDC_CALLBACK_PATH
+}
+
+// メールアドレスのドメイン ※小文字
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(domain)
+}
+
+// IDトークン検証 ※署名(RS256)・発行者・対象者・有効期限・nonce
+func verifyIDToken(
+	r repository.IOIDCRepository,
+	provider *dto.OIDCProvider,
+	clientID string,
+	idToken string,
+	nonce string,
+	now time.Time,
+) (*dto.OIDCClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithoutClaimsValidation(),
+	)
+
+	var claims oidcIDTokenClaims
+	if _, err := parser.ParseWithClaims(idToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return r.PublicKey(provider.JWKSURI, kid)
+	}); err != nil {
+		return nil, err
+	}
+
+	if claims.Issuer != provider.Issuer {
+		return nil, fmt.Errorf("unexpected issuer: %s", claims.Issuer)
+	}
+	if !claims.VerifyAudience(clientID, true) {
+		return nil, errors.New("unexpected audience")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != clientID {
+		return nil, errors.New("unexpected authorized party")
+	}
+	if claims.ExpiresAt == nil || !now.Before(claims.ExpiresAt.Add(static.OIDC_CLOCK_SKEW)) {
+		return nil, errors.New("id token expired")
+	}
+	if claims.IssuedAt != nil && now.Add(static.OIDC_CLOCK_SKEW).Before(claims.IssuedAt.Time) {
+		return nil, errors.New("id token issued in the future")
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, errors.New("unexpected nonce")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("subject is empty")
+	}
+	// 未送信は未確認扱い ※メールアドレスで既存ユーザーに紐づけるため
+	if claims.Email == "" || claims.EmailVerified == nil || !*claims.EmailVerified {
+		return nil, errors.New("email is not verified")
+	}
+
+	return &dto.OIDCClaims{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}, nil
+}
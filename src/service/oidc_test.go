@@ -0,0 +1,228 @@
+package service
+
+import (
+	"api/src/model/dto"
+	"api/src/repository"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// OpenID Connect プロバイダ(テスト用) ※認可コードとcode_challengeの組を保持
+type mockOIDCProvider struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey
+	challenges map[string]string
+	// 発行するIDトークンのクレーム
+	claims jwt.MapClaims
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	p := &mockOIDCProvider{
+		key:        key,
+		challenges: map[string]string{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		challenge, ok := p.challenges[r.Form.Get("code")]
+		if !ok || pkceChallenge(r.Form.Get("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     p.sign(t, p.key, p.claims),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *mockOIDCProvider) sign(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key1"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func TestOIDCAuthorizationCodeFlow(t *testing.T) {
+	p := newMockOIDCProvider(t)
+	r := repository.NewOIDCRepository()
+	now := time.Now()
+
+	provider, err := r.Discover(p.server.URL)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	// 認可URLにPKCE・nonceが含まれること
+	verifier := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	m := &dto.OIDCExchange{
+		Provider:     provider,
+		ClientID:     "client_a",
+		ClientSecret: "secret_a",
+		RedirectURI:  "https://example.com/sso/callback",
+	}
+	authURL, err := url.Parse(r.AuthCodeURL(m, "state_a", "nonce_a", pkceChallenge(verifier)))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	query := authURL.Query()
+	if query.Get("code_challenge") != pkceChallenge(verifier) || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("AuthCodeURL() pkce = %v", query)
+	}
+	if query.Get("nonce") != "nonce_a" || query.Get("state") != "state_a" || query.Get("scope") != "openid email profile" {
+		t.Fatalf("AuthCodeURL() params = %v", query)
+	}
+	p.challenges["code_a"] = query.Get("code_challenge")
+
+	p.claims = jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "subject_a",
+		"aud":            "client_a",
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          "nonce_a",
+		"email":          "user@example.com",
+		"email_verified": true,
+	}
+
+	// ng code_verifier不一致
+	m.Code = "code_a"
+	m.Verifier = "wrong_verifier"
+	if _, err := r.Exchange(m); err == nil {
+		t.Fatalf("Exchange() with wrong verifier error = nil")
+	}
+
+	// ok
+	m.Verifier = verifier
+	idToken, err := r.Exchange(m)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	claims, err := verifyIDToken(r, provider, "client_a", idToken, "nonce_a", now)
+	if err != nil {
+		t.Fatalf("verifyIDToken() error = %v", err)
+	}
+	if claims.Issuer != p.server.URL || claims.Subject != "subject_a" || claims.Email != "user@example.com" {
+		t.Errorf("verifyIDToken() = %+v", claims)
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	p := newMockOIDCProvider(t)
+	r := repository.NewOIDCRepository()
+	now := time.Now()
+
+	provider, err := r.Discover(p.server.URL)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	base := func(overrides map[string]interface{}) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"iss":            p.server.URL,
+			"sub":            "subject_a",
+			"aud":            "client_a",
+			"exp":            now.Add(time.Hour).Unix(),
+			"iat":            now.Unix(),
+			"nonce":          "nonce_a",
+			"email":          "user@example.com",
+			"email_verified": true,
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(claims, k)
+				continue
+			}
+			claims[k] = v
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		// ok
+		{"ok", p.sign(t, p.key, base(nil)), false},
+		// ok 複数audかつazpが自身
+		{"ok_azp", p.sign(t, p.key, base(map[string]interface{}{"aud": []string{"client_a", "other"}, "azp": "client_a"})), false},
+		// ng 別の鍵で署名
+		{"ng_signature", p.sign(t, otherKey, base(nil)), true},
+		// ng 発行者不一致
+		{"ng_issuer", p.sign(t, p.key, base(map[string]interface{}{"iss": "https://evil.example.com"})), true},
+		// ng 対象者不一致
+		{"ng_audience", p.sign(t, p.key, base(map[string]interface{}{"aud": "other"})), true},
+		// ng 複数audかつazpが他者
+		{"ng_azp", p.sign(t, p.key, base(map[string]interface{}{"aud": []string{"client_a", "other"}, "azp": "other"})), true},
+		// ng 有効期限切れ
+		{"ng_expired", p.sign(t, p.key, base(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})), true},
+		// ng nonce不一致
+		{"ng_nonce", p.sign(t, p.key, base(map[string]interface{}{"nonce": "nonce_b"})), true},
+		// ng メールアドレス未確認
+		{"ng_email_verified", p.sign(t, p.key, base(map[string]interface{}{"email_verified": false})), true},
+		// ng email_verified未送信
+		{"ng_email_verified_missing", p.sign(t, p.key, base(map[string]interface{}{"email_verified": nil})), true},
+		// ng email_verifiedが文字列
+		{"ng_email_verified_string", p.sign(t, p.key, base(map[string]interface{}{"email_verified": "true"})), true},
+		// ng メールアドレスなし
+		{"ng_email", p.sign(t, p.key, base(map[string]interface{}{"email": nil})), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifyIDToken(r, provider, "client_a", tt.token, "nonce_a", now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
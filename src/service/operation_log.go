@@ -0,0 +1,266 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+type IOperationLogService interface {
+	// 検索
+	Search(req *request.SearchOperationLog) (*response.SearchOperationLog, *response.Error)
+	// 取得
+	Get(req *request.GetOperationLog) (*response.GetOperationLog, *response.Error)
+	// イベント一覧
+	ListEvent(req *request.ListOperationLogEvent) (*response.ListOperationLogEvent, *response.Error)
+}
+
+type OperationLogService struct {
+	r      repository.IOperationLogRepository
+	master repository.IMasterRepository
+	redis  repository.IRedisRepository
+	v      validator.IOperationLogValidator
+}
+
+func NewOperationLogService(
+	r repository.IOperationLogRepository,
+	master repository.IMasterRepository,
+	redis repository.IRedisRepository,
+	v validator.IOperationLogValidator,
+) IOperationLogService {
+	return &OperationLogService{r, master, redis, v}
+}
+
+// 検索
+func (s *OperationLogService) Search(req *request.SearchOperationLog) (*response.SearchOperationLog, *response.Error) {
+	// バリデーション
+	if err := s.v.Search(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 検索対象企業
+	companyID, companyErr := s.scope(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	// 検索
+	logs, num, searchErr := s.r.Search(&dto.SearchOperationLog{
+		SearchOperationLog: *req,
+		CompanyID:          companyID,
+	})
+	if searchErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.OperationLog{}
+	for _, row := range logs {
+		res = append(res, *maskOperationLog(&row))
+	}
+
+	return &response.SearchOperationLog{
+		List: res,
+		Num:  num,
+	}, nil
+}
+
+// 取得
+func (s *OperationLogService) Get(req *request.GetOperationLog) (*response.GetOperationLog, *response.Error) {
+	// バリデーション
+	if err := s.v.Get(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 検索対象企業
+	companyID, companyErr := s.scope(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	// 取得
+	row, err := s.r.Get(&ddl.OperationLog{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.HashKey,
+			CompanyID: companyID,
+		},
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusNotFound,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.GetOperationLog{
+		OperationLog: *maskOperationLog(row),
+	}, nil
+}
+
+// イベント一覧
+func (s *OperationLogService) ListEvent(req *request.ListOperationLogEvent) (*response.ListOperationLogEvent, *response.Error) {
+	events, err := s.master.ListOperationLogEvent()
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res := []entity.OperationLogEvent{}
+	for _, event := range events {
+		event.ID = 0
+		res = append(res, event)
+	}
+
+	return &response.ListOperationLogEvent{
+		List: res,
+	}, nil
+}
+
+// 検索対象企業取得 ※管理者の場合は全企業(0)
+func (s *OperationLogService) scope(userHashKey string) (uint64, *response.Error) {
+	ctx := context.Background()
+	loginType, loginTypeErr := s.redis.Get(ctx, userHashKey, static.REDIS_USER_LOGIN_TYPE)
+	if loginTypeErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *loginType == strconv.FormatUint(uint64(static.LOGIN_TYPE_ADMIN), 10) {
+		return 0, nil
+	}
+
+	company, companyErr := s.redis.Get(ctx, userHashKey, static.REDIS_USER_COMPANY_ID)
+	if companyErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID, companyIDErr := strconv.ParseUint(*company, 10, 64)
+	if companyIDErr != nil {
+		log.Printf("%v", companyIDErr)
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return companyID, nil
+}
+
+// 内部IDの除去
+func maskOperationLog(m *entity.OperationLog) *entity.OperationLog {
+	return &entity.OperationLog{
+		OperationLog: ddl.OperationLog{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey:   m.HashKey,
+				CreatedAt: m.CreatedAt,
+			},
+			Target: m.Target,
+			Log:    m.Log,
+		},
+		EventName:     m.EventName,
+		EventHash:     m.EventHash,
+		UserHash:      m.UserHash,
+		UserName:      m.UserName,
+		ApplicantHash: m.ApplicantHash,
+		ApplicantName: m.ApplicantName,
+		TeamHash:      m.TeamHash,
+		TeamName:      m.TeamName,
+	}
+}
+
+// 操作ログ書き込み ※呼び出し元のトランザクション内で実行
+func writeOperationLog(
+	tx *gorm.DB,
+	r repository.IOperationLogRepository,
+	redis repository.IRedisRepository,
+	m *dto.OperationLog,
+) error {
+	row := ddl.OperationLog{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: m.CompanyID,
+		},
+		EventID:     m.EventID,
+		ApplicantID: m.ApplicantID,
+		TeamID:      m.TeamID,
+		Target:      m.Target,
+	}
+
+	// 操作ユーザー情報はセッションから取得
+	if m.UserHashKey != "" {
+		ctx := context.Background()
+		user, userErr := redis.Get(ctx, m.UserHashKey, static.REDIS_USER_ID)
+		if userErr != nil {
+			return userErr
+		}
+		userID, userIDErr := strconv.ParseUint(*user, 10, 64)
+		if userIDErr != nil {
+			log.Printf("%v", userIDErr)
+			return userIDErr
+		}
+		row.UserID = userID
+
+		if row.CompanyID == 0 {
+			company, companyErr := redis.Get(ctx, m.UserHashKey, static.REDIS_USER_COMPANY_ID)
+			if companyErr != nil {
+				return companyErr
+			}
+			companyID, companyIDErr := strconv.ParseUint(*company, 10, 64)
+			if companyIDErr != nil {
+				log.Printf("%v", companyIDErr)
+				return companyIDErr
+			}
+			row.CompanyID = companyID
+		}
+
+		// チーム未所属の場合は0
+		if row.TeamID == 0 {
+			team, teamErr := redis.Get(ctx, m.UserHashKey, static.REDIS_USER_TEAM_ID)
+			if teamErr == nil {
+				teamID, teamIDErr := strconv.ParseUint(*team, 10, 64)
+				if teamIDErr == nil {
+					row.TeamID = teamID
+				}
+			}
+		}
+	}
+
+	if m.Detail != nil {
+		detail, jsonErr := json.Marshal(m.Detail)
+		if jsonErr != nil {
+			log.Printf("%v", jsonErr)
+			return jsonErr
+		}
+		row.Log = string(detail)
+	}
+
+	_, hash, hashErr := GenerateHash(1, 25)
+	if hashErr != nil {
+		return hashErr
+	}
+	row.HashKey = static.PRE_OPERATION_LOG + "_" + *hash
+
+	return r.Insert(tx, &row)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"crypto/rand"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 漏洩パスワード一覧 ※小文字で保持
+var breachedPasswords = func() map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, line := range strings.Split(static.BreachedPasswords, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			res[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return res
+}()
+
+// 企業のパスワードポリシー ※未設定の項目は既定値
+func passwordPolicy(security *entity.CompanySecurity) *dto.PasswordPolicy {
+	policy := &dto.PasswordPolicy{
+		MinLength:  static.PASSWORD_MIN_LENGTH,
+		MinClasses: static.PASSWORD_MIN_CLASSES,
+		History:    static.PASSWORD_HISTORY,
+	}
+	if security == nil {
+		return policy
+	}
+	if security.PasswordMinLength > 0 {
+		policy.MinLength = security.PasswordMinLength
+	}
+	if security.PasswordMinClasses > 0 {
+		policy.MinClasses = security.PasswordMinClasses
+	}
+	if security.PasswordHistory > 0 {
+		policy.History = security.PasswordHistory
+	}
+	return policy
+}
+
+// 企業のパスワードポリシー取得
+func companyPasswordPolicy(company repository.ICompanyRepository, companyID uint64) (*dto.PasswordPolicy, *response.Error) {
+	security, err := company.GetSecurity(&ddl.CompanySecurity{
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return passwordPolicy(security), nil
+}
+
+// 文字種数(英小文字・英大文字・数字・記号)
+func passwordClasses(password string) uint {
+	var lower, upper, digit, symbol uint
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// パスワードポリシー検証 ※文字数・文字種・漏洩パスワード
+func checkPasswordPolicy(policy *dto.PasswordPolicy, password string) *response.Error {
+	if uint(utf8.RuneCountInString(password)) < policy.MinLength ||
+		passwordClasses(password) < policy.MinClasses {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_PASSWORD_POLICY,
+		}
+	}
+	if _, ok := breachedPasswords[strings.ToLower(password)]; ok {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_PASSWORD_BREACHED,
+		}
+	}
+	return nil
+}
+
+// パスワード再利用確認 ※現在のパスワードと直近の履歴
+func checkPasswordReuse(
+	user repository.IUserRepository,
+	target *entity.User,
+	policy *dto.PasswordPolicy,
+	password string,
+) *response.Error {
+	histories, err := user.ListPasswordHistory(&ddl.UserPasswordHistory{
+		UserID: target.ID,
+	}, int(policy.History))
+	if err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	hashes := []string{target.Password}
+	for _, history := range histories {
+		hashes = append(hashes, history.Password)
+	}
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+				Code:   static.CODE_PASSWORD_REUSED,
+			}
+		}
+	}
+	return nil
+}
+
+// パスワード生成 ※ポリシーを満たすよう全文字種を含める
+func GeneratePassword(policy *dto.PasswordPolicy) (*string, *string, error) {
+	classes := []string{
+		"abcdefghijkmnopqrstuvwxyz",
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"23456789",
+		static.PASSWORD_SYMBOLS,
+	}
+	all := strings.Join(classes, "")
+
+	length := static.PASSWORD_GENERATE_LENGTH
+	if int(policy.MinLength) > length {
+		length = int(policy.MinLength)
+	}
+
+	pick := func(chars string) (byte, error) {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return 0, err
+		}
+		return chars[n.Int64()], nil
+	}
+
+	buffer := make([]byte, 0, length)
+	for _, chars := range classes {
+		c, err := pick(chars)
+		if err != nil {
+			return nil, nil, err
+		}
+		buffer = append(buffer, c)
+	}
+	for len(buffer) < length {
+		c, err := pick(all)
+		if err != nil {
+			return nil, nil, err
+		}
+		buffer = append(buffer, c)
+	}
+
+	// 文字種の位置が固定にならないようシャッフル
+	for i := len(buffer) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, nil, err
+		}
+		j := n.Int64()
+		buffer[i], buffer[j] = buffer[j], buffer[i]
+	}
+	str := string(buffer)
+
+	hash, err := bcrypt.GenerateFromPassword(buffer, bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, nil, err
+	}
+	hashStr := string(hash)
+
+	return &str, &hashStr, nil
+}
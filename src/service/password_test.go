@@ -0,0 +1,67 @@
+package service
+
+import (
+	"api/src/model/dto"
+	"api/src/model/static"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	policy := passwordPolicy(nil)
+	tests := []struct {
+		name     string
+		password string
+		wantCode uint
+	}{
+		// ok 3文字種
+		{"ok_classes_3", "Adoption2024", 0},
+		// ok 記号含む
+		{"ok_symbol", "adoption-2024", 0},
+		// ng 文字数不足
+		{"ng_length", "Ab1", static.CODE_PASSWORD_POLICY},
+		// ng 文字種不足
+		{"ng_classes", "adoptionadoption", static.CODE_PASSWORD_POLICY},
+		// ng 漏洩パスワード ※大文字小文字は区別しない
+		{"ng_breached", "PASSWORD1!", static.CODE_PASSWORD_BREACHED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPasswordPolicy(policy, tt.password)
+			var code uint
+			if err != nil {
+				code = err.Code
+			}
+			if code != tt.wantCode {
+				t.Errorf("checkPasswordPolicy() code = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *dto.PasswordPolicy
+		wantLength int
+	}{
+		// ok 既定の文字数
+		{"ok_default", passwordPolicy(nil), static.PASSWORD_GENERATE_LENGTH},
+		// ok ポリシーの最小文字数が長い場合は合わせる
+		{"ok_min_length", &dto.PasswordPolicy{MinLength: 32, MinClasses: 4}, 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, _, err := GeneratePassword(tt.policy)
+			if err != nil {
+				t.Fatalf("GeneratePassword() error = %v", err)
+			}
+			if utf8.RuneCountInString(*password) != tt.wantLength {
+				t.Errorf("GeneratePassword() length = %v, want %v", len(*password), tt.wantLength)
+			}
+			if passwordClasses(*password) != 4 {
+				t.Errorf("GeneratePassword() classes = %v, want 4", passwordClasses(*password))
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"api/src/repository"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 繰り返しの曜日指定
+type recurrenceDay struct {
+	// 曜日
+	Weekday time.Weekday
+	// 第何週 ※0は全週、負数は月末から(毎月のみ)
+	Ordinal int
+}
+
+// 予定の展開 ※期間(両端を含む)と重なる回を開始時刻順に返す、保存済みの予定は変更しない
+// 繰り返しの日時はlocの壁時計時刻で計算し、例外(取消・移動)は本来の開始時刻で照合
+func expandSchedule(
+	s *ddl.Schedule,
+	exceptions []entity.ScheduleException,
+	from time.Time,
+	to time.Time,
+	loc *time.Location,
+) []dto.ScheduleOccurrence {
+	skips := make(map[int64]bool)
+	moves := make(map[int64]entity.ScheduleException)
+	last := to
+	for _, row := range exceptions {
+		key := row.OriginalStart.UnixNano()
+		switch row.Kind {
+		case static.SCHEDULE_EXCEPTION_SKIP:
+			skips[key] = true
+		case static.SCHEDULE_EXCEPTION_MOVE:
+			if row.Start == nil || row.End == nil {
+				continue
+			}
+			moves[key] = row
+			// 期間外の回から期間内への移動も対象
+			if row.OriginalStart.After(last) {
+				last = row.OriginalStart
+			}
+		}
+	}
+
+	duration := s.End.Sub(s.Start)
+	if duration < 0 {
+		duration = 0
+	}
+
+	var res []dto.ScheduleOccurrence
+	for _, start := range recurrenceStarts(s, last, loc) {
+		key := start.UnixNano()
+		if skips[key] {
+			continue
+		}
+		occurrence := dto.ScheduleOccurrence{
+			HashKey:       s.HashKey,
+			Start:         start,
+			End:           start.Add(duration),
+			OriginalStart: start,
+		}
+		if move, ok := moves[key]; ok {
+			occurrence.Start = move.Start.In(loc)
+			occurrence.End = move.End.In(loc)
+			occurrence.Moved = true
+		}
+		if occurrence.Start.After(to) || occurrence.End.Before(from) {
+			continue
+		}
+		res = append(res, occurrence)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Start.Before(res[j].Start)
+	})
+	return res
+}
+
+// 予定の回か ※例外を適用する前の本来の開始時刻で判定
+func isScheduleOccurrence(s *ddl.Schedule, originalStart time.Time, loc *time.Location) bool {
+	for _, row := range expandSchedule(s, nil, originalStart, originalStart, loc) {
+		if row.OriginalStart.Equal(originalStart) {
+			return true
+		}
+	}
+	return false
+}
+
+// 繰り返しの各回の開始時刻 ※lastまでに開始する回、回数・終了日時を適用
+func recurrenceStarts(s *ddl.Schedule, last time.Time, loc *time.Location) []time.Time {
+	start := s.Start.In(loc)
+	if !isRecurring(s.FreqID) {
+		if start.After(last) {
+			return nil
+		}
+		return []time.Time{start}
+	}
+
+	interval := int(s.Interval)
+	if interval < 1 {
+		interval = 1
+	}
+	if s.Until != nil && s.Until.Before(last) {
+		last = *s.Until
+	}
+	// 不正な曜日指定は無視 ※登録時にバリデーション済み
+	days, daysErr := parseByDay(s.ByDay)
+	if daysErr != nil {
+		days = nil
+	}
+
+	var res []time.Time
+	count := 0
+	for i := 0; i < static.SCHEDULE_EXPAND_MAX_ITERATIONS; i++ {
+		begin, candidates := recurrencePeriod(start, s.FreqID, i*interval, days, loc)
+		if begin.After(last) {
+			break
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if candidate.After(last) {
+				return res
+			}
+			count++
+			if s.Count > 0 && count > int(s.Count) {
+				return res
+			}
+			res = append(res, candidate)
+		}
+	}
+	return res
+}
+
+// 繰り返しの期間 ※開始から数えてn番目の期間(日・週・月・年)の始まりと、期間内の回の開始時刻
+func recurrencePeriod(
+	start time.Time,
+	freq uint,
+	n int,
+	days []recurrenceDay,
+	loc *time.Location,
+) (time.Time, []time.Time) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), loc)
+	}
+	year, month, day := start.Date()
+
+	var res []time.Time
+	switch freq {
+	case static.FREQ_DAILY:
+		begin := time.Date(year, month, day+n, 0, 0, 0, 0, loc)
+		if len(days) == 0 || hasRecurrenceWeekday(days, begin.Weekday()) {
+			res = append(res, at(year, month, day+n))
+		}
+		return begin, res
+
+	case static.FREQ_WEEKLY:
+		// 週の始まりは月曜(WKST=MO)
+		monday := day - (int(start.Weekday())+6)%7 + n*7
+		begin := time.Date(year, month, monday, 0, 0, 0, 0, loc)
+		if len(days) == 0 {
+			days = []recurrenceDay{{Weekday: start.Weekday()}}
+		}
+		for offset := 0; offset < 7; offset++ {
+			if hasRecurrenceWeekday(days, time.Weekday((offset+1)%7)) {
+				res = append(res, at(year, month, monday+offset))
+			}
+		}
+		return begin, res
+
+	case static.FREQ_MONTHLY:
+		begin := time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, loc)
+		// 存在しない日付(31日など)の月は対象外
+		if len(days) == 0 {
+			t := at(begin.Year(), begin.Month(), day)
+			if t.Month() == begin.Month() {
+				res = append(res, t)
+			}
+			return begin, res
+		}
+		lastDay := begin.AddDate(0, 1, -1).Day()
+		for d := 1; d <= lastDay; d++ {
+			t := at(begin.Year(), begin.Month(), d)
+			for _, row := range days {
+				if row.Weekday != t.Weekday() {
+					continue
+				}
+				if row.Ordinal == 0 ||
+					(row.Ordinal > 0 && (d-1)/7+1 == row.Ordinal) ||
+					(row.Ordinal < 0 && (lastDay-d)/7+1 == -row.Ordinal) {
+					res = append(res, t)
+					break
+				}
+			}
+		}
+		return begin, res
+
+	case static.FREQ_YEARLY:
+		begin := time.Date(year+n, time.January, 1, 0, 0, 0, 0, loc)
+		// 2/29は閏年のみ
+		t := at(year+n, month, day)
+		if t.Month() == month {
+			res = append(res, t)
+		}
+		return begin, res
+	}
+	return start, nil
+}
+
+// 繰り返し予定か
+func isRecurring(freqID uint) bool {
+	switch freqID {
+	case static.FREQ_DAILY, static.FREQ_WEEKLY, static.FREQ_MONTHLY, static.FREQ_YEARLY:
+		return true
+	}
+	return false
+}
+
+// 曜日指定の解析 ※「MO,WE」「2TU,-1FR」形式、空文字は指定なし
+func parseByDay(s string) ([]recurrenceDay, error) {
+	var res []recurrenceDay
+	if strings.TrimSpace(s) == "" {
+		return res, nil
+	}
+	for _, token := range strings.Split(s, ",") {
+		token = strings.ToUpper(strings.TrimSpace(token))
+		if len(token) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY: %s", token)
+		}
+		code := token[len(token)-2:]
+		weekday := -1
+		for i, row := range static.SCHEDULE_BYDAY_WEEKDAYS {
+			if row == code {
+				weekday = i
+			}
+		}
+		if weekday < 0 {
+			return nil, fmt.Errorf("invalid BYDAY: %s", token)
+		}
+		var ordinal int
+		if prefix := token[:len(token)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid BYDAY: %s", token)
+			}
+			ordinal = n
+		}
+		res = append(res, recurrenceDay{
+			Weekday: time.Weekday(weekday),
+			Ordinal: ordinal,
+		})
+	}
+	return res, nil
+}
+
+// 曜日が指定に含まれるか
+func hasRecurrenceWeekday(days []recurrenceDay, weekday time.Weekday) bool {
+	for _, row := range days {
+		if row.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// 予定のタイムゾーン ※チーム既定の予約枠設定、取得済みのチームはcacheを使用
+func scheduleLocation(
+	t repository.ITeamRepository,
+	teamID uint64,
+	cache map[uint64]*time.Location,
+) (*time.Location, error) {
+	if loc, ok := cache[teamID]; ok {
+		return loc, nil
+	}
+	w, err := getBookingWindow(t, teamID, 0)
+	if err != nil {
+		return nil, err
+	}
+	cache[teamID] = w.Location
+	return w.Location, nil
+}
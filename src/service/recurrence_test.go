@@ -0,0 +1,139 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/static"
+	"testing"
+	"time"
+)
+
+func TestExpandSchedule(t *testing.T) {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	at := func(loc *time.Location, month time.Month, day int, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, loc)
+	}
+	until := at(jst, 1, 20, 23)
+	moved := at(jst, 2, 20, 15)
+	movedEnd := at(jst, 2, 20, 16)
+
+	tests := []struct {
+		name       string
+		schedule   *ddl.Schedule
+		exceptions []entity.ScheduleException
+		from       time.Time
+		to         time.Time
+		loc        *time.Location
+		want       []string
+	}{
+		// ok 繰り返しなし
+		{"ok_none", &ddl.Schedule{
+			FreqID: static.FREQ_NONE, Start: at(jst, 1, 10, 10), End: at(jst, 1, 10, 11),
+		}, nil, at(jst, 1, 1, 0), at(jst, 1, 31, 0), jst, []string{"01-10 10:00"}},
+		// ok 毎日・2日毎
+		{"ok_daily_interval", &ddl.Schedule{
+			FreqID: static.FREQ_DAILY, Interval: 2, Start: at(jst, 1, 1, 10), End: at(jst, 1, 1, 11),
+		}, nil, at(jst, 1, 4, 0), at(jst, 1, 9, 0), jst, []string{"01-05 10:00", "01-07 10:00"}},
+		// ok 毎日・平日のみ
+		{"ok_daily_byday", &ddl.Schedule{
+			FreqID: static.FREQ_DAILY, ByDay: "MO,TU,WE,TH,FR", Start: at(jst, 1, 5, 10), End: at(jst, 1, 5, 11),
+		}, nil, at(jst, 1, 5, 0), at(jst, 1, 9, 0), jst, []string{"01-05 10:00", "01-08 10:00"}},
+		// ok 毎週・火木、期間前から続く
+		{"ok_weekly_byday", &ddl.Schedule{
+			FreqID: static.FREQ_WEEKLY, ByDay: "TU,TH", Start: at(jst, 1, 2, 10), End: at(jst, 1, 2, 11),
+		}, nil, at(jst, 1, 15, 0), at(jst, 1, 21, 0), jst, []string{"01-16 10:00", "01-18 10:00"}},
+		// ok 隔週・曜日指定なしは開始日の曜日
+		{"ok_weekly_interval", &ddl.Schedule{
+			FreqID: static.FREQ_WEEKLY, Interval: 2, Start: at(jst, 1, 3, 10), End: at(jst, 1, 3, 11),
+		}, nil, at(jst, 1, 1, 0), at(jst, 1, 31, 23), jst, []string{"01-03 10:00", "01-17 10:00", "01-31 10:00"}},
+		// ok 毎月・31日がない月は対象外
+		{"ok_monthly_31", &ddl.Schedule{
+			FreqID: static.FREQ_MONTHLY, Start: at(jst, 1, 31, 10), End: at(jst, 1, 31, 11),
+		}, nil, at(jst, 1, 1, 0), at(jst, 5, 1, 0), jst, []string{"01-31 10:00", "03-31 10:00"}},
+		// ok 毎月・最終金曜
+		{"ok_monthly_last_friday", &ddl.Schedule{
+			FreqID: static.FREQ_MONTHLY, ByDay: "-1FR", Start: at(jst, 1, 26, 10), End: at(jst, 1, 26, 11),
+		}, nil, at(jst, 1, 1, 0), at(jst, 3, 31, 0), jst, []string{"01-26 10:00", "02-23 10:00", "03-29 10:00"}},
+		// ok 毎月・第2火曜
+		{"ok_monthly_second_tuesday", &ddl.Schedule{
+			FreqID: static.FREQ_MONTHLY, ByDay: "2TU", Start: at(jst, 1, 9, 10), End: at(jst, 1, 9, 11),
+		}, nil, at(jst, 1, 1, 0), at(jst, 2, 29, 0), jst, []string{"01-09 10:00", "02-13 10:00"}},
+		// ok 毎年・2/29は閏年のみ
+		{"ok_yearly_leap_day", &ddl.Schedule{
+			FreqID: static.FREQ_YEARLY, Start: time.Date(2020, 2, 29, 10, 0, 0, 0, jst), End: time.Date(2020, 2, 29, 11, 0, 0, 0, jst),
+		}, nil, time.Date(2021, 1, 1, 0, 0, 0, 0, jst), time.Date(2024, 12, 31, 0, 0, 0, 0, jst), jst, []string{"02-29 10:00"}},
+		// ok 回数
+		{"ok_count", &ddl.Schedule{
+			FreqID: static.FREQ_DAILY, Count: 3, Start: at(jst, 1, 1, 10), End: at(jst, 1, 1, 11),
+		}, nil, at(jst, 1, 2, 0), at(jst, 1, 31, 0), jst, []string{"01-02 10:00", "01-03 10:00"}},
+		// ok 終了日時
+		{"ok_until", &ddl.Schedule{
+			FreqID: static.FREQ_WEEKLY, Until: &until, Start: at(jst, 1, 1, 10), End: at(jst, 1, 1, 11),
+		}, nil, at(jst, 1, 1, 0), at(jst, 2, 29, 0), jst, []string{"01-01 10:00", "01-08 10:00", "01-15 10:00"}},
+		// ok 取消・移動 ※期間外の回から期間内への移動を含む
+		{"ok_exceptions", &ddl.Schedule{
+			FreqID: static.FREQ_WEEKLY, Start: at(jst, 1, 1, 10), End: at(jst, 1, 1, 11),
+		}, []entity.ScheduleException{
+			{ScheduleException: ddl.ScheduleException{OriginalStart: at(jst, 2, 5, 10), Kind: static.SCHEDULE_EXCEPTION_SKIP}},
+			{ScheduleException: ddl.ScheduleException{OriginalStart: at(jst, 3, 4, 10), Kind: static.SCHEDULE_EXCEPTION_MOVE, Start: &moved, End: &movedEnd}},
+		}, at(jst, 2, 1, 0), at(jst, 2, 29, 0), jst, []string{"02-12 10:00", "02-19 10:00", "02-20 15:00", "02-26 10:00"}},
+		// ok 夏時間の切り替わり後も現地時刻を維持
+		{"ok_dst", &ddl.Schedule{
+			FreqID: static.FREQ_WEEKLY, Start: at(ny, 3, 4, 9), End: at(ny, 3, 4, 10),
+		}, nil, at(ny, 3, 1, 0), at(ny, 3, 14, 0), ny, []string{"03-04 09:00", "03-11 09:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandSchedule(tt.schedule, tt.exceptions, tt.from, tt.to, tt.loc)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expandSchedule() = %v, want %v", got, tt.want)
+			}
+			for i, row := range got {
+				if s := row.Start.In(tt.loc).Format("01-02 15:04"); s != tt.want[i] {
+					t.Errorf("expandSchedule()[%d] = %v, want %v", i, s, tt.want[i])
+				}
+				if !row.Moved && row.End.Sub(row.Start) != time.Hour {
+					t.Errorf("expandSchedule()[%d] duration = %v", i, row.End.Sub(row.Start))
+				}
+			}
+		})
+	}
+}
+
+func TestParseByDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		// ok 指定なし
+		{"ok_empty", "", 0, false},
+		// ok 曜日
+		{"ok_weekdays", "MO, we,FR", 3, false},
+		// ok 第何週
+		{"ok_ordinal", "2TU,-1FR", 2, false},
+		// ng 不明な曜日
+		{"ng_weekday", "MO,XX", 0, true},
+		// ng 第何週の範囲外
+		{"ng_ordinal", "6MO", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseByDay(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseByDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("parseByDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"api/src/repository"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Redis(ハッシュ・配信)のメモリ実装 ※ロックはmemoryLockRedis
+type memoryRedis struct {
+	repository.IRedisRepository
+	mu        sync.Mutex
+	hashes    map[string]map[string]string
+	counters  map[string]int64
+	published []string
+}
+
+func newMemoryRedis() *memoryRedis {
+	return &memoryRedis{
+		hashes:   make(map[string]map[string]string),
+		counters: make(map[string]int64),
+	}
+}
+
+func (r *memoryRedis) Set(ctx context.Context, hashKey string, key string, value *string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes[hashKey] == nil {
+		r.hashes[hashKey] = make(map[string]string)
+	}
+	r.hashes[hashKey][key] = *value
+	return nil
+}
+
+func (r *memoryRedis) Get(ctx context.Context, hashKey string, key string) (*string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.hashes[hashKey][key]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return &value, nil
+}
+
+func (r *memoryRedis) Delete(ctx context.Context, hashKey string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.hashes, hashKey)
+	return nil
+}
+
+func (r *memoryRedis) Pop(ctx context.Context, hashKey string, key string) (*string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.hashes[hashKey][key]
+	delete(r.hashes, hashKey)
+	if !ok {
+		return nil, redis.Nil
+	}
+	return &value, nil
+}
+
+func (r *memoryRedis) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[key]++
+	return r.counters[key], nil
+}
+
+func (r *memoryRedis) Publish(ctx context.Context, channel string, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.published = append(r.published, channel)
+	return nil
+}
+
+// トランザクションの記録 ※DB接続なし
+type mockDB struct {
+	tx         *gorm.DB
+	started    int
+	committed  int
+	rolledBack int
+}
+
+func newMockDB() *mockDB {
+	return &mockDB{tx: &gorm.DB{}}
+}
+
+func (d *mockDB) TxStart() (*gorm.DB, error) {
+	d.started++
+	return d.tx, nil
+}
+
+func (d *mockDB) TxCommit(tx *gorm.DB) error {
+	d.committed++
+	return nil
+}
+
+func (d *mockDB) TxRollback(tx *gorm.DB) error {
+	d.rolledBack++
+	return nil
+}
+
+// トランザクションが開始から終了まで閉じているか
+func (d *mockDB) closed() bool {
+	return d.started == d.committed+d.rolledBack
+}
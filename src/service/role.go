@@ -2,6 +2,8 @@ package service
 
 import (
 	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
 	"api/src/model/request"
 	"api/src/model/response"
 	"api/src/model/static"
@@ -11,6 +13,8 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+
+	"gorm.io/gorm"
 )
 
 type IRoleService interface {
@@ -18,20 +22,40 @@ type IRoleService interface {
 	Check(req *request.CheckRole) (bool, *response.Error)
 	// 検索_企業ID
 	SearchRoleByComapny(req *request.SearchRoleByComapny) (*response.SearchRoleByComapny, *response.Error)
+	// ロールマスタ一覧
+	ListMasterRole(req *request.ListMasterRole) (*response.ListMasterRole, *response.Error)
+	// 取得
+	Get(req *request.GetRole) (*response.GetRole, *response.Error)
+	// 登録
+	Create(req *request.CreateRole) *response.Error
+	// 更新
+	Update(req *request.UpdateRole) *response.Error
+	// 削除
+	Delete(req *request.DeleteRole) *response.Error
+	// ユーザー割り当て
+	Assign(req *request.AssignRole) *response.Error
 }
 
 type RoleService struct {
-	role  repository.IRoleRepository
-	redis repository.IRedisRepository
-	v     validator.IRoleValidator
+	role         repository.IRoleRepository
+	master       repository.IMasterRepository
+	user         repository.IUserRepository
+	redis        repository.IRedisRepository
+	v            validator.IRoleValidator
+	db           repository.IDBRepository
+	operationLog repository.IOperationLogRepository
 }
 
 func NewRoleService(
 	role repository.IRoleRepository,
+	master repository.IMasterRepository,
+	user repository.IUserRepository,
 	redis repository.IRedisRepository,
 	v validator.IRoleValidator,
+	db repository.IDBRepository,
+	operationLog repository.IOperationLogRepository,
 ) IRoleService {
-	return &RoleService{role, redis, v}
+	return &RoleService{role, master, user, redis, v, db, operationLog}
 }
 
 // ロールチェック
@@ -44,75 +68,719 @@ func (r *RoleService) Check(req *request.CheckRole) (bool, *response.Error) {
 		}
 	}
 
+	held, heldErr := heldMasterRoleIDs(r.redis, r.role, req.UserHashKey)
+	if heldErr != nil {
+		return false, heldErr
+	}
+
+	// ロールの存在チェック
+	return held[req.ID], nil
+}
+
+// 検索_企業ID
+func (r *RoleService) SearchRoleByComapny(req *request.SearchRoleByComapny) (*response.SearchRoleByComapny, *response.Error) {
 	// ロールID取得
 	ctx := context.Background()
-	role, roleErr := r.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_ROLE)
+	company, companyErr := r.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_COMPANY_ID)
+	if companyErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID, companyParseErr := strconv.ParseUint(*company, 10, 64)
+	if companyParseErr != nil {
+		log.Printf("%v", companyParseErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 検索
+	roles, err := r.role.SearchByCompanyID(&ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+	})
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.SearchRoleByComapny{
+		List: roles,
+	}, nil
+}
+
+// ロールマスタ一覧 ※ログイン種別で付与可能なもの
+func (r *RoleService) ListMasterRole(req *request.ListMasterRole) (*response.ListMasterRole, *response.Error) {
+	roles, rolesErr := r.listMasterRole(req.UserHashKey)
+	if rolesErr != nil {
+		return nil, rolesErr
+	}
+
+	return &response.ListMasterRole{
+		List: roles,
+	}, nil
+}
+
+// 取得
+func (r *RoleService) Get(req *request.GetRole) (*response.GetRole, *response.Error) {
+	// バリデーション
+	if err := r.v.Get(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	role, roleErr := r.get(req.UserHashKey, req.HashKey)
 	if roleErr != nil {
-		return false, &response.Error{
+		return nil, roleErr
+	}
+
+	// 付与ロール
+	roles, rolesErr := r.role.ListMasterRole(&role.CustomRole)
+	if rolesErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.GetRole{
+		CustomRole: entity.CustomRole{
+			CustomRole: ddl.CustomRole{
+				AbstractTransactionModel: ddl.AbstractTransactionModel{
+					HashKey: role.HashKey,
+				},
+				AbstractTransactionFlgModel: role.AbstractTransactionFlgModel,
+				Name:                        role.Name,
+			},
+		},
+		Roles: roles,
+	}, nil
+}
+
+// 登録
+func (r *RoleService) Create(req *request.CreateRole) *response.Error {
+	// バリデーション
+	if err := r.v.Create(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 企業ID取得
+	companyID, companyErr := r.companyID(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	// ロール名重複チェック
+	count, countErr := r.role.CountDuplName(&ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: companyID,
+		},
+		Name: req.Name,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_ROLE_DUPL_NAME,
+		}
+	}
+
+	// 付与ロール
+	masterIDs, masterErr := r.masterRoleIDs(req.UserHashKey, req.Roles)
+	if masterErr != nil {
+		return masterErr
+	}
+
+	// ハッシュキー生成
+	_, hash, hashErr := GenerateHash(1, 25)
+	if hashErr != nil {
+		log.Printf("%v", hashErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := r.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	role, roleErr := r.role.Insert(tx, &ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   static.PRE_ROLE + "_" + *hash,
+			CompanyID: companyID,
+		},
+		Name: req.Name,
+	})
+	if roleErr != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 付与ロール登録
+	if err := r.insertsAssociation(tx, role.ID, masterIDs); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, r.operationLog, r.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_ROLE_CREATE,
+		Target:      role.HashKey,
+		Detail:      map[string]interface{}{"name": req.Name, "roles": req.Roles},
+	}); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := r.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 更新 ※権限の変更はロールチェック時にDBから参照するため即時反映
+func (r *RoleService) Update(req *request.UpdateRole) *response.Error {
+	// バリデーション
+	if err := r.v.Update(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	role, roleErr := r.get(req.UserHashKey, req.HashKey)
+	if roleErr != nil {
+		return roleErr
+	}
+	if role.EditFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_EDIT,
+		}
+	}
+
+	// ロール名重複チェック
+	count, countErr := r.role.CountDuplName(&ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID:        role.ID,
+			CompanyID: role.CompanyID,
+		},
+		Name: req.Name,
+	})
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_ROLE_DUPL_NAME,
+		}
+	}
+
+	// 付与ロール
+	masterIDs, masterErr := r.masterRoleIDs(req.UserHashKey, req.Roles)
+	if masterErr != nil {
+		return masterErr
+	}
+
+	tx, txErr := r.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 更新
+	if err := r.role.Update(tx, &ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: role.ID,
+		},
+		Name: req.Name,
+	}); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 付与ロール洗い替え
+	if err := r.role.DeleteAssociation(tx, &role.CustomRole); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if err := r.insertsAssociation(tx, role.ID, masterIDs); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, r.operationLog, r.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_ROLE_EDIT,
+		Target:      role.HashKey,
+		Detail:      map[string]interface{}{"name": req.Name, "roles": req.Roles},
+	}); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := r.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 削除
+func (r *RoleService) Delete(req *request.DeleteRole) *response.Error {
+	// バリデーション
+	if err := r.v.Delete(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	role, roleErr := r.get(req.UserHashKey, req.HashKey)
+	if roleErr != nil {
+		return roleErr
+	}
+	if role.DeleteFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_DELETE,
+		}
+	}
+
+	// 割り当て中のロールは削除不可
+	count, countErr := r.role.CountUser(&role.CustomRole)
+	if countErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if *count > 0 {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_DELETE_USER,
+		}
+	}
+
+	tx, txErr := r.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 付与ロール削除
+	if err := r.role.DeleteAssociation(tx, &role.CustomRole); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 削除
+	if err := r.role.Delete(tx, &role.CustomRole); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, r.operationLog, r.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_ROLE_DELETE,
+		Target:      role.HashKey,
+		Detail:      map[string]interface{}{"name": role.Name},
+	}); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := r.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// ユーザー割り当て
+func (r *RoleService) Assign(req *request.AssignRole) *response.Error {
+	// バリデーション
+	if err := r.v.Assign(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 自身への割り当ては不可 ※権限の昇格防止
+	for _, hashKey := range req.Users {
+		if hashKey == req.UserHashKey {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+				Code:   static.CODE_ROLE_CANNOT_ASSIGN_SELF,
+			}
+		}
+	}
+
+	role, roleErr := r.get(req.UserHashKey, req.HashKey)
+	if roleErr != nil {
+		return roleErr
+	}
+	if err := checkAssignableRole(r.redis, r.role, req.UserHashKey, &role.CustomRole); err != nil {
+		return err
+	}
+
+	// 対象ユーザー取得 ※他企業のユーザーは不可
+	users, usersErr := r.user.GetByHashKeys(req.Users)
+	if usersErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(users) != len(req.Users) {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+	var ids []uint64
+	for _, row := range users {
+		if row.CompanyID != role.CompanyID {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
+		ids = append(ids, row.ID)
+	}
+
+	tx, txErr := r.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := r.role.AssignUser(tx, &role.CustomRole, ids); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 対象ユーザーのセッション失効 ※新しいロールで再ログインさせる
+	sessionIDs, revokeErr := revokeSessions(tx, r.user, ids)
+	if revokeErr != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, r.operationLog, r.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_ROLE_ASSIGN,
+		Target:      role.HashKey,
+		Detail:      map[string]interface{}{"users": req.Users},
+	}); err != nil {
+		if err := r.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := r.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 強制ログアウト
+	clearSessions(r.redis, sessionIDs, req.Users)
+
+	return nil
+}
+
+// 企業ID取得
+func (r *RoleService) companyID(userHashKey string) (uint64, *response.Error) {
+	ctx := context.Background()
+	company, companyErr := r.redis.Get(ctx, userHashKey, static.REDIS_USER_COMPANY_ID)
+	if companyErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	companyID, companyIDErr := strconv.ParseUint(*company, 10, 64)
+	if companyIDErr != nil {
+		log.Printf("%v", companyIDErr)
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return companyID, nil
+}
+
+// ロール取得_同一企業
+func (r *RoleService) get(userHashKey string, hashKey string) (*entity.CustomRole, *response.Error) {
+	companyID, companyErr := r.companyID(userHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	role, roleErr := r.role.Get(&ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   hashKey,
+			CompanyID: companyID,
+		},
+	})
+	if roleErr != nil {
+		if roleErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusNotFound,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return role, nil
+}
+
+// 操作者の保持ロールマスタID
+func heldMasterRoleIDs(redis repository.IRedisRepository, roleRepo repository.IRoleRepository, userHashKey string) (map[uint]bool, *response.Error) {
+	// ロールID取得
+	ctx := context.Background()
+	role, roleErr := redis.Get(ctx, userHashKey, static.REDIS_USER_ROLE)
+	if roleErr != nil {
+		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 	roleID, roleParseErr := strconv.ParseUint(*role, 10, 64)
 	if roleParseErr != nil {
 		log.Printf("%v", roleParseErr)
-		return false, &response.Error{
+		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
 	// 該当ロールのマスタID取得
-	roles, masterRoleErr := r.role.GetRoleIDs(&ddl.CustomRole{
+	roles, masterRoleErr := roleRepo.GetRoleIDs(&ddl.CustomRole{
 		AbstractTransactionModel: ddl.AbstractTransactionModel{
 			ID: roleID,
 		},
 	})
 	if masterRoleErr != nil {
-		return false, &response.Error{
+		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// ロールの存在チェック
+	held := make(map[uint]bool)
 	for _, row := range roles {
-		if row.MasterRoleID == req.ID {
-			return true, nil
+		held[row.MasterRoleID] = true
+	}
+	return held, nil
+}
+
+// 割り当て可否判定 ※保護ロール・操作者が保持していない権限を含むロールは不可(権限の昇格防止)
+func checkAssignableRole(redis repository.IRedisRepository, roleRepo repository.IRoleRepository, userHashKey string, role *ddl.CustomRole) *response.Error {
+	if role.EditFlg == static.ON {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_PROTECTED,
 		}
 	}
 
-	return false, nil
+	held, heldErr := heldMasterRoleIDs(redis, roleRepo, userHashKey)
+	if heldErr != nil {
+		return heldErr
+	}
+	granted, grantedErr := roleRepo.GetRoleIDs(role)
+	if grantedErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	for _, row := range granted {
+		if !held[row.MasterRoleID] {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+				Code:   static.CODE_ROLE_CANNOT_ASSIGN_EXCEED,
+			}
+		}
+	}
+	return nil
 }
 
-// 検索_企業ID
-func (r *RoleService) SearchRoleByComapny(req *request.SearchRoleByComapny) (*response.SearchRoleByComapny, *response.Error) {
-	// ロールID取得
+// 付与可能ロールマスタ一覧 ※ログイン種別と同じ種別かつ操作者が保持しているもののみ
+func (r *RoleService) listMasterRole(userHashKey string) ([]entity.Role, *response.Error) {
 	ctx := context.Background()
-	company, companyErr := r.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_COMPANY_ID)
-	if companyErr != nil {
+	loginType, loginTypeErr := r.redis.Get(ctx, userHashKey, static.REDIS_USER_LOGIN_TYPE)
+	if loginTypeErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
-	companyID, companyParseErr := strconv.ParseUint(*company, 10, 64)
-	if companyParseErr != nil {
-		log.Printf("%v", companyParseErr)
+	roleType, roleTypeErr := strconv.ParseUint(*loginType, 10, 64)
+	if roleTypeErr != nil {
+		log.Printf("%v", roleTypeErr)
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// 検索
-	roles, err := r.role.SearchByCompanyID(&ddl.CustomRole{
-		AbstractTransactionModel: ddl.AbstractTransactionModel{
-			CompanyID: companyID,
-		},
+	roles, rolesErr := r.master.ListRole(&ddl.Role{
+		RoleType: uint(roleType),
 	})
-	if err != nil {
+	if rolesErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	return &response.SearchRoleByComapny{
-		List: roles,
-	}, nil
+	// 保持していない権限は付与不可 ※権限の昇格防止
+	held, heldErr := heldMasterRoleIDs(r.redis, r.role, userHashKey)
+	if heldErr != nil {
+		return nil, heldErr
+	}
+	res := []entity.Role{}
+	for _, row := range roles {
+		if held[row.ID] {
+			res = append(res, row)
+		}
+	}
+	return res, nil
+}
+
+// ロールマスタハッシュキー→ID変換 ※付与不可のマスタを含む場合はエラー
+func (r *RoleService) masterRoleIDs(userHashKey string, hashKeys []string) ([]uint, *response.Error) {
+	roles, rolesErr := r.listMasterRole(userHashKey)
+	if rolesErr != nil {
+		return nil, rolesErr
+	}
+
+	m := make(map[string]uint)
+	for _, row := range roles {
+		m[row.HashKey] = row.ID
+	}
+
+	var ids []uint
+	for _, hashKey := range hashKeys {
+		id, ok := m[hashKey]
+		if !ok {
+			return nil, &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// 付与ロール一括登録
+func (r *RoleService) insertsAssociation(tx *gorm.DB, roleID uint64, masterIDs []uint) error {
+	var list []*ddl.RoleAssociation
+	for _, id := range masterIDs {
+		list = append(list, &ddl.RoleAssociation{
+			RoleID:       roleID,
+			MasterRoleID: id,
+		})
+	}
+	return r.role.InsertsAssociation(tx, list)
 }
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// ロールマスタ ※1: 応募者閲覧、2: 応募者編集、3: ロール編集
+var testMasterRoles = []entity.Role{
+	{Role: ddl.Role{AbstractMasterModel: ddl.AbstractMasterModel{ID: 1, HashKey: "master_1"}, RoleType: static.LOGIN_TYPE_MANAGEMENT}},
+	{Role: ddl.Role{AbstractMasterModel: ddl.AbstractMasterModel{ID: 2, HashKey: "master_2"}, RoleType: static.LOGIN_TYPE_MANAGEMENT}},
+	{Role: ddl.Role{AbstractMasterModel: ddl.AbstractMasterModel{ID: 3, HashKey: "master_3"}, RoleType: static.LOGIN_TYPE_MANAGEMENT}},
+}
+
+type mockRoleRepo struct {
+	repository.IRoleRepository
+	// ロールID毎の付与ロールマスタID
+	granted  map[uint64][]uint
+	roles    map[string]*entity.CustomRole
+	inserted []uint
+	assigned []uint64
+}
+
+func (r *mockRoleRepo) GetRoleIDs(m *ddl.CustomRole) ([]entity.RoleAssociation, error) {
+	var res []entity.RoleAssociation
+	for _, id := range r.granted[m.ID] {
+		res = append(res, entity.RoleAssociation{RoleAssociation: ddl.RoleAssociation{RoleID: m.ID, MasterRoleID: id}})
+	}
+	return res, nil
+}
+
+func (r *mockRoleRepo) Get(m *ddl.CustomRole) (*entity.CustomRole, error) {
+	role, ok := r.roles[m.HashKey]
+	if !ok || role.CompanyID != m.CompanyID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return role, nil
+}
+
+func (r *mockRoleRepo) CountDuplName(m *ddl.CustomRole) (*int64, error) {
+	var count int64
+	return &count, nil
+}
+
+func (r *mockRoleRepo) Insert(tx *gorm.DB, m *ddl.CustomRole) (*entity.CustomRole, error) {
+	return &entity.CustomRole{CustomRole: *m}, nil
+}
+
+func (r *mockRoleRepo) Update(tx *gorm.DB, m *ddl.CustomRole) error {
+	return nil
+}
+
+func (r *mockRoleRepo) DeleteAssociation(tx *gorm.DB, m *ddl.CustomRole) error {
+	return nil
+}
+
+func (r *mockRoleRepo) InsertsAssociation(tx *gorm.DB, m []*ddl.RoleAssociation) error {
+	for _, row := range m {
+		r.inserted = append(r.inserted, row.MasterRoleID)
+	}
+	return nil
+}
+
+func (r *mockRoleRepo) AssignUser(tx *gorm.DB, m *ddl.CustomRole, users []uint64) error {
+	r.assigned = users
+	return nil
+}
+
+type mockRoleMasterRepo struct {
+	repository.IMasterRepository
+}
+
+func (r *mockRoleMasterRepo) ListRole(m *ddl.Role) ([]entity.Role, error) {
+	var res []entity.Role
+	for _, row := range testMasterRoles {
+		if row.RoleType == m.RoleType {
+			res = append(res, row)
+		}
+	}
+	return res, nil
+}
+
+type mockRoleUserRepo struct {
+	repository.IUserRepository
+}
+
+func (r *mockRoleUserRepo) GetByHashKeys(m []string) ([]entity.User, error) {
+	users := map[string]entity.User{
+		"user_1": {User: ddl.User{AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 10, HashKey: "user_1", CompanyID: 1}}},
+		"user_2": {User: ddl.User{AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 20, HashKey: "user_2", CompanyID: 1}}},
+	}
+	var res []entity.User
+	for _, hashKey := range m {
+		if user, ok := users[hashKey]; ok {
+			res = append(res, user)
+		}
+	}
+	return res, nil
+}
+
+func (r *mockRoleUserRepo) ListSessionID(m []uint64) ([]string, error) {
+	return nil, nil
+}
+
+func (r *mockRoleUserRepo) RevokeSessionByUser(tx *gorm.DB, m []uint64) error {
+	return nil
+}
+
+type mockRoleOperationLogRepo struct {
+	repository.IOperationLogRepository
+}
+
+func (r *mockRoleOperationLogRepo) Insert(tx *gorm.DB, m *ddl.OperationLog) error {
+	return nil
+}
+
+// 操作者(user_1)のロールはマスタ1・3のみ保持
+func newRoleTestService(r *mockRoleRepo, db *mockDB) *RoleService {
+	redis := newMemoryRedis()
+	for key, value := range map[string]string{
+		static.REDIS_USER_ID:         "10",
+		static.REDIS_USER_COMPANY_ID: "1",
+		static.REDIS_USER_TEAM_ID:    "1",
+		static.REDIS_USER_ROLE:       "100",
+		static.REDIS_USER_LOGIN_TYPE: "2",
+	} {
+		value := value
+		_ = redis.Set(context.Background(), "user_1", key, &value, 0)
+	}
+	r.granted[100] = []uint{1, 3}
+	return &RoleService{
+		role:         r,
+		master:       &mockRoleMasterRepo{},
+		user:         &mockRoleUserRepo{},
+		redis:        redis,
+		v:            validator.NewRoleValidator(),
+		db:           db,
+		operationLog: &mockRoleOperationLogRepo{},
+	}
+}
+
+func TestRoleService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   []string
+		wantErr *response.Error
+		want    []uint
+	}{
+		// ok 保持しているロールのみ
+		{"ok", []string{"master_1", "master_3"}, nil, []uint{1, 3}},
+		// ng 保持していないロールを含む ※権限の昇格
+		{"ng_escalation", []string{"master_1", "master_2"}, &response.Error{Status: http.StatusBadRequest}, nil},
+		// ng 存在しないロール
+		{"ng_unknown", []string{"master_9"}, &response.Error{Status: http.StatusBadRequest}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &mockRoleRepo{granted: map[uint64][]uint{}}
+			db := newMockDB()
+			s := newRoleTestService(r, db)
+
+			err := s.Create(&request.CreateRole{
+				Abstract:   request.Abstract{UserHashKey: "user_1"},
+				CustomRole: ddl.CustomRole{Name: "面接官"},
+				Roles:      tt.roles,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Create() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(r.inserted, tt.want) {
+				t.Errorf("inserted = %v, want %v", r.inserted, tt.want)
+			}
+			if !db.closed() {
+				t.Errorf("transaction not closed: %+v", db)
+			}
+		})
+	}
+}
+
+func TestRoleService_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   []string
+		wantErr *response.Error
+	}{
+		// ok 保持しているロールのみ
+		{"ok", []string{"master_3"}, nil},
+		// ng 保持していないロールを含む ※自身のロールの編集による昇格
+		{"ng_escalation", []string{"master_2"}, &response.Error{Status: http.StatusBadRequest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &mockRoleRepo{
+				granted: map[uint64][]uint{},
+				roles: map[string]*entity.CustomRole{
+					"role_own": {CustomRole: ddl.CustomRole{AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 100, HashKey: "role_own", CompanyID: 1}}},
+				},
+			}
+			s := newRoleTestService(r, newMockDB())
+
+			err := s.Update(&request.UpdateRole{
+				Abstract: request.Abstract{UserHashKey: "user_1"},
+				CustomRole: ddl.CustomRole{
+					AbstractTransactionModel: ddl.AbstractTransactionModel{HashKey: "role_own"},
+					Name:                     "面接官",
+				},
+				Roles: tt.roles,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Update() error = %+v, want %+v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleService_ListMasterRole(t *testing.T) {
+	r := &mockRoleRepo{granted: map[uint64][]uint{}}
+	s := newRoleTestService(r, newMockDB())
+
+	res, err := s.ListMasterRole(&request.ListMasterRole{
+		Abstract: request.Abstract{UserHashKey: "user_1"},
+	})
+	if err != nil {
+		t.Fatalf("ListMasterRole() error = %+v", err)
+	}
+	// 保持しているロールのみ
+	var got []string
+	for _, row := range res.List {
+		got = append(got, row.HashKey)
+	}
+	if want := []string{"master_1", "master_3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListMasterRole() = %v, want %v", got, want)
+	}
+}
+
+func TestRoleService_Assign(t *testing.T) {
+	newRole := func(id uint64, hashKey string, editFlg uint) *entity.CustomRole {
+		return &entity.CustomRole{CustomRole: ddl.CustomRole{
+			AbstractTransactionModel:    ddl.AbstractTransactionModel{ID: id, HashKey: hashKey, CompanyID: 1},
+			AbstractTransactionFlgModel: ddl.AbstractTransactionFlgModel{EditFlg: editFlg},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		hashKey  string
+		users    []string
+		wantErr  *response.Error
+		assigned []uint64
+	}{
+		// ok 他ユーザーへ保持範囲内のロール
+		{"ok", "role_read", []string{"user_2"}, nil, []uint64{20}},
+		// ng 自身への割り当て
+		{"ng_self", "role_read", []string{"user_2", "user_1"}, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_SELF,
+		}, nil},
+		// ng 保護されたロール
+		{"ng_protected", "role_admin", []string{"user_2"}, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_PROTECTED,
+		}, nil},
+		// ng 操作者が保持していない権限を含むロール
+		{"ng_exceed", "role_write", []string{"user_2"}, &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_EXCEED,
+		}, nil},
+		// ng 存在しないユーザー
+		{"ng_user", "role_read", []string{"user_9"}, &response.Error{
+			Status: http.StatusBadRequest,
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &mockRoleRepo{
+				granted: map[uint64][]uint{
+					200: {1},
+					300: {1, 3},
+					400: {1, 2},
+				},
+				roles: map[string]*entity.CustomRole{
+					"role_read":  newRole(200, "role_read", static.OFF),
+					"role_admin": newRole(300, "role_admin", static.ON),
+					"role_write": newRole(400, "role_write", static.OFF),
+				},
+			}
+			db := newMockDB()
+			s := newRoleTestService(r, db)
+
+			err := s.Assign(&request.AssignRole{
+				Abstract:   request.Abstract{UserHashKey: "user_1"},
+				CustomRole: ddl.CustomRole{AbstractTransactionModel: ddl.AbstractTransactionModel{HashKey: tt.hashKey}},
+				Users:      tt.users,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Assign() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(r.assigned, tt.assigned) {
+				t.Errorf("assigned = %v, want %v", r.assigned, tt.assigned)
+			}
+			if !db.closed() || (err != nil && db.started != 0) {
+				t.Errorf("transaction = %+v", db)
+			}
+		})
+	}
+}
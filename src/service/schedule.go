@@ -2,6 +2,7 @@ package service
 
 import (
 	"api/src/model/ddl"
+	"api/src/model/dto"
 	"api/src/model/entity"
 	"api/src/model/request"
 	"api/src/model/response"
@@ -11,6 +12,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -26,20 +28,24 @@ type IScheduleService interface {
 	Search(req *request.SearchSchedule) (*response.SearchSchedule, *response.Error)
 	// 予定削除
 	Delete(req *request.DeleteSchedule) *response.Error
+	// 予定例外登録 ※繰り返し予定の特定の回の取消・移動
+	SaveException(req *request.SaveScheduleException) *response.Error
+	// 予定例外削除 ※取消・移動した回を元に戻す
+	DeleteException(req *request.DeleteScheduleException) *response.Error
 }
 
 type ScheduleService struct {
-	db         repository.IDBRepository
-	redis      repository.IRedisRepository
-	user       repository.IUserRepository
-	team       repository.ITeamRepository
-	schedule   repository.IScheduleRepository
-	applicant  repository.IApplicantRepository
-	role       repository.IRoleRepository
-	manuscript repository.IManuscriptRepository
-	master     repository.IMasterRepository
-	v          validator.IScheduleValidator
-	outer      repository.IOuterIFRepository
+	db           repository.IDBRepository
+	redis        repository.IRedisRepository
+	user         repository.IUserRepository
+	team         repository.ITeamRepository
+	schedule     repository.IScheduleRepository
+	applicant    repository.IApplicantRepository
+	role         repository.IRoleRepository
+	manuscript   repository.IManuscriptRepository
+	master       repository.IMasterRepository
+	v            validator.IScheduleValidator
+	operationLog repository.IOperationLogRepository
 }
 
 func NewScheduleService(
@@ -53,9 +59,9 @@ func NewScheduleService(
 	manuscript repository.IManuscriptRepository,
 	master repository.IMasterRepository,
 	v validator.IScheduleValidator,
-	outer repository.IOuterIFRepository,
+	operationLog repository.IOperationLogRepository,
 ) IScheduleService {
-	return &ScheduleService{db, redis, user, team, schedule, applicant, role, manuscript, master, v, outer}
+	return &ScheduleService{db, redis, user, team, schedule, applicant, role, manuscript, master, v, operationLog}
 }
 
 // 予定登録種別一覧
@@ -127,6 +133,21 @@ func (u *ScheduleService) Create(req *request.CreateSchedule) *response.Error {
 		}
 	}
 
+	// 間隔の未指定は毎回
+	if req.Interval == 0 {
+		req.Interval = 1
+	}
+
+	// 予約ロック ※面接予約・面接官割り振りとの競合を防止、コミットまで保持
+	lock, lockErr := lockBooking(u.redis, companyID, static.BOOKING_LOCK_WAIT)
+	if lockErr != nil {
+		log.Printf("%v", lockErr)
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+	defer lock.Unlock()
+
 	tx, txErr := u.db.TxStart()
 	if txErr != nil {
 		return &response.Error{
@@ -142,6 +163,10 @@ func (u *ScheduleService) Create(req *request.CreateSchedule) *response.Error {
 		},
 		InterviewFlg: req.InterviewFlg,
 		FreqID:       req.FreqID,
+		Interval:     req.Interval,
+		ByDay:        req.ByDay,
+		Count:        req.Count,
+		Until:        req.Until,
 		Start:        req.Start,
 		End:          req.End,
 		Title:        req.Title,
@@ -177,6 +202,37 @@ func (u *ScheduleService) Create(req *request.CreateSchedule) *response.Error {
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      teamID,
+		EventID:     static.OPERATION_LOG_EVENT_SCHEDULE_CREATE,
+		Target:      static.PRE_SCHEDULE + "_" + *hashKey,
+		Detail:      map[string]interface{}{"title": req.Title, "freq_id": req.FreqID, "interview_flg": req.InterviewFlg, "start": req.Start, "end": req.End, "users": req.Users},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ロック保持確認 ※処理が長引き失われた場合は中断
+	if !lock.Held() {
+		log.Printf("Booking lock lost.")
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -216,6 +272,21 @@ func (u *ScheduleService) Update(req *request.UpdateSchedule) *response.Error {
 		}
 	}
 
+	// 間隔の未指定は毎回
+	if req.Interval == 0 {
+		req.Interval = 1
+	}
+
+	// 予約ロック ※面接予約・面接官割り振りとの競合を防止、コミットまで保持
+	lock, lockErr := lockBooking(u.redis, schedule.CompanyID, static.BOOKING_LOCK_WAIT)
+	if lockErr != nil {
+		log.Printf("%v", lockErr)
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+	defer lock.Unlock()
+
 	tx, txErr := u.db.TxStart()
 	if txErr != nil {
 		return &response.Error{
@@ -229,10 +300,14 @@ func (u *ScheduleService) Update(req *request.UpdateSchedule) *response.Error {
 			HashKey:   schedule.HashKey,
 			UpdatedAt: time.Now(),
 		},
-		FreqID: req.FreqID,
-		Start:  req.Start,
-		End:    req.End,
-		Title:  req.Title,
+		FreqID:   req.FreqID,
+		Interval: req.Interval,
+		ByDay:    req.ByDay,
+		Count:    req.Count,
+		Until:    req.Until,
+		Start:    req.Start,
+		End:      req.End,
+		Title:    req.Title,
 	}); err != nil {
 		if err := u.db.TxRollback(tx); err != nil {
 			return &response.Error{
@@ -243,6 +318,25 @@ func (u *ScheduleService) Update(req *request.UpdateSchedule) *response.Error {
 			Status: http.StatusInternalServerError,
 		}
 	}
+
+	// 開始時刻・繰り返しの規則の変更時は例外削除 ※本来の開始時刻が各回と一致しなくなるため
+	if !schedule.Start.Equal(req.Start) ||
+		schedule.FreqID != req.FreqID ||
+		schedule.Interval != req.Interval ||
+		schedule.ByDay != req.ByDay {
+		if err := u.schedule.DeleteExceptionBySchedule(tx, &ddl.ScheduleException{
+			ScheduleID: schedule.ID,
+		}); err != nil {
+			if err := u.db.TxRollback(tx); err != nil {
+				return &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+	}
 	// 問答無用で紐づけテーブルの該当予定IDのレコード削除
 	if err := u.schedule.DeleteScheduleAssociation(tx, &ddl.ScheduleAssociation{
 		ScheduleID: schedule.ID,
@@ -276,6 +370,36 @@ func (u *ScheduleService) Update(req *request.UpdateSchedule) *response.Error {
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_SCHEDULE_UPDATE,
+		Target:      req.HashKey,
+		Detail:      map[string]interface{}{"title": req.Title, "freq_id": req.FreqID, "interview_flg": req.InterviewFlg, "start": req.Start, "end": req.End, "users": req.Users},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// ロック保持確認 ※処理が長引き失われた場合は中断
+	if !lock.Held() {
+		log.Printf("Booking lock lost.")
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusServiceUnavailable,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -285,8 +409,16 @@ func (u *ScheduleService) Update(req *request.UpdateSchedule) *response.Error {
 	return nil
 }
 
-// 予定検索 (バッチでも実行したい)
+// 予定検索 ※繰り返し予定は期間内の各回に展開(保存済みの予定は変更しない)
 func (u *ScheduleService) Search(req *request.SearchSchedule) (*response.SearchSchedule, *response.Error) {
+	// バリデーション
+	if err := u.v.Search(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
 	// チームID取得
 	ctx := context.Background()
 	teamRedis, teamRedisErr := u.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
@@ -302,129 +434,259 @@ func (u *ScheduleService) Search(req *request.SearchSchedule) (*response.SearchS
 		}
 	}
 
-	schedulesBefore, sErr := u.schedule.Search(&ddl.Schedule{
+	schedules, err := u.schedule.Search(&ddl.Schedule{
 		TeamID: teamID,
 	})
-	if sErr != nil {
+	if err != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	var deleteList []uint64
-	var editList []*ddl.Schedule
-
-	tx, txErr := u.db.TxStart()
-	if txErr != nil {
+	// 期間 ※未指定の場合は当日から既定の日数
+	window, windowErr := getBookingWindow(u.team, teamID, 0)
+	if windowErr != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
+	loc := window.Location
+	from := req.From
+	if from.IsZero() {
+		now := time.Now().In(loc)
+		from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	}
+	to := req.To
+	if to.IsZero() {
+		to = from.AddDate(0, 0, static.SCHEDULE_SEARCH_DEFAULT_DAYS)
+	}
 
-	// 日付が過去の場合、更新or削除
-	if len(schedulesBefore) > 0 {
-		for _, schedule := range schedulesBefore {
-			if schedule.Start.Before(time.Now()) {
-				deleteList = append(deleteList, schedule.ID)
-
-				// なし以外の場合
-				if schedule.FreqID != uint(static.FREQ_NONE) {
-					s := schedule.Start
-					e := schedule.End
-					if schedule.FreqID == uint(static.FREQ_DAILY) {
-						s = s.AddDate(0, 0, 1)
-						e = e.AddDate(0, 0, 1)
-					}
-					if schedule.FreqID == uint(static.FREQ_WEEKLY) {
-						s = s.AddDate(0, 0, 7)
-						e = e.AddDate(0, 0, 7)
-					}
-					if schedule.FreqID == uint(static.FREQ_MONTHLY) {
-						s = s.AddDate(0, 1, 0)
-						e = e.AddDate(0, 1, 0)
-					}
-					if schedule.FreqID == uint(static.FREQ_YEARLY) {
-						s = s.AddDate(1, 0, 0)
-						e = e.AddDate(1, 0, 0)
-					}
-
-					editList = append(editList, &ddl.Schedule{
-						AbstractTransactionModel: ddl.AbstractTransactionModel{
-							HashKey:   schedule.HashKey,
-							CompanyID: schedule.CompanyID,
-							CreatedAt: schedule.CreatedAt,
-							UpdatedAt: time.Now(),
-						},
-						Start:        s,
-						End:          e,
-						Title:        schedule.Title,
-						FreqID:       schedule.FreqID,
-						InterviewFlg: schedule.InterviewFlg,
-						TeamID:       schedule.TeamID,
-					})
-				}
+	var res []entity.Schedule
+	var occurrences []dto.ScheduleOccurrence
+	for _, row := range schedules {
+		occurrences = append(occurrences, expandSchedule(&row.Schedule, row.Exceptions, from, to, loc)...)
+
+		row.ID = 0
+		for _, row2 := range row.Users {
+			row2.ID = 0
+		}
+		for i := range row.Exceptions {
+			row.Exceptions[i].ScheduleID = 0
+		}
+
+		res = append(res, *row)
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+
+	return &response.SearchSchedule{
+		List:        res,
+		Occurrences: occurrences,
+	}, nil
+}
+
+// 予定削除
+func (u *ScheduleService) Delete(req *request.DeleteSchedule) *response.Error {
+	// バリデーション
+	if err := u.v.Delete(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 予定取得
+	schedule, scheduleErr := u.schedule.Get(&ddl.Schedule{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.HashKey,
+		},
+	})
+	if scheduleErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, err := u.db.TxStart()
+	if err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 紐づけ削除
+	if err := u.schedule.DeleteScheduleAssociation(tx, &ddl.ScheduleAssociation{
+		ScheduleID: schedule.ID,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
 			}
 		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
 	}
 
-	if err := u.schedule.Deletes(tx, deleteList); err != nil {
+	// 例外削除
+	if err := u.schedule.DeleteExceptionBySchedule(tx, &ddl.ScheduleException{
+		ScheduleID: schedule.ID,
+	}); err != nil {
 		if err := u.db.TxRollback(tx); err != nil {
-			return nil, &response.Error{
+			return &response.Error{
 				Status: http.StatusInternalServerError,
 			}
 		}
-		return nil, &response.Error{
+		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	if len(editList) > 0 {
-		if err := u.schedule.Inserts(tx, editList); err != nil {
-			if err := u.db.TxRollback(tx); err != nil {
-				return nil, &response.Error{
-					Status: http.StatusInternalServerError,
-				}
+	// 削除
+	if err := u.schedule.Delete(tx, &ddl.Schedule{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: schedule.HashKey,
+		},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
 			}
-			return nil, &response.Error{
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      schedule.TeamID,
+		EventID:     static.OPERATION_LOG_EVENT_SCHEDULE_DELETE,
+		Target:      schedule.HashKey,
+		Detail:      map[string]interface{}{"title": schedule.Title},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
 				Status: http.StatusInternalServerError,
 			}
 		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
 	}
 
 	if err := u.db.TxCommit(tx); err != nil {
-		return nil, &response.Error{
+		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	schedulesAfter, err := u.schedule.Search(&ddl.Schedule{
-		TeamID: teamID,
+	return nil
+}
+
+// 予定例外登録
+func (u *ScheduleService) SaveException(req *request.SaveScheduleException) *response.Error {
+	// バリデーション
+	if err := u.v.SaveException(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 予定取得
+	schedule, scheduleErr := u.schedule.Get(&ddl.Schedule{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.HashKey,
+		},
 	})
-	if err != nil {
-		return nil, &response.Error{
+	if scheduleErr != nil {
+		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	var res []entity.Schedule
-	for _, row := range schedulesAfter {
-		row.ID = 0
-		for _, row2 := range row.Users {
-			row2.ID = 0
+	// 繰り返し予定の回であること
+	if !isRecurring(schedule.FreqID) {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_SCHEDULE_NOT_RECURRING,
+		}
+	}
+	window, windowErr := getBookingWindow(u.team, schedule.TeamID, 0)
+	if windowErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if !isScheduleOccurrence(&schedule.Schedule, req.OriginalStart, window.Location) {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_SCHEDULE_OCCURRENCE_NOT_FOUND,
 		}
+	}
 
-		res = append(res, *row)
+	exception := &ddl.ScheduleException{
+		ScheduleID:    schedule.ID,
+		OriginalStart: req.OriginalStart,
+		Kind:          req.Kind,
+	}
+	if req.Kind == static.SCHEDULE_EXCEPTION_MOVE {
+		exception.Start = &req.Start
+		exception.End = &req.End
 	}
 
-	return &response.SearchSchedule{
-		List: res,
-	}, nil
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.schedule.InsertException(tx, exception); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      schedule.TeamID,
+		EventID:     static.OPERATION_LOG_EVENT_SCHEDULE_SAVE_EXCEPTION,
+		Target:      schedule.HashKey,
+		Detail:      map[string]interface{}{"original_start": req.OriginalStart, "kind": req.Kind, "start": req.Start, "end": req.End},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
 }
 
-// 予定削除
-func (u *ScheduleService) Delete(req *request.DeleteSchedule) *response.Error {
+// 予定例外削除
+func (u *ScheduleService) DeleteException(req *request.DeleteScheduleException) *response.Error {
 	// バリデーション
-	if err := u.v.Delete(req); err != nil {
+	if err := u.v.DeleteException(req); err != nil {
 		log.Printf("%v", err)
 		return &response.Error{
 			Status: http.StatusBadRequest,
@@ -443,16 +705,16 @@ func (u *ScheduleService) Delete(req *request.DeleteSchedule) *response.Error {
 		}
 	}
 
-	tx, err := u.db.TxStart()
-	if err != nil {
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
-	// 紐づけ削除
-	if err := u.schedule.DeleteScheduleAssociation(tx, &ddl.ScheduleAssociation{
-		ScheduleID: schedule.ID,
+	if err := u.schedule.DeleteException(tx, &ddl.ScheduleException{
+		ScheduleID:    schedule.ID,
+		OriginalStart: req.OriginalStart,
 	}); err != nil {
 		if err := u.db.TxRollback(tx); err != nil {
 			return &response.Error{
@@ -464,11 +726,13 @@ func (u *ScheduleService) Delete(req *request.DeleteSchedule) *response.Error {
 		}
 	}
 
-	// 削除
-	if err := u.schedule.Delete(tx, &ddl.Schedule{
-		AbstractTransactionModel: ddl.AbstractTransactionModel{
-			HashKey: schedule.HashKey,
-		},
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      schedule.TeamID,
+		EventID:     static.OPERATION_LOG_EVENT_SCHEDULE_DELETE_EXCEPTION,
+		Target:      schedule.HashKey,
+		Detail:      map[string]interface{}{"original_start": req.OriginalStart},
 	}); err != nil {
 		if err := u.db.TxRollback(tx); err != nil {
 			return &response.Error{
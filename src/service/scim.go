@@ -0,0 +1,1731 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/dto"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ISCIMService interface {
+	// トークン設定取得
+	GetToken(req *request.GetSCIMToken) (*response.GetSCIMToken, *response.Error)
+	// トークン発行 ※発行済みの場合は再発行
+	IssueToken(req *request.IssueSCIMToken) (*response.IssueSCIMToken, *response.Error)
+	// トークン削除
+	DeleteToken(req *request.DeleteSCIMToken) *response.Error
+	// ユーザー一覧
+	ListUser(req *request.SCIMList) (*response.SCIMList, *response.SCIMError)
+	// ユーザー取得
+	GetUser(req *request.SCIMResource) (*response.SCIMUser, *response.SCIMError)
+	// ユーザー登録
+	CreateUser(req *request.SCIMUser) (*response.SCIMUser, *response.SCIMError)
+	// ユーザー置換 ※activeにより無効化・有効化
+	ReplaceUser(req *request.SCIMUser) (*response.SCIMUser, *response.SCIMError)
+	// ユーザー部分更新 ※activeにより無効化・有効化
+	PatchUser(req *request.SCIMPatch) (*response.SCIMUser, *response.SCIMError)
+	// ユーザー削除
+	DeleteUser(req *request.SCIMResource) *response.SCIMError
+	// グループ一覧
+	ListGroup(req *request.SCIMList) (*response.SCIMList, *response.SCIMError)
+	// グループ取得
+	GetGroup(req *request.SCIMResource) (*response.SCIMGroup, *response.SCIMError)
+	// グループ登録
+	CreateGroup(req *request.SCIMGroup) (*response.SCIMGroup, *response.SCIMError)
+	// グループ置換
+	ReplaceGroup(req *request.SCIMGroup) (*response.SCIMGroup, *response.SCIMError)
+	// グループ部分更新
+	PatchGroup(req *request.SCIMPatch) (*response.SCIMGroup, *response.SCIMError)
+	// グループ削除
+	DeleteGroup(req *request.SCIMResource) *response.SCIMError
+}
+
+type SCIMService struct {
+	scim          repository.ISCIMRepository
+	user          repository.IUserRepository
+	team          repository.ITeamRepository
+	role          repository.IRoleRepository
+	applicant     repository.IApplicantRepository
+	schedule      repository.IScheduleRepository
+	manuscript    repository.IManuscriptRepository
+	company       repository.ICompanyRepository
+	v             validator.ISCIMValidator
+	validatorUser validator.IUserValidator
+	validatorTeam validator.ITeamValidator
+	db            repository.IDBRepository
+	redis         repository.IRedisRepository
+	mail          repository.IMailRepository
+	operationLog  repository.IOperationLogRepository
+}
+
+func NewSCIMService(
+	scim repository.ISCIMRepository,
+	user repository.IUserRepository,
+	team repository.ITeamRepository,
+	role repository.IRoleRepository,
+	applicant repository.IApplicantRepository,
+	schedule repository.IScheduleRepository,
+	manuscript repository.IManuscriptRepository,
+	company repository.ICompanyRepository,
+	v validator.ISCIMValidator,
+	validatorUser validator.IUserValidator,
+	validatorTeam validator.ITeamValidator,
+	db repository.IDBRepository,
+	redis repository.IRedisRepository,
+	mail repository.IMailRepository,
+	operationLog repository.IOperationLogRepository,
+) ISCIMService {
+	return &SCIMService{scim, user, team, role, applicant, schedule, manuscript, company, v, validatorUser, validatorTeam, db, redis, mail, operationLog}
+}
+
+// トークン設定取得
+func (s *SCIMService) GetToken(req *request.GetSCIMToken) (*response.GetSCIMToken, *response.Error) {
+	companyID, companyErr := s.operatorCompany(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	res := response.GetSCIMToken{
+		Path: static.SCIM_BASE_PATH,
+	}
+	token, tokenErr := s.scim.GetToken(&ddl.CompanySCIMToken{
+		CompanyID: companyID,
+	})
+	if tokenErr != nil {
+		if tokenErr == gorm.ErrRecordNotFound {
+			return &res, nil
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	res.Issued = true
+	res.RoleHashKey = token.Role.HashKey
+	res.CreatedAt = token.CreatedAt
+	return &res, nil
+}
+
+// トークン発行 ※発行済みの場合は再発行
+func (s *SCIMService) IssueToken(req *request.IssueSCIMToken) (*response.IssueSCIMToken, *response.Error) {
+	// バリデーション
+	if err := s.v.IssueToken(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	companyID, companyErr := s.operatorCompany(req.UserHashKey)
+	if companyErr != nil {
+		return nil, companyErr
+	}
+
+	// 連携ユーザーのロール ※自社のロールのみ
+	role, roleErr := s.role.Get(&ddl.CustomRole{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey:   req.RoleHashKey,
+			CompanyID: companyID,
+		},
+	})
+	if roleErr != nil {
+		if roleErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 割り当て可否 ※ロール割り当てと同様に保護ロール・操作者の権限を超えるロールは不可
+	if err := checkAssignableRole(s.redis, s.role, req.UserHashKey, &role.CustomRole); err != nil {
+		return nil, err
+	}
+
+	// トークン生成 ※DBにはハッシュ値のみ保存
+	token, tokenErr := newSessionToken(static.SCIM_TOKEN_BYTES)
+	if tokenErr != nil {
+		log.Printf("%v", tokenErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 既存トークン削除
+	if err := s.scim.DeleteToken(tx, &ddl.CompanySCIMToken{
+		CompanyID: companyID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	if err := s.scim.InsertToken(tx, &ddl.CompanySCIMToken{
+		CompanyID: companyID,
+		Token:     sessionTokenHash(token),
+		RoleID:    role.ID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &response.IssueSCIMToken{
+		Token: token,
+		Path:  static.SCIM_BASE_PATH,
+	}, nil
+}
+
+// トークン削除
+func (s *SCIMService) DeleteToken(req *request.DeleteSCIMToken) *response.Error {
+	companyID, companyErr := s.operatorCompany(req.UserHashKey)
+	if companyErr != nil {
+		return companyErr
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.scim.DeleteToken(tx, &ddl.CompanySCIMToken{
+		CompanyID: companyID,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// ユーザー一覧
+func (s *SCIMService) ListUser(req *request.SCIMList) (*response.SCIMList, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	filter, filterErr := parseSCIMFilter(req.Filter, "userName")
+	if filterErr != nil {
+		log.Printf("%v", filterErr)
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_FILTER, static.SCIM_DETAIL_INVALID_FILTER)
+	}
+
+	m := scimListCondition(token.CompanyID, filter, req)
+	users, total, listErr := s.scim.ListUser(m)
+	if listErr != nil {
+		return nil, scimInternalError()
+	}
+
+	resources := []*request.SCIMUserResource{}
+	for i := range users {
+		resources = append(resources, scimUser(&users[i].User))
+	}
+	return &response.SCIMList{
+		Schemas:      []string{static.SCIM_SCHEMA_LIST},
+		TotalResults: total,
+		StartIndex:   m.Offset + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// ユーザー取得
+func (s *SCIMService) GetUser(req *request.SCIMResource) (*response.SCIMUser, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	user, userErr := s.getUser(token.CompanyID, req.ID)
+	if userErr != nil {
+		return nil, userErr
+	}
+	return &response.SCIMUser{
+		SCIMUserResource: *scimUser(&user.User),
+	}, nil
+}
+
+// ユーザー登録 ※ユーザー登録と同じ規則(初回パスワード発行・送信を含む)
+func (s *SCIMService) CreateUser(req *request.SCIMUser) (*response.SCIMUser, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	// バリデーション
+	m := ddl.User{
+		Name:  scimUserName(&req.SCIMUserResource),
+		Email: strings.TrimSpace(req.UserName),
+	}
+	if err := s.validatorUser.Create(&request.CreateUser{
+		User:        m,
+		RoleHashKey: token.Role.HashKey,
+	}); err != nil {
+		log.Printf("%v", err)
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_USER)
+	}
+
+	// メールアドレス重複チェック
+	if err := s.user.EmailDuplCheck(&m); err != nil {
+		return nil, newSCIMError(http.StatusConflict, static.SCIM_TYPE_UNIQUENESS, static.SCIM_DETAIL_USER_NAME_DUPL)
+	}
+
+	// 初回パスワード発行
+	password, hashPassword, passwordErr := issueInitPassword(s.company, token.CompanyID)
+	if passwordErr != nil {
+		return nil, scimInternalError()
+	}
+
+	// 無効状態での登録
+	var deactivatedAt *time.Time
+	if req.Active != nil && !*req.Active {
+		now := time.Now()
+		deactivatedAt = &now
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return nil, scimInternalError()
+	}
+
+	// 登録
+	user, userErr := insertUser(tx, s.user, &ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: token.CompanyID,
+		},
+		Name:          m.Name,
+		Email:         m.Email,
+		Password:      *hashPassword,
+		InitPassword:  *hashPassword,
+		RoleID:        token.RoleID,
+		UserType:      static.LOGIN_TYPE_MANAGEMENT,
+		DeactivatedAt: deactivatedAt,
+	})
+	if userErr != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		CompanyID: token.CompanyID,
+		EventID:   static.OPERATION_LOG_EVENT_USER_CREATE,
+		Target:    user.HashKey,
+		Detail:    map[string]interface{}{"name": m.Name, "email": m.Email, "role": token.Role.HashKey, "source": static.SCIM_OPERATION_SOURCE},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return nil, scimInternalError()
+	}
+
+	// 初回パスワード送信
+	sendInitPassword(s.mail, &user.User, *password)
+
+	return &response.SCIMUser{
+		SCIMUserResource: *scimUser(&user.User),
+	}, nil
+}
+
+// ユーザー置換
+func (s *SCIMService) ReplaceUser(req *request.SCIMUser) (*response.SCIMUser, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	user, userErr := s.getUser(token.CompanyID, req.ID)
+	if userErr != nil {
+		return nil, userErr
+	}
+	return s.saveUser(token, user, &req.SCIMUserResource)
+}
+
+// ユーザー部分更新
+func (s *SCIMService) PatchUser(req *request.SCIMPatch) (*response.SCIMUser, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	user, userErr := s.getUser(token.CompanyID, req.ID)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	m := scimUser(&user.User)
+	if err := applySCIMUserPatch(m, req.Operations); err != nil {
+		return nil, err
+	}
+	return s.saveUser(token, user, m)
+}
+
+// ユーザー削除 ※ユーザー削除と同じ規則(応募者・予定が紐づく場合は不可)
+func (s *SCIMService) DeleteUser(req *request.SCIMResource) *response.SCIMError {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return authErr
+	}
+
+	user, userErr := s.getUser(token.CompanyID, req.ID)
+	if userErr != nil {
+		return userErr
+	}
+	return s.deleteUser(token, user)
+}
+
+// グループ一覧
+func (s *SCIMService) ListGroup(req *request.SCIMList) (*response.SCIMList, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	filter, filterErr := parseSCIMFilter(req.Filter, "displayName")
+	if filterErr != nil {
+		log.Printf("%v", filterErr)
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_FILTER, static.SCIM_DETAIL_INVALID_FILTER)
+	}
+
+	m := scimListCondition(token.CompanyID, filter, req)
+	teams, total, listErr := s.scim.ListTeam(m)
+	if listErr != nil {
+		return nil, scimInternalError()
+	}
+
+	resources := []*request.SCIMGroupResource{}
+	for i := range teams {
+		resources = append(resources, scimGroup(&teams[i]))
+	}
+	return &response.SCIMList{
+		Schemas:      []string{static.SCIM_SCHEMA_LIST},
+		TotalResults: total,
+		StartIndex:   m.Offset + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// グループ取得
+func (s *SCIMService) GetGroup(req *request.SCIMResource) (*response.SCIMGroup, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	team, teamErr := s.getTeam(token.CompanyID, req.ID)
+	if teamErr != nil {
+		return nil, teamErr
+	}
+	return &response.SCIMGroup{
+		SCIMGroupResource: *scimGroup(team),
+	}, nil
+}
+
+// グループ登録 ※チーム登録と同じ規則(面接毎設定・選考状況を含む)
+func (s *SCIMService) CreateGroup(req *request.SCIMGroup) (*response.SCIMGroup, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	// バリデーション
+	m := ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			CompanyID: token.CompanyID,
+		},
+		Name: strings.TrimSpace(req.DisplayName),
+	}
+	if err := s.validatorTeam.Create(&request.CreateTeam{
+		Team: m,
+	}); err != nil {
+		log.Printf("%v", err)
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_GROUP)
+	}
+
+	// メンバー存在確認
+	ids, idsErr := s.memberIDs(token.CompanyID, req.Members)
+	if idsErr != nil {
+		return nil, idsErr
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return nil, scimInternalError()
+	}
+
+	// 登録
+	team, teamErr := insertTeam(tx, s.team, &m, ids)
+	if teamErr != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		CompanyID: token.CompanyID,
+		TeamID:    team.ID,
+		EventID:   static.OPERATION_LOG_EVENT_TEAM_CREATE,
+		Target:    team.HashKey,
+		Detail:    map[string]interface{}{"name": m.Name, "members": scimMemberValues(req.Members), "source": static.SCIM_OPERATION_SOURCE},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return nil, scimInternalError()
+	}
+
+	created, createdErr := s.getTeam(token.CompanyID, team.HashKey)
+	if createdErr != nil {
+		return nil, createdErr
+	}
+	return &response.SCIMGroup{
+		SCIMGroupResource: *scimGroup(created),
+	}, nil
+}
+
+// グループ置換
+func (s *SCIMService) ReplaceGroup(req *request.SCIMGroup) (*response.SCIMGroup, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	team, teamErr := s.getTeam(token.CompanyID, req.ID)
+	if teamErr != nil {
+		return nil, teamErr
+	}
+	return s.saveGroup(token, team, &req.SCIMGroupResource)
+}
+
+// グループ部分更新
+func (s *SCIMService) PatchGroup(req *request.SCIMPatch) (*response.SCIMGroup, *response.SCIMError) {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	team, teamErr := s.getTeam(token.CompanyID, req.ID)
+	if teamErr != nil {
+		return nil, teamErr
+	}
+
+	m := scimGroup(team)
+	if err := applySCIMGroupPatch(m, req.Operations); err != nil {
+		return nil, err
+	}
+	return s.saveGroup(token, team, m)
+}
+
+// グループ削除 ※チーム削除と同じ規則(応募者・予定・原稿が紐づく場合は不可)
+func (s *SCIMService) DeleteGroup(req *request.SCIMResource) *response.SCIMError {
+	token, authErr := s.authenticate(req.Token)
+	if authErr != nil {
+		return authErr
+	}
+
+	team, teamErr := s.getTeam(token.CompanyID, req.ID)
+	if teamErr != nil {
+		return teamErr
+	}
+
+	// 削除可能判定
+	if err := checkTeamDeletable(s.applicant, s.schedule, s.manuscript, team.ID); err != nil {
+		return scimConflictError(err, map[uint]string{
+			static.CODE_TEAM_USER_CANNOT_DELETE_APPLICANT:  static.SCIM_DETAIL_GROUP_HAS_APPLICANT,
+			static.CODE_TEAM_USER_CANNOT_DELETE_SCHEDULE:   static.SCIM_DETAIL_GROUP_HAS_SCHEDULE,
+			static.CODE_TEAM_USER_CANNOT_DELETE_MANUSCRIPT: static.SCIM_DETAIL_GROUP_HAS_MANUSCRIPT,
+		})
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return scimInternalError()
+	}
+
+	if err := deleteTeam(tx, s.team, &team.Team); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return scimInternalError()
+		}
+		return scimInternalError()
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		CompanyID: token.CompanyID,
+		TeamID:    team.ID,
+		EventID:   static.OPERATION_LOG_EVENT_TEAM_DELETE,
+		Target:    team.HashKey,
+		Detail:    map[string]interface{}{"source": static.SCIM_OPERATION_SOURCE},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return scimInternalError()
+		}
+		return scimInternalError()
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return scimInternalError()
+	}
+
+	return nil
+}
+
+// 操作者の企業ID
+func (s *SCIMService) operatorCompany(userHashKey string) (uint64, *response.Error) {
+	user, userErr := s.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: userHashKey,
+		},
+	})
+	if userErr != nil {
+		return 0, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return user.CompanyID, nil
+}
+
+// トークン認証
+func (s *SCIMService) authenticate(token string) (*entity.CompanySCIMToken, *response.SCIMError) {
+	if token == "" {
+		return nil, newSCIMError(http.StatusUnauthorized, "", static.SCIM_DETAIL_UNAUTHORIZED)
+	}
+
+	res, err := s.scim.GetToken(&ddl.CompanySCIMToken{
+		Token: sessionTokenHash(token),
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, newSCIMError(http.StatusUnauthorized, "", static.SCIM_DETAIL_UNAUTHORIZED)
+		}
+		return nil, scimInternalError()
+	}
+	return res, nil
+}
+
+// ユーザー取得 ※他企業のユーザーは存在しないものとして扱う
+func (s *SCIMService) getUser(companyID uint64, hashKey string) (*entity.User, *response.SCIMError) {
+	if hashKey == "" {
+		return nil, newSCIMError(http.StatusNotFound, "", static.SCIM_DETAIL_NOT_FOUND)
+	}
+
+	user, err := s.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: hashKey,
+		},
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, newSCIMError(http.StatusNotFound, "", static.SCIM_DETAIL_NOT_FOUND)
+		}
+		return nil, scimInternalError()
+	}
+	if user.CompanyID != companyID {
+		return nil, newSCIMError(http.StatusNotFound, "", static.SCIM_DETAIL_NOT_FOUND)
+	}
+	return user, nil
+}
+
+// チーム取得 ※他企業のチームは存在しないものとして扱う
+func (s *SCIMService) getTeam(companyID uint64, hashKey string) (*entity.Team, *response.SCIMError) {
+	if hashKey == "" {
+		return nil, newSCIMError(http.StatusNotFound, "", static.SCIM_DETAIL_NOT_FOUND)
+	}
+
+	team, err := s.team.Get(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: hashKey,
+		},
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, newSCIMError(http.StatusNotFound, "", static.SCIM_DETAIL_NOT_FOUND)
+		}
+		return nil, scimInternalError()
+	}
+	if team.CompanyID != companyID {
+		return nil, newSCIMError(http.StatusNotFound, "", static.SCIM_DETAIL_NOT_FOUND)
+	}
+	return team, nil
+}
+
+// メンバーID取得 ※自社のユーザーのみ
+func (s *SCIMService) memberIDs(companyID uint64, members []request.SCIMMember) ([]uint64, *response.SCIMError) {
+	values := scimMemberValues(members)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.user.GetByHashKeys(values)
+	if err != nil {
+		return nil, scimInternalError()
+	}
+	if len(users) != len(values) {
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_MEMBER)
+	}
+
+	var ids []uint64
+	for _, user := range users {
+		if user.CompanyID != companyID {
+			return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_MEMBER)
+		}
+		ids = append(ids, user.ID)
+	}
+	return ids, nil
+}
+
+// ユーザー保存 ※置換・部分更新共通、無効化時はログイン中セッションを失効
+func (s *SCIMService) saveUser(
+	token *entity.CompanySCIMToken,
+	user *entity.User,
+	m *request.SCIMUserResource,
+) (*response.SCIMUser, *response.SCIMError) {
+	// バリデーション
+	row := ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: user.HashKey,
+		},
+		Name:  scimUserName(m),
+		Email: strings.TrimSpace(m.UserName),
+	}
+	if err := s.validatorUser.Create(&request.CreateUser{
+		User:        row,
+		RoleHashKey: token.Role.HashKey,
+	}); err != nil {
+		log.Printf("%v", err)
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_USER)
+	}
+
+	// メールアドレス重複チェック ※変更時のみ
+	if !strings.EqualFold(row.Email, user.Email) {
+		if err := s.user.EmailDuplCheck(&row); err != nil {
+			return nil, newSCIMError(http.StatusConflict, static.SCIM_TYPE_UNIQUENESS, static.SCIM_DETAIL_USER_NAME_DUPL)
+		}
+	}
+
+	// 有効状態 ※未指定の場合は変更しない
+	active := user.DeactivatedAt == nil
+	if m.Active != nil {
+		active = *m.Active
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return nil, scimInternalError()
+	}
+
+	if err := s.user.Update(tx, &row); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	// 無効化・有効化
+	var sessionIDs []string
+	if active == (user.DeactivatedAt != nil) {
+		var deactivatedAt *time.Time
+		if !active {
+			now := time.Now()
+			deactivatedAt = &now
+		}
+		if err := s.user.UpdateDeactivated(tx, &ddl.User{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey: user.HashKey,
+			},
+			DeactivatedAt: deactivatedAt,
+		}); err != nil {
+			if err := s.db.TxRollback(tx); err != nil {
+				return nil, scimInternalError()
+			}
+			return nil, scimInternalError()
+		}
+	}
+	// ログイン中セッション失効 ※無効化済みでも再送時に残存分を失効
+	if !active {
+		ids, err := revokeSessions(tx, s.user, []uint64{user.ID})
+		if err != nil {
+			if err := s.db.TxRollback(tx); err != nil {
+				return nil, scimInternalError()
+			}
+			return nil, scimInternalError()
+		}
+		sessionIDs = ids
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		CompanyID: token.CompanyID,
+		EventID:   static.OPERATION_LOG_EVENT_USER_UPDATE,
+		Target:    user.HashKey,
+		Detail:    map[string]interface{}{"name": row.Name, "email": row.Email, "active": active, "source": static.SCIM_OPERATION_SOURCE},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return nil, scimInternalError()
+	}
+
+	// 強制ログアウト
+	if !active {
+		clearSessions(s.redis, sessionIDs, []string{user.HashKey})
+	}
+
+	updated, updatedErr := s.getUser(token.CompanyID, user.HashKey)
+	if updatedErr != nil {
+		return nil, updatedErr
+	}
+	return &response.SCIMUser{
+		SCIMUserResource: *scimUser(&updated.User),
+	}, nil
+}
+
+// ユーザー削除 ※SCIMのDELETEのみ、削除後に強制ログアウト
+func (s *SCIMService) deleteUser(token *entity.CompanySCIMToken, user *entity.User) *response.SCIMError {
+	ids := []uint64{user.ID}
+	hashKeys := []string{user.HashKey}
+
+	// 削除可能判定
+	if err := checkUserDeletable(s.user, ids); err != nil {
+		return scimConflictError(err, map[uint]string{
+			static.CODE_USER_CANNOT_DELETE_APPLICANT: static.SCIM_DETAIL_USER_HAS_APPLICANT,
+			static.CODE_USER_CANNOT_DELETE_SCHEDULE:  static.SCIM_DETAIL_USER_HAS_SCHEDULE,
+		})
+	}
+
+	// ログイン中セッション取得
+	sessionIDs, sessionIDsErr := s.user.ListSessionID(ids)
+	if sessionIDsErr != nil {
+		return scimInternalError()
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return scimInternalError()
+	}
+
+	if err := deleteUsers(tx, s.user, ids, hashKeys); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return scimInternalError()
+		}
+		return scimInternalError()
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		CompanyID: token.CompanyID,
+		EventID:   static.OPERATION_LOG_EVENT_USER_DELETE,
+		Target:    user.HashKey,
+		Detail:    map[string]interface{}{"source": static.SCIM_OPERATION_SOURCE},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return scimInternalError()
+		}
+		return scimInternalError()
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return scimInternalError()
+	}
+
+	// 強制ログアウト
+	clearSessions(s.redis, sessionIDs, hashKeys)
+
+	return nil
+}
+
+// グループ保存 ※置換・部分更新共通、メンバーは差分を追加・削除
+func (s *SCIMService) saveGroup(
+	token *entity.CompanySCIMToken,
+	team *entity.Team,
+	m *request.SCIMGroupResource,
+) (*response.SCIMGroup, *response.SCIMError) {
+	// バリデーション
+	name := strings.TrimSpace(m.DisplayName)
+	if err := s.validatorTeam.Update(&request.UpdateTeam{
+		Team: ddl.Team{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey: team.HashKey,
+			},
+			Name: name,
+		},
+	}); err != nil {
+		log.Printf("%v", err)
+		return nil, newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_GROUP)
+	}
+
+	// メンバー存在確認
+	ids, idsErr := s.memberIDs(token.CompanyID, m.Members)
+	if idsErr != nil {
+		return nil, idsErr
+	}
+
+	// 差分
+	next := map[uint64]bool{}
+	for _, id := range ids {
+		next[id] = true
+	}
+	current := map[uint64]bool{}
+	var removed []uint64
+	for _, user := range team.Users {
+		current[user.ID] = true
+		if !next[user.ID] {
+			removed = append(removed, user.ID)
+		}
+	}
+	var added []uint64
+	for _, id := range ids {
+		if !current[id] {
+			added = append(added, id)
+		}
+	}
+
+	tx, txErr := s.db.TxStart()
+	if txErr != nil {
+		return nil, scimInternalError()
+	}
+
+	// 更新
+	if _, err := s.team.Update(tx, &ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: team.HashKey,
+		},
+		Name: name,
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	// メンバー追加
+	if err := insertTeamMembers(tx, s.team, &team.Team, added); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	// メンバー削除
+	for _, id := range removed {
+		if err := s.team.DeleteMember(tx, &ddl.TeamAssociation{
+			TeamID: team.ID,
+			UserID: id,
+		}); err != nil {
+			if err := s.db.TxRollback(tx); err != nil {
+				return nil, scimInternalError()
+			}
+			return nil, scimInternalError()
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, s.operationLog, s.redis, &dto.OperationLog{
+		CompanyID: token.CompanyID,
+		TeamID:    team.ID,
+		EventID:   static.OPERATION_LOG_EVENT_TEAM_UPDATE,
+		Target:    team.HashKey,
+		Detail:    map[string]interface{}{"name": name, "members": scimMemberValues(m.Members), "source": static.SCIM_OPERATION_SOURCE},
+	}); err != nil {
+		if err := s.db.TxRollback(tx); err != nil {
+			return nil, scimInternalError()
+		}
+		return nil, scimInternalError()
+	}
+
+	if err := s.db.TxCommit(tx); err != nil {
+		return nil, scimInternalError()
+	}
+
+	updated, updatedErr := s.getTeam(token.CompanyID, team.HashKey)
+	if updatedErr != nil {
+		return nil, updatedErr
+	}
+	return &response.SCIMGroup{
+		SCIMGroupResource: *scimGroup(updated),
+	}, nil
+}
+
+// SCIMエラー
+func newSCIMError(status int, scimType string, detail string) *response.SCIMError {
+	return &response.SCIMError{
+		Schemas:    []string{static.SCIM_SCHEMA_ERROR},
+		Status:     strconv.Itoa(status),
+		ScimType:   scimType,
+		Detail:     detail,
+		HTTPStatus: status,
+	}
+}
+
+// SCIMエラー(500)
+func scimInternalError() *response.SCIMError {
+	return newSCIMError(http.StatusInternalServerError, "", "")
+}
+
+// 削除不可エラーのSCIM変換 ※コード毎の詳細で409を返却
+func scimConflictError(err *response.Error, details map[uint]string) *response.SCIMError {
+	detail, ok := details[err.Code]
+	if !ok || err.Status == http.StatusInternalServerError {
+		return scimInternalError()
+	}
+	return newSCIMError(http.StatusConflict, "", detail)
+}
+
+// 一覧条件 ※startIndexは1始まり、countは上限あり
+func scimListCondition(companyID uint64, filter string, req *request.SCIMList) *dto.SCIMList {
+	offset := req.StartIndex - 1
+	if offset < 0 {
+		offset = 0
+	}
+	limit := req.Count
+	if limit == 0 {
+		limit = static.SCIM_DEFAULT_COUNT
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	if limit > static.SCIM_MAX_COUNT {
+		limit = static.SCIM_MAX_COUNT
+	}
+	return &dto.SCIMList{
+		CompanyID: companyID,
+		Filter:    filter,
+		Offset:    offset,
+		Limit:     limit,
+	}
+}
+
+// 絞り込み解析 ※「属性 eq "値"」のみ対応、属性名・演算子は大文字小文字を区別しない
+func parseSCIMFilter(filter string, attr string) (string, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return "", nil
+	}
+
+	parts := strings.SplitN(filter, " ", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[0], attr) || !strings.EqualFold(parts[1], "eq") {
+		return "", fmt.Errorf("unsupported filter: %s", filter)
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(parts[2])), &value); err != nil {
+		return "", fmt.Errorf("invalid filter value: %s", filter)
+	}
+	return value, nil
+}
+
+// ユーザー→SCIMユーザー変換
+func scimUser(m *ddl.User) *request.SCIMUserResource {
+	active := m.DeactivatedAt == nil
+	return &request.SCIMUserResource{
+		Schemas:  []string{static.SCIM_SCHEMA_USER},
+		ID:       m.HashKey,
+		UserName: m.Email,
+		Name: &request.SCIMName{
+			Formatted: m.Name,
+		},
+		DisplayName: m.Name,
+		Emails: []request.SCIMEmail{
+			{
+				Value:   m.Email,
+				Type:    "work",
+				Primary: true,
+			},
+		},
+		Active: &active,
+		Meta:   scimMeta(static.SCIM_RESOURCE_USER, "/Users/", m.HashKey, m.CreatedAt, m.UpdatedAt),
+	}
+}
+
+// チーム→SCIMグループ変換
+func scimGroup(m *entity.Team) *request.SCIMGroupResource {
+	members := []request.SCIMMember{}
+	for _, user := range m.Users {
+		members = append(members, request.SCIMMember{
+			Value:   user.HashKey,
+			Display: user.Name,
+		})
+	}
+	return &request.SCIMGroupResource{
+		Schemas:     []string{static.SCIM_SCHEMA_GROUP},
+		ID:          m.HashKey,
+		DisplayName: m.Name,
+		Members:     members,
+		Meta:        scimMeta(static.SCIM_RESOURCE_GROUP, "/Groups/", m.HashKey, m.CreatedAt, m.UpdatedAt),
+	}
+}
+
+// メタ情報
+func scimMeta(resourceType string, path string, hashKey string, createdAt time.Time, updatedAt time.Time) *request.SCIMMeta {
+	return &request.SCIMMeta{
+		ResourceType: resourceType,
+		Created:      createdAt.UTC().Format(time.RFC3339),
+		LastModified: updatedAt.UTC().Format(time.RFC3339),
+		Location:     static.SCIM_BASE_PATH + path + hashKey,
+	}
+}
+
+// 氏名 ※displayName → name.formatted → 姓 名 の順に採用
+func scimUserName(m *request.SCIMUserResource) string {
+	if name := strings.TrimSpace(m.DisplayName); name != "" {
+		return name
+	}
+	if m.Name == nil {
+		return ""
+	}
+	if name := strings.TrimSpace(m.Name.Formatted); name != "" {
+		return name
+	}
+	return strings.TrimSpace(m.Name.FamilyName + " " + m.Name.GivenName)
+}
+
+// メンバーID一覧 ※重複排除
+func scimMemberValues(members []request.SCIMMember) []string {
+	var values []string
+	seen := map[string]bool{}
+	for _, member := range members {
+		if member.Value == "" || seen[member.Value] {
+			continue
+		}
+		seen[member.Value] = true
+		values = append(values, member.Value)
+	}
+	return values
+}
+
+// ユーザー部分更新の適用 ※active・userName・displayName・name のみ、externalId・emails は無視
+func applySCIMUserPatch(m *request.SCIMUserResource, ops []request.SCIMPatchOperation) *response.SCIMError {
+	for _, op := range ops {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		default:
+			return newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_PATH, static.SCIM_DETAIL_INVALID_PATH)
+		}
+
+		// パス未指定の場合は値が属性のオブジェクト
+		values := map[string]interface{}{}
+		if op.Path == "" {
+			object, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_SYNTAX)
+			}
+			values = object
+		} else {
+			values[op.Path] = op.Value
+		}
+
+		for path, value := range values {
+			if err := setSCIMUserAttribute(m, path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ユーザー属性設定
+func setSCIMUserAttribute(m *request.SCIMUserResource, path string, value interface{}) *response.SCIMError {
+	invalidValue := newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_SYNTAX)
+
+	attr := strings.ToLower(path)
+	switch {
+	case attr == "active":
+		active, err := scimBool(value)
+		if err != nil {
+			return invalidValue
+		}
+		m.Active = &active
+	case attr == "username":
+		s, ok := value.(string)
+		if !ok {
+			return invalidValue
+		}
+		m.UserName = s
+	case attr == "displayname":
+		s, ok := value.(string)
+		if !ok {
+			return invalidValue
+		}
+		m.DisplayName = s
+	case attr == "name":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return invalidValue
+		}
+		for k, v := range object {
+			if err := setSCIMUserAttribute(m, "name."+k, v); err != nil {
+				return err
+			}
+		}
+	case strings.HasPrefix(attr, "name."):
+		s, ok := value.(string)
+		if !ok {
+			return invalidValue
+		}
+		// 氏名の変更を優先するため表示名は破棄
+		m.DisplayName = ""
+		if m.Name == nil {
+			m.Name = &request.SCIMName{}
+		}
+		switch attr {
+		case "name.formatted":
+			m.Name.Formatted = s
+		case "name.familyname":
+			m.Name.Formatted = ""
+			m.Name.FamilyName = s
+		case "name.givenname":
+			m.Name.Formatted = ""
+			m.Name.GivenName = s
+		}
+	case attr == "externalid", strings.HasPrefix(attr, "emails"):
+		// メールアドレスはuserNameで管理
+	default:
+		return newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_PATH, static.SCIM_DETAIL_INVALID_PATH)
+	}
+	return nil
+}
+
+// グループ部分更新の適用 ※displayName・members のみ
+func applySCIMGroupPatch(m *request.SCIMGroupResource, ops []request.SCIMPatchOperation) *response.SCIMError {
+	invalidValue := newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_VALUE, static.SCIM_DETAIL_INVALID_SYNTAX)
+	invalidPath := newSCIMError(http.StatusBadRequest, static.SCIM_TYPE_INVALID_PATH, static.SCIM_DETAIL_INVALID_PATH)
+
+	for _, op := range ops {
+		kind := strings.ToLower(op.Op)
+		path, filter, _ := strings.Cut(strings.TrimSuffix(op.Path, "]"), "[")
+
+		switch {
+		// パス未指定 ※値が属性のオブジェクト
+		case path == "" && (kind == "add" || kind == "replace"):
+			object, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return invalidValue
+			}
+			for k, v := range object {
+				if err := applySCIMGroupPatch(m, []request.SCIMPatchOperation{{Op: op.Op, Path: k, Value: v}}); err != nil {
+					return err
+				}
+			}
+		case strings.EqualFold(path, "displayName") && (kind == "add" || kind == "replace"):
+			s, ok := op.Value.(string)
+			if !ok {
+				return invalidValue
+			}
+			m.DisplayName = s
+		case strings.EqualFold(path, "members") && filter == "":
+			members, err := scimMembers(op.Value)
+			if err != nil {
+				return invalidValue
+			}
+			switch kind {
+			case "add":
+				m.Members = append(m.Members, members...)
+			case "replace":
+				m.Members = members
+			case "remove":
+				// 値未指定の場合は全員削除
+				if op.Value == nil {
+					m.Members = nil
+					break
+				}
+				m.Members = removeSCIMMembers(m.Members, scimMemberValues(members))
+			default:
+				return invalidPath
+			}
+		case strings.EqualFold(path, "members") && kind == "remove":
+			value, err := parseSCIMFilter(filter, "value")
+			if err != nil {
+				return invalidPath
+			}
+			m.Members = removeSCIMMembers(m.Members, []string{value})
+		default:
+			return invalidPath
+		}
+	}
+	return nil
+}
+
+// メンバー変換 ※[{"value": "..."}] 形式
+func scimMembers(value interface{}) ([]request.SCIMMember, error) {
+	if value == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var members []request.SCIMMember
+	if err := json.Unmarshal(b, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// メンバー除外
+func removeSCIMMembers(members []request.SCIMMember, values []string) []request.SCIMMember {
+	removed := map[string]bool{}
+	for _, value := range values {
+		removed[value] = true
+	}
+	var res []request.SCIMMember
+	for _, member := range members {
+		if !removed[member.Value] {
+			res = append(res, member)
+		}
+	}
+	return res
+}
+
+// 真偽値変換 ※"True"/"False" の文字列で送信するIdPもあるため許容
+func scimBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.ToLower(v))
+	}
+	return false, errors.New("not a boolean")
+}
+
+// 初回パスワード発行 ※企業のパスワードポリシーを満たすこと
+func issueInitPassword(company repository.ICompanyRepository, companyID uint64) (*string, *string, *response.Error) {
+	policy, policyErr := companyPasswordPolicy(company, companyID)
+	if policyErr != nil {
+		return nil, nil, policyErr
+	}
+	password, hashPassword, passwordErr := GeneratePassword(policy)
+	if passwordErr != nil {
+		log.Printf("%v", passwordErr)
+		return nil, nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	return password, hashPassword, nil
+}
+
+// ユーザー登録 ※パスワード履歴を含む
+func insertUser(tx *gorm.DB, r repository.IUserRepository, m *ddl.User) (*entity.User, error) {
+	_, hashKey, hashErr := GenerateHash(1, 25)
+	if hashErr != nil {
+		log.Printf("%v", hashErr)
+		return nil, hashErr
+	}
+	m.HashKey = static.PRE_USER + "_" + *hashKey
+
+	user, err := r.Insert(tx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.InsertPasswordHistory(tx, &ddl.UserPasswordHistory{
+		UserID:   user.ID,
+		Password: m.Password,
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// 初回パスワード送信 ※失敗時はログのみ
+func sendInitPassword(mail repository.IMailRepository, user *ddl.User, password string) {
+	if err := mail.Send(&dto.Mail{
+		CompanyID: user.CompanyID,
+		Kind:      static.MAIL_KIND_INIT_PASSWORD_USER,
+		To:        user.Email,
+		Subject:   static.MAIL_SUBJECT_INIT_PASSWORD,
+		Body:      fmt.Sprintf(static.MAIL_BODY_INIT_PASSWORD, user.Name, user.Email, password),
+	}); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+// ユーザー削除可能判定 ※応募者・予定が紐づく場合は不可
+func checkUserDeletable(r repository.IUserRepository, ids []uint64) *response.Error {
+	// ユーザーと紐づいている応募者数を取得
+	applicantUserCount, applicantUserCountErr := r.CountApplicantUserAssociation(ids)
+	if applicantUserCountErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if applicantUserCount > 0 {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_USER_CANNOT_DELETE_APPLICANT,
+		}
+	}
+
+	// ユーザーと紐づいているスケジュール数を取得
+	scheduleCount, scheduleCountErr := r.CountScheduleAssociation(ids)
+	if scheduleCountErr != nil {
+		log.Printf("%v", scheduleCountErr)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if scheduleCount > 0 {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_USER_CANNOT_DELETE_SCHEDULE,
+		}
+	}
+	return nil
+}
+
+// ユーザー削除 ※関連する紐づけ・認証情報を含む
+func deleteUsers(tx *gorm.DB, r repository.IUserRepository, ids []uint64, hashKeys []string) error {
+	// 通知削除
+	if err := r.DeleteNotice(tx, ids); err != nil {
+		return err
+	}
+	// 面接毎参加可能者削除
+	if err := r.DeleteTeamAssignPossible(tx, ids); err != nil {
+		return err
+	}
+	// 面接割り振り優先順位削除
+	if err := r.DeleteTeamAssignPriority(tx, ids); err != nil {
+		return err
+	}
+	// チーム紐づけ削除
+	if err := r.DeleteTeamAssociation(tx, ids); err != nil {
+		return err
+	}
+	// リフレッシュトークン紐づけ削除
+	if err := r.DeleteUserRefreshTokenAssociation(tx, ids); err != nil {
+		return err
+	}
+	// セッショントークン削除
+	if err := r.DeleteSessionToken(tx, ids); err != nil {
+		return err
+	}
+	// カレンダー購読トークン削除
+	if err := r.DeleteCalendarToken(tx, ids); err != nil {
+		return err
+	}
+	// 認証アプリ削除
+	if err := r.DeleteTOTP(tx, ids); err != nil {
+		return err
+	}
+	// リカバリーコード削除
+	if err := r.DeleteRecoveryCode(tx, ids); err != nil {
+		return err
+	}
+	// パスワード履歴削除
+	if err := r.DeletePasswordHistory(tx, ids); err != nil {
+		return err
+	}
+	// シングルサインオン紐づけ削除
+	if err := r.DeleteOIDC(tx, ids); err != nil {
+		return err
+	}
+	// ユーザー削除
+	return r.Delete(tx, hashKeys)
+}
+
+// チーム登録 ※面接毎設定・面接毎参加可能者(全員参加可能)・選考状況を含む
+func insertTeam(tx *gorm.DB, r repository.ITeamRepository, m *ddl.Team, userIDs []uint64) (*entity.Team, error) {
+	_, hashKey, hashErr := GenerateHash(1, 25)
+	if hashErr != nil {
+		log.Printf("%v", hashErr)
+		return nil, hashErr
+	}
+
+	m.HashKey = string(static.PRE_TEAM) + "_" + *hashKey
+	m.NumOfInterview = 3
+	m.RuleID = static.ASSIGN_RULE_MANUAL
+	team, err := r.Insert(tx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// 面接毎設定登録
+	var perList []*ddl.TeamPerInterview
+	for i := 1; i <= int(team.NumOfInterview); i++ {
+		perList = append(perList, &ddl.TeamPerInterview{
+			TeamID:         team.ID,
+			NumOfInterview: uint(i),
+			UserMin:        1,
+		})
+	}
+	if err := r.InsertsPerInterview(tx, perList); err != nil {
+		return nil, err
+	}
+
+	// メンバー登録
+	if err := insertTeamMembers(tx, r, &team.Team, userIDs); err != nil {
+		return nil, err
+	}
+
+	// 選考状況登録
+	for _, name := range []string{"日程未回答", "日程回答済み"} {
+		_, selectHash, selectHashErr := GenerateHash(1, 25)
+		if selectHashErr != nil {
+			log.Printf("%v", selectHashErr)
+			return nil, selectHashErr
+		}
+		if err := r.InsertSelectStatus(tx, &ddl.SelectStatus{
+			AbstractTransactionModel: ddl.AbstractTransactionModel{
+				HashKey:   string(static.PRE_SELECT_STATUS) + "_" + *selectHash,
+				CompanyID: m.CompanyID,
+			},
+			TeamID:     team.ID,
+			StatusName: name,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return team, nil
+}
+
+// メンバー登録 ※面接毎参加可能者(全員参加可能)、優先順位設定済みの場合は末尾に追加
+func insertTeamMembers(tx *gorm.DB, r repository.ITeamRepository, team *ddl.Team, userIDs []uint64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	priority, priorityErr := r.GetAssignPriorityOnly(&ddl.TeamAssignPriority{
+		TeamID: team.ID,
+	})
+	if priorityErr != nil {
+		return priorityErr
+	}
+
+	var teamAssociations []*ddl.TeamAssociation
+	var possibleList []*ddl.TeamAssignPossible
+	var priorityList []*ddl.TeamAssignPriority
+	for index, id := range userIDs {
+		teamAssociations = append(teamAssociations, &ddl.TeamAssociation{
+			TeamID: team.ID,
+			UserID: id,
+		})
+		for i := 1; i <= int(team.NumOfInterview); i++ {
+			possibleList = append(possibleList, &ddl.TeamAssignPossible{
+				TeamID:         team.ID,
+				UserID:         id,
+				NumOfInterview: uint(i),
+			})
+		}
+		if len(priority) > 0 {
+			priorityList = append(priorityList, &ddl.TeamAssignPriority{
+				TeamID:   team.ID,
+				UserID:   id,
+				Priority: uint(len(priority) + index + 1),
+			})
+		}
+	}
+
+	if err := r.InsertsTeamAssociation(tx, teamAssociations); err != nil {
+		return err
+	}
+	if len(possibleList) > 0 {
+		if err := r.InsertsAssignPossible(tx, possibleList); err != nil {
+			return err
+		}
+	}
+	if len(priorityList) > 0 {
+		if err := r.InsertsAssignPriority(tx, priorityList); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// チーム削除可能判定 ※応募者・予定・原稿が紐づく場合は不可
+func checkTeamDeletable(
+	applicant repository.IApplicantRepository,
+	schedule repository.IScheduleRepository,
+	manuscript repository.IManuscriptRepository,
+	teamID uint64,
+) *response.Error {
+	// t_applicant
+	apps, appsErr := applicant.GetByTeamID(&ddl.Applicant{
+		TeamID: teamID,
+	})
+	if appsErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(apps) > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_TEAM_USER_CANNOT_DELETE_APPLICANT,
+		}
+	}
+	// t_schedule
+	schedules, schedulesErr := schedule.GetByTeamID(&ddl.Schedule{
+		TeamID: teamID,
+	})
+	if schedulesErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(schedules) > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_TEAM_USER_CANNOT_DELETE_SCHEDULE,
+		}
+	}
+	// t_manuscript_team_association
+	manuscripts, manuscriptsErr := manuscript.GetAssociationByTeamID(
+		&ddl.ManuscriptTeamAssociation{
+			TeamID: teamID,
+		},
+	)
+	if manuscriptsErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if len(manuscripts) > 0 {
+		return &response.Error{
+			Status: http.StatusConflict,
+			Code:   static.CODE_TEAM_USER_CANNOT_DELETE_MANUSCRIPT,
+		}
+	}
+	return nil
+}
+
+// チーム削除 ※関連する紐づけを含む
+func deleteTeam(tx *gorm.DB, r repository.ITeamRepository, team *ddl.Team) error {
+	// t_team_association
+	if err := r.DeleteTeamAssociation(tx, &ddl.TeamAssociation{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_per_interview
+	if err := r.DeletePerInterview(tx, &ddl.TeamPerInterview{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_assign_possible
+	if err := r.DeleteAssignPossible(tx, &ddl.TeamAssignPossible{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_booking_window, t_team_booking_hours
+	if err := r.DeleteBookingWindow(tx, &ddl.TeamBookingWindow{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_weekday
+	if err := r.DeleteWeekday(tx, &ddl.TeamWeekday{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_assign_priority
+	if err := r.DeleteAssignPriority(tx, &ddl.TeamAssignPriority{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_select_status
+	if err := r.DeleteSelectStatus(tx, &ddl.SelectStatus{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_auto_assign_rule_association
+	if err := r.DeleteAutoAssignRule(tx, &ddl.TeamAutoAssignRule{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_event_each_interview
+	if err := r.DeleteEventEachInterviewAssociation(tx, &ddl.TeamEventEachInterview{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+	// t_team_event
+	if err := r.DeleteEventAssociation(tx, &ddl.TeamEvent{
+		TeamID: team.ID,
+	}); err != nil {
+		return err
+	}
+
+	return r.Delete(tx, &ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: team.HashKey,
+		},
+	})
+}
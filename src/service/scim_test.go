@@ -0,0 +1,423 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    string
+		wantErr bool
+	}{
+		// ok 未指定
+		{"ok_empty", "", "", false},
+		// ok 完全一致
+		{"ok_eq", `userName eq "user@example.com"`, "user@example.com", false},
+		// ok 属性名・演算子の大文字小文字を区別しない
+		{"ok_case", `USERNAME EQ "user@example.com"`, "user@example.com", false},
+		// ok 値に空白を含む
+		{"ok_space", `userName eq "a b"`, "a b", false},
+		// ng 対象外の属性
+		{"ng_attr", `displayName eq "a"`, "", true},
+		// ng eq以外の演算子
+		{"ng_operator", `userName co "a"`, "", true},
+		// ng 値が文字列でない
+		{"ng_value", `userName eq a`, "", true},
+		// ng 複合条件
+		{"ng_and", `userName eq "a" and userName eq "b"`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSCIMFilter(tt.filter, "userName")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSCIMFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSCIMFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplySCIMUserPatch(t *testing.T) {
+	base := func() *request.SCIMUserResource {
+		active := true
+		return &request.SCIMUserResource{
+			UserName:    "user@example.com",
+			DisplayName: "山田 太郎",
+			Name:        &request.SCIMName{Formatted: "山田 太郎"},
+			Active:      &active,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		ops        []request.SCIMPatchOperation
+		wantName   string
+		wantEmail  string
+		wantActive bool
+		wantErr    bool
+	}{
+		// ok パス指定で無効化
+		{"ok_active_path", []request.SCIMPatchOperation{{Op: "replace", Path: "active", Value: false}}, "山田 太郎", "user@example.com", false, false},
+		// ok 値のオブジェクトで無効化 ※文字列の真偽値
+		{"ok_active_value", []request.SCIMPatchOperation{{Op: "Replace", Value: map[string]interface{}{"active": "False"}}}, "山田 太郎", "user@example.com", false, false},
+		// ok userName変更
+		{"ok_user_name", []request.SCIMPatchOperation{{Op: "replace", Path: "userName", Value: "new@example.com"}}, "山田 太郎", "new@example.com", true, false},
+		// ok 姓名変更 ※表示名より優先
+		{"ok_name_parts", []request.SCIMPatchOperation{
+			{Op: "replace", Path: "name.familyName", Value: "佐藤"},
+			{Op: "replace", Path: "name.givenName", Value: "花子"},
+		}, "佐藤 花子", "user@example.com", true, false},
+		// ok externalId・emailsは無視
+		{"ok_ignored", []request.SCIMPatchOperation{
+			{Op: "add", Path: "externalId", Value: "ext"},
+			{Op: "replace", Path: `emails[type eq "work"].value`, Value: "other@example.com"},
+		}, "山田 太郎", "user@example.com", true, false},
+		// ng 削除操作
+		{"ng_remove", []request.SCIMPatchOperation{{Op: "remove", Path: "displayName"}}, "", "", false, true},
+		// ng 対象外の属性
+		{"ng_path", []request.SCIMPatchOperation{{Op: "replace", Path: "title", Value: "a"}}, "", "", false, true},
+		// ng 型不一致
+		{"ng_value", []request.SCIMPatchOperation{{Op: "replace", Path: "active", Value: "maybe"}}, "", "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base()
+			err := applySCIMUserPatch(m, tt.ops)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applySCIMUserPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := scimUserName(m); got != tt.wantName {
+				t.Errorf("scimUserName() = %v, want %v", got, tt.wantName)
+			}
+			if m.UserName != tt.wantEmail {
+				t.Errorf("UserName = %v, want %v", m.UserName, tt.wantEmail)
+			}
+			if *m.Active != tt.wantActive {
+				t.Errorf("Active = %v, want %v", *m.Active, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestApplySCIMGroupPatch(t *testing.T) {
+	base := func() *request.SCIMGroupResource {
+		return &request.SCIMGroupResource{
+			DisplayName: "営業部",
+			Members:     []request.SCIMMember{{Value: "a"}, {Value: "b"}},
+		}
+	}
+	members := func(values ...string) []interface{} {
+		var res []interface{}
+		for _, v := range values {
+			res = append(res, map[string]interface{}{"value": v})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		ops         []request.SCIMPatchOperation
+		wantName    string
+		wantMembers []string
+		wantErr     bool
+	}{
+		// ok 名称変更
+		{"ok_rename", []request.SCIMPatchOperation{{Op: "replace", Path: "displayName", Value: "開発部"}}, "開発部", []string{"a", "b"}, false},
+		// ok 値のオブジェクトで名称変更
+		{"ok_rename_value", []request.SCIMPatchOperation{{Op: "replace", Value: map[string]interface{}{"displayName": "開発部"}}}, "開発部", []string{"a", "b"}, false},
+		// ok メンバー追加
+		{"ok_add", []request.SCIMPatchOperation{{Op: "add", Path: "members", Value: members("c")}}, "営業部", []string{"a", "b", "c"}, false},
+		// ok メンバー置換
+		{"ok_replace", []request.SCIMPatchOperation{{Op: "replace", Path: "members", Value: members("c")}}, "営業部", []string{"c"}, false},
+		// ok フィルタ指定でメンバー削除
+		{"ok_remove_filter", []request.SCIMPatchOperation{{Op: "remove", Path: `members[value eq "a"]`}}, "営業部", []string{"b"}, false},
+		// ok 値指定でメンバー削除
+		{"ok_remove_value", []request.SCIMPatchOperation{{Op: "remove", Path: "members", Value: members("b")}}, "営業部", []string{"a"}, false},
+		// ok 全メンバー削除
+		{"ok_remove_all", []request.SCIMPatchOperation{{Op: "remove", Path: "members"}}, "営業部", nil, false},
+		// ng 対象外の属性
+		{"ng_path", []request.SCIMPatchOperation{{Op: "replace", Path: "externalId", Value: "a"}}, "", nil, true},
+		// ng 不正なフィルタ
+		{"ng_filter", []request.SCIMPatchOperation{{Op: "remove", Path: `members[display eq "a"]`}}, "", nil, true},
+		// ng メンバーの形式不正
+		{"ng_members", []request.SCIMPatchOperation{{Op: "add", Path: "members", Value: "a"}}, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base()
+			err := applySCIMGroupPatch(m, tt.ops)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applySCIMGroupPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if m.DisplayName != tt.wantName {
+				t.Errorf("DisplayName = %v, want %v", m.DisplayName, tt.wantName)
+			}
+			if got := scimMemberValues(m.Members); !reflect.DeepEqual(got, tt.wantMembers) {
+				t.Errorf("members = %v, want %v", got, tt.wantMembers)
+			}
+		})
+	}
+}
+
+// SCIM連携ユーザー ※応募者が紐づくため削除不可
+type mockSCIMUserRepo struct {
+	repository.IUserRepository
+	user    entity.User
+	revoked int
+	deleted bool
+}
+
+func (r *mockSCIMUserRepo) Get(m *ddl.User) (*entity.User, error) {
+	if m.HashKey != r.user.HashKey || r.deleted {
+		return nil, gorm.ErrRecordNotFound
+	}
+	user := r.user
+	return &user, nil
+}
+
+func (r *mockSCIMUserRepo) Update(tx *gorm.DB, m *ddl.User) error {
+	r.user.Name = m.Name
+	r.user.Email = m.Email
+	return nil
+}
+
+func (r *mockSCIMUserRepo) UpdateDeactivated(tx *gorm.DB, m *ddl.User) error {
+	r.user.DeactivatedAt = m.DeactivatedAt
+	return nil
+}
+
+func (r *mockSCIMUserRepo) ListSessionID(m []uint64) ([]string, error) {
+	return []string{"session_1"}, nil
+}
+
+func (r *mockSCIMUserRepo) RevokeSessionByUser(tx *gorm.DB, m []uint64) error {
+	r.revoked++
+	return nil
+}
+
+func (r *mockSCIMUserRepo) CountApplicantUserAssociation(m []uint64) (int64, error) {
+	return 1, nil
+}
+
+func (r *mockSCIMUserRepo) Delete(tx *gorm.DB, m []string) error {
+	r.deleted = true
+	return nil
+}
+
+type mockSCIMRepo struct {
+	repository.ISCIMRepository
+}
+
+func (r *mockSCIMRepo) GetToken(m *ddl.CompanySCIMToken) (*entity.CompanySCIMToken, error) {
+	if m.Token != sessionTokenHash("token_a") {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &entity.CompanySCIMToken{CompanySCIMToken: ddl.CompanySCIMToken{
+		CompanyID: 1,
+		RoleID:    1,
+		Role:      ddl.CustomRole{AbstractTransactionModel: ddl.AbstractTransactionModel{HashKey: "role_1"}},
+	}}, nil
+}
+
+type mockSCIMOperationLogRepo struct {
+	repository.IOperationLogRepository
+}
+
+func (r *mockSCIMOperationLogRepo) Insert(tx *gorm.DB, m *ddl.OperationLog) error {
+	return nil
+}
+
+func TestSCIMService_PatchUser(t *testing.T) {
+	deactivatedAt := time.Now().Add(-time.Hour)
+	patch := func(value interface{}) []request.SCIMPatchOperation {
+		return []request.SCIMPatchOperation{{Op: "replace", Path: "active", Value: value}}
+	}
+
+	tests := []struct {
+		name string
+		// 現在の無効化日時
+		deactivatedAt *time.Time
+		ops           []request.SCIMPatchOperation
+		wantActive    bool
+		// セッション失効・強制ログアウト
+		wantRevoked bool
+	}{
+		// ok 無効化 ※削除せず、ログイン中セッションを失効
+		{"ok_deactivate", nil, patch(false), false, true},
+		// ok 有効化 ※無効化したユーザーを復元
+		{"ok_restore", &deactivatedAt, patch(true), true, false},
+		// ok 無効状態のまま氏名変更
+		{"ok_keep_deactivated", &deactivatedAt, []request.SCIMPatchOperation{{Op: "replace", Path: "displayName", Value: "佐藤 花子"}}, false, true},
+		// ok 有効状態のまま氏名変更
+		{"ok_keep_active", nil, []request.SCIMPatchOperation{{Op: "replace", Path: "displayName", Value: "佐藤 花子"}}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &mockSCIMUserRepo{user: entity.User{User: ddl.User{
+				AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 1, HashKey: "user_1", CompanyID: 1},
+				Name:                     "山田 太郎",
+				Email:                    "user@example.com",
+				DeactivatedAt:            tt.deactivatedAt,
+			}}}
+			redis := newMemoryRedis()
+			hashKey := "user_1"
+			_ = redis.Set(context.Background(), "user_1", static.REDIS_USER_HASH_KEY, &hashKey, 0)
+			_ = redis.Set(context.Background(), sessionKey("session_1"), static.REDIS_USER_HASH_KEY, &hashKey, 0)
+			db := newMockDB()
+			s := &SCIMService{
+				scim:          &mockSCIMRepo{},
+				user:          users,
+				validatorUser: validator.NewUserValidator(),
+				db:            db,
+				redis:         redis,
+				operationLog:  &mockSCIMOperationLogRepo{},
+			}
+
+			res, err := s.PatchUser(&request.SCIMPatch{
+				SCIMAbstract: request.SCIMAbstract{Token: "token_a"},
+				ID:           "user_1",
+				Operations:   tt.ops,
+			})
+			if err != nil {
+				t.Fatalf("PatchUser() error = %+v", err)
+			}
+			if users.deleted || res == nil || res.Active == nil || *res.Active != tt.wantActive {
+				t.Fatalf("PatchUser() = %+v, deleted = %v", res, users.deleted)
+			}
+			if (users.user.DeactivatedAt == nil) != tt.wantActive {
+				t.Errorf("deactivatedAt = %v, want active %v", users.user.DeactivatedAt, tt.wantActive)
+			}
+			if tt.deactivatedAt != nil && !tt.wantActive && !users.user.DeactivatedAt.Equal(deactivatedAt) {
+				t.Errorf("deactivatedAt = %v, want unchanged", users.user.DeactivatedAt)
+			}
+			if (users.revoked > 0) != tt.wantRevoked {
+				t.Errorf("revoked = %d, want %v", users.revoked, tt.wantRevoked)
+			}
+			_, userErr := redis.Get(context.Background(), "user_1", static.REDIS_USER_HASH_KEY)
+			_, sessionErr := redis.Get(context.Background(), sessionKey("session_1"), static.REDIS_USER_HASH_KEY)
+			if (userErr != nil || sessionErr != nil) != tt.wantRevoked {
+				t.Errorf("session cleared = %v, %v, want %v", userErr, sessionErr, tt.wantRevoked)
+			}
+			if db.committed != 1 {
+				t.Errorf("committed = %d, want 1", db.committed)
+			}
+		})
+	}
+}
+
+func TestSCIMService_IssueToken(t *testing.T) {
+	newRole := func(id uint64, hashKey string, editFlg uint) *entity.CustomRole {
+		return &entity.CustomRole{CustomRole: ddl.CustomRole{
+			AbstractTransactionModel:    ddl.AbstractTransactionModel{ID: id, HashKey: hashKey, CompanyID: 1},
+			AbstractTransactionFlgModel: ddl.AbstractTransactionFlgModel{EditFlg: editFlg},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		hashKey string
+		wantErr *response.Error
+	}{
+		// ng 保護されたロール
+		{"ng_protected", "role_admin", &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_PROTECTED,
+		}},
+		// ng 操作者が保持していない権限を含むロール
+		{"ng_exceed", "role_write", &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_EXCEED,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 操作者(user_1)のロールはマスタ1・3のみ保持
+			redis := newMemoryRedis()
+			for key, value := range map[string]string{
+				static.REDIS_USER_COMPANY_ID: "1",
+				static.REDIS_USER_ROLE:       "100",
+				static.REDIS_USER_LOGIN_TYPE: "1",
+			} {
+				value := value
+				_ = redis.Set(context.Background(), "user_1", key, &value, 0)
+			}
+			db := newMockDB()
+			s := &SCIMService{
+				scim: &mockSCIMRepo{},
+				user: &mockSCIMUserRepo{user: entity.User{User: ddl.User{
+					AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 1, HashKey: "user_1", CompanyID: 1},
+				}}},
+				role: &mockRoleRepo{
+					granted: map[uint64][]uint{
+						100: {1, 3},
+						300: {1, 3},
+						400: {1, 2},
+					},
+					roles: map[string]*entity.CustomRole{
+						"role_admin": newRole(300, "role_admin", static.ON),
+						"role_write": newRole(400, "role_write", static.OFF),
+					},
+				},
+				v:     validator.NewSCIMValidator(),
+				db:    db,
+				redis: redis,
+			}
+
+			req := &request.IssueSCIMToken{RoleHashKey: tt.hashKey}
+			req.UserHashKey = "user_1"
+			res, err := s.IssueToken(req)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("IssueToken() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if res != nil || db.started != 0 {
+				t.Errorf("res = %+v, transaction = %+v, want none", res, db)
+			}
+		})
+	}
+}
+
+func TestSCIMService_DeleteUser(t *testing.T) {
+	users := &mockSCIMUserRepo{user: entity.User{User: ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{ID: 1, HashKey: "user_1", CompanyID: 1},
+	}}}
+	s := &SCIMService{
+		scim: &mockSCIMRepo{},
+		user: users,
+		db:   newMockDB(),
+	}
+
+	// ng 応募者が紐づくユーザーは削除不可 ※無効化は可能
+	err := s.DeleteUser(&request.SCIMResource{
+		SCIMAbstract: request.SCIMAbstract{Token: "token_a"},
+		ID:           "user_1",
+	})
+	if err == nil || err.HTTPStatus != http.StatusConflict || err.Detail != static.SCIM_DETAIL_USER_HAS_APPLICANT {
+		t.Errorf("DeleteUser() error = %+v, want 409", err)
+	}
+	if users.deleted {
+		t.Errorf("user deleted")
+	}
+}
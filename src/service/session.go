@@ -0,0 +1,88 @@
+package service
+
+import (
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ランダムトークン生成(16進数)
+func newSessionToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// リフレッシュトークンのハッシュ化 ※DBには平文を保持しない
+func sessionTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// セッションキー(Redis)
+func sessionKey(sessionID string) string {
+	return static.REDIS_SESSION_PRE + sessionID
+}
+
+// リフレッシュトークンCookie
+func refreshCookie(token string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     static.REFRESH_TOKEN_COOKIE,
+		Value:    token,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteDefaultMode,
+		Path:     static.REFRESH_TOKEN_PATH,
+	}
+}
+
+// 破棄用Cookie(使えないcookieに更新)
+func expiredCookie(name string, path string) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteDefaultMode,
+		Path:     path,
+	}
+}
+
+// ユーザーのセッション失効 ※コミット後にclearSessionsでRedisを破棄すること
+func revokeSessions(tx *gorm.DB, user repository.IUserRepository, ids []uint64) ([]string, error) {
+	sessionIDs, err := user.ListSessionID(ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.RevokeSessionByUser(tx, ids); err != nil {
+		return nil, err
+	}
+	return sessionIDs, nil
+}
+
+// セッション破棄(Redis) ※ユーザーセッションも破棄し再ログインを必須とする
+func clearSessions(redis repository.IRedisRepository, sessionIDs []string, hashKeys []string) {
+	ctx := context.Background()
+	for _, sessionID := range sessionIDs {
+		if err := redis.Delete(ctx, sessionKey(sessionID)); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+	for _, hashKey := range hashKeys {
+		if err := redis.Delete(ctx, hashKey); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+}
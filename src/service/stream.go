@@ -0,0 +1,139 @@
+package service
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type IStreamService interface {
+	// 購読 ※所属チームとログインユーザー宛のイベント
+	Subscribe(ctx context.Context, req *request.Stream) (<-chan string, *response.Error)
+	// 購読継続確認 ※セッション失効時は401、チーム切替時は409
+	Check(ctx context.Context, req *request.Stream) *response.Error
+}
+
+type StreamService struct {
+	redis repository.IRedisRepository
+}
+
+func NewStreamService(redis repository.IRedisRepository) IStreamService {
+	return &StreamService{redis}
+}
+
+// 購読 ※所属チームとログインユーザー宛のイベント
+func (s *StreamService) Subscribe(ctx context.Context, req *request.Stream) (<-chan string, *response.Error) {
+	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*team, 10, 64)
+	if teamIDErr != nil {
+		log.Printf("%v", teamIDErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	req.TeamID = teamID
+
+	user, userErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_ID)
+	if userErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	userID, userIDErr := strconv.ParseUint(*user, 10, 64)
+	if userIDErr != nil {
+		log.Printf("%v", userIDErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	messages, err := s.redis.Subscribe(ctx, []string{
+		teamChannel(teamID),
+		userChannel(userID),
+	})
+	if err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return messages, nil
+}
+
+// 購読継続確認 ※セッション失効時は401、チーム切替時は409
+func (s *StreamService) Check(ctx context.Context, req *request.Stream) *response.Error {
+	// ログインの一時的セッション・端末毎セッションが失効していないか
+	// ※有効期限は更新しない(接続維持のみでセッションを延長しない)
+	if _, err := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_HASH_KEY); err != nil {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+	owner, ownerErr := s.redis.Get(ctx, sessionKey(req.SessionID), static.REDIS_USER_HASH_KEY)
+	if req.SessionID == "" || ownerErr != nil || *owner != req.UserHashKey {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+
+	// 購読中のチームから切り替えていないか
+	team, teamErr := s.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamErr != nil {
+		return &response.Error{
+			Status: http.StatusUnauthorized,
+			Code:   static.CODE_LOGIN_REQUIRED,
+		}
+	}
+	if *team != strconv.FormatUint(req.TeamID, 10) {
+		return &response.Error{
+			Status: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
+
+func teamChannel(teamID uint64) string {
+	return static.STREAM_CHANNEL_TEAM + "_" + strconv.FormatUint(teamID, 10)
+}
+
+func userChannel(userID uint64) string {
+	return static.STREAM_CHANNEL_USER + "_" + strconv.FormatUint(userID, 10)
+}
+
+// チーム宛イベント配信 ※コミット後に実行、失敗しても処理は継続
+func publishTeamEvent(redis repository.IRedisRepository, teamID uint64, m *response.StreamEvent) {
+	message, err := json.Marshal(m)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	_ = redis.Publish(context.Background(), teamChannel(teamID), string(message))
+}
+
+// 通知配信 ※コミット後に実行、失敗しても処理は継続
+func publishNotice(redis repository.IRedisRepository, userIDs []uint64) {
+	message, err := json.Marshal(&response.StreamEvent{
+		Type: static.STREAM_EVENT_NOTICE,
+	})
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	for _, userID := range userIDs {
+		_ = redis.Publish(context.Background(), userChannel(userID), string(message))
+	}
+}
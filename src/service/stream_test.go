@@ -0,0 +1,86 @@
+package service
+
+import (
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStreamService_Check(t *testing.T) {
+	unauthorized := &response.Error{
+		Status: http.StatusUnauthorized,
+		Code:   static.CODE_LOGIN_REQUIRED,
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(r *memoryRedis)
+		req     request.Stream
+		wantErr *response.Error
+	}{
+		// ok セッション有効・同一チーム
+		{"ok", func(r *memoryRedis) {}, request.Stream{
+			Abstract:  request.Abstract{UserHashKey: "user_1"},
+			SessionID: "s1",
+			TeamID:    1,
+		}, nil},
+		// ng 端末毎セッション失効
+		{"ng_session_revoked", func(r *memoryRedis) {
+			_ = r.Delete(context.Background(), sessionKey("s1"))
+		}, request.Stream{
+			Abstract:  request.Abstract{UserHashKey: "user_1"},
+			SessionID: "s1",
+			TeamID:    1,
+		}, unauthorized},
+		// ng ログアウト済み
+		{"ng_logout", func(r *memoryRedis) {
+			_ = r.Delete(context.Background(), "user_1")
+		}, request.Stream{
+			Abstract:  request.Abstract{UserHashKey: "user_1"},
+			SessionID: "s1",
+			TeamID:    1,
+		}, unauthorized},
+		// ng 他ユーザーのセッション
+		{"ng_session_owner", func(r *memoryRedis) {}, request.Stream{
+			Abstract:  request.Abstract{UserHashKey: "user_2"},
+			SessionID: "s1",
+			TeamID:    1,
+		}, unauthorized},
+		// ng セッションIDなし
+		{"ng_session_empty", func(r *memoryRedis) {}, request.Stream{
+			Abstract: request.Abstract{UserHashKey: "user_1"},
+			TeamID:   1,
+		}, unauthorized},
+		// ng チーム切替
+		{"ng_team_changed", func(r *memoryRedis) {}, request.Stream{
+			Abstract:  request.Abstract{UserHashKey: "user_1"},
+			SessionID: "s1",
+			TeamID:    2,
+		}, &response.Error{Status: http.StatusConflict}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := newMemoryRedis()
+			for hashKey, values := range map[string]map[string]string{
+				"user_1":         {static.REDIS_USER_HASH_KEY: "user_1", static.REDIS_USER_TEAM_ID: "1"},
+				"user_2":         {static.REDIS_USER_HASH_KEY: "user_2", static.REDIS_USER_TEAM_ID: "1"},
+				sessionKey("s1"): {static.REDIS_USER_HASH_KEY: "user_1"},
+			} {
+				for key, value := range values {
+					value := value
+					_ = redis.Set(context.Background(), hashKey, key, &value, 0)
+				}
+			}
+			tt.setup(redis)
+
+			s := &StreamService{redis: redis}
+			if err := s.Check(context.Background(), &tt.req); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Check() error = %+v, want %+v", err, tt.wantErr)
+			}
+		})
+	}
+}
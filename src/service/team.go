@@ -36,20 +36,30 @@ type ITeamService interface {
 	StatusEvents(req *request.StatusEventsByTeam) (*response.StatusEventsByTeam, *response.Error)
 	// 面接過程マスタ一覧
 	ListInterviewProcessing() (*response.ListInterviewProcessing, *response.Error)
+	// 面接予約枠設定取得
+	GetBookingWindow(req *request.GetBookingWindow) (*response.GetBookingWindow, *response.Error)
+	// 面接予約枠設定更新
+	UpdateBookingWindow(req *request.UpdateBookingWindow) *response.Error
+	// 面接予約枠設定削除
+	DeleteBookingWindow(req *request.DeleteBookingWindow) *response.Error
+	// 定休日設定取得
+	GetWeekday(req *request.GetTeamWeekday) (*response.GetTeamWeekday, *response.Error)
+	// 定休日設定更新
+	UpdateWeekday(req *request.UpdateTeamWeekday) *response.Error
 }
 
 type TeamService struct {
-	db         repository.IDBRepository
-	redis      repository.IRedisRepository
-	user       repository.IUserRepository
-	team       repository.ITeamRepository
-	schedule   repository.IScheduleRepository
-	applicant  repository.IApplicantRepository
-	role       repository.IRoleRepository
-	manuscript repository.IManuscriptRepository
-	master     repository.IMasterRepository
-	v          validator.ITeamValidator
-	outer      repository.IOuterIFRepository
+	db           repository.IDBRepository
+	redis        repository.IRedisRepository
+	user         repository.IUserRepository
+	team         repository.ITeamRepository
+	schedule     repository.IScheduleRepository
+	applicant    repository.IApplicantRepository
+	role         repository.IRoleRepository
+	manuscript   repository.IManuscriptRepository
+	master       repository.IMasterRepository
+	v            validator.ITeamValidator
+	operationLog repository.IOperationLogRepository
 }
 
 func NewTeamService(
@@ -63,9 +73,9 @@ func NewTeamService(
 	manuscript repository.IManuscriptRepository,
 	master repository.IMasterRepository,
 	v validator.ITeamValidator,
-	outer repository.IOuterIFRepository,
+	operationLog repository.IOperationLogRepository,
 ) ITeamService {
-	return &TeamService{db, redis, user, team, schedule, applicant, role, manuscript, master, v, outer}
+	return &TeamService{db, redis, user, team, schedule, applicant, role, manuscript, master, v, operationLog}
 }
 
 // 検索
@@ -457,6 +467,24 @@ func (u *TeamService) Create(req *request.CreateTeam) *response.Error {
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      team.ID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_CREATE,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"name": req.Name, "num_of_interview": req.NumOfInterview, "users": req.Users},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -589,6 +617,24 @@ func (u *TeamService) Update(req *request.UpdateTeam) *response.Error {
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      team.ID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_UPDATE,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"name": req.Name, "num_of_interview": req.NumOfInterview, "users": req.Users},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -706,7 +752,7 @@ func (u *TeamService) UpdateBasic(req *request.UpdateBasicTeam) *response.Error
 			}
 		}
 	} else if team.NumOfInterview > updateTeam.NumOfInterview {
-		// 面接毎設定 & 面接毎参加可能者削除
+		// 面接毎設定 & 面接毎参加可能者 & 面接予約枠設定削除
 		if err := u.team.DeletePerInterviewByNum(tx, &ddl.TeamPerInterview{
 			TeamID:         team.ID,
 			NumOfInterview: updateTeam.NumOfInterview,
@@ -733,6 +779,19 @@ func (u *TeamService) UpdateBasic(req *request.UpdateBasicTeam) *response.Error
 				Status: http.StatusInternalServerError,
 			}
 		}
+		if err := u.team.DeleteBookingWindowByNum(tx, &ddl.TeamBookingWindow{
+			TeamID:         team.ID,
+			NumOfInterview: updateTeam.NumOfInterview,
+		}); err != nil {
+			if err := u.db.TxRollback(tx); err != nil {
+				return &response.Error{
+					Status: http.StatusInternalServerError,
+				}
+			}
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
 
 		// 選考ステータス紐づけ削除
 		if err := u.team.DeleteEventEachInterviewAssociationByNum(tx, &ddl.TeamEventEachInterview{
@@ -750,6 +809,24 @@ func (u *TeamService) UpdateBasic(req *request.UpdateBasicTeam) *response.Error
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      team.ID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_UPDATE_BASIC,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"name": req.Name, "num_of_interview": req.NumOfInterview},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -993,6 +1070,23 @@ func (u *TeamService) Delete(req *request.DeleteTeam) *response.Error {
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_DELETE,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"name": team.Name},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -1089,3 +1183,441 @@ func (u *TeamService) ListInterviewProcessing() (*response.ListInterviewProcessi
 		List: list,
 	}, nil
 }
+
+// 面接予約枠設定取得 ※指定の面接回数の設定がない場合は継承元(チーム既定 → システム既定)を返却
+func (u *TeamService) GetBookingWindow(req *request.GetBookingWindow) (*response.GetBookingWindow, *response.Error) {
+	// ID取得
+	ctx := context.Background()
+	t, teamRedisErr := u.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamRedisErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*t, 10, 64)
+	if teamIDErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 取得
+	windows, windowsErr := u.team.ListBookingWindow(&ddl.TeamBookingWindow{
+		TeamID:         teamID,
+		NumOfInterview: req.NumOfInterview,
+	})
+	if windowsErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	hours, hoursErr := u.team.ListBookingHours(&ddl.TeamBookingHours{
+		TeamID:         teamID,
+		NumOfInterview: req.NumOfInterview,
+	})
+	if hoursErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	window, windowHours := selectBookingWindow(teamID, req.NumOfInterview, windows, hours)
+
+	inherited := true
+	for _, row := range windows {
+		if row.NumOfInterview == req.NumOfInterview {
+			inherited = false
+		}
+	}
+
+	var resHours []entity.TeamBookingHours
+	for _, row := range windowHours {
+		resHours = append(resHours, entity.TeamBookingHours{
+			TeamBookingHours: ddl.TeamBookingHours{
+				NumOfInterview: req.NumOfInterview,
+				Weekday:        row.Weekday,
+				StartMinute:    row.StartMinute,
+				EndMinute:      row.EndMinute,
+			},
+		})
+	}
+
+	return &response.GetBookingWindow{
+		Window: entity.TeamBookingWindow{
+			TeamBookingWindow: ddl.TeamBookingWindow{
+				NumOfInterview: req.NumOfInterview,
+				TimeZone:       window.TimeZone,
+				Duration:       window.Duration,
+				SlotInterval:   window.SlotInterval,
+				LeadTime:       window.LeadTime,
+				Horizon:        window.Horizon,
+				Buffer:         window.Buffer,
+			},
+		},
+		Hours:     resHours,
+		Inherited: inherited,
+	}, nil
+}
+
+// 面接予約枠設定更新 ※受付時間は全曜日分を置換
+func (u *TeamService) UpdateBookingWindow(req *request.UpdateBookingWindow) *response.Error {
+	// バリデーション
+	if err := u.v.UpdateBookingWindow(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// ID取得
+	ctx := context.Background()
+	t, teamRedisErr := u.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamRedisErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*t, 10, 64)
+	if teamIDErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 取得
+	team, teamErr := u.team.GetByPrimary(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: teamID,
+		},
+	})
+	if teamErr != nil {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 最大面接回数を超える場合は不可
+	if req.NumOfInterview > team.NumOfInterview {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 削除
+	if err := u.team.DeleteBookingWindowByPrimary(tx, &ddl.TeamBookingWindow{
+		TeamID:         teamID,
+		NumOfInterview: req.NumOfInterview,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	if err := u.team.InsertBookingWindow(tx, &ddl.TeamBookingWindow{
+		TeamID:         teamID,
+		NumOfInterview: req.NumOfInterview,
+		TimeZone:       req.TimeZone,
+		Duration:       req.Duration,
+		SlotInterval:   req.SlotInterval,
+		LeadTime:       req.LeadTime,
+		Horizon:        req.Horizon,
+		Buffer:         req.Buffer,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	var hours []*ddl.TeamBookingHours
+	for _, row := range req.Hours {
+		hours = append(hours, &ddl.TeamBookingHours{
+			TeamID:         teamID,
+			NumOfInterview: req.NumOfInterview,
+			Weekday:        row.Weekday,
+			StartMinute:    row.StartMinute,
+			EndMinute:      row.EndMinute,
+		})
+	}
+	if err := u.team.InsertsBookingHours(tx, hours); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      teamID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_UPDATE_BOOKING,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"num_of_interview": req.NumOfInterview, "time_zone": req.TimeZone, "duration": req.Duration, "slot_interval": req.SlotInterval, "lead_time": req.LeadTime, "horizon": req.Horizon, "buffer": req.Buffer},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 面接予約枠設定削除
+func (u *TeamService) DeleteBookingWindow(req *request.DeleteBookingWindow) *response.Error {
+	// ID取得
+	ctx := context.Background()
+	t, teamRedisErr := u.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamRedisErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*t, 10, 64)
+	if teamIDErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 取得
+	team, teamErr := u.team.GetByPrimary(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: teamID,
+		},
+	})
+	if teamErr != nil {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.team.DeleteBookingWindowByPrimary(tx, &ddl.TeamBookingWindow{
+		TeamID:         teamID,
+		NumOfInterview: req.NumOfInterview,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      teamID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_UPDATE_BOOKING,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"num_of_interview": req.NumOfInterview},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+// 定休日設定取得 ※チームの設定がない場合はシステム既定(土日)を返却
+func (u *TeamService) GetWeekday(req *request.GetTeamWeekday) (*response.GetTeamWeekday, *response.Error) {
+	// ID取得
+	ctx := context.Background()
+	t, teamRedisErr := u.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamRedisErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*t, 10, 64)
+	if teamIDErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 取得
+	rows, rowsErr := u.team.ListWeekday(&ddl.TeamWeekday{
+		TeamID: teamID,
+	})
+	if rowsErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	closed, inherited := closedWeekdays(rows)
+	res := response.GetTeamWeekday{
+		ClosedWeekdays: []uint{},
+		Inherited:      inherited,
+	}
+	for weekday, ok := range closed {
+		if ok {
+			res.ClosedWeekdays = append(res.ClosedWeekdays, uint(weekday))
+		}
+	}
+
+	return &res, nil
+}
+
+// 定休日設定更新 ※全曜日分を置換
+func (u *TeamService) UpdateWeekday(req *request.UpdateTeamWeekday) *response.Error {
+	// バリデーション
+	if err := u.v.UpdateWeekday(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// ID取得
+	ctx := context.Background()
+	t, teamRedisErr := u.redis.Get(ctx, req.UserHashKey, static.REDIS_USER_TEAM_ID)
+	if teamRedisErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	teamID, teamIDErr := strconv.ParseUint(*t, 10, 64)
+	if teamIDErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 取得
+	team, teamErr := u.team.GetByPrimary(&ddl.Team{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			ID: teamID,
+		},
+	})
+	if teamErr != nil {
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	var closed [7]bool
+	for _, weekday := range req.ClosedWeekdays {
+		closed[weekday] = true
+	}
+	var rows []*ddl.TeamWeekday
+	for weekday, ok := range closed {
+		rows = append(rows, &ddl.TeamWeekday{
+			TeamID:  teamID,
+			Weekday: uint(weekday),
+			Closed:  ok,
+		})
+	}
+
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 削除
+	if err := u.team.DeleteWeekday(tx, &ddl.TeamWeekday{
+		TeamID: teamID,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 登録
+	if err := u.team.InsertsWeekday(tx, rows); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      teamID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_UPDATE_WEEKDAY,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"closed_weekdays": req.ClosedWeekdays},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
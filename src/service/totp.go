@@ -0,0 +1,162 @@
+package service
+
+import (
+	"api/src/model/static"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// シークレット生成(Base32)
+func newTOTPSecret() (string, error) {
+	buf := make([]byte, static.TOTP_SECRET_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// 時間ステップ
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(static.TOTP_PERIOD/time.Second)
+}
+
+// 認証コード算出 ※RFC 4226 動的切り捨て
+func totpCode(secret []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < static.TOTP_DIGITS; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", static.TOTP_DIGITS, bin%mod)
+}
+
+// 認証コード検証 ※一致したステップを返却
+func verifyTOTP(secret string, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := totpStep(now)
+	for step := current - static.TOTP_SKEW; step <= current+static.TOTP_SKEW; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// プロビジョニングURI ※クライアントでQRコード化する
+func totpURI(account string, secret string) string {
+	label := url.PathEscape(static.TOTP_ISSUER + ":" + account)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", static.TOTP_ISSUER)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(static.TOTP_DIGITS))
+	query.Set("period", fmt.Sprint(int64(static.TOTP_PERIOD/time.Second)))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// シークレット暗号化用AEAD ※鍵は環境変数から導出
+func secretAEAD(keyName string) (cipher.AEAD, error) {
+	key := os.Getenv(keyName)
+	if key == "" {
+		return nil, fmt.Errorf("%s is not set", keyName)
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// シークレット暗号化 ※nonce＋暗号文をBase64で保持
+func encryptSecret(keyName string, secret string) (string, error) {
+	aead, err := secretAEAD(keyName)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// シークレット復号
+func decryptSecret(keyName string, encrypted string) (string, error) {
+	aead, err := secretAEAD(keyName)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid encrypted secret")
+	}
+	nonce, body := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, body, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// 認証アプリシークレット暗号化
+func encryptTOTPSecret(secret string) (string, error) {
+	return encryptSecret(static.TOTP_ENCRYPTION_KEY, secret)
+}
+
+// 認証アプリシークレット復号
+func decryptTOTPSecret(encrypted string) (string, error) {
+	return decryptSecret(static.TOTP_ENCRYPTION_KEY, encrypted)
+}
+
+// リカバリーコード生成 ※表示用に5桁ずつ区切る
+func newRecoveryCodes() ([]string, error) {
+	var codes []string
+	for i := 0; i < static.RECOVERY_CODE_COUNT; i++ {
+		code, err := newSessionToken(static.RECOVERY_CODE_BYTES)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code[:len(code)/2]+"-"+code[len(code)/2:])
+	}
+	return codes, nil
+}
+
+// リカバリーコードのハッシュ化 ※区切り・大文字小文字は無視
+func recoveryCodeHash(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return sessionTokenHash(normalized)
+}
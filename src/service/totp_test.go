@@ -0,0 +1,85 @@
+package service
+
+import (
+	"api/src/model/static"
+	"testing"
+	"time"
+)
+
+// RFC 6238 Appendix B (SHA1) ※6桁のため下位6桁で比較
+func TestTotpCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{"ok_59", 59, "287082"},
+		{"ok_1111111109", 1111111109, "081804"},
+		{"ok_1111111111", 1111111111, "050471"},
+		{"ok_1234567890", 1234567890, "005924"},
+		{"ok_2000000000", 2000000000, "279037"},
+		{"ok_20000000000", 20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := totpCode(secret, totpStep(time.Unix(tt.unix, 0))); got != tt.want {
+				t.Errorf("totpCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	// "12345678901234567890"のBase32
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	now := time.Unix(1111111111, 0)
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOk   bool
+	}{
+		// ok 現在のステップ
+		{"ok_current", "050471", totpStep(now), true},
+		// ok 1ステップ前 ※時刻ずれ許容
+		{"ok_previous", totpCode([]byte("12345678901234567890"), totpStep(now)-1), totpStep(now) - 1, true},
+		// ng 許容範囲外
+		{"ng_out_of_window", totpCode([]byte("12345678901234567890"), totpStep(now)-2), 0, false},
+		// ng 不一致
+		{"ng_invalid", "000000", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := verifyTOTP(secret, tt.code, now)
+			if ok != tt.wantOk || step != tt.wantStep {
+				t.Errorf("verifyTOTP() = (%v, %v), want (%v, %v)", step, ok, tt.wantStep, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestEncryptTOTPSecret(t *testing.T) {
+	t.Setenv(static.TOTP_ENCRYPTION_KEY, "test_key")
+
+	encrypted, err := encryptTOTPSecret("GEZDGNBVGY3TQOJQ")
+	if err != nil {
+		t.Fatalf("encryptTOTPSecret() error = %v", err)
+	}
+	if encrypted == "GEZDGNBVGY3TQOJQ" {
+		t.Fatalf("encryptTOTPSecret() stored plain secret")
+	}
+	plain, err := decryptTOTPSecret(encrypted)
+	if err != nil {
+		t.Fatalf("decryptTOTPSecret() error = %v", err)
+	}
+	if plain != "GEZDGNBVGY3TQOJQ" {
+		t.Errorf("decryptTOTPSecret() = %v, want %v", plain, "GEZDGNBVGY3TQOJQ")
+	}
+
+	// ng 別の鍵では復号不可
+	t.Setenv(static.TOTP_ENCRYPTION_KEY, "other_key")
+	if _, err := decryptTOTPSecret(encrypted); err == nil {
+		t.Errorf("decryptTOTPSecret() with other key error = nil")
+	}
+}
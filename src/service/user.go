@@ -10,10 +10,14 @@ import (
 	"api/src/repository"
 	"api/src/validator"
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
 )
 
 type IUserService interface {
@@ -37,6 +41,12 @@ type IUserService interface {
 	OccupationMaster() (*response.Occupation, *response.Error)
 	// 削除
 	Delete(req *request.DeleteUser) *response.Error
+	// 認証アプリリセット
+	ResetTOTP(req *request.ResetUserTOTP) *response.Error
+	// パスワード再発行 ※次回ログイン時にパスワード変更を必須とする
+	ReissuePassword(req *request.ReissueUserPassword) (*response.ReissueUserPassword, *response.Error)
+	// ログインロック解除
+	Unlock(req *request.UnlockUser) *response.Error
 }
 
 type UserService struct {
@@ -50,8 +60,11 @@ type UserService struct {
 	validator     validator.IUserValidator
 	validatorTeam validator.ITeamValidator
 	db            repository.IDBRepository
-	outer         repository.IOuterIFRepository
 	redis         repository.IRedisRepository
+	mail          repository.IMailRepository
+	operationLog  repository.IOperationLogRepository
+	lockout       repository.ILoginLockoutRepository
+	company       repository.ICompanyRepository
 }
 
 func NewUserService(
@@ -65,10 +78,13 @@ func NewUserService(
 	validator validator.IUserValidator,
 	validatorTeam validator.ITeamValidator,
 	db repository.IDBRepository,
-	outer repository.IOuterIFRepository,
 	redis repository.IRedisRepository,
+	mail repository.IMailRepository,
+	operationLog repository.IOperationLogRepository,
+	lockout repository.ILoginLockoutRepository,
+	company repository.ICompanyRepository,
 ) IUserService {
-	return &UserService{user, team, schedule, role, applicant, manuscript, master, validator, validatorTeam, db, outer, redis}
+	return &UserService{user, team, schedule, role, applicant, manuscript, master, validator, validatorTeam, db, redis, mail, operationLog, lockout, company}
 }
 
 // 登録
@@ -149,20 +165,33 @@ func (u *UserService) Create(req *request.CreateUser) (*response.CreateUser, *re
 		}
 	}
 
-	// ロール取得
+	// ロール取得 ※自社のロールのみ
 	role, roleErr := u.role.Get(&ddl.CustomRole{
 		AbstractTransactionModel: ddl.AbstractTransactionModel{
-			HashKey: req.RoleHashKey,
+			HashKey:   req.RoleHashKey,
+			CompanyID: companyID,
 		},
 	})
 	if roleErr != nil {
+		if roleErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
+	if err := checkAssignableRole(u.redis, u.role, req.HashKey, &role.CustomRole); err != nil {
+		return nil, err
+	}
 
-	// 初回パスワード発行
-	password, hashPassword, passwordErr := GenerateHash(8, 16)
+	// 初回パスワード発行 ※企業のパスワードポリシーを満たすこと
+	policy, policyErr := companyPasswordPolicy(u.company, companyID)
+	if policyErr != nil {
+		return nil, policyErr
+	}
+	password, hashPassword, passwordErr := GeneratePassword(policy)
 	if passwordErr != nil {
 		log.Printf("%v", passwordErr)
 		return nil, &response.Error{
@@ -210,6 +239,21 @@ func (u *UserService) Create(req *request.CreateUser) (*response.CreateUser, *re
 		}
 	}
 
+	// パスワード履歴登録
+	if err := u.user.InsertPasswordHistory(tx, &ddl.UserPasswordHistory{
+		UserID:   user.ID,
+		Password: *hashPassword,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// チーム紐づけ一括登録
 	var teamAssociations []*ddl.TeamAssociation
 	for _, id := range ids {
@@ -282,17 +326,44 @@ func (u *UserService) Create(req *request.CreateUser) (*response.CreateUser, *re
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.HashKey,
+		EventID:     static.OPERATION_LOG_EVENT_USER_CREATE,
+		Target:      user.HashKey,
+		Detail:      map[string]interface{}{"name": req.Name, "email": req.Email, "teams": req.Teams, "role": req.RoleHashKey},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return nil, &response.Error{
 			Status: http.StatusInternalServerError,
 		}
 	}
 
+	// 初回パスワード送信 ※平文のパスワードはメールでのみ通知し、レスポンスには含めない
+	if err := u.mail.Send(&dto.Mail{
+		CompanyID: companyID,
+		Kind:      static.MAIL_KIND_INIT_PASSWORD_USER,
+		To:        user.Email,
+		Subject:   static.MAIL_SUBJECT_INIT_PASSWORD,
+		Body:      fmt.Sprintf(static.MAIL_BODY_INIT_PASSWORD, user.Name, user.Email, *password),
+	}); err != nil {
+		log.Printf("%v", err)
+	}
+
 	res := response.CreateUser{
 		User: entity.User{
 			User: ddl.User{
-				Email:        user.Email,
-				InitPassword: *password,
+				Email: user.Email,
 			},
 		},
 	}
@@ -718,6 +789,24 @@ func (u *UserService) UpdateAssignMethod(req *request.UpdateAssignMethod) *respo
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		TeamID:      team.ID,
+		EventID:     static.OPERATION_LOG_EVENT_TEAM_UPDATE_ASSIGN,
+		Target:      team.HashKey,
+		Detail:      map[string]interface{}{"user_min": req.UserMin, "rule": req.RuleHash, "auto_rule": req.AutoRuleHash, "priority": req.Priority},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
 			Status: http.StatusInternalServerError,
@@ -816,6 +905,14 @@ func (u *UserService) Delete(req *request.DeleteUser) *response.Error {
 		}
 	}
 
+	// ログイン中セッション取得 ※削除後に強制ログアウト
+	sessionIDs, sessionIDsErr := u.user.ListSessionID(ids)
+	if sessionIDsErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// トランザクションの開始
 	tx, txErr := u.db.TxStart()
 	if txErr != nil {
@@ -884,6 +981,78 @@ func (u *UserService) Delete(req *request.DeleteUser) *response.Error {
 		}
 	}
 
+	// セッショントークン削除
+	if err := u.user.DeleteSessionToken(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// カレンダー購読トークン削除
+	if err := u.user.DeleteCalendarToken(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 認証アプリ削除
+	if err := u.user.DeleteTOTP(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// リカバリーコード削除
+	if err := u.user.DeleteRecoveryCode(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワード履歴削除
+	if err := u.user.DeletePasswordHistory(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// シングルサインオン紐づけ削除
+	if err := u.user.DeleteOIDC(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// ユーザー削除
 	if err := u.user.Delete(tx, req.HashKeys); err != nil {
 		if err := u.db.TxRollback(tx); err != nil {
@@ -896,6 +1065,23 @@ func (u *UserService) Delete(req *request.DeleteUser) *response.Error {
 		}
 	}
 
+	// 操作ログ
+	if err := writeOperationLog(tx, u.operationLog, u.redis, &dto.OperationLog{
+		UserHashKey: req.UserHashKey,
+		EventID:     static.OPERATION_LOG_EVENT_USER_DELETE,
+		Target:      strings.Join(req.HashKeys, ","),
+		Detail:      map[string]interface{}{"num": len(req.HashKeys)},
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
 	// トランザクションのコミット
 	if err := u.db.TxCommit(tx); err != nil {
 		return &response.Error{
@@ -903,6 +1089,316 @@ func (u *UserService) Delete(req *request.DeleteUser) *response.Error {
 		}
 	}
 
+	// 強制ログアウト
+	clearSessions(u.redis, sessionIDs, req.HashKeys)
+
 	return nil
 
 }
+
+// 認証アプリリセット ※端末紛失時、次回ログインで再登録
+func (u *UserService) ResetTOTP(req *request.ResetUserTOTP) *response.Error {
+	// バリデーション
+	if err := u.validator.ResetTOTP(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者と対象ユーザーが同一企業であること
+	operator, operatorErr := u.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if operatorErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	target, targetErr := u.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.HashKey,
+		},
+	})
+	if targetErr != nil {
+		if targetErr == gorm.ErrRecordNotFound {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if operator.CompanyID != target.CompanyID {
+		return &response.Error{
+			Status: http.StatusForbidden,
+		}
+	}
+
+	ids := []uint64{target.ID}
+
+	// ログイン中セッション取得 ※リセット後に強制ログアウト
+	sessionIDs, sessionIDsErr := u.user.ListSessionID(ids)
+	if sessionIDsErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 認証アプリ削除
+	if err := u.user.DeleteTOTP(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// リカバリーコード削除
+	if err := u.user.DeleteRecoveryCode(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// セッション失効
+	if err := u.user.RevokeSessionByUser(tx, ids); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.db.TxCommit(tx); err != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// 強制ログアウト
+	clearSessions(u.redis, sessionIDs, []string{target.HashKey})
+
+	return nil
+}
+
+// パスワード再発行
+func (u *UserService) ReissuePassword(req *request.ReissueUserPassword) (*response.ReissueUserPassword, *response.Error) {
+	// バリデーション
+	if err := u.validator.ReissuePassword(req); err != nil {
+		log.Printf("%v", err)
+		return nil, &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者と対象ユーザーが同一企業であること
+	operator, operatorErr := u.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if operatorErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	target, targetErr := u.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.HashKey,
+		},
+	})
+	if targetErr != nil {
+		if targetErr == gorm.ErrRecordNotFound {
+			return nil, &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if operator.CompanyID != target.CompanyID {
+		return nil, &response.Error{
+			Status: http.StatusForbidden,
+		}
+	}
+
+	// 仮パスワード発行
+	policy, policyErr := companyPasswordPolicy(u.company, target.CompanyID)
+	if policyErr != nil {
+		return nil, policyErr
+	}
+	password, hashPassword, passwordErr := GeneratePassword(policy)
+	if passwordErr != nil {
+		log.Printf("%v", passwordErr)
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	tx, txErr := u.db.TxStart()
+	if txErr != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワードと初回パスワードを同一にし、次回ログイン時に変更を必須とする
+	if err := u.user.Update(tx, &ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: target.HashKey,
+		},
+		Password:     *hashPassword,
+		InitPassword: *hashPassword,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// パスワード履歴登録
+	if err := u.user.InsertPasswordHistory(tx, &ddl.UserPasswordHistory{
+		UserID:   target.ID,
+		Password: *hashPassword,
+	}); err != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// セッション失効
+	sessionIDs, revokeErr := revokeSessions(tx, u.user, []uint64{target.ID})
+	if revokeErr != nil {
+		if err := u.db.TxRollback(tx); err != nil {
+			return nil, &response.Error{
+				Status: http.StatusInternalServerError,
+			}
+		}
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	if err := u.db.TxCommit(tx); err != nil {
+		return nil, &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// Redis破棄
+	clearSessions(u.redis, sessionIDs, []string{target.HashKey})
+
+	// 仮パスワード送信 ※失敗時も再発行は確定済みのため、レスポンスで返却する
+	if err := u.mail.Send(&dto.Mail{
+		CompanyID: target.CompanyID,
+		Kind:      static.MAIL_KIND_PASSWORD_REISSUE,
+		To:        target.Email,
+		Subject:   static.MAIL_SUBJECT_PASSWORD_REISSUE,
+		Body:      fmt.Sprintf(static.MAIL_BODY_PASSWORD_REISSUE, target.Name, target.Email, *password),
+	}); err != nil {
+		log.Printf("%v", err)
+	}
+
+	return &response.ReissueUserPassword{
+		User: entity.User{
+			User: ddl.User{
+				Email:        target.Email,
+				InitPassword: *password,
+			},
+		},
+	}, nil
+}
+
+// ログインロック解除 ※ログイン・MFAのアカウント単位のロックを解除
+func (u *UserService) Unlock(req *request.UnlockUser) *response.Error {
+	// バリデーション
+	if err := u.validator.Unlock(req); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusBadRequest,
+		}
+	}
+
+	// 操作者と対象ユーザーが同一企業であること
+	operator, operatorErr := u.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.UserHashKey,
+		},
+	})
+	if operatorErr != nil {
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	target, targetErr := u.user.Get(&ddl.User{
+		AbstractTransactionModel: ddl.AbstractTransactionModel{
+			HashKey: req.HashKey,
+		},
+	})
+	if targetErr != nil {
+		if targetErr == gorm.ErrRecordNotFound {
+			return &response.Error{
+				Status: http.StatusBadRequest,
+			}
+		}
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+	if operator.CompanyID != target.CompanyID {
+		return &response.Error{
+			Status: http.StatusForbidden,
+		}
+	}
+
+	if err := unlock(
+		u.redis,
+		u.lockout,
+		[]string{
+			static.LOCKOUT_TARGET_LOGIN + strings.ToLower(target.Email),
+			static.LOCKOUT_TARGET_MFA + target.HashKey,
+			static.LOCKOUT_TARGET_PASSWORD_CHANGE + target.HashKey,
+		},
+		target.CompanyID,
+		operator.ID,
+	); err != nil {
+		log.Printf("%v", err)
+		return &response.Error{
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
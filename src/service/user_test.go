@@ -0,0 +1,115 @@
+package service
+
+import (
+	"api/src/model/ddl"
+	"api/src/model/entity"
+	"api/src/model/request"
+	"api/src/model/response"
+	"api/src/model/static"
+	"api/src/repository"
+	"api/src/validator"
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type mockCreateUserRepo struct {
+	repository.IUserRepository
+}
+
+func (r *mockCreateUserRepo) EmailDuplCheck(m *ddl.User) error {
+	return nil
+}
+
+type mockCreateTeamRepo struct {
+	repository.ITeamRepository
+}
+
+func (r *mockCreateTeamRepo) GetByHashKeys(m []string) ([]entity.Team, error) {
+	return nil, nil
+}
+
+func (r *mockCreateTeamRepo) GetAssignPriorityTeams(m []uint64) ([]*entity.TeamAssignPriority, error) {
+	return nil, nil
+}
+
+func TestUserService_Create(t *testing.T) {
+	newRole := func(id uint64, hashKey string, companyID uint64, editFlg uint) *entity.CustomRole {
+		return &entity.CustomRole{CustomRole: ddl.CustomRole{
+			AbstractTransactionModel:    ddl.AbstractTransactionModel{ID: id, HashKey: hashKey, CompanyID: companyID},
+			AbstractTransactionFlgModel: ddl.AbstractTransactionFlgModel{EditFlg: editFlg},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		hashKey string
+		wantErr *response.Error
+	}{
+		// ng 他企業のロール
+		{"ng_other_company", "role_other", &response.Error{
+			Status: http.StatusBadRequest,
+		}},
+		// ng 保護されたロール
+		{"ng_protected", "role_admin", &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_PROTECTED,
+		}},
+		// ng 操作者が保持していない権限を含むロール
+		{"ng_exceed", "role_write", &response.Error{
+			Status: http.StatusBadRequest,
+			Code:   static.CODE_ROLE_CANNOT_ASSIGN_EXCEED,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 操作者(user_1)のロールはマスタ1・3のみ保持
+			redis := newMemoryRedis()
+			for key, value := range map[string]string{
+				static.REDIS_USER_COMPANY_ID: "1",
+				static.REDIS_USER_ROLE:       "100",
+				static.REDIS_USER_LOGIN_TYPE: "1",
+			} {
+				value := value
+				_ = redis.Set(context.Background(), "user_1", key, &value, 0)
+			}
+			db := newMockDB()
+			s := &UserService{
+				user: &mockCreateUserRepo{},
+				team: &mockCreateTeamRepo{},
+				role: &mockRoleRepo{
+					granted: map[uint64][]uint{
+						100: {1, 3},
+						300: {1, 3},
+						400: {1, 2},
+						500: {1},
+					},
+					roles: map[string]*entity.CustomRole{
+						"role_admin": newRole(300, "role_admin", 1, static.ON),
+						"role_write": newRole(400, "role_write", 1, static.OFF),
+						"role_other": newRole(500, "role_other", 2, static.OFF),
+					},
+				},
+				validator: validator.NewUserValidator(),
+				db:        db,
+				redis:     redis,
+			}
+
+			res, err := s.Create(&request.CreateUser{
+				User: ddl.User{
+					AbstractTransactionModel: ddl.AbstractTransactionModel{HashKey: "user_1"},
+					Name:                     "test",
+					Email:                    "test@example.com",
+				},
+				RoleHashKey: tt.hashKey,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Create() error = %+v, want %+v", err, tt.wantErr)
+			}
+			if res != nil || db.started != 0 {
+				t.Errorf("res = %+v, transaction = %+v, want none", res, db)
+			}
+		})
+	}
+}
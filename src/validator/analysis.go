@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"api/src/model/request"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IAnalysisValidator interface {
+	// 分析
+	Analysis(m *request.Analysis) error
+}
+
+type AnalysisValidator struct{}
+
+func NewAnalysisValidator() IAnalysisValidator {
+	return &AnalysisValidator{}
+}
+
+// 分析
+func (v *AnalysisValidator) Analysis(m *request.Analysis) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.CreatedAtFrom,
+			validation.By(func(value interface{}) error {
+				return IsBeforeTime(m.CreatedAtFrom, m.CreatedAtTo)
+			}),
+		),
+	)
+}
@@ -11,10 +11,14 @@ import (
 type IApplicantValidator interface {
 	// 検索
 	Search(a *request.SearchApplicant) error
+	// 応募者エクスポート
+	Export(a *request.ExportApplicant) error
 	// 応募者ダウンロード
 	Download(a *request.ApplicantDownload) error
 	// 応募者ダウンロード_サブ構造体
 	DownloadSub(a *request.ApplicantDownloadSub) error
+	// 応募者取込取消
+	RollbackUpload(a *request.RollbackUpload) error
 	// 応募者ステータス一覧取得
 	GetStatusList(a *request.ApplicantStatusList) error
 	// 予約表表示
@@ -103,6 +107,21 @@ func (v *ApplicantValidator) Search(a *request.SearchApplicant) error {
 	)
 }
 
+// 応募者エクスポート
+func (v *ApplicantValidator) Export(a *request.ExportApplicant) error {
+	if err := v.Search(&a.SearchApplicant); err != nil {
+		return err
+	}
+	return validation.ValidateStruct(
+		a,
+		validation.Field(
+			&a.Format,
+			validation.Required,
+			validation.In(static.EXPORT_FORMAT_CSV, static.EXPORT_FORMAT_XLSX),
+		),
+	)
+}
+
 // 応募者ダウンロード
 func (v *ApplicantValidator) Download(a *request.ApplicantDownload) error {
 	return validation.ValidateStruct(
@@ -148,6 +167,17 @@ func (v *ApplicantValidator) DownloadSub(a *request.ApplicantDownloadSub) error
 	)
 }
 
+// 応募者取込取消
+func (v *ApplicantValidator) RollbackUpload(a *request.RollbackUpload) error {
+	return validation.ValidateStruct(
+		a,
+		validation.Field(
+			&a.CommitID,
+			validation.Required,
+		),
+	)
+}
+
 // 応募者ステータス一覧取得
 func (v *ApplicantValidator) GetStatusList(a *request.ApplicantStatusList) error {
 	return validation.ValidateStruct(
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"api/src/model/request"
+	"api/src/model/static"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -12,6 +13,22 @@ type ICompanyValidator interface {
 	Create(c *request.CreateCompany) error
 	// 検索
 	Search(c *request.SearchCompany) error
+	// セキュリティ設定取得
+	GetSecurity(c *request.GetCompanySecurity) error
+	// セキュリティ設定更新
+	UpdateSecurity(c *request.UpdateCompanySecurity) error
+	// シングルサインオン設定取得
+	GetOIDC(c *request.GetCompanyOIDC) error
+	// シングルサインオン設定更新
+	UpdateOIDC(c *request.UpdateCompanyOIDC) error
+	// 休日一覧
+	ListHoliday(c *request.ListCompanyHoliday) error
+	// 休日登録
+	SaveHoliday(c *request.SaveCompanyHoliday) error
+	// 休日削除
+	DeleteHoliday(c *request.DeleteCompanyHoliday) error
+	// 休日取込
+	ImportHoliday(c *request.ImportCompanyHoliday) error
 }
 
 type CompanyValidator struct{}
@@ -56,3 +73,182 @@ func (v *CompanyValidator) Search(c *request.SearchCompany) error {
 		),
 	)
 }
+
+// セキュリティ設定取得
+func (v *CompanyValidator) GetSecurity(c *request.GetCompanySecurity) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+	)
+}
+
+// セキュリティ設定更新
+func (v *CompanyValidator) UpdateSecurity(c *request.UpdateCompanySecurity) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+		// 試行制限 ※0は既定値
+		validation.Field(
+			&c.LockoutThreshold,
+			validation.Min(uint(3)),
+			validation.Max(uint(20)),
+		),
+		validation.Field(
+			&c.LockoutSeconds,
+			validation.Min(uint(30)),
+			validation.Max(uint(3600)),
+		),
+		validation.Field(
+			&c.LockoutMaxSeconds,
+			validation.Min(c.LockoutSeconds),
+			validation.Max(uint(86400)),
+		),
+		// パスワードポリシー ※0は既定値
+		validation.Field(
+			&c.PasswordMinLength,
+			validation.Min(uint(8)),
+			validation.Max(uint(static.PASSWORD_MAX_LENGTH)),
+		),
+		validation.Field(
+			&c.PasswordMinClasses,
+			validation.Min(uint(1)),
+			validation.Max(uint(4)),
+		),
+		validation.Field(
+			&c.PasswordHistory,
+			validation.Min(uint(1)),
+			validation.Max(uint(24)),
+		),
+	)
+}
+
+// シングルサインオン設定取得
+func (v *CompanyValidator) GetOIDC(c *request.GetCompanyOIDC) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+	)
+}
+
+// シングルサインオン設定更新 ※パスワードログイン無効化は有効時のみ
+func (v *CompanyValidator) UpdateOIDC(c *request.UpdateCompanyOIDC) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&c.Issuer,
+			validation.When(c.Enabled, validation.Required),
+			validation.Length(0, 255),
+			is.URL,
+		),
+		validation.Field(
+			&c.ClientID,
+			validation.When(c.Enabled, validation.Required),
+			validation.Length(0, 255),
+		),
+		validation.Field(
+			&c.ClientSecret,
+			validation.Length(0, 1024),
+		),
+		validation.Field(
+			&c.DisablePassword,
+			validation.When(!c.Enabled, validation.In(false)),
+		),
+		validation.Field(
+			&c.Domains,
+			validation.When(c.Enabled, validation.Required),
+			validation.Length(0, static.OIDC_MAX_DOMAINS),
+			validation.Each(
+				validation.Required,
+				validation.Length(1, 100),
+				is.Domain,
+			),
+		),
+	)
+}
+
+// 休日一覧
+func (v *CompanyValidator) ListHoliday(c *request.ListCompanyHoliday) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&c.Year,
+			validation.Required,
+			validation.Min(2000),
+			validation.Max(2100),
+		),
+	)
+}
+
+// 休日登録
+func (v *CompanyValidator) SaveHoliday(c *request.SaveCompanyHoliday) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&c.Date,
+			validation.Required,
+			validation.Date("2006-01-02"),
+		),
+		validation.Field(
+			&c.Kind,
+			validation.Required,
+			validation.In(static.HOLIDAY_KIND_CLOSED, static.HOLIDAY_KIND_WORKING),
+		),
+		validation.Field(
+			&c.Name,
+			validation.RuneLength(0, static.HOLIDAY_NAME_MAX_LENGTH),
+		),
+	)
+}
+
+// 休日削除
+func (v *CompanyValidator) DeleteHoliday(c *request.DeleteCompanyHoliday) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&c.Date,
+			validation.Required,
+			validation.Date("2006-01-02"),
+		),
+	)
+}
+
+// 休日取込
+func (v *CompanyValidator) ImportHoliday(c *request.ImportCompanyHoliday) error {
+	return validation.ValidateStruct(
+		c,
+		validation.Field(
+			&c.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&c.Kind,
+			validation.Required,
+			validation.In(static.HOLIDAY_KIND_CLOSED, static.HOLIDAY_KIND_WORKING),
+		),
+	)
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"api/src/model/request"
+	"api/src/model/static"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IJobValidator interface {
+	// 実行履歴検索
+	SearchRun(m *request.SearchJobRun) error
+}
+
+type JobValidator struct{}
+
+func NewJobValidator() IJobValidator {
+	return &JobValidator{}
+}
+
+// 実行履歴検索
+func (v *JobValidator) SearchRun(m *request.SearchJobRun) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.Page,
+			validation.Required,
+			validation.Min(1),
+		),
+		validation.Field(
+			&m.PageSize,
+			validation.Required,
+			validation.Min(1),
+			validation.Max(100),
+		),
+		validation.Field(
+			&m.Names,
+			validation.Each(
+				validation.Required,
+				validation.In(
+					static.JOB_SCHEDULE_MAINTENANCE,
+					static.JOB_INTERVIEW_REMINDER,
+					static.JOB_STALE_CLEANUP,
+				),
+			),
+			UniqueValidator{},
+		),
+		validation.Field(
+			&m.Statuses,
+			validation.Each(
+				validation.In(
+					static.JOB_STATUS_RUNNING,
+					static.JOB_STATUS_SUCCESS,
+					static.JOB_STATUS_FAILED,
+				),
+			),
+		),
+		validation.Field(
+			&m.StartedAtFrom,
+			validation.By(func(value interface{}) error {
+				return IsBeforeTime(m.StartedAtFrom, m.StartedAtTo)
+			}),
+		),
+	)
+}
@@ -2,7 +2,9 @@ package validator
 
 import (
 	"api/src/model/request"
+	"api/src/model/static"
 	"errors"
+	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -19,24 +21,43 @@ type ILoginValidator interface {
 	JWTDecode(u *request.JWTDecode) error
 	// パスワード変更
 	PasswordChange(u *request.PasswordChange) error
+	// シングルサインオン開始
+	SSOStart(u *request.SSOStart) error
+	// シングルサインオン認証
+	SSOCallback(u *request.SSOCallback) error
+	// パスワード再設定メール送信
+	PasswordResetRequest(u *request.PasswordResetRequest) error
+	// パスワード再設定
+	PasswordReset(u *request.PasswordReset) error
 	// ログアウト
 	Logout(u *request.Logout) error
+	// 認証アプリ取得
+	GetTOTP(u *request.GetTOTP) error
+	// 認証アプリ登録
+	TOTPSetup(u *request.TOTPSetup) error
+	// 認証アプリ確認
+	TOTPConfirm(u *request.TOTPConfirm) error
+	// 認証アプリ解除
+	TOTPDisable(u *request.TOTPDisable) error
+	// リカバリーコード再発行
+	RegenerateRecoveryCode(u *request.RegenerateRecoveryCode) error
 	// ログイン種別取得
 	GetLoginType(u *request.GetLoginType) error
 	// チーム存在確認(応募者)
 	ConfirmTeamApplicant(u *request.ConfirmTeamApplicant) error
 	// ログイン(応募者)
 	LoginApplicant(u *request.LoginApplicant) error
-	// MFA 認証コード生成(応募者)
-	CodeGenerateApplicant(u *request.CodeGenerateApplicant) error
-	// MFA(応募者)
-	MFAApplicant(u *request.MFAApplicant) error
+	// ログインリンク検証(応募者)
+	MagicLinkApplicant(u *request.MagicLinkApplicant) error
 	// ログアウト(応募者)
 	LogoutApplicant(u *request.LogoutApplicant) error
 	// 応募者チェック
 	CheckApplicant(u *request.CheckApplicant) error
 }
 
+// リカバリーコード形式 ※16進数10桁、5桁毎のハイフン区切りは任意
+var recoveryCodeRegexp = regexp.MustCompile(`^[0-9a-fA-F]{5}-?[0-9a-fA-F]{5}$`)
+
 type LoginValidator struct{}
 
 func NewLoginValidator() ILoginValidator {
@@ -56,8 +77,7 @@ func (v *LoginValidator) Login(u *request.Login) error {
 		validation.Field(
 			&u.Password,
 			validation.Required,
-			validation.Length(8, 16),
-			is.Alphanumeric,
+			validation.Length(1, static.PASSWORD_MAX_LENGTH),
 		),
 	)
 }
@@ -83,10 +103,15 @@ func (v *LoginValidator) MFA(u *request.MFA) error {
 		),
 		validation.Field(
 			&u.Code,
-			validation.Required,
+			validation.When(u.RecoveryCode == "", validation.Required),
 			validation.Length(6, 6),
 			is.UTFNumeric,
 		),
+		validation.Field(
+			&u.RecoveryCode,
+			validation.Length(10, 11),
+			validation.Match(recoveryCodeRegexp),
+		),
 	)
 }
 
@@ -109,17 +134,16 @@ func (v *LoginValidator) PasswordChange(u *request.PasswordChange) error {
 			&u.HashKey,
 			validation.Required,
 		),
+		// 文字数・文字種は企業のパスワードポリシーで検証
 		validation.Field(
 			&u.Password,
 			validation.Required,
-			validation.Length(8, 16),
-			is.Alphanumeric,
+			validation.Length(1, static.PASSWORD_MAX_LENGTH),
 		),
 		validation.Field(
 			&u.InitPassword,
 			validation.Required,
-			validation.Length(8, 16),
-			is.Alphanumeric,
+			validation.Length(1, static.PASSWORD_MAX_LENGTH),
 			validation.By(func(value interface{}) error {
 				initPassword, _ := value.(string)
 				if initPassword == u.Password {
@@ -131,6 +155,67 @@ func (v *LoginValidator) PasswordChange(u *request.PasswordChange) error {
 	)
 }
 
+// シングルサインオン開始
+func (v *LoginValidator) SSOStart(u *request.SSOStart) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.Email,
+			validation.Required,
+			validation.Length(1, 100),
+			is.Email,
+		),
+	)
+}
+
+// シングルサインオン認証
+func (v *LoginValidator) SSOCallback(u *request.SSOCallback) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.Code,
+			validation.Required,
+			validation.Length(1, 2048),
+		),
+		validation.Field(
+			&u.State,
+			validation.Required,
+			validation.Length(1, 128),
+		),
+	)
+}
+
+// パスワード再設定メール送信
+func (v *LoginValidator) PasswordResetRequest(u *request.PasswordResetRequest) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.Email,
+			validation.Required,
+			validation.Length(1, 100),
+			is.Email,
+		),
+	)
+}
+
+// パスワード再設定
+func (v *LoginValidator) PasswordReset(u *request.PasswordReset) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.Token,
+			validation.Required,
+			validation.Length(static.PASSWORD_RESET_TOKEN_BYTES*2, static.PASSWORD_RESET_TOKEN_BYTES*2),
+			is.Hexadecimal,
+		),
+		validation.Field(
+			&u.Password,
+			validation.Required,
+			validation.Length(1, static.PASSWORD_MAX_LENGTH),
+		),
+	)
+}
+
 // ログアウト
 func (v *LoginValidator) Logout(u *request.Logout) error {
 	return validation.ValidateStruct(
@@ -142,8 +227,8 @@ func (v *LoginValidator) Logout(u *request.Logout) error {
 	)
 }
 
-// ログイン種別取得
-func (v *LoginValidator) GetLoginType(u *request.GetLoginType) error {
+// 認証アプリ取得
+func (v *LoginValidator) GetTOTP(u *request.GetTOTP) error {
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
@@ -153,8 +238,8 @@ func (v *LoginValidator) GetLoginType(u *request.GetLoginType) error {
 	)
 }
 
-// チーム存在確認(応募者)
-func (v *LoginValidator) ConfirmTeamApplicant(u *request.ConfirmTeamApplicant) error {
+// 認証アプリ登録
+func (v *LoginValidator) TOTPSetup(u *request.TOTPSetup) error {
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
@@ -164,36 +249,47 @@ func (v *LoginValidator) ConfirmTeamApplicant(u *request.ConfirmTeamApplicant) e
 	)
 }
 
-// ログイン(応募者)
-func (v *LoginValidator) LoginApplicant(u *request.LoginApplicant) error {
+// 認証アプリ確認
+func (v *LoginValidator) TOTPConfirm(u *request.TOTPConfirm) error {
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
-			&u.Email,
+			&u.HashKey,
 			validation.Required,
-			validation.Length(1, 100),
-			is.Email,
 		),
 		validation.Field(
-			&u.TeamHashKey,
+			&u.Code,
 			validation.Required,
+			validation.Length(6, 6),
+			is.UTFNumeric,
 		),
 	)
 }
 
-// MFA 認証コード生成(応募者)
-func (v *LoginValidator) CodeGenerateApplicant(u *request.CodeGenerateApplicant) error {
+// 認証アプリ解除
+func (v *LoginValidator) TOTPDisable(u *request.TOTPDisable) error {
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
 			&u.HashKey,
 			validation.Required,
 		),
+		validation.Field(
+			&u.Code,
+			validation.When(u.RecoveryCode == "", validation.Required),
+			validation.Length(6, 6),
+			is.UTFNumeric,
+		),
+		validation.Field(
+			&u.RecoveryCode,
+			validation.Length(10, 11),
+			validation.Match(recoveryCodeRegexp),
+		),
 	)
 }
 
-// MFA(応募者)
-func (v *LoginValidator) MFAApplicant(u *request.MFAApplicant) error {
+// リカバリーコード再発行
+func (v *LoginValidator) RegenerateRecoveryCode(u *request.RegenerateRecoveryCode) error {
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
@@ -209,6 +305,57 @@ func (v *LoginValidator) MFAApplicant(u *request.MFAApplicant) error {
 	)
 }
 
+// ログイン種別取得
+func (v *LoginValidator) GetLoginType(u *request.GetLoginType) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// チーム存在確認(応募者)
+func (v *LoginValidator) ConfirmTeamApplicant(u *request.ConfirmTeamApplicant) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// ログイン(応募者)
+func (v *LoginValidator) LoginApplicant(u *request.LoginApplicant) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.Email,
+			validation.Required,
+			validation.Length(1, 100),
+			is.Email,
+		),
+		validation.Field(
+			&u.TeamHashKey,
+			validation.Required,
+		),
+	)
+}
+
+// ログインリンク検証(応募者)
+func (v *LoginValidator) MagicLinkApplicant(u *request.MagicLinkApplicant) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.Token,
+			validation.Required,
+			validation.Length(1, 512),
+		),
+	)
+}
+
 // ログアウト(応募者)
 func (v *LoginValidator) LogoutApplicant(u *request.LogoutApplicant) error {
 	return validation.ValidateStruct(
@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"api/src/model/request"
+	"api/src/model/static"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IMailTemplateValidator interface {
+	// テンプレート登録
+	Create(m *request.CreateMailTemplate) error
+	// テンプレート更新
+	Update(m *request.UpdateMailTemplate) error
+	// テンプレート削除
+	Delete(m *request.DeleteMailTemplate) error
+	// テンプレート取得
+	Get(m *request.GetMailTemplate) error
+	// 変数登録
+	CreateVariable(m *request.CreateVariable) error
+	// 変数更新
+	UpdateVariable(m *request.UpdateVariable) error
+	// 変数削除
+	DeleteVariable(m *request.DeleteVariable) error
+	// プレビュー
+	Preview(m *request.PreviewMail) error
+	// 送信
+	Send(m *request.SendMail) error
+}
+
+type MailTemplateValidator struct{}
+
+func NewMailTemplateValidator() IMailTemplateValidator {
+	return &MailTemplateValidator{}
+}
+
+// テンプレート登録
+func (v *MailTemplateValidator) Create(m *request.CreateMailTemplate) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.Title,
+			validation.Required,
+			validation.RuneLength(1, 50),
+		),
+		validation.Field(
+			&m.Subject,
+			validation.Required,
+		),
+		validation.Field(
+			&m.Template,
+			validation.Required,
+		),
+	)
+}
+
+// テンプレート更新
+func (v *MailTemplateValidator) Update(m *request.UpdateMailTemplate) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&m.Title,
+			validation.Required,
+			validation.RuneLength(1, 50),
+		),
+		validation.Field(
+			&m.Subject,
+			validation.Required,
+		),
+		validation.Field(
+			&m.Template,
+			validation.Required,
+		),
+	)
+}
+
+// テンプレート削除
+func (v *MailTemplateValidator) Delete(m *request.DeleteMailTemplate) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// テンプレート取得
+func (v *MailTemplateValidator) Get(m *request.GetMailTemplate) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// 変数登録
+func (v *MailTemplateValidator) CreateVariable(m *request.CreateVariable) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.Title,
+			validation.Required,
+			validation.RuneLength(1, 25),
+		),
+		validation.Field(
+			&m.JsonName,
+			validation.Required,
+			validation.In(static.MailVariables...),
+		),
+	)
+}
+
+// 変数更新
+func (v *MailTemplateValidator) UpdateVariable(m *request.UpdateVariable) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&m.Title,
+			validation.Required,
+			validation.RuneLength(1, 25),
+		),
+		validation.Field(
+			&m.JsonName,
+			validation.Required,
+			validation.In(static.MailVariables...),
+		),
+	)
+}
+
+// 変数削除
+func (v *MailTemplateValidator) DeleteVariable(m *request.DeleteVariable) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// プレビュー
+func (v *MailTemplateValidator) Preview(m *request.PreviewMail) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.TemplateHash,
+			validation.Required,
+		),
+		validation.Field(
+			&m.ApplicantHash,
+			validation.Required,
+		),
+	)
+}
+
+// 送信
+func (v *MailTemplateValidator) Send(m *request.SendMail) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.TemplateHash,
+			validation.Required,
+		),
+		validation.Field(
+			&m.Applicants,
+			validation.Required,
+			validation.Length(1, 0),
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+	)
+}
@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"api/src/model/request"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type INoticeValidator interface {
+	// 検索
+	Search(m *request.SearchNotice) error
+	// 既読
+	Read(m *request.ReadNotice) error
+}
+
+type NoticeValidator struct{}
+
+func NewNoticeValidator() INoticeValidator {
+	return &NoticeValidator{}
+}
+
+// 検索
+func (v *NoticeValidator) Search(m *request.SearchNotice) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.Page,
+			validation.Required,
+			validation.Min(1),
+		),
+		validation.Field(
+			&m.PageSize,
+			validation.Required,
+			validation.Min(1),
+			validation.Max(100),
+		),
+	)
+}
+
+// 既読
+func (v *NoticeValidator) Read(m *request.ReadNotice) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKeys,
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+	)
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"api/src/model/request"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IOperationLogValidator interface {
+	// 検索
+	Search(m *request.SearchOperationLog) error
+	// 取得
+	Get(m *request.GetOperationLog) error
+}
+
+type OperationLogValidator struct{}
+
+func NewOperationLogValidator() IOperationLogValidator {
+	return &OperationLogValidator{}
+}
+
+// 検索
+func (v *OperationLogValidator) Search(m *request.SearchOperationLog) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.Page,
+			validation.Required,
+			validation.Min(1),
+		),
+		validation.Field(
+			&m.PageSize,
+			validation.Required,
+			validation.Min(1),
+			validation.Max(100),
+		),
+		validation.Field(
+			&m.Users,
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+		validation.Field(
+			&m.Events,
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+		validation.Field(
+			&m.Teams,
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+		validation.Field(
+			&m.CreatedAtFrom,
+			validation.By(func(value interface{}) error {
+				return IsBeforeTime(m.CreatedAtFrom, m.CreatedAtTo)
+			}),
+		),
+	)
+}
+
+// 取得
+func (v *OperationLogValidator) Get(m *request.GetOperationLog) error {
+	return validation.ValidateStruct(
+		m,
+		validation.Field(
+			&m.HashKey,
+			validation.Required,
+		),
+	)
+}
@@ -9,6 +9,16 @@ import (
 type IRoleValidator interface {
 	// ロールチェック
 	Check(req *request.CheckRole) error
+	// ロール取得
+	Get(req *request.GetRole) error
+	// ロール登録
+	Create(req *request.CreateRole) error
+	// ロール更新
+	Update(req *request.UpdateRole) error
+	// ロール削除
+	Delete(req *request.DeleteRole) error
+	// ロール割り当て
+	Assign(req *request.AssignRole) error
 }
 
 type RoleValidator struct{}
@@ -27,3 +37,85 @@ func (v *RoleValidator) Check(req *request.CheckRole) error {
 		),
 	)
 }
+
+// ロール取得
+func (v *RoleValidator) Get(req *request.GetRole) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(
+			&req.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// ロール登録
+func (v *RoleValidator) Create(req *request.CreateRole) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(
+			&req.Name,
+			validation.Required,
+			validation.RuneLength(1, 75),
+		),
+		validation.Field(
+			&req.Roles,
+			validation.Required,
+			validation.Length(1, 0),
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+	)
+}
+
+// ロール更新
+func (v *RoleValidator) Update(req *request.UpdateRole) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(
+			&req.HashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&req.Name,
+			validation.Required,
+			validation.RuneLength(1, 75),
+		),
+		validation.Field(
+			&req.Roles,
+			validation.Required,
+			validation.Length(1, 0),
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+	)
+}
+
+// ロール削除
+func (v *RoleValidator) Delete(req *request.DeleteRole) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(
+			&req.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// ロール割り当て
+func (v *RoleValidator) Assign(req *request.AssignRole) error {
+	return validation.ValidateStruct(
+		req,
+		validation.Field(
+			&req.HashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&req.Users,
+			validation.Required,
+			validation.Length(1, 0),
+			validation.Each(validation.Required),
+			UniqueValidator{},
+		),
+	)
+}
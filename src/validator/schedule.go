@@ -1,8 +1,12 @@
 package validator
 
 import (
+	"api/src/model/ddl"
 	"api/src/model/request"
 	"api/src/model/static"
+	"errors"
+	"regexp"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -12,8 +16,14 @@ type IScheduleValidator interface {
 	Create(u *request.CreateSchedule) error
 	// 予定更新
 	Update(u *request.UpdateSchedule) error
+	// 予定検索
+	Search(u *request.SearchSchedule) error
 	// 予定削除
 	Delete(u *request.DeleteSchedule) error
+	// 予定例外登録
+	SaveException(u *request.SaveScheduleException) error
+	// 予定例外削除
+	DeleteException(u *request.DeleteScheduleException) error
 }
 
 type ScheduleValidator struct{}
@@ -24,6 +34,9 @@ func NewScheduleValidator() IScheduleValidator {
 
 // 予定登録
 func (v *ScheduleValidator) Create(u *request.CreateSchedule) error {
+	if err := validateRecurrence(&u.Schedule); err != nil {
+		return err
+	}
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
@@ -50,6 +63,9 @@ func (v *ScheduleValidator) Create(u *request.CreateSchedule) error {
 
 // 予定更新
 func (v *ScheduleValidator) Update(u *request.UpdateSchedule) error {
+	if err := validateRecurrence(&u.Schedule); err != nil {
+		return err
+	}
 	return validation.ValidateStruct(
 		u,
 		validation.Field(
@@ -68,6 +84,31 @@ func (v *ScheduleValidator) Update(u *request.UpdateSchedule) error {
 	)
 }
 
+// 予定検索
+func (v *ScheduleValidator) Search(u *request.SearchSchedule) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.From,
+			validation.By(func(value interface{}) error {
+				return IsBeforeTime(u.From, u.To)
+			}),
+		),
+		validation.Field(
+			&u.To,
+			validation.By(func(value interface{}) error {
+				if u.From.IsZero() || u.To.IsZero() {
+					return nil
+				}
+				if u.To.After(u.From.AddDate(0, 0, static.SCHEDULE_SEARCH_MAX_DAYS)) {
+					return errors.New("period is too long")
+				}
+				return nil
+			}),
+		),
+	)
+}
+
 // 予定削除
 func (v *ScheduleValidator) Delete(u *request.DeleteSchedule) error {
 	return validation.ValidateStruct(
@@ -78,3 +119,103 @@ func (v *ScheduleValidator) Delete(u *request.DeleteSchedule) error {
 		),
 	)
 }
+
+// 予定例外登録
+func (v *ScheduleValidator) SaveException(u *request.SaveScheduleException) error {
+	move := u.Kind == static.SCHEDULE_EXCEPTION_MOVE
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&u.OriginalStart,
+			validation.Required,
+		),
+		validation.Field(
+			&u.Kind,
+			validation.Required,
+			validation.In(static.SCHEDULE_EXCEPTION_SKIP, static.SCHEDULE_EXCEPTION_MOVE),
+		),
+		validation.Field(
+			&u.Start,
+			validation.When(move, validation.Required),
+		),
+		validation.Field(
+			&u.End,
+			validation.When(move, validation.Required),
+			validation.By(func(value interface{}) error {
+				return IsBeforeTime(u.Start, u.End)
+			}),
+		),
+	)
+}
+
+// 予定例外削除
+func (v *ScheduleValidator) DeleteException(u *request.DeleteScheduleException) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&u.OriginalStart,
+			validation.Required,
+		),
+	)
+}
+
+// 曜日指定(BYDAY)の1要素 ※数字は第何週(毎月のみ)
+var byDayPattern = regexp.MustCompile(`^(-?[1-5])?(` + strings.Join(static.SCHEDULE_BYDAY_WEEKDAYS[:], "|") + `)$`)
+
+// 繰り返しの設定 ※回数と終了日時は併用不可
+func validateRecurrence(s *ddl.Schedule) error {
+	recurring := s.FreqID == static.FREQ_DAILY ||
+		s.FreqID == static.FREQ_WEEKLY ||
+		s.FreqID == static.FREQ_MONTHLY ||
+		s.FreqID == static.FREQ_YEARLY
+	return validation.ValidateStruct(
+		s,
+		validation.Field(
+			&s.Interval,
+			validation.Max(static.SCHEDULE_INTERVAL_MAX),
+		),
+		validation.Field(
+			&s.ByDay,
+			validation.Length(0, static.SCHEDULE_BYDAY_MAX_LENGTH),
+			validation.When(!recurring || s.FreqID == static.FREQ_YEARLY, validation.Empty),
+			validation.By(func(value interface{}) error {
+				if s.ByDay == "" {
+					return nil
+				}
+				for _, token := range strings.Split(s.ByDay, ",") {
+					m := byDayPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(token)))
+					if m == nil {
+						return errors.New("invalid weekday")
+					}
+					if m[1] != "" && s.FreqID != static.FREQ_MONTHLY {
+						return errors.New("ordinal weekday is only for monthly")
+					}
+				}
+				return nil
+			}),
+		),
+		validation.Field(
+			&s.Count,
+			validation.Max(static.SCHEDULE_COUNT_MAX),
+			validation.When(!recurring || s.Until != nil, validation.Empty),
+		),
+		validation.Field(
+			&s.Until,
+			validation.When(!recurring, validation.Nil),
+			validation.By(func(value interface{}) error {
+				if s.Until == nil {
+					return nil
+				}
+				return IsBeforeTime(s.Start, *s.Until)
+			}),
+		),
+	)
+}
@@ -0,0 +1,33 @@
+package validator
+
+import (
+	"api/src/model/request"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ISCIMValidator interface {
+	// トークン発行
+	IssueToken(s *request.IssueSCIMToken) error
+}
+
+type SCIMValidator struct{}
+
+func NewSCIMValidator() ISCIMValidator {
+	return &SCIMValidator{}
+}
+
+// トークン発行
+func (v *SCIMValidator) IssueToken(s *request.IssueSCIMToken) error {
+	return validation.ValidateStruct(
+		s,
+		validation.Field(
+			&s.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&s.RoleHashKey,
+			validation.Required,
+		),
+	)
+}
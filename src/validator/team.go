@@ -1,7 +1,10 @@
 package validator
 
 import (
+	"api/src/model/ddl"
 	"api/src/model/request"
+	"errors"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -27,6 +30,10 @@ type ITeamValidator interface {
 	UpdateAssignMethod3(u *request.UpdateAssignMethod) error
 	// 面接官割り振り方法更新4
 	UpdateAssignMethod4(u *request.UpdateAssignMethodSub) error
+	// 面接予約枠設定更新
+	UpdateBookingWindow(u *request.UpdateBookingWindow) error
+	// 定休日設定更新
+	UpdateWeekday(u *request.UpdateTeamWeekday) error
 }
 
 type TeamValidator struct{}
@@ -168,3 +175,98 @@ func (v *TeamValidator) UpdateAssignMethod4(u *request.UpdateAssignMethodSub) er
 		),
 	)
 }
+
+// 面接予約枠設定更新
+func (v *TeamValidator) UpdateBookingWindow(u *request.UpdateBookingWindow) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.NumOfInterview,
+			validation.Max(uint(30)),
+		),
+		validation.Field(
+			&u.TimeZone,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				_, err := time.LoadLocation(u.TimeZone)
+				return err
+			}),
+		),
+		validation.Field(
+			&u.Duration,
+			validation.Required,
+			validation.Min(uint(5)),
+			validation.Max(uint(480)),
+		),
+		validation.Field(
+			&u.SlotInterval,
+			validation.Required,
+			validation.Min(uint(5)),
+			validation.Max(uint(240)),
+		),
+		validation.Field(
+			&u.LeadTime,
+			validation.Max(uint(2160)),
+		),
+		validation.Field(
+			&u.Horizon,
+			validation.Required,
+			validation.Min(uint(1)),
+			validation.Max(uint(180)),
+		),
+		validation.Field(
+			&u.Buffer,
+			validation.Max(uint(240)),
+		),
+		validation.Field(
+			&u.Hours,
+			validation.Required,
+			validation.Length(1, 7),
+			validation.By(func(value interface{}) error {
+				return validBookingHours(u.Hours)
+			}),
+		),
+	)
+}
+
+// 定休日設定更新 ※曜日の重複不可
+func (v *TeamValidator) UpdateWeekday(u *request.UpdateTeamWeekday) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.ClosedWeekdays,
+			validation.Length(0, 7),
+			validation.Each(
+				validation.Max(uint(6)),
+			),
+			validation.By(func(value interface{}) error {
+				seen := make(map[uint]bool)
+				for _, weekday := range u.ClosedWeekdays {
+					if seen[weekday] {
+						return errors.New("duplicate weekday")
+					}
+					seen[weekday] = true
+				}
+				return nil
+			}),
+		),
+	)
+}
+
+// 受付時間の検証 ※曜日の重複不可、開始 < 終了
+func validBookingHours(hours []ddl.TeamBookingHours) error {
+	seen := make(map[uint]bool)
+	for _, row := range hours {
+		if row.Weekday > 6 {
+			return errors.New("invalid weekday")
+		}
+		if seen[row.Weekday] {
+			return errors.New("duplicate weekday")
+		}
+		seen[row.Weekday] = true
+		if row.StartMinute >= row.EndMinute || row.EndMinute > 24*60 {
+			return errors.New("invalid booking hours")
+		}
+	}
+	return nil
+}
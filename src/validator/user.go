@@ -16,6 +16,12 @@ type IUserValidator interface {
 	Search(u *request.SearchUser) error
 	// 取得
 	Get(u *request.GetUser) error
+	// 認証アプリリセット
+	ResetTOTP(u *request.ResetUserTOTP) error
+	// パスワード再発行
+	ReissuePassword(u *request.ReissueUserPassword) error
+	// ログインロック解除
+	Unlock(u *request.UnlockUser) error
 }
 
 type UserValidator struct{}
@@ -73,3 +79,48 @@ func (v *UserValidator) Get(u *request.GetUser) error {
 		u,
 	)
 }
+
+// 認証アプリリセット
+func (v *UserValidator) ResetTOTP(u *request.ResetUserTOTP) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// パスワード再発行
+func (v *UserValidator) ReissuePassword(u *request.ReissueUserPassword) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+	)
+}
+
+// ログインロック解除
+func (v *UserValidator) Unlock(u *request.UnlockUser) error {
+	return validation.ValidateStruct(
+		u,
+		validation.Field(
+			&u.UserHashKey,
+			validation.Required,
+		),
+		validation.Field(
+			&u.HashKey,
+			validation.Required,
+		),
+	)
+}